@@ -0,0 +1,246 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package css
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/css/scanner"
+)
+
+// corpus returns the stylesheets of testdata, by file name. Their sources
+// and licenses are listed in testdata/README.md.
+//
+//   - bootstrap-3.3.1.css is the unminified build of a component framework.
+//   - font-awesome-4.7.0.min.css is the minified stylesheet of an icon font.
+//   - normalize-8.0.1.min.css is a minified reset with many browser
+//     fixes.
+//   - nodejs-api.css is the hand-written stylesheet of a documentation site.
+//   - rustdoc.min.css is the minified bundle of the rustdoc pages.
+//   - escapes.css is generated pathological input, dense in escape
+//     sequences and non-ASCII characters.
+//   - tricky.css has browser hacks, escaped class names, modern at-rules and
+//     other constructs of real-world stylesheets that are easy to get wrong.
+func corpus(tb testing.TB) map[string]string {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.css"))
+	if err != nil || len(files) == 0 {
+		tb.Fatalf("no testdata: %v", err)
+	}
+	m := map[string]string{}
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		m[filepath.Base(f)] = string(b)
+	}
+	return m
+}
+
+// forEachFile runs a benchmark for each stylesheet of the corpus.
+func forEachFile(b *testing.B, fn func(b *testing.B, input string)) {
+	files := corpus(b)
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		input := files[name]
+		b.Run(name, func(b *testing.B) {
+			b.SetBytes(int64(len(input)))
+			b.ReportAllocs()
+			fn(b, input)
+		})
+	}
+}
+
+func TestCorpus(t *testing.T) {
+	for name, input := range corpus(t) {
+		s, err := ParseStylesheet(input)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if len(s.Rules) == 0 {
+			t.Errorf("%s: no rules", name)
+		}
+		// Serializing again gives the same stylesheet, made of the same
+		// rules, declarations and tokens.
+		again, err := ParseStylesheet(s.String())
+		if err != nil || again.String() != s.String() {
+			t.Errorf("%s: serialization doesn't round-trip: %v", name, err)
+			continue
+		}
+		if len(again.Rules) != len(s.Rules) {
+			t.Errorf("%s: got %d rules after serialization, want %d", name, len(again.Rules), len(s.Rules))
+			continue
+		}
+		for i, r := range s.Rules {
+			if !reflect.DeepEqual(conformRules(again.Rules[i:i+1]), conformRules(s.Rules[i:i+1])) {
+				t.Errorf("%s: rule at line %d is serialized as %q, which parses to another rule", name, r.Line, r.String())
+			}
+		}
+	}
+}
+
+// conformRules returns the representation of a list of rules, with the
+// representation of the tokens of TestConformance where consecutive
+// whitespace tokens count as one. Unlike the raw text of rules, it doesn't
+// depend on their formatting.
+func conformRules(rules []*Rule) []interface{} {
+	list := []interface{}{}
+	for _, r := range rules {
+		var decls []interface{}
+		for _, d := range r.Declarations {
+			decls = append(decls, collapseSpace(conformDeclaration(d).([]interface{})))
+		}
+		list = append(list, []interface{}{r.AtKeyword, collapseSpace(conformValues(r.Prelude)), r.HasBlock, decls, conformRules(r.Rules)})
+	}
+	return list
+}
+
+// collapseSpace removes the whitespace following whitespace from the
+// representation of component values, at any depth. Consecutive whitespace
+// tokens, as in "a /**/ b", are written as one.
+func collapseSpace(list []interface{}) []interface{} {
+	out := []interface{}{}
+	for _, v := range list {
+		if sub, ok := v.([]interface{}); ok {
+			v = collapseSpace(sub)
+		}
+		if v == " " && len(out) > 0 && out[len(out)-1] == " " {
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// TestCorpusTokens checks the number of tokens of each type of the files of
+// the corpus against testdata/tokens.txt, to catch changes of the scanner
+// on real-world input. A line of the file lists the counts of a file, such
+// as:
+//
+//	escapes.css CHAR=5200 COMMENT=1 HASH=400 IDENT=2400 S=5201 STRING=800 URI=800
+func TestCorpusTokens(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join("testdata", "tokens.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		name, counts, _ := strings.Cut(line, " ")
+		want[name] = counts
+	}
+	files := corpus(t)
+	for name, input := range files {
+		n := map[string]int{}
+		s := scanner.New(input)
+		for tok := s.Next(); tok.Type != scanner.TokenEOF && tok.Type != scanner.TokenError; tok = s.Next() {
+			n[tok.Type.String()]++
+		}
+		var counts []string
+		for typ, c := range n {
+			counts = append(counts, fmt.Sprintf("%s=%d", typ, c))
+		}
+		sort.Strings(counts)
+		if got := strings.Join(counts, " "); got != want[name] {
+			t.Errorf("%s: got counts\n%s %s\nwant\n%s %s", name, name, got, name, want[name])
+		}
+	}
+	for name := range want {
+		if _, ok := files[name]; !ok {
+			t.Errorf("%s: listed in testdata/tokens.txt but not in testdata", name)
+		}
+	}
+}
+
+func BenchmarkScan(b *testing.B) {
+	forEachFile(b, func(b *testing.B, input string) {
+		for i := 0; i < b.N; i++ {
+			s := scanner.New(input)
+			for t := s.Next(); t.Type != scanner.TokenEOF && t.Type != scanner.TokenError; t = s.Next() {
+			}
+		}
+	})
+}
+
+func BenchmarkValidate(b *testing.B) {
+	forEachFile(b, func(b *testing.B, input string) {
+		for i := 0; i < b.N; i++ {
+			if errs := Validate(strings.NewReader(input)); errs != nil {
+				b.Fatal(errs[0])
+			}
+		}
+	})
+}
+
+func BenchmarkParse(b *testing.B) {
+	forEachFile(b, func(b *testing.B, input string) {
+		for i := 0; i < b.N; i++ {
+			if _, err := ParseStylesheet(input); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkParseArena(b *testing.B) {
+	forEachFile(b, func(b *testing.B, input string) {
+		var a Arena
+		for i := 0; i < b.N; i++ {
+			if _, err := a.ParseStylesheet(input); err != nil {
+				b.Fatal(err)
+			}
+			a.Release()
+		}
+	})
+}
+
+func BenchmarkParseParallel(b *testing.B) {
+	forEachFile(b, func(b *testing.B, input string) {
+		for i := 0; i < b.N; i++ {
+			if _, err := ParseStylesheetParallel(input, 0); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkSerialize(b *testing.B) {
+	forEachFile(b, func(b *testing.B, input string) {
+		s, err := ParseStylesheet(input)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var out strings.Builder
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			out.Reset()
+			s.WriteTo(&out)
+		}
+	})
+}
+
+func BenchmarkRender(b *testing.B) {
+	forEachFile(b, func(b *testing.B, input string) {
+		s, err := ParseStylesheet(input)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			s.Render(io.Discard, RenderOptions{Canonical: true})
+		}
+	})
+}
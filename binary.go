@@ -0,0 +1,269 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package css
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+
+	"github.com/gorilla/css/scanner"
+)
+
+// binaryMagic and binaryVersion start the binary encoding of stylesheets.
+const (
+	binaryMagic   = "GCSS"
+	binaryVersion = 1
+)
+
+// crcTable is the table of the checksums of the binary format.
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// MarshalBinary returns a compact binary encoding of the stylesheet, to
+// cache the result of parsing an input, keyed by a hash of the input, and
+// skip parsing it again. The encoding keeps all the fields of the rules,
+// declarations and tokens, including their positions, comments and raw
+// text. It has a version and a checksum, which UnmarshalBinary verifies.
+func (s *Stylesheet) MarshalBinary() ([]byte, error) {
+	e := &binaryEncoder{}
+	e.collectRules(s.Rules)
+	b := append([]byte(binaryMagic), binaryVersion)
+	b = appendString(b, string(scanner.MarshalTokens(e.tokens)))
+	b = e.appendRules(b, s.Rules)
+	return binary.LittleEndian.AppendUint32(b, crc32.Checksum(b, crcTable)), nil
+}
+
+// UnmarshalBinary decodes a stylesheet encoded by MarshalBinary into s. It
+// returns scanner.ErrBinaryVersion if the data was encoded by another
+// version of the package, and scanner.ErrCorrupt if the data is corrupted.
+func (s *Stylesheet) UnmarshalBinary(data []byte) error {
+	if len(data) < len(binaryMagic)+5 || string(data[:len(binaryMagic)]) != binaryMagic {
+		return scanner.ErrCorrupt
+	}
+	if data[len(binaryMagic)] != binaryVersion {
+		return scanner.ErrBinaryVersion
+	}
+	end := len(data) - 4
+	if crc32.Checksum(data[:end], crcTable) != binary.LittleEndian.Uint32(data[end:]) {
+		return scanner.ErrCorrupt
+	}
+	d := &binaryDecoder{data: string(data[:end]), pos: len(binaryMagic) + 1}
+	tokens, err := scanner.UnmarshalTokens([]byte(d.string()))
+	if err != nil {
+		return err
+	}
+	d.tokens = tokens
+	rules := d.rules()
+	if d.err != nil || d.pos != len(d.data) || len(d.tokens) != 0 {
+		return scanner.ErrCorrupt
+	}
+	s.Rules = rules
+	return nil
+}
+
+// binaryEncoder holds the state of MarshalBinary.
+type binaryEncoder struct {
+	// tokens are the tokens of the stylesheet, in the order they are
+	// encoded.
+	tokens []*scanner.Token
+}
+
+// collectRules collects the tokens of a list of rules.
+func (e *binaryEncoder) collectRules(rules []*Rule) {
+	for _, r := range rules {
+		e.collectValues(r.Prelude)
+		for _, d := range r.Declarations {
+			e.collectValues(d.Value)
+		}
+		e.collectRules(r.Rules)
+	}
+}
+
+// collectValues collects the tokens of a list of component values.
+func (e *binaryEncoder) collectValues(values []*ComponentValue) {
+	for _, v := range values {
+		e.tokens = append(e.tokens, v.Token)
+		e.collectValues(v.Children)
+	}
+}
+
+// appendRules appends a list of rules to b, in the order of collectRules.
+func (e *binaryEncoder) appendRules(b []byte, rules []*Rule) []byte {
+	b = binary.AppendUvarint(b, uint64(len(rules)))
+	for _, r := range rules {
+		b = appendString(b, r.AtKeyword)
+		b = appendValues(b, r.Prelude)
+		b = appendBool(b, r.HasBlock)
+		b = binary.AppendUvarint(b, uint64(len(r.Declarations)))
+		for _, d := range r.Declarations {
+			b = appendString(b, d.Property)
+			b = appendValues(b, d.Value)
+			b = appendBool(b, d.Important)
+			b = appendNode(b, d.Line, d.Column, d.Comments, d.Raw, d.sum)
+		}
+		b = e.appendRules(b, r.Rules)
+		b = appendNode(b, r.Line, r.Column, r.Comments, r.Raw, r.sum)
+	}
+	return b
+}
+
+// appendValues appends the structure of a list of component values to b,
+// whose tokens are encoded separately.
+func appendValues(b []byte, values []*ComponentValue) []byte {
+	b = binary.AppendUvarint(b, uint64(len(values)))
+	for _, v := range values {
+		b = appendValues(b, v.Children)
+	}
+	return b
+}
+
+// appendNode appends the fields common to rules and declarations to b.
+func appendNode(b []byte, line, col int, comments []string, raw string, sum uint64) []byte {
+	b = binary.AppendUvarint(b, uint64(line))
+	b = binary.AppendUvarint(b, uint64(col))
+	b = binary.AppendUvarint(b, uint64(len(comments)))
+	for _, c := range comments {
+		b = appendString(b, c)
+	}
+	b = appendString(b, raw)
+	return binary.LittleEndian.AppendUint64(b, sum)
+}
+
+// appendString appends a string to b, as its length followed by its bytes.
+func appendString(b []byte, s string) []byte {
+	return append(binary.AppendUvarint(b, uint64(len(s))), s...)
+}
+
+// appendBool appends a boolean to b.
+func appendBool(b []byte, v bool) []byte {
+	if v {
+		return append(b, 1)
+	}
+	return append(b, 0)
+}
+
+// binaryDecoder holds the state of UnmarshalBinary. Reading past the end of
+// the data returns zero values and sets err.
+type binaryDecoder struct {
+	data   string
+	pos    int
+	err    error
+	tokens []*scanner.Token
+}
+
+// rules decodes a list of rules.
+func (d *binaryDecoder) rules() []*Rule {
+	n := d.count()
+	if n == 0 {
+		return nil
+	}
+	rules := make([]*Rule, n)
+	for i := range rules {
+		r := &Rule{AtKeyword: d.string()}
+		r.Prelude = d.values()
+		r.HasBlock = d.bool()
+		if n := d.count(); n > 0 {
+			r.Declarations = make([]*Declaration, n)
+			for j := range r.Declarations {
+				decl := &Declaration{Property: d.string()}
+				decl.Value = d.values()
+				decl.Important = d.bool()
+				decl.Line, decl.Column, decl.Comments, decl.Raw, decl.sum = d.node()
+				r.Declarations[j] = decl
+			}
+		}
+		r.Rules = d.rules()
+		r.Line, r.Column, r.Comments, r.Raw, r.sum = d.node()
+		rules[i] = r
+	}
+	return rules
+}
+
+// values decodes the structure of a list of component values, taking
+// their tokens from the decoded tokens.
+func (d *binaryDecoder) values() []*ComponentValue {
+	n := d.count()
+	if n == 0 || n > len(d.tokens) {
+		if n > 0 {
+			d.err = scanner.ErrCorrupt
+		}
+		return nil
+	}
+	values := make([]ComponentValue, n)
+	list := make([]*ComponentValue, n)
+	for i := range values {
+		v := &values[i]
+		v.Token, d.tokens = d.tokens[0], d.tokens[1:]
+		v.Children = d.values()
+		list[i] = v
+	}
+	return list
+}
+
+// node decodes the fields common to rules and declarations.
+func (d *binaryDecoder) node() (line, col int, comments []string, raw string, sum uint64) {
+	line, col = int(d.uvarint()), int(d.uvarint())
+	if n := d.count(); n > 0 {
+		comments = make([]string, n)
+		for i := range comments {
+			comments[i] = d.string()
+		}
+	}
+	raw = d.string()
+	if len(d.data)-d.pos < 8 {
+		d.err = scanner.ErrCorrupt
+		return
+	}
+	sum = binary.LittleEndian.Uint64([]byte(d.data[d.pos : d.pos+8]))
+	d.pos += 8
+	return
+}
+
+// count decodes the length of a list, which can't exceed the data left,
+// since each element takes at least a byte.
+func (d *binaryDecoder) count() int {
+	n := d.uvarint()
+	if n > uint64(len(d.data)-d.pos) {
+		d.err = scanner.ErrCorrupt
+		return 0
+	}
+	return int(n)
+}
+
+// uvarint decodes an unsigned integer.
+func (d *binaryDecoder) uvarint() uint64 {
+	var v uint64
+	for shift := 0; shift < 64; shift += 7 {
+		c := d.byte()
+		v |= uint64(c&0x7f) << shift
+		if c < 0x80 {
+			return v
+		}
+	}
+	d.err = scanner.ErrCorrupt
+	return 0
+}
+
+// byte decodes a byte.
+func (d *binaryDecoder) byte() byte {
+	if d.pos >= len(d.data) {
+		d.err = scanner.ErrCorrupt
+		return 0
+	}
+	d.pos++
+	return d.data[d.pos-1]
+}
+
+// string decodes a string, which shares the memory of the data.
+func (d *binaryDecoder) string() string {
+	n := d.count()
+	s := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return s
+}
+
+// bool decodes a boolean.
+func (d *binaryDecoder) bool() bool {
+	return d.byte() != 0
+}
@@ -0,0 +1,419 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package gorilla/css/cascade resolves the cascade of CSS Cascading and
+Inheritance Level 5 for the elements of a document, telling which
+declaration of a set of stylesheets wins for each property:
+
+	https://www.w3.org/TR/css-cascade-5/
+
+Elements are matched through the selector.Element interface, so that any
+document tree can be styled:
+
+	c := cascade.New([]cascade.Sheet{
+		{Stylesheet: defaults, Origin: cascade.UserAgent},
+		{Stylesheet: sheet, Origin: cascade.Author},
+	}, cascade.Options{})
+	values := c.Resolve(e, inline)
+	values["color"].Declaration.Value // the cascaded value of color
+
+Declarations are sorted by origin and importance, then by whether they
+come from the style attribute of the element, then by cascade layer, then
+by the specificity of the selector matching the element, and last by order
+of appearance. Shorthands are resolved as the longhands they set, so that
+"margin: 0" and a later "margin-top: 1px" each win for some properties.
+
+Compute goes further, applying inheritance, the initial values of the props
+package and var() substitution to return the computed style of an element,
+whose declarations can be inlined in its style attribute, as for HTML
+emails:
+
+	style := c.Compute(e, nil)
+	attr := css.RenderInline(style.Declarations())
+
+Declarations aren't validated: invalid ones, which browsers drop when they
+parse a stylesheet, should be removed beforehand, with the validate package
+for instance.
+*/
+package cascade
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/props"
+	"github.com/gorilla/css/scanner"
+	"github.com/gorilla/css/selector"
+)
+
+// Origin is the origin of a stylesheet.
+type Origin int
+
+const (
+	// UserAgent is the origin of the default stylesheet of the user agent.
+	UserAgent Origin = iota
+	// User is the origin of the stylesheets of the user.
+	User
+	// Author is the origin of the stylesheets of the document, and of its
+	// style attributes.
+	Author
+)
+
+// String returns a string representation of the origin.
+func (o Origin) String() string {
+	switch o {
+	case UserAgent:
+		return "user-agent"
+	case User:
+		return "user"
+	case Author:
+		return "author"
+	}
+	return fmt.Sprintf("Origin(%d)", int(o))
+}
+
+// Sheet is a stylesheet with its origin.
+type Sheet struct {
+	Stylesheet *css.Stylesheet
+	Origin     Origin
+}
+
+// Options are the options of a Cascade.
+type Options struct {
+	// Condition, if not nil, reports whether the contents of a conditional
+	// rule, such as @media, @supports or @container, apply. When it is nil,
+	// they always apply.
+	Condition func(r *css.Rule) bool
+}
+
+// Cascade resolves the cascade of a set of stylesheets. It is safe for
+// concurrent use, as long as the stylesheets aren't modified.
+type Cascade struct {
+	entries []*entry
+}
+
+// entry is a list of declarations of a stylesheet, applying to the elements
+// matched by a selector list.
+type entry struct {
+	rule *css.Rule
+	// list is the selector list of rule, relative to parents, the selector
+	// lists of the style rules holding it, if any.
+	list    selector.List
+	parents []selector.List
+	origin  Origin
+	layer   *layer
+	// order is the order of appearance of the first declaration.
+	order int
+}
+
+// layer is a cascade layer, or the implicit outer layer of the
+// declarations of an origin that aren't in a layer.
+type layer struct {
+	children []*layer
+	byName   map[string]*layer
+	// rank is the position of the layer in the layer order: layers are
+	// sorted after their sublayers, and sibling layers by the order in
+	// which they were first declared.
+	rank int
+}
+
+// sublayer returns the sublayer of l named name, declaring it if it is new.
+// An empty name declares a new anonymous layer.
+func (l *layer) sublayer(name string) *layer {
+	if s := l.byName[name]; s != nil && name != "" {
+		return s
+	}
+	s := &layer{byName: map[string]*layer{}}
+	l.children = append(l.children, s)
+	l.byName[name] = s
+	return s
+}
+
+// rankLayers sets the rank of l and its sublayers, starting from rank, and
+// returns the next rank.
+func (l *layer) rankLayers(rank int) int {
+	for _, c := range l.children {
+		rank = c.rankLayers(rank)
+	}
+	l.rank = rank
+	return rank + 1
+}
+
+// New returns the cascade of a set of stylesheets, in their order of
+// appearance.
+//
+// Style rules, including nested ones, apply to the elements their
+// selectors match; rules with an invalid selector are ignored. The contents
+// of @media, @supports and @container rules apply according to the
+// Condition option, and those of @layer rules belong to their layer. The
+// layers named in @layer statements and in the layer() of @import rules are
+// declared in order, though imported stylesheets must be passed to New to
+// be part of the cascade. Other at-rules, such as @font-face or @keyframes,
+// are ignored.
+func New(sheets []Sheet, opts Options) *Cascade {
+	b := &builder{opts: opts, layers: map[Origin]*layer{}}
+	for _, s := range sheets {
+		root := b.layers[s.Origin]
+		if root == nil {
+			root = &layer{byName: map[string]*layer{}}
+			b.layers[s.Origin] = root
+		}
+		b.origin = s.Origin
+		b.rules(s.Stylesheet.Rules, nil, root)
+	}
+	for _, root := range b.layers {
+		root.rankLayers(0)
+	}
+	return &Cascade{entries: b.entries}
+}
+
+// builder collects the entries of a cascade.
+type builder struct {
+	opts    Options
+	entries []*entry
+	origin  Origin
+	layers  map[Origin]*layer
+	order   int
+}
+
+// rules collects the entries of a list of rules, held by style rules whose
+// selector lists are parents, in layer l.
+func (b *builder) rules(rules []*css.Rule, parents []selector.List, l *layer) {
+	for _, r := range rules {
+		if !r.IsAtRule() {
+			var list selector.List
+			var err error
+			if len(parents) == 0 {
+				list, err = selector.Parse(r.Prelude)
+			} else {
+				list, err = selector.ParseRelative(r.Prelude)
+			}
+			if err != nil {
+				continue
+			}
+			b.add(r, list, parents, l)
+			b.rules(r.Rules, append(parents[:len(parents):len(parents)], list), l)
+			continue
+		}
+		inner := l
+		switch strings.ToLower(r.AtKeyword) {
+		case "media", "supports", "container":
+			if !r.HasBlock || b.opts.Condition != nil && !b.opts.Condition(r) {
+				continue
+			}
+		case "layer":
+			names := layerNames(r.Prelude)
+			if !r.HasBlock {
+				for _, name := range names {
+					declareLayer(l, name)
+				}
+				continue
+			}
+			if len(names) > 1 {
+				continue
+			}
+			name := ""
+			if len(names) == 1 {
+				name = names[0]
+			}
+			inner = declareLayer(l, name)
+		case "import":
+			for _, v := range r.Prelude {
+				if v.IsFunction() && strings.EqualFold(v.Name(), "layer") {
+					declareLayer(l, strings.Join(layerNames(v.Children), ""))
+				}
+			}
+			continue
+		default:
+			continue
+		}
+		// The declarations of a conditional or layer rule nested in a
+		// style rule apply as those of the style rule.
+		if len(parents) > 0 {
+			b.add(r, parents[len(parents)-1], parents[:len(parents)-1], inner)
+		}
+		b.rules(r.Rules, parents, inner)
+	}
+}
+
+// add adds the entry of the declarations of r, if any.
+func (b *builder) add(r *css.Rule, list selector.List, parents []selector.List, l *layer) {
+	if len(r.Declarations) == 0 {
+		return
+	}
+	b.entries = append(b.entries, &entry{
+		rule:    r,
+		list:    list,
+		parents: parents,
+		origin:  b.origin,
+		layer:   l,
+		order:   b.order,
+	})
+	b.order += len(r.Declarations)
+}
+
+// declareLayer returns the sublayer of l with the dotted name, such as
+// "base.reset", declaring the layers that are new.
+func declareLayer(l *layer, name string) *layer {
+	if name == "" {
+		return l.sublayer("")
+	}
+	for _, part := range strings.Split(name, ".") {
+		l = l.sublayer(part)
+	}
+	return l
+}
+
+// layerNames returns the comma-separated layer names of the prelude of an
+// @layer rule.
+func layerNames(values []*css.ComponentValue) []string {
+	var names []string
+	var b strings.Builder
+	for _, v := range values {
+		switch {
+		case v.Token.Type == scanner.TokenS:
+		case v.Token.Type == scanner.TokenChar && v.Token.Value == ",":
+			names = append(names, b.String())
+			b.Reset()
+		case v.Token.Type == scanner.TokenIdent:
+			b.WriteString(v.Token.DecodedValue())
+		default:
+			b.WriteString(v.Token.Value)
+		}
+	}
+	if b.Len() > 0 || len(names) > 0 {
+		names = append(names, b.String())
+	}
+	return names
+}
+
+// Cascaded is the declaration winning the cascade for a property.
+type Cascaded struct {
+	// Declaration is the winning declaration. It may be a shorthand
+	// setting the property.
+	Declaration *css.Declaration
+	// Rule is the rule holding Declaration, or nil for the declarations
+	// of the style attribute.
+	Rule *css.Rule
+	// Origin is the origin of Declaration.
+	Origin Origin
+	// Specificity is the specificity of the selector of Rule matching the
+	// element.
+	Specificity selector.Specificity
+}
+
+// candidate is a declaration applying to an element, with its precedence.
+type candidate struct {
+	Cascaded
+	// rank is the rank of the origin and importance of the declaration,
+	// the highest winning.
+	rank   int
+	inline bool
+	// layer is the rank of the layer of the declaration, the highest
+	// winning.
+	layer int
+	order int
+}
+
+// beats reports whether c has precedence over d.
+func (c *candidate) beats(d *candidate) bool {
+	switch {
+	case c.rank != d.rank:
+		return c.rank > d.rank
+	case c.inline != d.inline:
+		return c.inline
+	case c.layer != d.layer:
+		return c.layer > d.layer
+	case c.Specificity != d.Specificity:
+		return d.Specificity.Less(c.Specificity)
+	}
+	return c.order > d.order
+}
+
+// Resolve returns the declarations winning the cascade for element e, by
+// property, given the declarations of its style attribute, if any, which
+// have the author origin.
+//
+// The keys of the result are the lowercase names of longhand properties,
+// unknown properties and custom properties, whose case is kept. "all" sets
+// every longhand but direction and unicode-bidi. Properties that no
+// declaration sets aren't in the result.
+func (c *Cascade) Resolve(e selector.Element, inline []*css.Declaration) map[string]Cascaded {
+	winners := map[string]*candidate{}
+	apply := func(cd *candidate) {
+		for _, name := range longhands(cd.Declaration.Property) {
+			if w := winners[name]; w == nil || cd.beats(w) {
+				winners[name] = cd
+			}
+		}
+	}
+	for _, en := range c.entries {
+		s, ok := en.list.MatchNested(e, en.parents)
+		if !ok {
+			continue
+		}
+		for i, d := range en.rule.Declarations {
+			cd := &candidate{
+				Cascaded: Cascaded{Declaration: d, Rule: en.rule, Origin: en.origin, Specificity: s},
+				rank:     rank(en.origin, d.Important),
+				order:    en.order + i,
+			}
+			cd.layer = en.layer.rank
+			if d.Important {
+				cd.layer = -cd.layer
+			}
+			apply(cd)
+		}
+	}
+	for i, d := range inline {
+		apply(&candidate{
+			Cascaded: Cascaded{Declaration: d, Origin: Author},
+			rank:     rank(Author, d.Important),
+			inline:   true,
+			order:    i,
+		})
+	}
+	values := make(map[string]Cascaded, len(winners))
+	for name, w := range winners {
+		values[name] = w.Cascaded
+	}
+	return values
+}
+
+// rank returns the rank of an origin and importance: normal declarations
+// rank by origin, and important ones above them in the reverse order.
+func rank(o Origin, important bool) int {
+	if important {
+		return 2*int(Author) + 1 - int(o)
+	}
+	return int(o)
+}
+
+// allLonghands are the properties set by "all".
+var allLonghands = func() []string {
+	var names []string
+	for _, p := range props.All() {
+		if !p.IsShorthand() && p.Name != "direction" && p.Name != "unicode-bidi" {
+			names = append(names, p.Name)
+		}
+	}
+	return names
+}()
+
+// longhands returns the properties set by a declaration of property.
+func longhands(property string) []string {
+	if strings.HasPrefix(property, "--") {
+		return []string{property}
+	}
+	name := strings.ToLower(property)
+	if name == "all" {
+		return allLonghands
+	}
+	if p := props.Lookup(name); p != nil && p.IsShorthand() {
+		return p.Longhands
+	}
+	return []string{name}
+}
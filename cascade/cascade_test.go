@@ -0,0 +1,275 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cascade
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/selector"
+)
+
+// node is an Element of the tests, with an id and classes.
+type node struct {
+	name, id, class string
+	parent          *node
+}
+
+func (n *node) LocalName() string { return n.name }
+
+func (n *node) Attr(name string) (string, bool) {
+	switch {
+	case name == "id" && n.id != "":
+		return n.id, true
+	case name == "class" && n.class != "":
+		return n.class, true
+	}
+	return "", false
+}
+
+func (n *node) Parent() selector.Element {
+	if n.parent == nil {
+		return nil
+	}
+	return n.parent
+}
+
+func (n *node) PrevSibling() selector.Element { return nil }
+func (n *node) NextSibling() selector.Element { return nil }
+
+// tree returns the p element of "html > body.dark > div#main.box > p.text".
+func tree() *node {
+	html := &node{name: "html"}
+	body := &node{name: "body", class: "dark", parent: html}
+	div := &node{name: "div", id: "main", class: "box", parent: body}
+	return &node{name: "p", class: "text", parent: div}
+}
+
+// mustParse parses a stylesheet of the tests.
+func mustParse(t *testing.T, input string) *css.Stylesheet {
+	t.Helper()
+	s, err := css.ParseStylesheet(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// values returns the cascaded values of properties, as "red" or
+// "blue !important", with the origin of the declarations other than author
+// ones.
+func values(cascaded map[string]Cascaded, properties []string) []string {
+	var out []string
+	for _, p := range properties {
+		c, ok := cascaded[p]
+		if !ok {
+			out = append(out, "")
+			continue
+		}
+		s := css.ValuesString(css.TrimSpace(c.Declaration.Value))
+		if c.Declaration.Important {
+			s += " !important"
+		}
+		if c.Origin != Author {
+			s += " (" + c.Origin.String() + ")"
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func TestResolve(t *testing.T) {
+	tcs := []struct {
+		ua, user, author, inline string
+		properties               []string
+		expected                 []string
+	}{
+		{
+			author:     "p { color: red } .text { color: blue } p { margin: 0 } a { top: 0 }",
+			properties: []string{"color", "margin-top", "top"},
+			expected:   []string{"blue", "0", ""},
+		},
+		{
+			author:     "#main p { color: red } body .box .text { color: blue } .dark p { color: green }",
+			properties: []string{"color"},
+			expected:   []string{"red"},
+		},
+		{
+			author:     "p { color: red !important } #main p { color: blue }",
+			inline:     "color: green",
+			properties: []string{"color"},
+			expected:   []string{"red !important"},
+		},
+		{
+			author:     "#main p { color: blue }",
+			inline:     "color: green; top: 1px !important; top: 2px",
+			properties: []string{"color", "top"},
+			expected:   []string{"green", "1px !important"},
+		},
+		{
+			ua:         "p { display: block; color: black !important; top: 0 }",
+			user:       "p { display: inline !important; top: 1px }",
+			author:     "p { display: flex !important; color: red; top: 2px }",
+			properties: []string{"display", "color", "top"},
+			expected:   []string{"inline !important (user)", "black !important (user-agent)", "2px"},
+		},
+		{
+			author:     "p { margin: 0 } .text { margin-left: 1px } p { margin-top: 2px } p { margin-block: 3px }",
+			properties: []string{"margin-top", "margin-right", "margin-bottom", "margin-left", "margin-block-start"},
+			expected:   []string{"2px", "0", "0", "1px", "3px"},
+		},
+		{
+			author:     "p { color: red; --Main: x } p { all: initial } p { top: 0 }",
+			properties: []string{"color", "top", "direction", "--Main", "--main"},
+			expected:   []string{"initial", "0", "", "x", ""},
+		},
+		{
+			author:     "@layer base, theme; @layer theme { #main p { color: red } } @layer base { p { color: blue; top: 0 !important } } p { top: 1px !important }",
+			properties: []string{"color", "top"},
+			expected:   []string{"red", "0 !important"},
+		},
+		{
+			author:     "@layer a { p { color: red } @layer b { #main p { color: blue } } } @layer a.c { p { color: green } } .text { color: black }",
+			properties: []string{"color"},
+			expected:   []string{"black"},
+		},
+		{
+			author:     "@layer a { p { color: red } @layer b { #main p { color: blue } } } @layer a.c { #main p { color: green } }",
+			properties: []string{"color"},
+			expected:   []string{"red"},
+		},
+		{
+			author:     "@layer { #main p { color: red } } @layer { p { color: blue } } @import url(x.css) layer(z);",
+			properties: []string{"color"},
+			expected:   []string{"blue"},
+		},
+		{
+			author:     "@media print { p { color: red } } @supports (display: grid) { p { top: 0 } } @font-face { color: blue }",
+			properties: []string{"color", "top"},
+			expected:   []string{"", "0"},
+		},
+		{
+			author:     ".box { color: red; & .text { color: blue } > p { top: 0 } @media screen { top: 1px } } .text { color: green }",
+			properties: []string{"color", "top"},
+			expected:   []string{"blue", "0"},
+		},
+		{
+			author:     ".box { .dark & p { color: red } } p:unknown(x) { color: blue } p ! { color: green }",
+			properties: []string{"color"},
+			expected:   []string{"red"},
+		},
+	}
+	condition := func(r *css.Rule) bool {
+		return !strings.Contains(css.ValuesString(r.Prelude), "print")
+	}
+	for _, tc := range tcs {
+		var sheets []Sheet
+		for _, s := range []struct {
+			input  string
+			origin Origin
+		}{{tc.ua, UserAgent}, {tc.user, User}, {tc.author, Author}} {
+			sheets = append(sheets, Sheet{Stylesheet: mustParse(t, s.input), Origin: s.origin})
+		}
+		var inline []*css.Declaration
+		if tc.inline != "" {
+			inline = mustParse(t, "a{"+tc.inline+"}").Rules[0].Declarations
+		}
+		c := New(sheets, Options{Condition: condition})
+		got := values(c.Resolve(tree(), inline), tc.properties)
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("%s:\ngot  %q\nwant %q", tc.author, got, tc.expected)
+		}
+	}
+}
+
+func TestResolveRule(t *testing.T) {
+	s := mustParse(t, "p { color: red } @media screen { .box .text { color: blue } }")
+	c := New([]Sheet{{Stylesheet: s, Origin: Author}}, Options{})
+	got := c.Resolve(tree(), nil)["color"]
+	r := s.Rules[1].Rules[0]
+	if got.Rule != r || got.Declaration != r.Declarations[0] || got.Specificity != (selector.Specificity{0, 2, 0}) {
+		t.Errorf("got %+v, want the declaration of %q", got, css.ValuesString(r.Prelude))
+	}
+}
+
+func TestCompute(t *testing.T) {
+	tcs := []struct {
+		author     string
+		properties []string
+		expected   []string
+	}{
+		{
+			author:     "body { color: red; margin: 1px } p { top: 0 }",
+			properties: []string{"color", "margin-top", "top", "cursor"},
+			expected:   []string{"color: red", "", "top: 0", ""},
+		},
+		{
+			author:     "body { margin: 1px; color: red } div { margin: inherit; color: initial } p { margin-top: unset; color: unset }",
+			properties: []string{"margin-top", "margin-left", "color"},
+			expected:   []string{"margin-top: 0", "", "color: canvastext"},
+		},
+		{
+			author:     "p { margin: 1px 2px 3px; overflow: hidden; padding: 1px / 2px }",
+			properties: []string{"margin-top", "margin-left", "margin-bottom", "overflow-y", "padding-top"},
+			expected:   []string{"margin-top: 1px", "margin-left: 2px", "margin-bottom: 3px", "overflow-y: hidden", "padding: 1px / 2px"},
+		},
+		{
+			author:     "body { font: 12px serif } p { font-weight: bold; border: 1px solid }",
+			properties: []string{"font-size", "font-weight", "border-top-width"},
+			expected:   []string{"font: 12px serif", "font-weight: bold", "border: 1px solid"},
+		},
+		{
+			author:     "html { --gap: 2px; --color: var(--main, blue) } div { --gap: 3px } p { margin: var(--gap) 0; color: var(--color); top: var(--none) }",
+			properties: []string{"margin-top", "margin-left", "color", "top", "--color", "--gap"},
+			expected:   []string{"margin-top: 3px", "margin-left: 0", "color: blue", "top: auto", "--color: blue", "--gap: 3px"},
+		},
+		{
+			author:     "div { --a: var(--b); --b: var(--a); color: red } p { --c: inherit; color: var(--a, green); top: var(--c, 1px) }",
+			properties: []string{"--a", "--b", "color", "top"},
+			expected:   []string{"", "", "color: green", "top: 1px"},
+		},
+		{
+			author:     "body { --k: inherit; color: red } p { color: var(--k); width: calc(var(--w, 2px) * 2) }",
+			properties: []string{"color", "width"},
+			expected:   []string{"color: red", "width: calc(2px * 2)"},
+		},
+	}
+	for _, tc := range tcs {
+		c := New([]Sheet{{Stylesheet: mustParse(t, tc.author), Origin: Author}}, Options{})
+		style := c.Compute(tree(), nil)
+		var got []string
+		for _, p := range tc.properties {
+			s := ""
+			if d, ok := style[p]; ok {
+				s = d.Property + ": " + css.ValuesString(css.TrimSpace(d.Value))
+			}
+			got = append(got, s)
+		}
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("%s:\ngot  %q\nwant %q", tc.author, got, tc.expected)
+		}
+	}
+}
+
+func TestComputeInline(t *testing.T) {
+	s := mustParse(t, "div { color: red } .text { margin: 0 }")
+	c := New([]Sheet{{Stylesheet: s, Origin: Author}}, Options{})
+	style := c.Compute(tree(), func(e selector.Element) []*css.Declaration {
+		if e.LocalName() == "div" {
+			return mustParse(t, "a{color: blue; font: 12px serif}").Rules[0].Declarations
+		}
+		return nil
+	})
+	var got []string
+	for _, d := range style.Declarations() {
+		got = append(got, d.Property+": "+css.ValuesString(css.TrimSpace(d.Value)))
+	}
+	expected := []string{"font: 12px serif", "color: blue", "margin-bottom: 0", "margin-left: 0", "margin-right: 0", "margin-top: 0"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got  %q\nwant %q", got, expected)
+	}
+}
@@ -0,0 +1,375 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cascade
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/props"
+	"github.com/gorilla/css/scanner"
+	"github.com/gorilla/css/selector"
+)
+
+// Style is the computed style of an element: the declarations setting its
+// properties, by property, with the keys of Resolve.
+//
+// The declaration of a property is one of the stylesheets, of an ancestor
+// for inherited values, or a new declaration of the property: for initial
+// values, for values with var() references, which are substituted, and for
+// the longhands of the shorthands taking one value per side or per axis,
+// such as margin or overflow, which are split. Other shorthands aren't
+// split, so the declaration of a longhand may be that of a shorthand
+// setting it, such as "font: 12px serif" for font-size.
+//
+// Properties not in the style have their initial value, except for custom
+// properties, which are then guaranteed-invalid. Values are the specified
+// values once CSS-wide keywords and var() references are resolved:
+// relative lengths, percentages and colors aren't computed further.
+type Style map[string]*css.Declaration
+
+// Declarations returns the declarations of the style, each once, sorted so
+// that they set the properties of the style when applied in order: the
+// shorthands first, from those setting the most longhands, then by
+// property.
+func (s Style) Declarations() []*css.Declaration {
+	seen := map[*css.Declaration]bool{}
+	var decls []*css.Declaration
+	for _, d := range s {
+		if !seen[d] {
+			seen[d] = true
+			decls = append(decls, d)
+		}
+	}
+	sort.Slice(decls, func(i, j int) bool {
+		a, b := len(longhands(decls[i].Property)), len(longhands(decls[j].Property))
+		if a != b {
+			return a > b
+		}
+		return decls[i].Property < decls[j].Property
+	})
+	return decls
+}
+
+// Compute returns the computed style of element e. It computes the styles
+// of the ancestors of e, from the root element, for the values e inherits.
+// inline, if not nil, returns the declarations of the style attribute of an
+// element.
+//
+// To compute the styles of every element of a document, ComputeFrom is
+// faster, reusing the styles of parents.
+func (c *Cascade) Compute(e selector.Element, inline func(e selector.Element) []*css.Declaration) Style {
+	var chain []selector.Element
+	for a := e; a != nil; a = a.Parent() {
+		chain = append(chain, a)
+	}
+	var style Style
+	for i := len(chain) - 1; i >= 0; i-- {
+		var decls []*css.Declaration
+		if inline != nil {
+			decls = inline(chain[i])
+		}
+		style = c.ComputeFrom(style, chain[i], decls)
+	}
+	return style
+}
+
+// ComputeFrom returns the computed style of element e, given the computed
+// style of its parent, nil for the root element, and the declarations of
+// its style attribute, if any.
+//
+// Inherited properties without a cascaded value take the value of the
+// parent. The CSS-wide keywords "inherit", "initial" and "unset" are
+// resolved, with the initial values of the props package; "revert" and
+// "revert-layer" are resolved as "unset". A value whose var() references
+// can't be substituted is also resolved as "unset".
+func (c *Cascade) ComputeFrom(parent Style, e selector.Element, inline []*css.Declaration) Style {
+	cascaded := c.Resolve(e, inline)
+	style := Style{}
+	for name, d := range parent {
+		if props.IsInherited(name) {
+			style[name] = d
+		}
+	}
+	cp := &computer{
+		parent:      parent,
+		style:       style,
+		cascaded:    cascaded,
+		state:       map[string]int{},
+		substituted: map[*css.Declaration]*css.Declaration{},
+		split:       map[*css.Declaration][]*css.Declaration{},
+	}
+	// Custom properties are computed first, for var() references.
+	var names []string
+	for name := range cascaded {
+		if isCustomProperty(name) {
+			cp.custom(name)
+		} else {
+			names = append(names, name)
+		}
+	}
+	for _, name := range names {
+		cp.property(name)
+	}
+	return style
+}
+
+// computer computes the style of an element.
+type computer struct {
+	parent, style Style
+	cascaded      map[string]Cascaded
+	// state is 1 for the custom properties being computed, and 2 for
+	// those computed.
+	state map[string]int
+	// substituted are the declarations with their var() references
+	// substituted, nil if they can't be, and split the declarations of the
+	// longhands of shorthand declarations, shared by the longhands.
+	substituted map[*css.Declaration]*css.Declaration
+	split       map[*css.Declaration][]*css.Declaration
+}
+
+// custom computes the custom property name, if it has a cascaded value and
+// isn't computed yet.
+func (cp *computer) custom(name string) {
+	if _, ok := cp.cascaded[name]; !ok || cp.state[name] != 0 {
+		return
+	}
+	cp.state[name] = 1
+	cp.property(name)
+	cp.state[name] = 2
+}
+
+// property sets the computed value of a property with a cascaded value.
+func (cp *computer) property(name string) {
+	d := cp.cascaded[name].Declaration
+	switch keyword(d.Value) {
+	case "inherit":
+		cp.inherit(name)
+		return
+	case "initial":
+		cp.initial(name)
+		return
+	case "unset", "revert", "revert-layer":
+		cp.unset(name)
+		return
+	}
+	if hasVar(d.Value) {
+		s, ok := cp.substituted[d]
+		if !ok {
+			if values, ok := cp.substitute(d.Value, 0); ok && keyword(values) == "" {
+				s = &css.Declaration{Property: d.Property, Value: values, Important: d.Important, Line: d.Line, Column: d.Column}
+			}
+			cp.substituted[d] = s
+		}
+		if s == nil {
+			cp.unset(name)
+			return
+		}
+		d = s
+	}
+	cp.style[name] = cp.longhand(name, d)
+}
+
+// longhand returns the declaration of the longhand name set by d, which is
+// split if it is a shorthand taking one value per side or per axis.
+func (cp *computer) longhand(name string, d *css.Declaration) *css.Declaration {
+	if isCustomProperty(name) || strings.EqualFold(d.Property, name) {
+		return d
+	}
+	decls, ok := cp.split[d]
+	if !ok {
+		decls = splitSides(d)
+		cp.split[d] = decls
+	}
+	for _, l := range decls {
+		if l.Property == name {
+			return l
+		}
+	}
+	return d
+}
+
+// inherit sets the value of a property to that of the parent.
+func (cp *computer) inherit(name string) {
+	if d, ok := cp.parent[name]; ok {
+		cp.style[name] = d
+		return
+	}
+	cp.initial(name)
+}
+
+// initial sets the value of a property to its initial value, or to
+// "initial" if the initial value isn't known.
+func (cp *computer) initial(name string) {
+	values := props.InitialValue(name)
+	switch {
+	case isCustomProperty(name):
+		delete(cp.style, name)
+	case values == nil:
+		values = []*css.ComponentValue{{Token: &scanner.Token{Type: scanner.TokenIdent, Value: "initial"}}}
+		fallthrough
+	default:
+		cp.style[name] = &css.Declaration{Property: name, Value: values}
+	}
+}
+
+// unset sets the value of a property to that of the parent if it is
+// inherited, and to its initial value otherwise.
+func (cp *computer) unset(name string) {
+	if props.IsInherited(name) {
+		cp.inherit(name)
+		return
+	}
+	cp.initial(name)
+}
+
+// maxSubstitutionDepth is the maximum depth of nested var() fallbacks and
+// references substituted in a value.
+const maxSubstitutionDepth = 32
+
+// substitute returns a copy of values with their var() references replaced
+// by the values of the custom properties, or their fallback values, and
+// reports whether they all could be.
+func (cp *computer) substitute(values []*css.ComponentValue, depth int) ([]*css.ComponentValue, bool) {
+	if depth > maxSubstitutionDepth {
+		return nil, false
+	}
+	var out []*css.ComponentValue
+	for _, v := range values {
+		if !hasVar([]*css.ComponentValue{v}) {
+			out = append(out, v)
+			continue
+		}
+		if !v.IsFunction() || !strings.EqualFold(v.Name(), "var") {
+			children, ok := cp.substitute(v.Children, depth)
+			if !ok {
+				return nil, false
+			}
+			out = append(out, &css.ComponentValue{Token: v.Token, Children: children})
+			continue
+		}
+		name, fallback, ok := varArgs(v.Children)
+		if !ok {
+			return nil, false
+		}
+		// A custom property referencing itself, directly or through
+		// others, is guaranteed-invalid.
+		cp.custom(name)
+		if d, ok := cp.style[name]; ok && cp.state[name] != 1 {
+			out = append(out, css.TrimSpace(d.Value)...)
+			continue
+		}
+		if fallback == nil {
+			return nil, false
+		}
+		values, ok := cp.substitute(fallback, depth+1)
+		if !ok {
+			return nil, false
+		}
+		out = append(out, css.TrimSpace(values)...)
+	}
+	return out, true
+}
+
+// varArgs returns the custom property name and the fallback value of the
+// arguments of a var() function, nil if there is none, and whether they
+// are valid.
+func varArgs(args []*css.ComponentValue) (name string, fallback []*css.ComponentValue, ok bool) {
+	args = css.TrimSpace(args)
+	if len(args) == 0 || args[0].Token.Type != scanner.TokenIdent || !isCustomProperty(args[0].Token.Value) {
+		return "", nil, false
+	}
+	name = args[0].Token.DecodedValue()
+	rest := css.TrimSpace(args[1:])
+	if len(rest) == 0 {
+		return name, nil, true
+	}
+	if rest[0].Token.Type != scanner.TokenChar || rest[0].Token.Value != "," {
+		return "", nil, false
+	}
+	return name, append([]*css.ComponentValue{}, rest[1:]...), true
+}
+
+// hasVar reports whether values have a var() reference.
+func hasVar(values []*css.ComponentValue) bool {
+	for _, v := range values {
+		if v.IsFunction() && strings.EqualFold(v.Name(), "var") || hasVar(v.Children) {
+			return true
+		}
+	}
+	return false
+}
+
+// keyword returns the lowercase CSS-wide keyword a value consists of, or an
+// empty string.
+func keyword(values []*css.ComponentValue) string {
+	values = css.TrimSpace(values)
+	if len(values) != 1 || values[0].Token.Type != scanner.TokenIdent {
+		return ""
+	}
+	switch k := strings.ToLower(values[0].Token.Value); k {
+	case "inherit", "initial", "unset", "revert", "revert-layer":
+		return k
+	}
+	return ""
+}
+
+// splitSides returns the declarations of the longhands of a shorthand
+// declaration taking one to four values for the sides, such as margin, or
+// one or two values for the axes, such as overflow, or nil if d isn't one
+// or its value can't be split.
+func splitSides(d *css.Declaration) []*css.Declaration {
+	p := props.Lookup(d.Property)
+	if p == nil {
+		return nil
+	}
+	max := 0
+	switch {
+	case len(p.Longhands) == 4 && strings.HasSuffix(p.Value, "{1,4}"):
+		max = 4
+	case len(p.Longhands) == 2 && strings.HasSuffix(p.Value, "{1,2}"):
+		max = 2
+	default:
+		return nil
+	}
+	var parts [][]*css.ComponentValue
+	start := 0
+	values := css.TrimSpace(d.Value)
+	for i := 0; i <= len(values); i++ {
+		if i < len(values) && values[i].Token.Type != scanner.TokenS {
+			if t := values[i].Token; t.Type == scanner.TokenChar && (t.Value == "," || t.Value == "/") {
+				return nil
+			}
+			continue
+		}
+		if i > start {
+			parts = append(parts, values[start:i])
+		}
+		start = i + 1
+	}
+	if len(parts) == 0 || len(parts) > max {
+		return nil
+	}
+	// The missing values are those of the opposite side, or of the first
+	// one.
+	for i := len(parts); i < max; i++ {
+		if i >= 2 {
+			parts = append(parts, parts[i-2])
+		} else {
+			parts = append(parts, parts[0])
+		}
+	}
+	decls := make([]*css.Declaration, max)
+	for i, l := range p.Longhands {
+		decls[i] = &css.Declaration{Property: l, Value: parts[i], Important: d.Important, Line: d.Line, Column: d.Column}
+	}
+	return decls
+}
+
+// isCustomProperty reports whether name is the name of a custom property.
+func isCustomProperty(name string) bool {
+	return strings.HasPrefix(name, "--")
+}
@@ -0,0 +1,191 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// maxDiffCells bounds the size of the table of the longest common
+// subsequence of the lines of two files. Beyond it, the lines that differ
+// are all reported as replaced.
+const maxDiffCells = 1 << 22
+
+// contextLines is the number of unchanged lines around the changes of a
+// hunk.
+const contextLines = 3
+
+// edit is a line of a diff: kind is ' ' for a line of both files, '-' for a
+// line of the old file only and '+' for a line of the new file only.
+type edit struct {
+	kind byte
+	line string
+}
+
+// unifiedDiff returns the differences between old and new, named oldName
+// and newName, in the unified format of diff -u, or nil if they are equal.
+func unifiedDiff(oldName, newName string, old, new []byte) []byte {
+	edits := diffLines(splitLines(old), splitLines(new))
+	var b bytes.Buffer
+	for i := 0; i < len(edits); {
+		if edits[i].kind == ' ' {
+			i++
+			continue
+		}
+		// A hunk starts contextLines before the first change, and ends
+		// contextLines after the last change that isn't followed by more
+		// than 2*contextLines unchanged lines.
+		start := i - contextLines
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for j := i; j < len(edits); j++ {
+			if edits[j].kind != ' ' {
+				end = j + 1
+			} else if j-end >= 2*contextLines {
+				break
+			}
+		}
+		stop := end + contextLines
+		if stop > len(edits) {
+			stop = len(edits)
+		}
+		if b.Len() == 0 {
+			fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+		}
+		writeHunk(&b, edits, start, stop)
+		i = stop
+	}
+	if b.Len() == 0 {
+		return nil
+	}
+	return b.Bytes()
+}
+
+// writeHunk writes the hunk of edits[start:stop] to b.
+func writeHunk(b *bytes.Buffer, edits []edit, start, stop int) {
+	// The hunk starts after the lines of the files before it.
+	oldLine, newLine := 1, 1
+	for _, e := range edits[:start] {
+		if e.kind != '+' {
+			oldLine++
+		}
+		if e.kind != '-' {
+			newLine++
+		}
+	}
+	oldCount, newCount := 0, 0
+	for _, e := range edits[start:stop] {
+		if e.kind != '+' {
+			oldCount++
+		}
+		if e.kind != '-' {
+			newCount++
+		}
+	}
+	// Empty ranges are numbered by the line before them.
+	if oldCount == 0 {
+		oldLine--
+	}
+	if newCount == 0 {
+		newLine--
+	}
+	fmt.Fprintf(b, "@@ -%d,%d +%d,%d @@\n", oldLine, oldCount, newLine, newCount)
+	for _, e := range edits[start:stop] {
+		b.WriteByte(e.kind)
+		b.WriteString(e.line)
+		if !strings.HasSuffix(e.line, "\n") {
+			b.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// splitLines splits b into lines, keeping their line feeds.
+func splitLines(b []byte) []string {
+	var lines []string
+	for len(b) > 0 {
+		i := bytes.IndexByte(b, '\n') + 1
+		if i == 0 {
+			i = len(b)
+		}
+		lines = append(lines, string(b[:i]))
+		b = b[i:]
+	}
+	return lines
+}
+
+// diffLines returns the edits turning the lines a into the lines b, keeping
+// their longest common subsequence.
+func diffLines(a, b []string) []edit {
+	var prefix, suffix []edit
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		prefix = append(prefix, edit{' ', a[0]})
+		a, b = a[1:], b[1:]
+	}
+	for len(a) > 0 && len(b) > 0 && a[len(a)-1] == b[len(b)-1] {
+		suffix = append(suffix, edit{' ', a[len(a)-1]})
+		a, b = a[:len(a)-1], b[:len(b)-1]
+	}
+	edits := prefix
+	if (len(a)+1)*(len(b)+1) > maxDiffCells {
+		for _, line := range a {
+			edits = append(edits, edit{'-', line})
+		}
+		for _, line := range b {
+			edits = append(edits, edit{'+', line})
+		}
+	} else {
+		edits = append(edits, lcsEdits(a, b)...)
+	}
+	for i := len(suffix) - 1; i >= 0; i-- {
+		edits = append(edits, suffix[i])
+	}
+	return edits
+}
+
+// lcsEdits returns the edits turning a into b computed from the table of
+// the lengths of the longest common subsequences of their suffixes.
+func lcsEdits(a, b []string) []edit {
+	n, m := len(a), len(b)
+	table := make([]int32, (n+1)*(m+1))
+	at := func(i, j int) int32 { return table[i*(m+1)+j] }
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				table[i*(m+1)+j] = at(i+1, j+1) + 1
+			case at(i+1, j) >= at(i, j+1):
+				table[i*(m+1)+j] = at(i+1, j)
+			default:
+				table[i*(m+1)+j] = at(i, j+1)
+			}
+		}
+	}
+	var edits []edit
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			edits = append(edits, edit{' ', a[i]})
+			i, j = i+1, j+1
+		case at(i+1, j) >= at(i, j+1):
+			edits = append(edits, edit{'-', a[i]})
+			i++
+		default:
+			edits = append(edits, edit{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		edits = append(edits, edit{'-', a[i]})
+	}
+	for ; j < m; j++ {
+		edits = append(edits, edit{'+', b[j]})
+	}
+	return edits
+}
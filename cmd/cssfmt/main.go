@@ -0,0 +1,169 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Cssfmt formats stylesheets with the gorilla/css/format package.
+
+Usage:
+
+	cssfmt [flags] [path ...]
+
+Without paths, it formats its standard input. Paths that are directories
+are walked for .css files. By default, the formatted stylesheets are written
+to the standard output. The flags are:
+
+	-d
+		Display diffs instead of rewriting files.
+	-l
+		List the files whose formatting differs from cssfmt's.
+	-w
+		Write the result to the source file instead of the standard output.
+	-indent string
+		Indentation of each nesting level (default two spaces).
+	-s
+		Sort the declarations of each block alphabetically.
+	-collapse
+		Write rules with a single declaration on one line.
+
+The exit status is 2 if a file can't be read or parsed, and 0 otherwise.
+*/
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/format"
+)
+
+// config holds the flags of the command.
+type config struct {
+	diff, list, write bool
+	opts              format.Options
+}
+
+func main() {
+	var c config
+	var sort bool
+	flag.BoolVar(&c.diff, "d", false, "display diffs instead of rewriting files")
+	flag.BoolVar(&c.list, "l", false, "list files whose formatting differs from cssfmt's")
+	flag.BoolVar(&c.write, "w", false, "write result to (source) file instead of stdout")
+	flag.StringVar(&c.opts.Indent, "indent", "  ", "indentation of each nesting level")
+	flag.BoolVar(&sort, "s", false, "sort declarations alphabetically")
+	flag.BoolVar(&c.opts.CollapseSingle, "collapse", false, "write rules with a single declaration on one line")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: cssfmt [flags] [path ...]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if sort {
+		c.opts.Order = format.Alphabetical
+	}
+	os.Exit(run(&c, flag.Args(), os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run formats the files at paths, or in if there are none, and returns the
+// exit status. Errors are reported to stderr.
+func run(c *config, paths []string, in io.Reader, stdout, stderr io.Writer) int {
+	status := 0
+	report := func(err error) {
+		fmt.Fprintln(stderr, err)
+		status = 2
+	}
+	if len(paths) == 0 {
+		if c.write {
+			report(errors.New("cssfmt: cannot use -w with standard input"))
+			return status
+		}
+		src, err := io.ReadAll(in)
+		if err != nil {
+			report(err)
+			return status
+		}
+		if err := c.process("<standard input>", src, stdout); err != nil {
+			report(err)
+		}
+		return status
+	}
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(name string, d fs.DirEntry, err error) error {
+			if err != nil {
+				report(err)
+				return nil
+			}
+			// Files named explicitly are formatted whatever their extension.
+			if d.IsDir() || name != path && filepath.Ext(name) != ".css" {
+				return nil
+			}
+			if err := c.processFile(name, stdout); err != nil {
+				report(err)
+			}
+			return nil
+		})
+		if err != nil {
+			report(err)
+		}
+	}
+	return status
+}
+
+// processFile formats the file named name.
+func (c *config) processFile(name string, stdout io.Writer) error {
+	src, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	return c.process(name, src, stdout)
+}
+
+// process formats src, read from the file named name, and writes the result
+// as requested by the flags.
+func (c *config) process(name string, src []byte, stdout io.Writer) error {
+	s, err := css.ParseStylesheet(string(src))
+	if err != nil {
+		return parseError(name, err)
+	}
+	res := []byte(format.String(s, c.opts))
+	if !c.list && !c.write && !c.diff {
+		_, err := stdout.Write(res)
+		return err
+	}
+	if bytes.Equal(src, res) {
+		return nil
+	}
+	if c.list {
+		fmt.Fprintln(stdout, name)
+	}
+	if c.write {
+		fi, err := os.Stat(name)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(name, res, fi.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	if c.diff {
+		_, err := stdout.Write(unifiedDiff(name+".orig", name, src, res))
+		return err
+	}
+	return nil
+}
+
+// parseError returns err prefixed with the position in the file named name,
+// as is usual for compilers and linters.
+func parseError(name string, err error) error {
+	var parseErr *css.ParseError
+	if errors.As(err, &parseErr) {
+		return fmt.Errorf("%s:%d:%d: %s", name, parseErr.Line, parseErr.Column, parseErr.Msg)
+	}
+	return fmt.Errorf("%s: %w", name, err)
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/css/format"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.css":       "a{color:red}\n",
+		"sub/b.css":   "b {\n  margin: 0;\n}\n",
+		"sub/c.txt":   "c{}",
+		"invalid.css": "d { content: 'e }",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	a, invalid := filepath.Join(dir, "a.css"), filepath.Join(dir, "invalid.css")
+	tcs := []struct {
+		desc   string
+		config config
+		paths  []string
+		stdin  string
+		stdout string
+		stderr string
+		status int
+	}{
+		{
+			desc:   "stdin",
+			stdin:  "a{color:red;margin:0}",
+			stdout: "a {\n  color: red;\n  margin: 0;\n}\n",
+		},
+		{
+			desc:   "sort",
+			config: config{opts: format.Options{Order: format.Alphabetical, CollapseSingle: true}},
+			stdin:  "a{margin:0;color:red}b{top:0}",
+			stdout: "a {\n  color: red;\n  margin: 0;\n}\n\nb { top: 0; }\n",
+		},
+		{
+			desc:   "list",
+			config: config{list: true},
+			paths:  []string{filepath.Join(dir, "a.css"), filepath.Join(dir, "sub")},
+			stdout: a + "\n",
+		},
+		{
+			desc:   "diff",
+			config: config{diff: true},
+			paths:  []string{a},
+			stdout: "--- " + a + ".orig\n+++ " + a + "\n@@ -1,1 +1,3 @@\n-a{color:red}\n+a {\n+  color: red;\n+}\n",
+		},
+		{
+			desc:   "parse error",
+			paths:  []string{invalid},
+			stderr: invalid + ":1:14: unclosed quotation mark\n",
+			status: 2,
+		},
+		{
+			desc:   "write stdin",
+			config: config{write: true},
+			stderr: "cssfmt: cannot use -w with standard input\n",
+			status: 2,
+		},
+	}
+	for _, tc := range tcs {
+		var stdout, stderr bytes.Buffer
+		status := run(&tc.config, tc.paths, strings.NewReader(tc.stdin), &stdout, &stderr)
+		if status != tc.status || stdout.String() != tc.stdout || stderr.String() != tc.stderr {
+			t.Errorf("%s: got %d, %q, %q, want %d, %q, %q", tc.desc, status, stdout.String(), stderr.String(), tc.status, tc.stdout, tc.stderr)
+		}
+	}
+
+	// Files are rewritten in place, and formatting them again changes
+	// nothing.
+	var stdout, stderr bytes.Buffer
+	if status := run(&config{write: true, list: true}, []string{dir}, nil, &stdout, &stderr); status != 2 {
+		t.Errorf("got status %d, want 2 for the invalid file", status)
+	}
+	if got, _ := os.ReadFile(a); string(got) != "a {\n  color: red;\n}\n" {
+		t.Errorf("got %q after -w", got)
+	}
+	if got, _ := os.ReadFile(filepath.Join(dir, "sub/c.txt")); string(got) != "c{}" {
+		t.Errorf("got %q, want the file without the .css extension unchanged", got)
+	}
+	stdout.Reset()
+	run(&config{list: true}, []string{a, filepath.Join(dir, "sub")}, nil, &stdout, &stderr)
+	if stdout.Len() != 0 {
+		t.Errorf("got %q, want no file listed once formatted", stdout.String())
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	tcs := []struct{ old, new, expected string }{
+		{"a\nb\n", "a\nb\n", ""},
+		{
+			"1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n16\n",
+			"1\n2\nx\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n16\n",
+			"--- a\n+++ b\n@@ -1,6 +1,6 @@\n 1\n 2\n-3\n+x\n 4\n 5\n 6\n@@ -12,5 +12,4 @@\n 12\n 13\n 14\n-15\n 16\n",
+		},
+		{
+			"1\n2\n3\n",
+			"1\nx\n3\ny\n",
+			"--- a\n+++ b\n@@ -1,3 +1,4 @@\n 1\n-2\n+x\n 3\n+y\n",
+		},
+		{"", "a", "--- a\n+++ b\n@@ -0,0 +1,1 @@\n+a\n\\ No newline at end of file\n"},
+	}
+	for _, tc := range tcs {
+		if got := string(unifiedDiff("a", "b", []byte(tc.old), []byte(tc.new))); got != tc.expected {
+			t.Errorf("%q, %q: got %q, want %q", tc.old, tc.new, got, tc.expected)
+		}
+	}
+}
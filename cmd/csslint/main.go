@@ -0,0 +1,360 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Csslint checks stylesheets with the lint passes of the gorilla/css/validate
+package.
+
+Usage:
+
+	csslint [flags] [path ...]
+
+Without paths, it checks its standard input. Paths that are directories are
+walked for .css files. The flags are:
+
+	-config file
+		Read the configuration from file, .csslint.json by default if it
+		exists.
+	-format text|json|sarif
+		Write the problems as lines of text, as a JSON array, or as a SARIF
+		log for code scanning (default text).
+	-max-warnings n
+		Fail if there are more than n warnings (default -1, no limit).
+	-specificity
+		Write the selectors of the stylesheets with their specificity,
+		instead of the problems, as lines of text or as a JSON array,
+		for specificity graphs.
+
+The configuration is a JSON object enabling, disabling or changing the
+severity of rules by their diagnostic code, and setting the maximum
+specificity of selectors, which enables the high-specificity rule:
+
+	{
+		"rules": {
+			"empty-rule": "off",
+			"unknown-property": "error",
+			"duplicate-declaration": "warning"
+		},
+		"maxSpecificity": [0, 4, 0]
+	}
+
+All the rules are enabled by default, with the severity of their
+diagnostics, except high-specificity. Parse errors, such as an unclosed
+string, are always reported as errors, with the "parse-error" code. The
+declarations and rules dropped by the parser are reported as warnings with
+the "invalid-declaration" and "incomplete-rule" codes.
+
+With -specificity, a line of text has the position, the specificity and
+the selector, as in "a.css:3:1: (1,1,0) #nav .active", and the elements of
+the JSON array have the fields "file", "line", "column", "specificity" and
+"selector". Files that can't be parsed are left out of the report and their
+errors written to the standard error.
+
+The exit status is 1 if an error was found or there are too many warnings,
+2 if a file or the configuration can't be read, and 0 otherwise.
+*/
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/diag"
+	"github.com/gorilla/css/sarif"
+	"github.com/gorilla/css/selector"
+	"github.com/gorilla/css/validate"
+)
+
+// defaultConfig is the configuration file read if -config isn't set.
+const defaultConfig = ".csslint.json"
+
+// config is the configuration of the rules.
+type config struct {
+	// Rules maps diagnostic codes to "off", "warning" or "error".
+	Rules map[string]string `json:"rules"`
+	// MaxSpecificity enables the high-specificity rule if it isn't zero.
+	MaxSpecificity selector.Specificity `json:"maxSpecificity"`
+}
+
+// problem is a diagnostic found in a file.
+type problem struct {
+	file string
+	validate.Diagnostic
+}
+
+// entry is a selector of a file with its specificity.
+type entry struct {
+	file string
+	selector.Entry
+}
+
+func main() {
+	configFile := flag.String("config", "", "read the configuration from `file`")
+	format := flag.String("format", "text", "output format: text, json or sarif")
+	maxWarnings := flag.Int("max-warnings", -1, "fail if there are more than `n` warnings")
+	specificity := flag.Bool("specificity", false, "write the specificity of the selectors instead of the problems")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: csslint [flags] [path ...]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	c, err := readConfig(*configFile)
+	if err == nil && *format != "text" && *format != "json" && *format != "sarif" {
+		err = fmt.Errorf("csslint: unknown format %q", *format)
+	}
+	if err == nil && *specificity && *format == "sarif" {
+		err = errors.New("csslint: -specificity can't be written in the sarif format")
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if *specificity {
+		entries, problems, err := reportPaths(flag.Args(), os.Stdin)
+		if err == nil {
+			err = writeReport(os.Stdout, *format, entries)
+		}
+		if err == nil {
+			err = write(os.Stderr, "text", problems)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		os.Exit(status(problems, -1))
+	}
+	problems, err := lintPaths(c, flag.Args(), os.Stdin)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if err := write(os.Stdout, *format, problems); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	os.Exit(status(problems, *maxWarnings))
+}
+
+// readConfig reads the configuration file named name, or the default
+// configuration file if name is empty.
+func readConfig(name string) (*config, error) {
+	c := &config{}
+	optional := name == ""
+	if optional {
+		name = defaultConfig
+	}
+	b, err := os.ReadFile(name)
+	if optional && errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	for code, severity := range c.Rules {
+		if severity != "off" && severity != "warning" && severity != "error" {
+			return nil, fmt.Errorf("%s: invalid severity %q for rule %q", name, severity, code)
+		}
+	}
+	return c, nil
+}
+
+// lintPaths checks the files at paths, or in if there are none, and returns
+// the problems found.
+func lintPaths(c *config, paths []string, in io.Reader) ([]problem, error) {
+	var problems []problem
+	err := readPaths(paths, in, func(name, src string) error {
+		problems = append(problems, lint(c, name, src)...)
+		return nil
+	})
+	return problems, err
+}
+
+// readPaths calls fn with the name and content of the files at paths, or of
+// in if there are none, and stops at the first error.
+func readPaths(paths []string, in io.Reader, fn func(name, src string) error) error {
+	if len(paths) == 0 {
+		src, err := io.ReadAll(in)
+		if err != nil {
+			return err
+		}
+		return fn("<standard input>", string(src))
+	}
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(name string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			// Files named explicitly are checked whatever their extension.
+			if d.IsDir() || name != path && filepath.Ext(name) != ".css" {
+				return nil
+			}
+			src, err := os.ReadFile(name)
+			if err != nil {
+				return err
+			}
+			return fn(name, string(src))
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reportPaths returns the selectors of the files at paths, or of in if there
+// are none, and the parse errors of the files that can't be parsed.
+func reportPaths(paths []string, in io.Reader) ([]entry, []problem, error) {
+	var entries []entry
+	var problems []problem
+	err := readPaths(paths, in, func(name, src string) error {
+		s, err := css.ParseStylesheet(src)
+		if err != nil {
+			problems = append(problems, problem{name, css.ErrorDiagnostic(err)})
+			return nil
+		}
+		for _, e := range selector.Report(s) {
+			entries = append(entries, entry{name, e})
+		}
+		return nil
+	})
+	return entries, problems, err
+}
+
+// lint checks the stylesheet src of the file named name, and returns the
+// problems found in the order of their position, with the severity set by
+// the configuration.
+func lint(c *config, name, src string) []problem {
+	var diags []validate.Diagnostic
+	p := css.Parser{OnWarning: func(d diag.Diagnostic) {
+		diags = append(diags, d)
+	}}
+	s, err := p.ParseStylesheet(src)
+	if err != nil {
+		return []problem{{name, css.ErrorDiagnostic(err)}}
+	}
+	diags = append(diags, validate.Stylesheet(s)...)
+	diags = append(diags, validate.Confusables(s)...)
+	diags = append(diags, validate.UnknownProperties(s)...)
+	diags = append(diags, validate.Duplicates(s)...)
+	diags = append(diags, validate.DuplicateRules(s)...)
+	diags = append(diags, validate.EmptyRules(s)...)
+	if c.MaxSpecificity != (selector.Specificity{}) {
+		diags = append(diags, validate.HighSpecificity(s, c.MaxSpecificity)...)
+	}
+	var problems []problem
+	for _, d := range diags {
+		switch c.Rules[d.Code] {
+		case "off":
+			continue
+		case "warning":
+			d.Severity = validate.Warning
+		case "error":
+			d.Severity = validate.Error
+		}
+		problems = append(problems, problem{name, d})
+	}
+	sort.SliceStable(problems, func(i, j int) bool {
+		a, b := problems[i].Span.Start, problems[j].Span.Start
+		return a.Line < b.Line || a.Line == b.Line && a.Column < b.Column
+	})
+	return problems
+}
+
+// write writes the problems to w in the given format.
+func write(w io.Writer, format string, problems []problem) error {
+	switch format {
+	case "json":
+		type jsonProblem struct {
+			File       string `json:"file"`
+			Line       int    `json:"line"`
+			Column     int    `json:"column"`
+			EndLine    int    `json:"endLine"`
+			EndColumn  int    `json:"endColumn"`
+			Severity   string `json:"severity"`
+			Code       string `json:"code"`
+			Message    string `json:"message"`
+			Suggestion string `json:"suggestion,omitempty"`
+		}
+		list := []jsonProblem{}
+		for _, p := range problems {
+			list = append(list, jsonProblem{
+				p.file, p.Span.Start.Line, p.Span.Start.Column, p.Span.End.Line, p.Span.End.Column,
+				p.Severity.String(), p.Code, p.Message, p.Suggestion,
+			})
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(list)
+	case "sarif":
+		log := &sarif.Log{ToolName: "csslint"}
+		for _, p := range problems {
+			log.AddDiagnostic(p.file, p.Diagnostic)
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(log)
+	}
+	for _, p := range problems {
+		_, err := fmt.Fprintf(w, "%s:%d:%d: %s: %s [%s]\n", p.file, p.Span.Start.Line, p.Span.Start.Column, p.Severity, p.Message, p.Code)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeReport writes the selectors to w in the given format, "text" or
+// "json".
+func writeReport(w io.Writer, format string, entries []entry) error {
+	if format == "json" {
+		type jsonEntry struct {
+			File        string               `json:"file"`
+			Line        int                  `json:"line"`
+			Column      int                  `json:"column"`
+			Specificity selector.Specificity `json:"specificity"`
+			Selector    string               `json:"selector"`
+		}
+		list := []jsonEntry{}
+		for _, e := range entries {
+			list = append(list, jsonEntry{e.file, e.Line, e.Column, e.Specificity, e.Selector})
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(list)
+	}
+	for _, e := range entries {
+		_, err := fmt.Fprintf(w, "%s:%d:%d: %s %s\n", e.file, e.Line, e.Column, e.Specificity, e.Selector)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// status returns the exit status for the problems found.
+func status(problems []problem, maxWarnings int) int {
+	warnings := 0
+	for _, p := range problems {
+		if p.Severity == validate.Error {
+			return 1
+		}
+		warnings++
+	}
+	if maxWarnings >= 0 && warnings > maxWarnings {
+		return 1
+	}
+	return 0
+}
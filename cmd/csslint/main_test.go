@@ -0,0 +1,221 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/css/selector"
+)
+
+const input = `a { colr: red; display: blok }
+b {}
+#x .y.z { color: red; color: red }
+`
+
+func TestLint(t *testing.T) {
+	tcs := []struct {
+		desc     string
+		config   config
+		input    string
+		expected []string
+	}{
+		{
+			desc:  "defaults",
+			input: input,
+			expected: []string{
+				"1:5 warning unknown-property",
+				"1:25 error unknown-keyword",
+				"2:1 warning empty-rule",
+				"3:23 warning duplicate-declaration",
+			},
+		},
+		{
+			desc: "configured",
+			config: config{
+				Rules:          map[string]string{"empty-rule": "off", "unknown-property": "error", "unknown-keyword": "warning"},
+				MaxSpecificity: selector.Specificity{1, 0, 0},
+			},
+			input: input,
+			expected: []string{
+				"1:5 error unknown-property",
+				"1:25 warning unknown-keyword",
+				"3:1 warning high-specificity",
+				"3:23 warning duplicate-declaration",
+			},
+		},
+		{
+			desc:     "parse error",
+			config:   config{Rules: map[string]string{"parse-error": "off"}},
+			input:    "a { content: 'b }",
+			expected: []string{"1:14 error parse-error"},
+		},
+		{
+			desc:     "dropped",
+			config:   config{Rules: map[string]string{"incomplete-rule": "error"}},
+			input:    "a { color red; margin: 0 }\nb",
+			expected: []string{"1:5 warning invalid-declaration", "2:1 error incomplete-rule"},
+		},
+	}
+	for _, tc := range tcs {
+		var got []string
+		for _, p := range lint(&tc.config, "a.css", tc.input) {
+			got = append(got, fmt.Sprintf("%d:%d %s %s", p.Span.Start.Line, p.Span.Start.Column, p.Severity, p.Code))
+		}
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("%s:\ngot  %q\nwant %q", tc.desc, got, tc.expected)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	problems := lint(&config{}, "a.css", input)
+	var b bytes.Buffer
+	if err := write(&b, "text", problems[:2]); err != nil {
+		t.Fatal(err)
+	}
+	expected := `a.css:1:5: warning: unknown property "colr", did you mean "color"? [unknown-property]
+a.css:1:25: error: unknown keyword "blok" for property "display", did you mean "block"? [unknown-keyword]
+`
+	if b.String() != expected {
+		t.Errorf("got %q, want %q", b.String(), expected)
+	}
+
+	b.Reset()
+	if err := write(&b, "json", problems[:1]); err != nil {
+		t.Fatal(err)
+	}
+	var list []map[string]interface{}
+	if err := json.Unmarshal(b.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0]["file"] != "a.css" || list[0]["endColumn"] != 9.0 || list[0]["suggestion"] != "color" {
+		t.Errorf("got %s", b.Bytes())
+	}
+
+	b.Reset()
+	if err := write(&b, "sarif", problems); err != nil {
+		t.Fatal(err)
+	}
+	var log struct {
+		Runs []struct {
+			Results []struct {
+				RuleID string
+				Level  string
+			}
+		}
+	}
+	if err := json.Unmarshal(b.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+	if r := log.Runs[0].Results; len(r) != 4 || r[1].RuleID != "unknown-keyword" || r[1].Level != "error" {
+		t.Errorf("got %s", b.Bytes())
+	}
+}
+
+func TestReport(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{"a.css": input, "b.css": "a { content: 'b }"}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	entries, problems, err := reportPaths([]string{dir}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 1 || problems[0].file != filepath.Join(dir, "b.css") || problems[0].Code != "parse-error" {
+		t.Errorf("got problems %v", problems)
+	}
+	var b bytes.Buffer
+	if err := writeReport(&b, "text", entries); err != nil {
+		t.Fatal(err)
+	}
+	name := filepath.Join(dir, "a.css")
+	expected := name + ":1:1: (0,0,1) a\n" + name + ":2:1: (0,0,1) b\n" + name + ":3:1: (1,2,0) #x .y.z\n"
+	if b.String() != expected {
+		t.Errorf("got %q, want %q", b.String(), expected)
+	}
+
+	b.Reset()
+	if err := writeReport(&b, "json", entries[2:]); err != nil {
+		t.Fatal(err)
+	}
+	var list []struct {
+		File        string
+		Line        int
+		Specificity selector.Specificity
+		Selector    string
+	}
+	if err := json.Unmarshal(b.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].File != name || list[0].Line != 3 || list[0].Specificity != (selector.Specificity{1, 2, 0}) || list[0].Selector != "#x .y.z" {
+		t.Errorf("got %s", b.Bytes())
+	}
+}
+
+func TestStatus(t *testing.T) {
+	warnings := lint(&config{Rules: map[string]string{"unknown-keyword": "off"}}, "a.css", input)
+	errors := lint(&config{}, "a.css", input)
+	tcs := []struct {
+		problems    []problem
+		maxWarnings int
+		expected    int
+	}{
+		{nil, 0, 0},
+		{warnings, -1, 0},
+		{warnings, 3, 0},
+		{warnings, 2, 1},
+		{errors, -1, 1},
+	}
+	for _, tc := range tcs {
+		if got := status(tc.problems, tc.maxWarnings); got != tc.expected {
+			t.Errorf("%d problems, %d max warnings: got %d, want %d", len(tc.problems), tc.maxWarnings, got, tc.expected)
+		}
+	}
+}
+
+func TestConfig(t *testing.T) {
+	dir := t.TempDir()
+	tcs := []struct {
+		content string
+		err     string
+	}{
+		{`{"rules": {"empty-rule": "off"}, "maxSpecificity": [0, 4, 0]}`, ""},
+		{`{"rules": {"empty-rule": "ignore"}}`, `invalid severity "ignore" for rule "empty-rule"`},
+		{`{"rules": [`, "unexpected end of JSON input"},
+	}
+	for i, tc := range tcs {
+		name := filepath.Join(dir, fmt.Sprintf("%d.json", i))
+		if err := os.WriteFile(name, []byte(tc.content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		c, err := readConfig(name)
+		if tc.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("%s: got %v, want an error containing %q", tc.content, err, tc.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.Rules["empty-rule"] != "off" || c.MaxSpecificity != (selector.Specificity{0, 4, 0}) {
+			t.Errorf("%s: got %+v", tc.content, c)
+		}
+	}
+	if _, err := readConfig(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected an error for a missing configuration file")
+	}
+}
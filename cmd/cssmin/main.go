@@ -0,0 +1,343 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Cssmin minifies and concatenates stylesheets with the gorilla/css/minify
+package.
+
+Usage:
+
+	cssmin [flags] [file ...]
+
+The files are decoded to UTF-8, minified and concatenated in order. Without
+files, or with a file named "-", the standard input is read. The flags are:
+
+	-o file
+		Write the output to file instead of the standard output.
+	-sourcemap file
+		Write a source map of the output to file, and reference it from the
+		output with a sourceMappingURL comment.
+	-license-comments
+		Keep the license comments, which start with "/*!" or contain
+		"@license" (default true).
+	-merge
+		Merge the rules with the same selectors or the same declarations,
+		where it doesn't change the cascade.
+
+Concatenating stylesheets is more than joining them: @charset rules are only
+valid at the start of a stylesheet, and @import rules before any other
+rule. The @charset rules of the inputs are removed, and the output starts
+with @charset "UTF-8" if it isn't ASCII. The @import rules at the start of
+the inputs are moved to the start of the output, in order, with the @layer
+statements preceding them, so that browsers don't ignore them. URLs are
+written as found in the inputs, so the inputs should be in the directory of
+the output, or use absolute URLs.
+
+With -sourcemap, the inputs that reference a source map with a
+sourceMappingURL comment, such as stylesheets minified or compiled by
+another tool, are mapped to the sources of their map, so that the output
+maps to the original sources. The map may be a file, relative to the input,
+or a data URL; maps on other servers are not fetched and missing map files
+are ignored.
+
+The exit status is 2 if an input can't be read or parsed, or the output
+can't be written, and 0 otherwise.
+*/
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/minify"
+	"github.com/gorilla/css/scanner"
+	"github.com/gorilla/css/sourcemap"
+)
+
+// config holds the flags of the command.
+type config struct {
+	out, sourceMap string
+	licenses       bool
+	merge          bool
+}
+
+func main() {
+	var c config
+	flag.StringVar(&c.out, "o", "", "write the output to `file` instead of stdout")
+	flag.StringVar(&c.sourceMap, "sourcemap", "", "write a source map of the output to `file`")
+	flag.BoolVar(&c.licenses, "license-comments", true, "keep license comments")
+	flag.BoolVar(&c.merge, "merge", false, "merge rules with the same selectors or declarations")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: cssmin [flags] [file ...]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if err := run(&c, flag.Args(), os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+}
+
+// input is a parsed input file.
+type input struct {
+	name  string
+	sheet *css.Stylesheet
+	// prev is the source map referenced by the input, if any.
+	prev *sourcemap.Map
+}
+
+// run minifies the files named names, or in if there are none, and writes
+// the output to stdout or to the file named by the -o flag.
+func run(c *config, names []string, in io.Reader, stdout io.Writer) error {
+	if len(names) == 0 {
+		names = []string{"-"}
+	}
+	inputs := make([]input, len(names))
+	for i, name := range names {
+		r, display := in, "<standard input>"
+		if name != "-" {
+			display = name
+			f, err := os.Open(name)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+		s, _, err := css.OpenStylesheet(r, "")
+		if err != nil {
+			return fmt.Errorf("%s: %w", display, err)
+		}
+		sheet, err := css.ParseStylesheet(s.Input())
+		if err != nil {
+			var parseErr *css.ParseError
+			if errors.As(err, &parseErr) {
+				return fmt.Errorf("%s:%d:%d: %s", display, parseErr.Line, parseErr.Column, parseErr.Msg)
+			}
+			return fmt.Errorf("%s: %w", display, err)
+		}
+		inputs[i] = input{name: name, sheet: sheet}
+		if c.sourceMap != "" {
+			prev, err := readSourceMap(c, name, s.Input())
+			if err != nil {
+				return fmt.Errorf("%s: %w", display, err)
+			}
+			inputs[i].prev = prev
+		}
+	}
+
+	var m *sourcemap.Map
+	if c.sourceMap != "" {
+		m = &sourcemap.Map{File: filepath.Base(c.out)}
+		for _, in := range inputs {
+			m.Sources = append(m.Sources, relativePath(c.sourceMap, in.name))
+		}
+		if c.out == "" {
+			m.File = ""
+		}
+	}
+	out := concat(inputs, minify.Options{DropLicenses: !c.licenses, MergeRules: c.merge}, m)
+	if m != nil {
+		// Applying a map removes the source of its input, shifting the
+		// sources of the next inputs.
+		removed := 0
+		for i, in := range inputs {
+			if in.prev != nil {
+				m.Apply(i-removed, in.prev)
+				removed++
+			}
+		}
+		out += "\n/*# sourceMappingURL=" + relativePath(c.out, c.sourceMap) + " */"
+		b, err := json.Marshal(m)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(c.sourceMap, b, 0o644); err != nil {
+			return err
+		}
+	}
+	if c.out == "" {
+		_, err := io.WriteString(stdout, out+"\n")
+		return err
+	}
+	return os.WriteFile(c.out, []byte(out+"\n"), 0o644)
+}
+
+// relativePath returns the path of the file named name relative to the
+// directory of the file named from, or name if it can't be made relative.
+func relativePath(from, name string) string {
+	if name == "-" {
+		return "stdin"
+	}
+	rel, err := filepath.Rel(filepath.Dir(from), name)
+	if err != nil {
+		return filepath.ToSlash(name)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// sourceMappingURL matches a sourceMappingURL comment.
+var sourceMappingURL = regexp.MustCompile(`/\*[#@][ \t]*sourceMappingURL=([^\s*]+)[^*]*\*/`)
+
+// readSourceMap returns the source map referenced by the last
+// sourceMappingURL comment of the input named name, whose source is src,
+// with its sources relative to the directory of the source map written by
+// c. It returns nil if there is no such comment, if the map is on another
+// server or if its file doesn't exist.
+func readSourceMap(c *config, name, src string) (*sourcemap.Map, error) {
+	matches := sourceMappingURL.FindAllStringSubmatch(src, -1)
+	if matches == nil {
+		return nil, nil
+	}
+	ref := matches[len(matches)-1][1]
+	var data []byte
+	dir := "."
+	if name != "-" {
+		dir = filepath.Dir(name)
+	}
+	switch {
+	case strings.HasPrefix(ref, "data:"):
+		meta, payload, ok := strings.Cut(ref[len("data:"):], ",")
+		if !ok {
+			return nil, fmt.Errorf("invalid source map URL %q", ref)
+		}
+		var err error
+		if strings.HasSuffix(meta, ";base64") {
+			data, err = base64.StdEncoding.DecodeString(payload)
+		} else {
+			payload, err = url.PathUnescape(payload)
+			data = []byte(payload)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid source map URL %q: %w", ref, err)
+		}
+	case strings.Contains(ref, "://") || strings.HasPrefix(ref, "//"):
+		return nil, nil
+	default:
+		file, err := url.PathUnescape(ref)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source map URL %q: %w", ref, err)
+		}
+		file = filepath.Join(dir, filepath.FromSlash(file))
+		data, err = os.ReadFile(file)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Dir(file)
+	}
+	prev := &sourcemap.Map{}
+	if err := prev.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	for i, source := range prev.Sources {
+		if prev.SourceRoot != "" {
+			source = strings.TrimSuffix(prev.SourceRoot, "/") + "/" + source
+		}
+		if !strings.Contains(source, "://") && !strings.HasPrefix(source, "/") {
+			source = relativePath(c.sourceMap, filepath.Join(dir, filepath.FromSlash(source)))
+		}
+		prev.Sources[i] = source
+	}
+	prev.SourceRoot = ""
+	return prev, nil
+}
+
+// part is a list of rules of the input of index source.
+type part struct {
+	source int
+	rules  []*css.Rule
+}
+
+// concat minifies the inputs and returns their concatenation, adding the
+// mappings to its positions to m if it isn't nil.
+func concat(inputs []input, opts minify.Options, m *sourcemap.Map) string {
+	var hoisted, rest []part
+	for i, in := range inputs {
+		minify.Stylesheet(in.sheet, opts)
+		var rules, pending []*css.Rule
+		preamble := true
+		for _, r := range in.sheet.Rules {
+			switch {
+			case isAtRule(r, "charset"):
+			case preamble && isAtRule(r, "layer") && !r.HasBlock:
+				pending = append(pending, r)
+			case preamble && isAtRule(r, "import"):
+				hoisted = append(hoisted, part{i, append(pending, r)})
+				pending = nil
+			default:
+				preamble = false
+				rules = append(rules, pending...)
+				pending = nil
+				rules = append(rules, r)
+			}
+		}
+		rules = append(rules, pending...)
+		rest = append(rest, part{i, rules})
+	}
+
+	var b strings.Builder
+	line, col := 1, 1
+	for _, p := range append(hoisted, rest...) {
+		var pm *sourcemap.Map
+		if m != nil {
+			pm = &sourcemap.Map{}
+		}
+		start := b.Len()
+		(&css.Stylesheet{Rules: p.rules}).Render(&b, css.RenderOptions{SourceMap: pm})
+		if pm != nil {
+			for _, mp := range pm.Mappings {
+				if mp.Line == 1 {
+					mp.Column += col - 1
+				}
+				mp.Line += line - 1
+				mp.Source = p.source
+				m.Add(mp)
+			}
+		}
+		line, col = advance(line, col, b.String()[start:])
+	}
+	out := b.String()
+	for i := 0; i < len(out); i++ {
+		if out[i] >= utf8.RuneSelf {
+			const charset = `@charset "UTF-8";`
+			if m != nil {
+				for i := range m.Mappings {
+					if m.Mappings[i].Line == 1 {
+						m.Mappings[i].Column += len(charset)
+					}
+				}
+			}
+			return charset + out
+		}
+	}
+	return out
+}
+
+// isAtRule reports whether r is an at-rule named name.
+func isAtRule(r *css.Rule, name string) bool {
+	return r.IsAtRule() && strings.EqualFold(scanner.Unescape(r.AtKeyword), name)
+}
+
+// advance returns the position after text, starting at line and col.
+func advance(line, col int, text string) (int, int) {
+	if i := strings.LastIndexByte(text, '\n'); i >= 0 {
+		return line + strings.Count(text, "\n"), utf8.RuneCountInString(text[i+1:]) + 1
+	}
+	return line, col + utf8.RuneCountInString(text)
+}
@@ -0,0 +1,225 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/minify"
+	"github.com/gorilla/css/sourcemap"
+)
+
+func TestConcat(t *testing.T) {
+	tcs := []struct {
+		desc     string
+		inputs   []string
+		opts     minify.Options
+		expected string
+	}{
+		{
+			desc:     "single",
+			inputs:   []string{"a { color : red ; }"},
+			expected: "a{color:red}",
+		},
+		{
+			desc: "hoisting",
+			inputs: []string{
+				"@charset \"utf-8\";\n/*! license */\n@import url(a.css);\na { color: red }",
+				"@layer base;\n@import 'b.css' layer(base);\n@import 'c.css';\n@layer base { b { margin: 0 } }",
+				"@layer x;\nc { color: blue }\n@import 'late.css';",
+			},
+			expected: "/*! license */@import url(a.css);@layer base;@import 'b.css' layer(base);@import 'c.css';" +
+				"a{color:red}@layer base{b{margin:0}}@layer x;c{color:blue}@import 'late.css';",
+		},
+		{
+			desc:     "licenses",
+			inputs:   []string{"/*! license */ a {}", "/* comment */ b {}"},
+			opts:     minify.Options{DropLicenses: true},
+			expected: "a{}b{}",
+		},
+		{
+			desc:     "merge",
+			inputs:   []string{"a { color: red }", "b { color: red }"},
+			opts:     minify.Options{MergeRules: true},
+			expected: "a{color:red}b{color:red}",
+		},
+		{
+			desc:     "charset",
+			inputs:   []string{"@charset \"iso-8859-1\"; a {}", "b { content: 'é' }"},
+			expected: "@charset \"UTF-8\";a{}b{content:'é'}",
+		},
+	}
+	for _, tc := range tcs {
+		var inputs []input
+		for i, src := range tc.inputs {
+			s, err := css.ParseStylesheet(src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			inputs = append(inputs, input{name: string(rune('a' + i)), sheet: s})
+		}
+		if got := concat(inputs, tc.opts, nil); got != tc.expected {
+			t.Errorf("%s: got %q, want %q", tc.desc, got, tc.expected)
+		}
+	}
+}
+
+func TestConcatSourceMap(t *testing.T) {
+	var inputs []input
+	for _, src := range []string{"a {\n  color: red;\n}", "@import 'b.css';\nb { content: 'é' }"} {
+		s, err := css.ParseStylesheet(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		inputs = append(inputs, input{sheet: s})
+	}
+	m := &sourcemap.Map{}
+	out := concat(inputs, minify.Options{}, m)
+	expected := `@charset "UTF-8";@import 'b.css';a{color:red}b{content:'é'}`
+	if out != expected {
+		t.Fatalf("got %q, want %q", out, expected)
+	}
+	// The mappings of the rules and declarations, by their output column.
+	mappings := map[int]sourcemap.Mapping{}
+	for _, mp := range m.Mappings {
+		mappings[mp.Column] = mp
+	}
+	tcs := []struct {
+		text         string
+		source       int
+		line, column int
+	}{
+		{"@import", 1, 1, 1},
+		{"a{", 0, 1, 1},
+		{"color", 0, 2, 3},
+		{"b{", 1, 2, 1},
+		{"content", 1, 2, 5},
+	}
+	for _, tc := range tcs {
+		col := len([]rune(out[:strings.Index(out, tc.text)])) + 1
+		mp, ok := mappings[col]
+		if !ok || mp.Line != 1 || mp.Source != tc.source || mp.SourceLine != tc.line || mp.SourceColumn != tc.column {
+			t.Errorf("%s: got %+v (%t), want source %d at %d:%d", tc.text, mp, ok, tc.source, tc.line, tc.column)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a.css"), filepath.Join(dir, "b.css")
+	if err := os.WriteFile(a, []byte("a { color: red }"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// A Latin-1 stylesheet is decoded according to its @charset rule.
+	if err := os.WriteFile(b, []byte("@charset \"iso-8859-1\";\nb { content: '\xe9' }"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	if err := run(&config{licenses: true}, []string{a, "-"}, strings.NewReader("/*! license */ c {}"), &stdout); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := stdout.String(), "a{color:red}/*! license */c{}\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	out, mapFile := filepath.Join(dir, "out.min.css"), filepath.Join(dir, "out.min.css.map")
+	if err := run(&config{out: out, sourceMap: mapFile}, []string{a, b}, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "@charset \"UTF-8\";a{color:red}b{content:'é'}\n/*# sourceMappingURL=out.min.css.map */\n"; string(got) != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	var m struct {
+		File    string
+		Sources []string
+	}
+	if b, err := os.ReadFile(mapFile); err != nil {
+		t.Fatal(err)
+	} else if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+	if m.File != "out.min.css" || strings.Join(m.Sources, " ") != "a.css b.css" {
+		t.Errorf("got %+v, want the output and the inputs relative to the map", m)
+	}
+
+	if err := run(&config{}, []string{filepath.Join(dir, "missing.css")}, nil, nil); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+	err = run(&config{}, []string{"-"}, strings.NewReader("a { content: 'b }"), nil)
+	if err == nil || err.Error() != "<standard input>:1:14: unclosed quotation mark" {
+		t.Errorf("got %v, want a parse error with its position", err)
+	}
+}
+
+func TestRunComposesSourceMaps(t *testing.T) {
+	dir := t.TempDir()
+	src, build := filepath.Join(dir, "src"), filepath.Join(dir, "build")
+	for _, d := range []string{src, build} {
+		if err := os.Mkdir(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	a := filepath.Join(src, "a.css")
+	if err := os.WriteFile(a, []byte("a {\n  color: red;\n}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mid, midMap := filepath.Join(build, "a.min.css"), filepath.Join(build, "a.min.css.map")
+	if err := run(&config{out: mid, sourceMap: midMap}, []string{a}, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// The second pass maps to src/a.css through build/a.min.css.map, and to
+	// the standard input through its inline map.
+	inline := base64.StdEncoding.EncodeToString([]byte(`{"version":3,"sources":["c.scss"],"names":[],"mappings":"AAAA,EAIE"}`))
+	stdin := "c{width:0}\n/*# sourceMappingURL=data:application/json;charset=utf-8;base64," + inline + " */"
+	out, outMap := filepath.Join(dir, "all.css"), filepath.Join(dir, "all.css.map")
+	if err := run(&config{out: out, sourceMap: outMap}, []string{mid, "-"}, strings.NewReader(stdin), nil); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(outMap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &sourcemap.Map{}
+	if err := json.Unmarshal(b, m); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(m.Sources, " "), "src/a.css c.scss"; got != want {
+		t.Errorf("got sources %q, want %q", got, want)
+	}
+	want := []sourcemap.Mapping{
+		{Line: 1, Column: 1, Source: 0, SourceLine: 1, SourceColumn: 1},
+		{Line: 1, Column: 3, Source: 0, SourceLine: 2, SourceColumn: 3},
+		{Line: 1, Column: 9, Source: 0, SourceLine: 2, SourceColumn: 10},
+		{Line: 1, Column: 13, Source: 1, SourceLine: 1, SourceColumn: 1},
+		{Line: 1, Column: 15, Source: 1, SourceLine: 5, SourceColumn: 3},
+		{Line: 1, Column: 21, Source: 1, SourceLine: 5, SourceColumn: 3},
+	}
+	if !reflect.DeepEqual(m.Mappings, want) {
+		t.Errorf("got mappings %+v, want %+v", m.Mappings, want)
+	}
+
+	// Missing maps are ignored, invalid ones are errors.
+	if err := run(&config{sourceMap: outMap}, []string{"-"}, strings.NewReader("/*# sourceMappingURL=missing.map */"), io.Discard); err != nil {
+		t.Errorf("unexpected error for a missing map: %v", err)
+	}
+	if err := run(&config{sourceMap: outMap}, []string{"-"}, strings.NewReader("/*# sourceMappingURL=data:,{} */"), io.Discard); err == nil {
+		t.Error("expected an error for an invalid map")
+	}
+}
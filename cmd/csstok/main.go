@@ -0,0 +1,287 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Csstok prints how the gorilla/css packages tokenize and parse a stylesheet,
+to debug or report unexpected results.
+
+Usage:
+
+	csstok [flags] [file]
+
+Without a file, it reads its standard input. The flags are:
+
+	-e css
+		Read the stylesheet from the argument instead of a file.
+	-ast
+		Print the parsed rules and declarations instead of the tokens.
+	-json
+		Print JSON instead of text.
+
+Tokens are printed one per line with their position, type and value, as
+found in the input, followed by their decoded value if it has escape
+sequences, and by the number and unit of numeric tokens:
+
+	$ csstok -e 'a { width: 1\2e 5em }'
+	1:1	IDENT	"a"
+	1:2	S	" "
+	...
+	1:12	DIMENSION	"1\\2e 5em"	decoded="1.5em" number="1.5" unit="em"
+
+The parsed stylesheet is printed as an indented tree of rules, declarations
+and component values.
+*/
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/scanner"
+)
+
+// config holds the flags of the command.
+type config struct {
+	ast, json bool
+}
+
+func main() {
+	var c config
+	expr := flag.String("e", "", "read the stylesheet from `css` instead of a file")
+	flag.BoolVar(&c.ast, "ast", false, "print the parsed stylesheet instead of the tokens")
+	flag.BoolVar(&c.json, "json", false, "print JSON instead of text")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: csstok [flags] [file]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	input := *expr
+	if input == "" || flag.NArg() > 0 {
+		var b []byte
+		var err error
+		switch flag.NArg() {
+		case 0:
+			b, err = io.ReadAll(os.Stdin)
+		case 1:
+			b, err = os.ReadFile(flag.Arg(0))
+		default:
+			flag.Usage()
+			os.Exit(2)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		input = string(b)
+	}
+	if err := run(&c, input, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run prints the tokens or the parsed stylesheet of input to w. Tokens are
+// printed up to the first error token, if any, while the parse error of a
+// stylesheet is returned.
+func run(c *config, input string, w io.Writer) error {
+	if !c.ast {
+		var tokens []*scanner.Token
+		s := scanner.New(input)
+		for {
+			t := s.Next()
+			if t.Type == scanner.TokenEOF {
+				break
+			}
+			tokens = append(tokens, t)
+			if t.Type == scanner.TokenError {
+				break
+			}
+		}
+		if c.json {
+			list := []interface{}{}
+			for _, t := range tokens {
+				list = append(list, tokenJSON(t))
+			}
+			return writeJSON(w, list)
+		}
+		for _, t := range tokens {
+			fmt.Fprintf(w, "%d:%d\t%s\t%q%s\n", t.Line, t.Column, t.Type, t.Value, extras(t))
+		}
+		return nil
+	}
+
+	s, err := css.ParseStylesheet(input)
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return writeJSON(w, rulesJSON(s.Rules))
+	}
+	fmt.Fprintln(w, "stylesheet")
+	printRules(w, s.Rules, 1)
+	return nil
+}
+
+// numberPrefix matches the number of a numeric token.
+var numberPrefix = regexp.MustCompile(`^[+-]?(\d*\.)?\d+([eE][+-]?\d+)?`)
+
+// extraFields returns the decoded value of a token if it has escape
+// sequences, and the number and unit of numeric tokens, as pairs of names
+// and values.
+func extraFields(t *scanner.Token) []string {
+	var fields []string
+	decoded := t.DecodedValue()
+	if strings.Contains(t.Value, `\`) {
+		fields = append(fields, "decoded", decoded)
+	}
+	switch t.Type {
+	case scanner.TokenNumber, scanner.TokenPercentage, scanner.TokenDimension:
+		number := numberPrefix.FindString(decoded)
+		fields = append(fields, "number", number)
+		if t.Type == scanner.TokenDimension {
+			fields = append(fields, "unit", decoded[len(number):])
+		}
+	}
+	return fields
+}
+
+// extras returns the extra fields of a token as text.
+func extras(t *scanner.Token) string {
+	var pairs []string
+	fields := extraFields(t)
+	for i := 0; i < len(fields); i += 2 {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", fields[i], fields[i+1]))
+	}
+	if pairs == nil {
+		return ""
+	}
+	return "\t" + strings.Join(pairs, " ")
+}
+
+// tokenJSON returns the JSON object of a token.
+func tokenJSON(t *scanner.Token) map[string]interface{} {
+	m := map[string]interface{}{
+		"type":   t.Type.String(),
+		"value":  t.Value,
+		"line":   t.Line,
+		"column": t.Column,
+	}
+	fields := extraFields(t)
+	for i := 0; i < len(fields); i += 2 {
+		m[fields[i]] = fields[i+1]
+	}
+	return m
+}
+
+// rulesJSON returns the JSON objects of a list of rules.
+func rulesJSON(rules []*css.Rule) []interface{} {
+	list := []interface{}{}
+	for _, r := range rules {
+		m := map[string]interface{}{
+			"prelude":  valuesJSON(r.Prelude),
+			"hasBlock": r.HasBlock,
+			"line":     r.Line,
+			"column":   r.Column,
+		}
+		if r.IsAtRule() {
+			m["atKeyword"] = r.AtKeyword
+		}
+		if len(r.Comments) > 0 {
+			m["comments"] = r.Comments
+		}
+		if r.HasBlock {
+			decls := []interface{}{}
+			for _, d := range r.Declarations {
+				dm := map[string]interface{}{
+					"property":  d.Property,
+					"value":     valuesJSON(d.Value),
+					"important": d.Important,
+					"line":      d.Line,
+					"column":    d.Column,
+				}
+				if len(d.Comments) > 0 {
+					dm["comments"] = d.Comments
+				}
+				decls = append(decls, dm)
+			}
+			m["declarations"] = decls
+			m["rules"] = rulesJSON(r.Rules)
+		}
+		list = append(list, m)
+	}
+	return list
+}
+
+// valuesJSON returns the JSON objects of a list of component values.
+func valuesJSON(values []*css.ComponentValue) []interface{} {
+	list := []interface{}{}
+	for _, v := range values {
+		m := tokenJSON(v.Token)
+		if v.IsFunction() || v.IsBlock() {
+			m["children"] = valuesJSON(v.Children)
+		}
+		list = append(list, m)
+	}
+	return list
+}
+
+// writeJSON writes v as indented JSON to w.
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// printRules prints a list of rules at the given indentation level.
+func printRules(w io.Writer, rules []*css.Rule, level int) {
+	indent := strings.Repeat("  ", level)
+	for _, r := range rules {
+		for _, c := range r.Comments {
+			fmt.Fprintf(w, "%scomment %q\n", indent, c)
+		}
+		kind := "style rule"
+		if r.IsAtRule() {
+			kind = "at-rule @" + r.AtKeyword
+		}
+		fmt.Fprintf(w, "%s%s %d:%d\n", indent, kind, r.Line, r.Column)
+		if len(r.Prelude) > 0 {
+			fmt.Fprintf(w, "%s  prelude\n", indent)
+			printValues(w, r.Prelude, level+2)
+		}
+		if !r.HasBlock {
+			continue
+		}
+		fmt.Fprintf(w, "%s  block\n", indent)
+		for _, d := range r.Declarations {
+			for _, c := range d.Comments {
+				fmt.Fprintf(w, "%s    comment %q\n", indent, c)
+			}
+			important := ""
+			if d.Important {
+				important = " !important"
+			}
+			fmt.Fprintf(w, "%s    declaration %q%s %d:%d\n", indent, d.Property, important, d.Line, d.Column)
+			printValues(w, d.Value, level+3)
+		}
+		printRules(w, r.Rules, level+2)
+	}
+}
+
+// printValues prints a list of component values at the given indentation
+// level.
+func printValues(w io.Writer, values []*css.ComponentValue, level int) {
+	indent := strings.Repeat("  ", level)
+	for _, v := range values {
+		t := v.Token
+		fmt.Fprintf(w, "%s%s %q %d:%d%s\n", indent, t.Type, t.Value, t.Line, t.Column, extras(t))
+		printValues(w, v.Children, level+1)
+	}
+}
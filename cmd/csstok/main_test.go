@@ -0,0 +1,113 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tcs := []struct {
+		desc     string
+		config   config
+		input    string
+		expected string
+	}{
+		{
+			desc:  "tokens",
+			input: `a{width:1\2e 5em;b:'\63' 50%}`,
+			expected: "1:1\tIDENT\t\"a\"\n" +
+				"1:2\tCHAR\t\"{\"\n" +
+				"1:3\tIDENT\t\"width\"\n" +
+				"1:8\tCHAR\t\":\"\n" +
+				"1:9\tDIMENSION\t\"1\\\\2e 5em\"\tdecoded=\"1.5em\" number=\"1.5\" unit=\"em\"\n" +
+				"1:17\tCHAR\t\";\"\n" +
+				"1:18\tIDENT\t\"b\"\n" +
+				"1:19\tCHAR\t\":\"\n" +
+				"1:20\tSTRING\t\"'\\\\63'\"\tdecoded=\"c\"\n" +
+				"1:25\tS\t\" \"\n" +
+				"1:26\tPERCENTAGE\t\"50%\"\tnumber=\"50\"\n" +
+				"1:29\tCHAR\t\"}\"\n",
+		},
+		{
+			desc:     "error token",
+			input:    "a 'b",
+			expected: "1:1\tIDENT\t\"a\"\n1:2\tS\t\" \"\n1:3\terror\t\"unclosed quotation mark\"\n",
+		},
+		{
+			desc:   "ast",
+			config: config{ast: true},
+			input:  "/* c */ @media print { a { color: red !important } } @import 'x';",
+			expected: `stylesheet
+  comment "/* c */"
+  at-rule @media 1:9
+    prelude
+      IDENT "print" 1:16
+    block
+      style rule 1:24
+        prelude
+          IDENT "a" 1:24
+        block
+          declaration "color" !important 1:28
+            IDENT "red" 1:35
+  at-rule @import 1:54
+    prelude
+      STRING "'x'" 1:62
+`,
+		},
+	}
+	for _, tc := range tcs {
+		var b bytes.Buffer
+		if err := run(&tc.config, tc.input, &b); err != nil {
+			t.Fatalf("%s: %v", tc.desc, err)
+		}
+		if b.String() != tc.expected {
+			t.Errorf("%s: got\n%s\nwant\n%s", tc.desc, b.String(), tc.expected)
+		}
+	}
+
+	var b bytes.Buffer
+	if err := run(&config{ast: true}, "a { content: 'b }", &b); err == nil {
+		t.Error("expected a parse error")
+	}
+}
+
+func TestRunJSON(t *testing.T) {
+	var b bytes.Buffer
+	if err := run(&config{ast: true, json: true}, "a { b: c(1px) }", &b); err != nil {
+		t.Fatal(err)
+	}
+	var rules []struct {
+		Prelude      []map[string]interface{}
+		HasBlock     bool
+		Declarations []struct {
+			Property string
+			Value    []struct {
+				Type     string
+				Children []map[string]interface{}
+			}
+		}
+	}
+	if err := json.Unmarshal(b.Bytes(), &rules); err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 1 || !rules[0].HasBlock || rules[0].Prelude[0]["value"] != "a" {
+		t.Fatalf("got %s", b.Bytes())
+	}
+	v := rules[0].Declarations[0].Value
+	if len(v) != 1 || v[0].Type != "FUNCTION" || v[0].Children[0]["unit"] != "px" {
+		t.Errorf("got %s", b.Bytes())
+	}
+
+	b.Reset()
+	if err := run(&config{json: true}, "a", &b); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), "[\n  {\n    \"column\": 1,\n    \"line\": 1,\n    \"type\": \"IDENT\",\n    \"value\": \"a\"\n  }\n]\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,435 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build compat
+
+package compat
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/gorilla/css/scanner"
+	"github.com/tdewolff/parse/v2"
+	tdcss "github.com/tdewolff/parse/v2/css"
+)
+
+// token is a token of either tokenizer, with a kind common to both.
+type token struct {
+	kind   string
+	value  string
+	offset int
+}
+
+// ourKinds maps the token types of the scanner to common kinds.
+var ourKinds = map[string]string{
+	"IDENT":          "ident",
+	"FUNCTION":       "function",
+	"ATKEYWORD":      "at-keyword",
+	"HASH":           "hash",
+	"STRING":         "string",
+	"URI":            "url",
+	"NUMBER":         "number",
+	"PERCENTAGE":     "percentage",
+	"DIMENSION":      "dimension",
+	"UNICODE-RANGE":  "unicode-range",
+	"INCLUDES":       "match",
+	"DASHMATCH":      "match",
+	"PREFIXMATCH":    "match",
+	"SUFFIXMATCH":    "match",
+	"SUBSTRINGMATCH": "match",
+	"S":              "whitespace",
+	"CDO":            "cdo",
+	"CDC":            "cdc",
+	"COMMENT":        "comment",
+	"CHAR":           "delim",
+	"BOM":            "bom",
+}
+
+// theirKinds maps the token types of tdewolff/parse to common kinds.
+var theirKinds = map[tdcss.TokenType]string{
+	tdcss.IdentToken:               "ident",
+	tdcss.CustomPropertyNameToken:  "ident",
+	tdcss.FunctionToken:            "function",
+	tdcss.AtKeywordToken:           "at-keyword",
+	tdcss.HashToken:                "hash",
+	tdcss.StringToken:              "string",
+	tdcss.BadStringToken:           "bad-string",
+	tdcss.URLToken:                 "url",
+	tdcss.BadURLToken:              "bad-url",
+	tdcss.NumberToken:              "number",
+	tdcss.PercentageToken:          "percentage",
+	tdcss.DimensionToken:           "dimension",
+	tdcss.UnicodeRangeToken:        "unicode-range",
+	tdcss.IncludeMatchToken:        "match",
+	tdcss.DashMatchToken:           "match",
+	tdcss.PrefixMatchToken:         "match",
+	tdcss.SuffixMatchToken:         "match",
+	tdcss.SubstringMatchToken:      "match",
+	tdcss.ColumnToken:              "column",
+	tdcss.WhitespaceToken:          "whitespace",
+	tdcss.CDOToken:                 "cdo",
+	tdcss.CDCToken:                 "cdc",
+	tdcss.CommentToken:             "comment",
+	tdcss.DelimToken:               "delim",
+	tdcss.ColonToken:               "delim",
+	tdcss.SemicolonToken:           "delim",
+	tdcss.CommaToken:               "delim",
+	tdcss.LeftBracketToken:         "delim",
+	tdcss.RightBracketToken:        "delim",
+	tdcss.LeftParenthesisToken:     "delim",
+	tdcss.RightParenthesisToken:    "delim",
+	tdcss.LeftBraceToken:           "delim",
+	tdcss.RightBraceToken:          "delim",
+	tdcss.CustomPropertyValueToken: "custom-property-value",
+}
+
+// ours returns the tokens of the scanner for a normalized input.
+func ours(input string) []token {
+	var tokens []token
+	s := scanner.New(input)
+	offset := 0
+	for t := s.Next(); t.Type != scanner.TokenEOF; t = s.Next() {
+		if t.Type == scanner.TokenError {
+			tokens = append(tokens, token{"error", input[offset:], offset})
+			break
+		}
+		tokens = append(tokens, token{ourKinds[t.Type.String()], t.Value, offset})
+		offset += len(t.Value)
+	}
+	return tokens
+}
+
+// theirs returns the tokens of tdewolff/parse for a normalized input.
+func theirs(input string) []token {
+	var tokens []token
+	l := tdcss.NewLexer(parse.NewInputString(input))
+	offset := 0
+	for {
+		tt, data := l.Next()
+		if tt == tdcss.ErrorToken {
+			if offset < len(input) {
+				tokens = append(tokens, token{"error", input[offset:], offset})
+			}
+			return tokens
+		}
+		tokens = append(tokens, token{theirKinds[tt], string(data), offset})
+		offset += len(data)
+	}
+}
+
+// difference is a known way in which the token streams differ, for a
+// range of the input where they don't have the same tokens.
+type difference struct {
+	name string
+	// explanation is why the scanner differs.
+	explanation string
+	match       func(ours, theirs []token) bool
+}
+
+// knownDifferences are the intentional differences between the scanner and
+// tdewolff/parse, which follows the CSS Syntax Level 3 tokenizer.
+var knownDifferences = []difference{
+	{
+		"exponent",
+		"numbers follow the num production of CSS 2.1, without exponents, " +
+			"so 1e3 is a dimension with the unit e3",
+		func(ours, theirs []token) bool {
+			return len(theirs) > 0 && len(ours) > 0 && strings.ContainsAny(theirs[0].value, "eE") &&
+				(theirs[0].kind == "number" || theirs[0].kind == "dimension" || theirs[0].kind == "percentage")
+		},
+	},
+	{
+		"column",
+		"the column combinator || is two delimiters, as in CSS 2.1",
+		func(ours, theirs []token) bool {
+			return len(theirs) > 0 && theirs[0].kind == "column"
+		},
+	},
+	{
+		"unicode-range",
+		"unicode ranges follow the CSS 2.1 production: the U and the " +
+			"hexadecimal digits are uppercase, and a range ends after six digits " +
+			"or before a dash without digits after it",
+		func(ours, theirs []token) bool {
+			return len(ours) > 0 && ours[0].kind == "unicode-range" ||
+				len(theirs) > 0 && theirs[0].kind == "unicode-range"
+		},
+	},
+	{
+		"double-dash",
+		"\"--\" alone is two delimiters rather than an identifier, since " +
+			"custom property names need a character after the dashes, so it " +
+			"isn't a unit or the name of an at-keyword either",
+		func(ours, theirs []token) bool {
+			if len(theirs) == 0 || !strings.HasSuffix(theirs[0].value, "--") {
+				return false
+			}
+			k := theirs[0].kind
+			return k == "ident" || k == "dimension" || k == "at-keyword"
+		},
+	},
+	{
+		"bad-string",
+		"a string with a newline is an error that ends scanning rather than " +
+			"a bad-string token",
+		func(ours, theirs []token) bool {
+			return len(ours) > 0 && ours[len(ours)-1].kind == "error" && hasKind(theirs, "bad-string")
+		},
+	},
+	{
+		"unclosed",
+		"unclosed strings and comments are errors that end scanning rather " +
+			"than being closed at the end of the input",
+		func(ours, theirs []token) bool {
+			return len(ours) > 0 && ours[len(ours)-1].kind == "error"
+		},
+	},
+	{
+		"bad-url",
+		"an invalid url is a function followed by other tokens rather than a " +
+			"bad-url token",
+		func(ours, theirs []token) bool {
+			return len(ours) > 0 && ours[0].kind == "function" && hasKind(theirs, "bad-url")
+		},
+	},
+	{
+		"url-parenthesis",
+		"unquoted urls may have opening parentheses, which make a bad-url " +
+			"token in CSS Syntax Level 3",
+		func(ours, theirs []token) bool {
+			return len(ours) > 0 && ours[0].kind == "url" && strings.Contains(ours[0].value[4:], "(") &&
+				hasKind(theirs, "bad-url")
+		},
+	},
+	{
+		"control",
+		"control characters other than whitespace can't be escaped and " +
+			"aren't allowed in strings and urls, as in CSS 2.1",
+		func(ours, theirs []token) bool {
+			return hasControl(ours) || hasControl(theirs)
+		},
+	},
+	{
+		"escaped-tab",
+		"a backslash followed by a tab is a delimiter rather than an escape " +
+			"sequence, as in CSS 2.1",
+		func(ours, theirs []token) bool {
+			for i := 0; i+1 < len(ours); i++ {
+				if ours[i].value == "\\" && strings.HasPrefix(ours[i+1].value, "\t") {
+					return true
+				}
+			}
+			return false
+		},
+	},
+	{
+		"dashed-function",
+		"a name starting with two dashes followed by a parenthesis is a " +
+			"function, as in CSS Syntax Level 3, where tdewolff/parse has a " +
+			"custom property name followed by a delimiter",
+		func(ours, theirs []token) bool {
+			return len(ours) > 0 && ours[0].kind == "function" && strings.HasPrefix(ours[0].value, "--") &&
+				len(theirs) > 0 && theirs[0].kind == "ident"
+		},
+	},
+	{
+		"unclosed-url",
+		"a url left open at the end of the input is a function rather than " +
+			"being closed implicitly",
+		func(ours, theirs []token) bool {
+			return len(ours) > 0 && ours[0].kind == "function" && len(theirs) == 1 &&
+				theirs[0].kind == "url" && !strings.HasSuffix(theirs[0].value, ")")
+		},
+	},
+}
+
+// hasKind reports whether one of the tokens has the given kind.
+func hasKind(tokens []token, kind string) bool {
+	for _, t := range tokens {
+		if t.kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// hasControl reports whether one of the tokens has a control character
+// other than whitespace.
+func hasControl(tokens []token) bool {
+	for _, t := range tokens {
+		if strings.IndexFunc(t.value, func(r rune) bool {
+			return r < ' ' && r != '\t' && r != '\n' || r == 0x7f
+		}) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// compare returns the ranges of the input where the token streams differ,
+// resynchronizing them on the next offset where both have a token.
+func compare(ours, theirs []token) [][2][]token {
+	var diffs [][2][]token
+	i, j := 0, 0
+	for i < len(ours) && j < len(theirs) {
+		if ours[i] == theirs[j] {
+			i++
+			j++
+			continue
+		}
+		i0, j0 := i, j
+		if ours[i].kind == "error" {
+			// The scanner stops at errors.
+			diffs = append(diffs, [2][]token{ours[i:], theirs[j:]})
+			return diffs
+		}
+		i++
+		j++
+		for i < len(ours) && j < len(theirs) && ours[i].offset != theirs[j].offset {
+			if ours[i].offset < theirs[j].offset {
+				i++
+			} else {
+				j++
+			}
+		}
+		if i == len(ours) || j == len(theirs) {
+			// There is nothing left to resynchronize on.
+			i, j = len(ours), len(theirs)
+		}
+		diffs = append(diffs, [2][]token{ours[i0:i], theirs[j0:j]})
+	}
+	if i < len(ours) || j < len(theirs) {
+		diffs = append(diffs, [2][]token{ours[i:], theirs[j:]})
+	}
+	return diffs
+}
+
+// corpus returns the stylesheets of the testdata directory of the css
+// module and samples of known differences, by name.
+func corpus(tb testing.TB) map[string]string {
+	files, err := filepath.Glob("../testdata/*.css")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	inputs := map[string]string{
+		"exponent":        "a { width: 1e3px; opacity: 5E-1 }",
+		"column":          "col.selected || td { color: red }",
+		"unicode-range":   "@font-face { unicode-range: u+0-7f, U+0100-024f, U+0000000, U+0- }",
+		"bad-url":         "a { background: url(a b.png) }",
+		"bad-string":      "a { content: \"a\nb\" }",
+		"unclosed":        "a { color: red } /* b",
+		"unclosed-string": "a { background: url(\"b.png) }",
+		"unclosed-url":    "a { background: url(a.png",
+		"url-parenthesis": "a { background: url(a(1).png) }",
+		"control":         "a { content: \"\x01\" }",
+		"double-dash":     "a { b: -- 0-- @-- }",
+		"escaped-tab":     ".a\\\tb { color: red }",
+		"dashed-function": "a { b: --c(d) }",
+	}
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		inputs[filepath.Base(f)] = string(b)
+	}
+	return inputs
+}
+
+// sortedNames returns the names of the inputs in order.
+func sortedNames(inputs map[string]string) []string {
+	names := make([]string, 0, len(inputs))
+	for name := range inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestCompat(t *testing.T) {
+	inputs := corpus(t)
+	for _, name := range sortedNames(inputs) {
+		input := scanner.Normalize(inputs[name])
+		o, th := ours(input), theirs(input)
+		known := map[string]int{}
+	diffs:
+		for _, d := range compare(o, th) {
+			for _, k := range knownDifferences {
+				if k.match(d[0], d[1]) {
+					known[k.name]++
+					continue diffs
+				}
+			}
+			t.Errorf("%s: unexplained difference at offset %d:\n\tours:   %v\n\ttheirs: %v", name, offsetOf(d), d[0], d[1])
+		}
+		t.Logf("%s: %d tokens, known differences: %v", name, len(o), known)
+	}
+}
+
+func FuzzCompat(f *testing.F) {
+	inputs := corpus(f)
+	for _, name := range sortedNames(inputs) {
+		// The stylesheets of the testdata directory are too large to be
+		// mutated efficiently, so only the samples are seeds.
+		if !strings.HasSuffix(name, ".css") {
+			f.Add(inputs[name])
+		}
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		if !utf8.ValidString(input) {
+			// The tokenizers handle invalid UTF-8 differently, and the
+			// specification tokenizes code points, not bytes.
+			t.Skip()
+		}
+		input = scanner.Normalize(input)
+	diffs:
+		for _, d := range compare(ours(input), theirs(input)) {
+			for _, k := range knownDifferences {
+				if k.match(d[0], d[1]) {
+					continue diffs
+				}
+			}
+			t.Errorf("%q: unexplained difference at offset %d:\n\tours:   %v\n\ttheirs: %v", input, offsetOf(d), d[0], d[1])
+		}
+	})
+}
+
+// offsetOf returns the offset of a difference in the input.
+func offsetOf(d [2][]token) int {
+	if len(d[0]) > 0 {
+		return d[0][0].offset
+	}
+	if len(d[1]) > 0 {
+		return d[1][0].offset
+	}
+	return -1
+}
+
+func BenchmarkCompat(b *testing.B) {
+	inputs := corpus(b)
+	for _, name := range sortedNames(inputs) {
+		input := scanner.Normalize(inputs[name])
+		b.Run(name+"/gorilla", func(b *testing.B) {
+			b.SetBytes(int64(len(input)))
+			for i := 0; i < b.N; i++ {
+				s := scanner.New(input)
+				for t := s.Next(); t.Type != scanner.TokenEOF && t.Type != scanner.TokenError; t = s.Next() {
+				}
+			}
+		})
+		b.Run(name+"/tdewolff", func(b *testing.B) {
+			b.SetBytes(int64(len(input)))
+			for i := 0; i < b.N; i++ {
+				l := tdcss.NewLexer(parse.NewInputString(input))
+				for tt, _ := l.Next(); tt != tdcss.ErrorToken; tt, _ = l.Next() {
+				}
+			}
+		})
+	}
+}
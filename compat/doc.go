@@ -0,0 +1,18 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package compat compares the scanner with the CSS tokenizer of
+// github.com/tdewolff/parse, on the corpus of the testdata directory and a
+// few samples. It lives in its own module so that the css module doesn't
+// depend on it, and its tests only build with the compat tag:
+//
+//	cd compat && go test -tags compat -v -bench .
+//
+// The test reports the differences between the token streams and fails on
+// those that aren't known and explained in knownDifferences; the benchmarks
+// compare the throughput of both tokenizers. FuzzCompat does the same with
+// generated inputs, to find the differences the corpus doesn't have:
+//
+//	cd compat && go test -tags compat -run '^$' -fuzz FuzzCompat
+package compat
@@ -0,0 +1,10 @@
+module github.com/gorilla/css/compat
+
+go 1.20
+
+require (
+	github.com/gorilla/css v0.0.0
+	github.com/tdewolff/parse/v2 v2.8.16
+)
+
+replace github.com/gorilla/css => ../
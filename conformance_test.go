@@ -0,0 +1,391 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package css
+
+//go:generate go run testdata/css-parsing-tests/fetch.go
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/css/scanner"
+)
+
+var (
+	updateConformance  = flag.Bool("update-conformance", false, "record the failures of TestConformance as known failures")
+	requireConformance = flag.Bool("conformance", false, "fail TestConformance instead of skipping it if the fixtures are missing")
+)
+
+// conformanceDir holds the fixtures of the css-parsing-tests suite, fetched
+// by go generate, and the list of their known failures.
+var conformanceDir = filepath.Join("testdata", "css-parsing-tests")
+
+// conformanceFiles maps the fixtures run by TestConformance to the function
+// returning the result of an input in the JSON representation of the suite:
+//
+//	https://github.com/SimonSapin/css-parsing-tests
+var conformanceFiles = map[string]func(input string) interface{}{
+	"component_value_list.json": conformComponentValueList,
+	"one_component_value.json":  conformOneComponentValue,
+	"declaration_list.json":     conformDeclarationList,
+	"one_declaration.json":      conformOneDeclaration,
+	"rule_list.json":            conformRuleList,
+	"one_rule.json":             conformOneRule,
+	"stylesheet.json":           conformRuleList,
+}
+
+// TestConformance runs the css-parsing-tests fixtures against the parser and
+// logs the share of passing cases. Cases that aren't in known-failures.txt
+// must pass, so that deviations from the specification aren't introduced
+// unnoticed; the list is rewritten with the -update-conformance flag. The
+// test is skipped if the fixtures weren't fetched with go generate, unless
+// the -conformance flag is set, as it is by "make conformance".
+//
+// The fixtures and their known failures aren't checked in, and go generate
+// fetches the latest revision of the suite, so the test isn't run in CI: its
+// result depends on when the fixtures were fetched. Record the known
+// failures with -update-conformance after fetching them to check a change
+// locally.
+func TestConformance(t *testing.T) {
+	known, err := readKnownFailures()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var failures []string
+	passed, total := 0, 0
+	names := make([]string, 0, len(conformanceFiles))
+	for name := range conformanceFiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cases, err := readConformanceFile(name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		n := 0
+		for i := 0; i+1 < len(cases); i += 2 {
+			var input string
+			if err := json.Unmarshal(cases[i], &input); err != nil {
+				t.Fatalf("%s: case %d: %v", name, i/2, err)
+			}
+			var expected interface{}
+			if err := json.Unmarshal(cases[i+1], &expected); err != nil {
+				t.Fatalf("%s: case %d: %v", name, i/2, err)
+			}
+			got := normalizeJSON(conformanceFiles[name](input))
+			key := name + " " + strconv.Quote(input)
+			ok := reflect.DeepEqual(got, expected)
+			switch {
+			case ok && known[key]:
+				t.Logf("%s %q: passes, remove it from the known failures", name, input)
+			case !ok && !known[key] && !*updateConformance:
+				t.Errorf("%s %q:\ngot  %s\nwant %s", name, input, toJSON(got), toJSON(expected))
+			}
+			if ok {
+				n++
+			} else {
+				failures = append(failures, key)
+			}
+		}
+		t.Logf("%s: %d of %d cases pass", name, n, len(cases)/2)
+		passed += n
+		total += len(cases) / 2
+	}
+	if total == 0 {
+		if *requireConformance {
+			t.Fatal("no fixtures, run go generate to fetch them")
+		}
+		t.Skip("no fixtures, run go generate to fetch them")
+	}
+	t.Logf("conformance: %d of %d cases pass (%.1f%%)", passed, total, 100*float64(passed)/float64(total))
+	if *updateConformance {
+		sort.Strings(failures)
+		b := []byte(strings.Join(failures, "\n") + "\n")
+		if err := os.WriteFile(filepath.Join(conformanceDir, "known-failures.txt"), b, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// readConformanceFile returns the raw inputs and expected results of a
+// fixture, which alternate in a JSON array.
+func readConformanceFile(name string) ([]json.RawMessage, error) {
+	b, err := os.ReadFile(filepath.Join(conformanceDir, name))
+	if err != nil {
+		return nil, err
+	}
+	var cases []json.RawMessage
+	err = json.Unmarshal(b, &cases)
+	return cases, err
+}
+
+// readKnownFailures returns the known failures, as the name of the fixture
+// followed by the quoted input. There are none if they weren't recorded.
+func readKnownFailures() (map[string]bool, error) {
+	b, err := os.ReadFile(filepath.Join(conformanceDir, "known-failures.txt"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]bool{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	known := map[string]bool{}
+	for _, line := range strings.Split(string(b), "\n") {
+		if line != "" {
+			known[line] = true
+		}
+	}
+	return known, nil
+}
+
+// normalizeJSON returns v as decoded from its JSON encoding, so that it can
+// be compared with the expected results.
+func normalizeJSON(v interface{}) interface{} {
+	var n interface{}
+	json.Unmarshal([]byte(toJSON(v)), &n)
+	return n
+}
+
+// toJSON returns the JSON encoding of v.
+func toJSON(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err.Error()
+	}
+	return string(b)
+}
+
+func conformComponentValueList(input string) interface{} {
+	values, err := ParseComponentValues(input)
+	if err != nil {
+		return []interface{}{"error", err.Error()}
+	}
+	return conformValues(values)
+}
+
+func conformOneComponentValue(input string) interface{} {
+	values, err := ParseComponentValues(input)
+	if err != nil {
+		return []interface{}{"error", err.Error()}
+	}
+	values = TrimSpace(values)
+	switch {
+	case len(values) == 0:
+		return []interface{}{"error", "empty"}
+	case len(values) > 1:
+		return []interface{}{"error", "extra-input"}
+	}
+	return conformValues(values)[0]
+}
+
+// conformDeclarationList parses the input as the block of a style rule,
+// since the parser has no entry point for lists of declarations. The
+// declarations and the nested rules are listed in the order of the input.
+func conformDeclarationList(input string) interface{} {
+	s, err := ParseStylesheet("x{" + input + "}")
+	if err != nil || len(s.Rules) != 1 {
+		return []interface{}{"error", "invalid"}
+	}
+	type item struct {
+		line, col int
+		json      interface{}
+	}
+	var items []item
+	for _, d := range s.Rules[0].Declarations {
+		items = append(items, item{d.Line, d.Column, conformDeclaration(d)})
+	}
+	for _, r := range s.Rules[0].Rules {
+		items = append(items, item{r.Line, r.Column, conformRule(r)})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].line != items[j].line {
+			return items[i].line < items[j].line
+		}
+		return items[i].col < items[j].col
+	})
+	list := []interface{}{}
+	for _, it := range items {
+		list = append(list, it.json)
+	}
+	return list
+}
+
+func conformOneDeclaration(input string) interface{} {
+	s, err := ParseStylesheet("x{" + input + "}")
+	if err != nil || len(s.Rules) != 1 || len(s.Rules[0].Declarations) != 1 {
+		return []interface{}{"error", "invalid"}
+	}
+	return conformDeclaration(s.Rules[0].Declarations[0])
+}
+
+func conformRuleList(input string) interface{} {
+	s, err := ParseStylesheet(input)
+	if err != nil {
+		return []interface{}{"error", err.Error()}
+	}
+	list := []interface{}{}
+	for _, r := range s.Rules {
+		list = append(list, conformRule(r))
+	}
+	return list
+}
+
+func conformOneRule(input string) interface{} {
+	s, err := ParseStylesheet(input)
+	switch {
+	case err != nil:
+		return []interface{}{"error", "invalid"}
+	case len(s.Rules) == 0:
+		return []interface{}{"error", "empty"}
+	case len(s.Rules) > 1:
+		return []interface{}{"error", "extra-input"}
+	}
+	return conformRule(s.Rules[0])
+}
+
+func conformDeclaration(d *Declaration) interface{} {
+	return []interface{}{"declaration", scanner.Unescape(d.Property), conformValues(d.Value), d.Important}
+}
+
+// conformRule returns the representation of a rule, whose block is the list
+// of its component values, parsed again from the raw text of the rule.
+func conformRule(r *Rule) interface{} {
+	var block interface{}
+	if r.HasBlock {
+		values, _ := ParseComponentValues(r.Raw)
+		for i := len(values) - 1; i >= 0; i-- {
+			if values[i].IsBlock() && values[i].Token.Value == "{" {
+				block = conformValues(values[i].Children)
+				break
+			}
+		}
+	}
+	if r.IsAtRule() {
+		return []interface{}{"at-rule", scanner.Unescape(r.AtKeyword), conformValues(r.Prelude), block}
+	}
+	return []interface{}{"qualified rule", conformValues(r.Prelude), block}
+}
+
+func conformValues(values []*ComponentValue) []interface{} {
+	list := []interface{}{}
+	for _, v := range values {
+		list = append(list, conformValue(v)...)
+	}
+	return list
+}
+
+// conformValue returns the representation of a component value. Tokens
+// that the specification splits in several, such as "~=", return several
+// values.
+func conformValue(v *ComponentValue) []interface{} {
+	t := v.Token
+	one := func(values ...interface{}) []interface{} {
+		return []interface{}{values}
+	}
+	switch t.Type {
+	case scanner.TokenIdent:
+		return one("ident", t.DecodedValue())
+	case scanner.TokenAtKeyword:
+		return one("at-keyword", t.DecodedValue())
+	case scanner.TokenHash:
+		kind := "unrestricted"
+		if startsIdent(t.Value[1:]) {
+			kind = "id"
+		}
+		return one("hash", t.DecodedValue(), kind)
+	case scanner.TokenString:
+		return one("string", t.DecodedValue())
+	case scanner.TokenURI:
+		inner := strings.Trim(t.Value[4:len(t.Value)-1], " \t\n")
+		if inner != "" && (inner[0] == '"' || inner[0] == '\'') {
+			// url("a") is a function with a string argument.
+			return one("function", "url", []interface{}{"string", t.DecodedValue()})
+		}
+		return one("url", t.DecodedValue())
+	case scanner.TokenNumber:
+		return one(append([]interface{}{"number"}, conformNumber(t.Value)...)...)
+	case scanner.TokenPercentage:
+		return one(append([]interface{}{"percentage"}, conformNumber(t.Value[:len(t.Value)-1])...)...)
+	case scanner.TokenDimension:
+		d := t.DecodedValue()
+		unit := strings.TrimLeft(d, "+-.0123456789")
+		n := d[:len(d)-len(unit)]
+		return one(append(append([]interface{}{"dimension"}, conformNumber(n)...), unit)...)
+	case scanner.TokenUnicodeRange:
+		start, end := conformUnicodeRange(t.Value[2:])
+		return one("unicode-range", start, end)
+	case scanner.TokenFunction:
+		return one(append([]interface{}{"function", t.DecodedValue()}, conformValues(v.Children)...)...)
+	case scanner.TokenS:
+		return []interface{}{" "}
+	case scanner.TokenCDO, scanner.TokenCDC:
+		return []interface{}{t.Value}
+	case scanner.TokenIncludes, scanner.TokenDashMatch, scanner.TokenPrefixMatch,
+		scanner.TokenSuffixMatch, scanner.TokenSubstringMatch:
+		return []interface{}{t.Value[:1], t.Value[1:]}
+	case scanner.TokenComment, scanner.TokenBOM:
+		return nil
+	}
+	if v.IsBlock() {
+		return one(append([]interface{}{t.Value + closingBrackets[t.Value]}, conformValues(v.Children)...)...)
+	}
+	switch t.Value {
+	case ")", "]", "}":
+		return one("error", t.Value)
+	}
+	return []interface{}{t.Value}
+}
+
+// conformNumber returns the representation, the value and the type of a
+// number.
+func conformNumber(s string) []interface{} {
+	f, _ := strconv.ParseFloat(s, 64)
+	kind := "integer"
+	if strings.ContainsAny(s, ".eE") {
+		kind = "number"
+	}
+	return []interface{}{s, f, kind}
+}
+
+// conformUnicodeRange returns the bounds of a unicode-range without its
+// "U+" prefix.
+func conformUnicodeRange(s string) (int64, int64) {
+	if start, end, ok := strings.Cut(s, "-"); ok {
+		a, _ := strconv.ParseInt(start, 16, 64)
+		b, _ := strconv.ParseInt(end, 16, 64)
+		return a, b
+	}
+	a, _ := strconv.ParseInt(strings.ReplaceAll(s, "?", "0"), 16, 64)
+	b, _ := strconv.ParseInt(strings.ReplaceAll(s, "?", "f"), 16, 64)
+	return a, b
+}
+
+// startsIdent reports whether s starts with an identifier.
+func startsIdent(s string) bool {
+	if strings.HasPrefix(s, "-") {
+		s = s[1:]
+		if strings.HasPrefix(s, "-") {
+			return true
+		}
+	}
+	if s == "" {
+		return false
+	}
+	c := s[0]
+	return c == '_' || c == '\\' || c >= 0x80 || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
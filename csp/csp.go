@@ -0,0 +1,155 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package gorilla/css/csp lists the resources that a stylesheet makes a
+browser fetch, to plan or verify the Content Security Policy of the pages
+that use it.
+
+Audit finds the fonts, images and imported stylesheets referenced by a
+stylesheet, with the fetch directive that governs them and their origin as a
+source expression:
+
+	base, _ := url.Parse("https://example.com/css/site.css")
+	report := csp.Audit(sheet, base)
+	for origin, fetches := range report.ByOrigin() {
+		fmt.Println(origin, len(fetches))
+	}
+	fmt.Println(report) // font-src https://fonts.example.net; img-src 'self' data:
+
+The report is as precise as the stylesheet: URLs built at run time, such as
+those of custom properties used through var(), can't be attributed to a
+directive and are listed under default-src.
+*/
+package csp
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/gorilla/css"
+)
+
+// Fetch is a resource that a stylesheet makes a browser fetch.
+type Fetch struct {
+	css.URLRef
+	// Directive is the fetch directive governing the resource, such as
+	// "img-src", or "default-src" if it is unknown.
+	Directive string
+	// Source is the source expression matching the origin of the resource,
+	// such as "https://cdn.example.com", "'self'" or "data:".
+	Source string
+}
+
+// Report lists the resources that a stylesheet makes a browser fetch.
+type Report struct {
+	Fetches []Fetch
+}
+
+// Audit returns the resources that a stylesheet makes a browser fetch.
+// base is the URL of the stylesheet, against which relative URLs are
+// resolved, or nil if it is unknown, in which case relative URLs are
+// same-origin. References to a fragment of the document, such as
+// url(#clip), are not fetches.
+func Audit(s *css.Stylesheet, base *url.URL) *Report {
+	r := &Report{}
+	for _, ref := range css.ExtractURLs(s) {
+		if strings.HasPrefix(strings.TrimSpace(ref.URL), "#") {
+			continue
+		}
+		r.Fetches = append(r.Fetches, Fetch{
+			URLRef:    ref,
+			Directive: directive(ref.Context),
+			Source:    source(strings.TrimSpace(ref.URL), base),
+		})
+	}
+	return r
+}
+
+// directive returns the fetch directive governing the URLs of a context.
+func directive(c css.URLContext) string {
+	switch c {
+	case css.URLImport:
+		return "style-src"
+	case css.URLFont:
+		return "font-src"
+	case css.URLImage, css.URLCursor:
+		return "img-src"
+	}
+	return "default-src"
+}
+
+// source returns the source expression matching the origin of a URL.
+func source(ref string, base *url.URL) string {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return "'none'"
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	switch {
+	case u.Scheme == "" && u.Host == "":
+		return "'self'"
+	case u.Host == "":
+		return strings.ToLower(u.Scheme) + ":"
+	case base != nil && strings.EqualFold(u.Scheme, base.Scheme) && strings.EqualFold(u.Host, base.Host):
+		return "'self'"
+	case u.Scheme == "":
+		// A scheme-relative URL without base has the scheme of the page.
+		return strings.ToLower(u.Host)
+	}
+	return strings.ToLower(u.Scheme + "://" + u.Host)
+}
+
+// ByOrigin returns the fetches grouped by source expression, in the order
+// of the stylesheet.
+func (r *Report) ByOrigin() map[string][]Fetch {
+	m := map[string][]Fetch{}
+	for _, f := range r.Fetches {
+		m[f.Source] = append(m[f.Source], f)
+	}
+	return m
+}
+
+// Directives returns the sorted source expressions needed by each fetch
+// directive.
+func (r *Report) Directives() map[string][]string {
+	m := map[string][]string{}
+	seen := map[[2]string]bool{}
+	for _, f := range r.Fetches {
+		if k := [2]string{f.Directive, f.Source}; !seen[k] {
+			seen[k] = true
+			m[f.Directive] = append(m[f.Directive], f.Source)
+		}
+	}
+	for _, sources := range m {
+		sort.Strings(sources)
+	}
+	return m
+}
+
+// String returns the fetch directives of a Content Security Policy allowing
+// the fetches, sorted by name, as in "font-src 'self'; img-src data:".
+func (r *Report) String() string {
+	directives := r.Directives()
+	names := make([]string, 0, len(directives))
+	for name := range directives {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(name)
+		for _, s := range directives[name] {
+			b.WriteByte(' ')
+			b.WriteString(s)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package csp
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/css"
+)
+
+const input = `@import url(theme.css);
+@import "https://CDN.example.com/a.css";
+@font-face { src: url(//fonts.example.net/a.woff2), url(data:font/woff2;base64,AAAA) }
+a { background: url(../img/a.png); cursor: url(https://example.com/c.cur), auto }
+b { clip-path: url(#c); filter: url(f.svg#x); --x: url(https://cdn.example.com/x.png) }`
+
+func TestAudit(t *testing.T) {
+	s, err := css.ParseStylesheet(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base, _ := url.Parse("https://example.com/css/site.css")
+	tcs := []struct {
+		base       *url.URL
+		directives map[string][]string
+		policy     string
+	}{
+		{
+			base: base,
+			directives: map[string][]string{
+				"style-src":   {"'self'", "https://cdn.example.com"},
+				"font-src":    {"data:", "https://fonts.example.net"},
+				"img-src":     {"'self'"},
+				"default-src": {"'self'", "https://cdn.example.com"},
+			},
+			policy: "default-src 'self' https://cdn.example.com; font-src data: https://fonts.example.net; img-src 'self'; style-src 'self' https://cdn.example.com",
+		},
+		{
+			directives: map[string][]string{
+				"style-src":   {"'self'", "https://cdn.example.com"},
+				"font-src":    {"data:", "fonts.example.net"},
+				"img-src":     {"'self'", "https://example.com"},
+				"default-src": {"'self'", "https://cdn.example.com"},
+			},
+			policy: "default-src 'self' https://cdn.example.com; font-src data: fonts.example.net; img-src 'self' https://example.com; style-src 'self' https://cdn.example.com",
+		},
+	}
+	for _, tc := range tcs {
+		r := Audit(s, tc.base)
+		if len(r.Fetches) != 8 {
+			t.Errorf("got %d fetches, want 8", len(r.Fetches))
+		}
+		if got := r.Directives(); !reflect.DeepEqual(got, tc.directives) {
+			t.Errorf("base %v: got %v, want %v", tc.base, got, tc.directives)
+		}
+		if got := r.String(); got != tc.policy {
+			t.Errorf("base %v:\ngot  %q\nwant %q", tc.base, got, tc.policy)
+		}
+	}
+	byOrigin := Audit(s, base).ByOrigin()
+	var urls []string
+	for _, f := range byOrigin["https://cdn.example.com"] {
+		urls = append(urls, f.URL)
+	}
+	if want := []string{"https://CDN.example.com/a.css", "https://cdn.example.com/x.png"}; !reflect.DeepEqual(urls, want) {
+		t.Errorf("got %v, want %v", urls, want)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package gorilla/css/diag defines Diagnostic, the problem reported by the
+parser, the validators, the linters and the sanitizer of gorilla/css, so
+that tools can render, filter and suppress them in one way:
+
+	var diags []diag.Diagnostic
+	s, err := css.ParseStylesheet(input)
+	if err != nil {
+		diags = append(diags, css.ErrorDiagnostic(err))
+	} else {
+		diags = append(diags, validate.Stylesheet(s)...)
+		for _, r := range policy.Stylesheet(s) {
+			diags = append(diags, r.Diagnostic())
+		}
+	}
+	for _, d := range diags {
+		fmt.Println(d)
+		// error: unclosed quotation mark [parse-error] (line: 1, column: 12)
+	}
+
+Each Diagnostic has a stable Code, which tools match to configure or
+suppress it:
+
+  - "parse-error" and "limit-exceeded" for the errors of the parser, given
+    by css.ErrorDiagnostic, and "invalid-declaration" and "incomplete-rule"
+    for the warnings of css.Parser.OnWarning;
+  - the codes of gorilla/css/validate, such as "unknown-keyword", for its
+    validators and lint passes;
+  - "removed-" followed by the kind of the construct, such as
+    "removed-property", for the removals of gorilla/css/sanitize, and the
+    risk of a finding of its audit, such as "attribute-leak";
+  - "removed-empty-rule" for the rules removed by the RemoveEmpty transform
+    of gorilla/css/transform.
+*/
+package diag
+
+import "fmt"
+
+// Codes of the errors of the parser.
+const (
+	CodeParseError    = "parse-error"
+	CodeLimitExceeded = "limit-exceeded"
+)
+
+// Codes of the warnings of the parser, for the problems it recovers from.
+const (
+	CodeInvalidDeclaration = "invalid-declaration"
+	CodeIncompleteRule     = "incomplete-rule"
+)
+
+// Severity is the severity of a diagnostic.
+type Severity int
+
+const (
+	// Error is used for input that is invalid, such as values dropped by
+	// browsers or stylesheets that can't be parsed.
+	Error Severity = iota
+	// Warning is used for valid but discouraged or suspicious constructs.
+	Warning
+)
+
+// String returns a string representation of the severity.
+func (s Severity) String() string {
+	if s == Warning {
+		return "warning"
+	}
+	return "error"
+}
+
+// Position is a position in the input, as the line and column numbers of a
+// character, starting at 1. Columns are counted in runes. A zero line means
+// no position, as for a read error.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// Span is a range of the input. End is the position just past the range,
+// or Start for a diagnostic of a position rather than a range.
+type Span struct {
+	Start Position
+	End   Position
+}
+
+// Related is another place of the input involved in a diagnostic, such as
+// the first declaration of a property declared twice.
+type Related struct {
+	Message string
+	Span    Span
+}
+
+// Diagnostic describes a problem found in a stylesheet.
+type Diagnostic struct {
+	// Code identifies the kind of problem, such as "unknown-keyword".
+	Code     string
+	Severity Severity
+	Message  string
+	// Suggestion is a replacement for the offending input, if any.
+	Suggestion string
+	Span       Span
+	Related    []Related
+}
+
+// String returns a string representation of the diagnostic.
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s [%s] (line: %d, column: %d)", d.Severity, d.Message, d.Code, d.Span.Start.Line, d.Span.Start.Column)
+}
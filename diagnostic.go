@@ -0,0 +1,64 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package css
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gorilla/css/diag"
+	"github.com/gorilla/css/scanner"
+)
+
+// Diagnostic returns the error as a diagnostic with the code "parse-error",
+// at the position of the error.
+func (e *ParseError) Diagnostic() diag.Diagnostic {
+	return errorDiagnostic(diag.CodeParseError, e.Msg, e.Line, e.Column)
+}
+
+// Diagnostic returns the error as a diagnostic with the code
+// "limit-exceeded", at the position where the limit was exceeded.
+func (e *LimitExceededError) Diagnostic() diag.Diagnostic {
+	return errorDiagnostic(diag.CodeLimitExceeded, fmt.Sprintf("%s of %d exceeded", e.Limit, e.Max), e.Line, e.Column)
+}
+
+// ErrorDiagnostic returns an error returned by the parsers as a diagnostic.
+// The diagnostic of a *ParseError or a *LimitExceededError, possibly
+// wrapped, is that of their Diagnostic method; other errors, such as read
+// errors, are parse errors without a position.
+func ErrorDiagnostic(err error) diag.Diagnostic {
+	var parseErr *ParseError
+	var limitErr *LimitExceededError
+	switch {
+	case errors.As(err, &parseErr):
+		return parseErr.Diagnostic()
+	case errors.As(err, &limitErr):
+		return limitErr.Diagnostic()
+	}
+	return errorDiagnostic(diag.CodeParseError, err.Error(), 0, 0)
+}
+
+// errorDiagnostic returns the diagnostic of an error at the given position.
+func errorDiagnostic(code, msg string, line, column int) diag.Diagnostic {
+	pos := diag.Position{Line: line, Column: column}
+	return diag.Diagnostic{
+		Code:     code,
+		Severity: diag.Error,
+		Message:  msg,
+		Span:     diag.Span{Start: pos, End: pos},
+	}
+}
+
+// warn reports a problem recovered from at the token t to onWarning, as a
+// warning. Nothing is reported once parsing failed, since the input is then
+// cut short and incomplete rules and declarations are expected.
+func (p *parser) warn(code, msg string, t *scanner.Token) {
+	if p.onWarning == nil || p.err != nil || p.limitErr != nil {
+		return
+	}
+	d := errorDiagnostic(code, msg, t.Line, t.Column)
+	d.Severity = diag.Warning
+	p.onWarning(d)
+}
@@ -0,0 +1,250 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package gorilla/css/diff compares stylesheets by their rules and
+declarations rather than by their text, for change reports in code reviews:
+
+	changes := diff.Diff(oldSheet, newSheet)
+	for _, c := range changes {
+		fmt.Println(c) // ~ @media print { a { color: red -> blue } }
+	}
+
+Rules are matched by their prelude, such as their selector list, and
+declarations by their property, in the canonical form of
+css.RenderOptions.Canonical, so that formatting changes, such as
+whitespace, comments and the case of property names, aren't reported.
+*/
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/scanner"
+)
+
+// Kind is the kind of a Change.
+type Kind int
+
+const (
+	// Added is a rule or declaration of the new stylesheet only.
+	Added Kind = iota
+	// Removed is a rule or declaration of the old stylesheet only.
+	Removed
+	// Modified is a declaration whose value or priority changed.
+	Modified
+)
+
+// String returns a string representation of the kind.
+func (k Kind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Modified:
+		return "modified"
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// Change is a difference between two stylesheets.
+type Change struct {
+	Kind Kind
+	// Path are the keys of the rules holding the change, from the
+	// outermost one, such as "@media print" and "a > b". For added and
+	// removed rules, the last key is that of the rule.
+	Path []string
+	// Property is the property of the declarations of the change, in
+	// lowercase unless it is a custom property, or empty for added and
+	// removed rules.
+	Property string
+	// OldRule and NewRule are the added or removed rule, in the old or new
+	// stylesheet, or nil for changes to declarations.
+	OldRule, NewRule *css.Rule
+	// OldDeclaration and NewDeclaration are the declaration in the old and
+	// new stylesheets, nil for added and removed ones respectively, and
+	// for changes to rules.
+	OldDeclaration, NewDeclaration *css.Declaration
+}
+
+// String returns a human-readable representation of the change, with the
+// rules holding it, in their canonical form: "+ @media print { a }" for an
+// added rule, "- a { margin: 0 }" for a removed declaration, and
+// "~ a { color: red -> blue !important }" for a modified one.
+func (c Change) String() string {
+	var b strings.Builder
+	switch c.Kind {
+	case Added:
+		b.WriteString("+ ")
+	case Removed:
+		b.WriteString("- ")
+	default:
+		b.WriteString("~ ")
+	}
+	path := c.Path
+	if c.Property == "" {
+		path = path[:len(path)-1]
+	}
+	for _, key := range path {
+		b.WriteString(key + " { ")
+	}
+	switch {
+	case c.Property == "":
+		b.WriteString(c.Path[len(c.Path)-1])
+	case c.Kind == Added:
+		b.WriteString(c.Property + ": " + value(c.NewDeclaration))
+	case c.Kind == Removed:
+		b.WriteString(c.Property + ": " + value(c.OldDeclaration))
+	default:
+		b.WriteString(c.Property + ": " + value(c.OldDeclaration) + " -> " + value(c.NewDeclaration))
+	}
+	for range path {
+		b.WriteString(" }")
+	}
+	return b.String()
+}
+
+// Diff returns the changes turning the old stylesheet into the new one:
+// the rules and declarations added or removed, and the declarations whose
+// value changed.
+//
+// Rules are matched by their key, the canonical form of their at-keyword
+// and prelude, without whitespace around combinators, and the rules with
+// the same key by order. Declarations are matched by property within
+// matched rules, and the declarations with the same property, such as
+// fallbacks, by order. The order of rules and of declarations isn't
+// compared, though it may matter for the cascade.
+//
+// The changes of each list of rules follow the order of the old
+// stylesheet, with the added rules last, and the changes to the
+// declarations of a rule come before those to its nested rules.
+func Diff(old, new *css.Stylesheet) []Change {
+	var changes []Change
+	diffRules(&changes, nil, old.Rules, new.Rules)
+	return changes
+}
+
+// diffRules appends the changes between two lists of rules, held by the
+// rules of path, to changes.
+func diffRules(changes *[]Change, path []string, old, new []*css.Rule) {
+	newKeys := make([]string, len(new))
+	byKey := map[string][]int{}
+	for i, r := range new {
+		newKeys[i] = Key(r)
+		byKey[newKeys[i]] = append(byKey[newKeys[i]], i)
+	}
+	matched := make([]bool, len(new))
+	for _, r := range old {
+		key := Key(r)
+		p := appendPath(path, key)
+		indexes := byKey[key]
+		if len(indexes) == 0 {
+			*changes = append(*changes, Change{Kind: Removed, Path: p, OldRule: r})
+			continue
+		}
+		n := new[indexes[0]]
+		matched[indexes[0]] = true
+		byKey[key] = indexes[1:]
+		diffDeclarations(changes, p, r.Declarations, n.Declarations)
+		diffRules(changes, p, r.Rules, n.Rules)
+	}
+	for i, r := range new {
+		if !matched[i] {
+			*changes = append(*changes, Change{Kind: Added, Path: appendPath(path, newKeys[i]), NewRule: r})
+		}
+	}
+}
+
+// diffDeclarations appends the changes between two lists of declarations of
+// the rule of path to changes.
+func diffDeclarations(changes *[]Change, path []string, old, new []*css.Declaration) {
+	byProperty := map[string][]int{}
+	for i, d := range new {
+		p := property(d)
+		byProperty[p] = append(byProperty[p], i)
+	}
+	matched := make([]bool, len(new))
+	for _, d := range old {
+		p := property(d)
+		indexes := byProperty[p]
+		if len(indexes) == 0 {
+			*changes = append(*changes, Change{Kind: Removed, Path: path, Property: p, OldDeclaration: d})
+			continue
+		}
+		n := new[indexes[0]]
+		matched[indexes[0]] = true
+		byProperty[p] = indexes[1:]
+		if value(d) != value(n) {
+			*changes = append(*changes, Change{Kind: Modified, Path: path, Property: p, OldDeclaration: d, NewDeclaration: n})
+		}
+	}
+	for i, d := range new {
+		if !matched[i] {
+			*changes = append(*changes, Change{Kind: Added, Path: path, Property: property(d), NewDeclaration: d})
+		}
+	}
+}
+
+// appendPath returns a copy of path with key appended, so that the paths
+// of changes don't share their backing array.
+func appendPath(path []string, key string) []string {
+	p := make([]string, len(path), len(path)+1)
+	copy(p, path)
+	return append(p, key)
+}
+
+// Key returns the key matching a rule with the rule of the other
+// stylesheet: the canonical form of its at-keyword and prelude, as
+// "@media print" or "a>b,c", without whitespace around the combinators of
+// style rules.
+func Key(r *css.Rule) string {
+	prelude := r.Prelude
+	if !r.IsAtRule() {
+		prelude = trimCombinators(prelude)
+	}
+	var b strings.Builder
+	(&css.Rule{AtKeyword: r.AtKeyword, Prelude: prelude}).Render(&b, css.RenderOptions{Canonical: true})
+	return strings.TrimSuffix(b.String(), ";")
+}
+
+// trimCombinators returns the values of a selector list without the
+// whitespace next to combinators and commas.
+func trimCombinators(values []*css.ComponentValue) []*css.ComponentValue {
+	isCombinator := func(v *css.ComponentValue) bool {
+		return v.Token.Type == scanner.TokenChar && strings.Contains(">+~,", v.Token.Value)
+	}
+	var out []*css.ComponentValue
+	for i, v := range values {
+		if v.Token.Type == scanner.TokenS &&
+			(i > 0 && isCombinator(values[i-1]) || i+1 < len(values) && isCombinator(values[i+1])) {
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// property returns the property of a declaration, in lowercase unless it
+// is a custom property.
+func property(d *css.Declaration) string {
+	if strings.HasPrefix(d.Property, "--") {
+		return d.Property
+	}
+	return strings.ToLower(d.Property)
+}
+
+// value returns the canonical form of the value of a declaration, followed
+// by " !important" if it is important.
+func value(d *css.Declaration) string {
+	var b strings.Builder
+	(&css.Declaration{Property: "x", Value: d.Value}).Render(&b, css.RenderOptions{Canonical: true})
+	s := strings.TrimPrefix(b.String(), "x:")
+	if d.Important {
+		s += " !important"
+	}
+	return s
+}
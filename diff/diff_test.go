@@ -0,0 +1,80 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package diff
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/css"
+)
+
+func TestDiff(t *testing.T) {
+	tcs := []struct {
+		old, new string
+		expected []string
+	}{
+		{"a { color: red }", "/* x */\nA {\n  COLOR : red;\n}", []string{"- a", "+ A"}},
+		{"a > b , c { color: red; margin: 0 }", "a>b,c{margin:0;color:red}", nil},
+		{"a { color: red; margin: 0 }", "a { color: blue !important; padding: 0 }", []string{
+			"~ a { color: red -> blue !important }",
+			"- a { margin: 0 }",
+			"+ a { padding: 0 }",
+		}},
+		{"a { color: red } b { top: 0 }", "b { top: 0 } c { top: 0 }", []string{"- a", "+ c"}},
+		{"@media print { a { color: red } b {} }", "@MEDIA print { a { color: blue } c {} } @import 'a.css';", []string{
+			"~ @media print { a { color: red -> blue } }",
+			"- @media print { b }",
+			"+ @media print { c }",
+			"+ @import \"a.css\"",
+		}},
+		{"a { display: -webkit-box; display: flex } a { top: 0 }", "a { display: -webkit-box; display: grid } a { top: 1px } a { top: 2px }", []string{
+			"~ a { display: flex -> grid }",
+			"~ a { top: 0 -> 1px }",
+			"+ a",
+		}},
+		{"a { --Main: red; & b { x: y } }", "a { --main: red; & b { x: z } }", []string{
+			"- a { --Main: red }",
+			"+ a { --main: red }",
+			"~ a { & b { x: y -> z } }",
+		}},
+	}
+	for _, tc := range tcs {
+		old, err := css.ParseStylesheet(tc.old)
+		if err != nil {
+			t.Fatal(err)
+		}
+		new, err := css.ParseStylesheet(tc.new)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, c := range Diff(old, new) {
+			got = append(got, c.String())
+		}
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("%s -> %s:\ngot  %q\nwant %q", tc.old, tc.new, got, tc.expected)
+		}
+	}
+}
+
+func TestChange(t *testing.T) {
+	old, _ := css.ParseStylesheet("@media print { a { color: red } }")
+	new, _ := css.ParseStylesheet("@media print { a { color: blue } b {} }")
+	changes := Diff(old, new)
+	if len(changes) != 2 {
+		t.Fatalf("got %d changes, want 2", len(changes))
+	}
+	c := changes[0]
+	if c.Kind != Modified || !reflect.DeepEqual(c.Path, []string{"@media print", "a"}) || c.Property != "color" ||
+		c.OldDeclaration != old.Rules[0].Rules[0].Declarations[0] || c.NewDeclaration != new.Rules[0].Rules[0].Declarations[0] {
+		t.Errorf("got %+v, want the modification of color", c)
+	}
+	c = changes[1]
+	if c.Kind != Added || !reflect.DeepEqual(c.Path, []string{"@media print", "b"}) || c.Property != "" ||
+		c.OldRule != nil || c.NewRule != new.Rules[0].Rules[1] {
+		t.Errorf("got %+v, want the addition of b", c)
+	}
+}
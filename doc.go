@@ -0,0 +1,177 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package gorilla/css parses stylesheets into rules, declarations and component
+values on top of the token stream produced by gorilla/css/scanner.
+
+It follows the parsing rules of the CSS Syntax specification located at:
+
+	https://www.w3.org/TR/css-syntax-3/#parsing
+
+The scanner keeps the tokens of its first version, and the parser reads
+them as the specification tokenizes the input: a sign followed by a number,
+such as "-" and "1px", is read as a single token, "-1px", and so are a
+custom name such as "--x", which the scanner reads as "-" and "-x", and a
+url such as "URL(a)", which it reads as a function.
+
+A component value is either a preserved token, a function or a simple block.
+Functions and simple blocks contain other component values:
+
+	values, err := css.ParseComponentValues("rgb(0 0 0 / 50%) [a]")
+	if err != nil {
+		// The input has an unclosed quotation mark or comment.
+	}
+	for _, v := range values {
+		if v.IsFunction() {
+			// Do something with v.Children...
+		}
+	}
+
+A stylesheet is a list of rules. Each rule has a prelude, such as the selector
+list of a style rule, and optionally a block with declarations and nested
+rules:
+
+	sheet, err := css.ParseStylesheet("a { color: red } @media print { a { color: black } }")
+	if err != nil {
+		// The input has an unclosed quotation mark or comment.
+	}
+	for _, r := range sheet.Rules {
+		for _, d := range r.Declarations {
+			fmt.Println(d.Property, css.ValuesString(d.Value))
+		}
+	}
+
+Comments preceding rules and declarations are kept in their Comments field;
+other comments are dropped while parsing. Whitespace is preserved as TokenS
+values because it is significant in many property values.
+
+Large stylesheets can be parsed with ParseStylesheetParallel, which parses
+top-level rules concurrently, or with an Arena, which allocates the nodes in
+large blocks rather than one by one. They can also be streamed with
+ParseRules, which passes each top-level rule to a callback as soon as it is
+read and doesn't retain it:
+
+	err := css.ParseRules(f, func(r *css.Rule) error {
+		// Do something with r...
+		return nil
+	})
+
+Stylesheets read from the network should be opened with OpenStylesheet,
+which determines their encoding from their byte order mark, the charset of
+their Content-Type header or their @charset rule, and decodes them:
+
+	s, enc, err := css.OpenStylesheet(resp.Body, resp.Header.Get("Content-Type"))
+
+Uploaded files can be checked with Sniff, which reads the start of a file
+and reports whether it is plausibly CSS rather than HTML, JavaScript or
+binary data, and whether it uses features such as nesting or layers:
+
+	if ok, profile := css.Sniff(bytes.NewReader(upload)); !ok {
+		return errNotCSS
+	} else if profile.Nesting {
+		// Compile the nesting away for older browsers.
+	}
+
+Stylesheets can be exchanged with JavaScript tooling as PostCSS ASTs
+encoded in JSON, with MarshalPostCSS and UnmarshalPostCSS.
+
+Parsed stylesheets can be cached in a compact binary encoding, which is much
+faster to decode than the CSS is to parse, with Stylesheet.MarshalBinary and
+UnmarshalBinary. The encoding is versioned and checksummed: decoding returns
+scanner.ErrBinaryVersion or scanner.ErrCorrupt rather than a wrong result,
+and the cache entry should then be discarded.
+
+Untrusted input should be parsed with a Parser with Limits, which bound
+the nesting depth, the number of rules and declarations and the length of
+selectors, and return a *LimitExceededError once one is exceeded:
+
+	p := css.Parser{Limits: css.Limits{MaxDepth: 32, MaxRules: 10000}}
+	sheet, err := p.ParseStylesheet(input)
+
+DefaultLimits suit most untrusted input, and are the default limits of
+gorilla/css/sanitize, gorilla/css/htmlstyle and gorilla/css/middleware.
+
+The errors can be told apart with errors.Is, as ErrBadString and
+ErrBadComment for unclosed quotation marks and comments, and
+ErrLimitExceeded for exceeded limits, and errors.As gives the *ParseError or
+*LimitExceededError with the position of the problem. ErrorDiagnostic
+returns them as a diag.Diagnostic, like the problems reported by
+gorilla/css/validate and gorilla/css/sanitize.
+
+Invalid declarations and rules are dropped without an error. A Parser with
+an OnWarning function reports them, for tools that show them to users:
+
+	p := css.Parser{OnWarning: func(d diag.Diagnostic) {
+		fmt.Println(d) // warning: declaration dropped: expected a colon after "color" [invalid-declaration] (line: 1, column: 5)
+	}}
+
+Stylesheets, rules, declarations and component values implement
+io.WriterTo, so they can be written to an http.ResponseWriter or a buffered
+file without building a string first:
+
+	if _, err := sheet.WriteTo(w); err != nil {
+		// The write failed.
+	}
+
+To only check that a stylesheet is well-formed, Validate reports the errors
+ParseStylesheet would return without building tokens or rules, which is
+several times faster.
+
+ExtractURLs returns the URLs referenced by a stylesheet, including those of
+@import rules and image-set(), with the kind of resource they reference and
+their position:
+
+	for _, ref := range css.ExtractURLs(sheet) {
+		fmt.Println(ref.Context, ref.URL) // image a.png
+	}
+
+FindDeclarations, FindRules, FindFunctions and FindVars return the
+declarations of a property, the rules matching a predicate, the calls of a
+function and the references to a custom property, with the rules holding
+them and their position, so that codemods can modify them in place:
+
+	for _, m := range sheet.FindDeclarations("color") {
+		line, col := m.Position()
+		fmt.Println(line, col, m.Declaration) // 1 5 color:red
+	}
+
+# Canonical form
+
+Rendering with RenderOptions.Canonical writes a canonical form of the CSS,
+meant for cache keys and subresource integrity hashes. Inputs that only
+differ in the following ways render to the same bytes:
+
+  - comments;
+  - whitespace, which is written as a single space between component values
+    and omitted at the start and end of preludes, values, functions and
+    blocks, and next to commas;
+  - the case of property names other than custom properties, at-rule names,
+    function names and units;
+  - the quotation marks of strings and urls, and escape sequences, which
+    follow the CSSOM serialization rules with lowercase hexadecimal digits.
+
+Other differences, such as the order of declarations or the spelling of
+numbers, are kept; transform them before rendering if needed. The canonical
+form of a given stylesheet is a compatibility promise: it only changes in a
+new major version of the package.
+
+# Minimal builds
+
+Building with the css_minimal tag leaves out the parts of the package that
+make up most of the size of small programs, such as WebAssembly modules
+built with Go or TinyGo that only tokenize and parse stylesheets:
+
+	GOOS=js GOARCH=wasm go build -tags css_minimal
+
+OpenStylesheet then only supports UTF-8 and UTF-16, without
+golang.org/x/text, and ignores the labels of other encodings, and the
+PostCSS functions are not available. The property metadata, the validator
+and the HTML style matcher are in the separate gorilla/css/props,
+gorilla/css/validate and gorilla/css/htmlstyle packages, which are only
+compiled in when imported.
+*/
+package css
+
+//go:generate go run ./internal/gen
@@ -0,0 +1,170 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package css
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gorilla/css/scanner"
+)
+
+// OpenStylesheet reads a stylesheet from r, decodes it to UTF-8 and returns
+// a scanner for it, along with the name of the encoding used, such as
+// "utf-8" or "windows-1252". httpContentType is the Content-Type header the
+// stylesheet was served with, or "" if there is none. The decoded
+// stylesheet, the Input of the scanner, can also be parsed with
+// ParseStylesheet.
+//
+// The encoding is determined as required by the CSS Syntax specification:
+//
+//	https://www.w3.org/TR/css-syntax-3/#input-byte-stream
+//
+// A byte order mark takes precedence, then the charset parameter of
+// httpContentType, then a @charset rule at the start of the stylesheet,
+// and UTF-8 is used otherwise. The byte order mark is removed, and
+// malformed sequences are replaced by U+FFFD.
+//
+// All the encodings of the Encoding specification are supported, such as
+// windows-1252, shift_jis or gbk, with all their labels, such as "latin1"
+// or "iso-8859-1" for windows-1252. Unknown labels are ignored. With the
+// css_minimal build tag, only UTF-8 and UTF-16 are supported, and the labels
+// of other encodings are ignored too.
+//
+// Unlike the specification, which would decode them as UTF-8, stylesheets
+// without a byte order mark or a label that start with an ASCII character
+// encoded in UTF-16 are decoded as UTF-16, since a stylesheet can't start
+// with a NUL character. This recovers the UTF-16 stylesheets saved without
+// a byte order mark by some editors.
+func OpenStylesheet(r io.Reader, httpContentType string) (*scanner.Scanner, string, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, "", err
+	}
+	enc, b := sniffEncoding(b, httpContentType)
+	input, err := decode(b, enc)
+	if err != nil {
+		return nil, "", err
+	}
+	return scanner.New(input), charsetName(enc), nil
+}
+
+// sniffEncoding returns the encoding of a stylesheet, and the stylesheet
+// without its byte order mark.
+func sniffEncoding(b []byte, httpContentType string) (charset, []byte) {
+	switch {
+	case bytes.HasPrefix(b, []byte{0xef, 0xbb, 0xbf}):
+		return utf8Charset, b[3:]
+	case bytes.HasPrefix(b, []byte{0xfe, 0xff}):
+		return utf16BE, b[2:]
+	case bytes.HasPrefix(b, []byte{0xff, 0xfe}):
+		return utf16LE, b[2:]
+	}
+	if label, ok := contentTypeCharset(httpContentType); ok {
+		if enc, ok := charsetFromLabel(label); ok {
+			return enc, b
+		}
+	}
+	if label, ok := charsetRule(b); ok {
+		if enc, ok := charsetFromLabel(label); ok {
+			if enc == utf16BE || enc == utf16LE {
+				// A stylesheet that can be read as ASCII can't be UTF-16.
+				return utf8Charset, b
+			}
+			return enc, b
+		}
+	}
+	if len(b) >= 2 {
+		switch {
+		case b[0] == 0 && b[1] != 0 && b[1] < utf8.RuneSelf:
+			return utf16BE, b
+		case b[0] != 0 && b[0] < utf8.RuneSelf && b[1] == 0:
+			return utf16LE, b
+		}
+	}
+	return utf8Charset, b
+}
+
+// contentTypeCharset returns the charset parameter of a Content-Type
+// header, parsed as a MIME type of the MIME Sniffing specification. It
+// doesn't use package mime, which is large for the programs that only
+// parse stylesheets, such as WebAssembly modules.
+func contentTypeCharset(contentType string) (string, bool) {
+	essence, params, _ := strings.Cut(contentType, ";")
+	essence = strings.Trim(essence, httpWhitespace)
+	slash := strings.IndexByte(essence, '/')
+	if slash <= 0 || slash == len(essence)-1 || strings.ContainsAny(essence, httpWhitespace) {
+		return "", false
+	}
+	for params != "" {
+		params = strings.TrimLeft(params, httpWhitespace)
+		i := strings.IndexAny(params, ";=")
+		if i < 0 {
+			break
+		}
+		name := params[:i]
+		if params[i] == ';' {
+			params = params[i+1:]
+			continue
+		}
+		params = params[i+1:]
+		var value string
+		if strings.HasPrefix(params, `"`) {
+			// A quoted string may contain semicolons and backslash escapes.
+			var b strings.Builder
+			j := 1
+			for ; j < len(params) && params[j] != '"'; j++ {
+				if params[j] == '\\' && j+1 < len(params) {
+					j++
+				}
+				b.WriteByte(params[j])
+			}
+			value = b.String()
+			params = params[j:]
+			if k := strings.IndexByte(params, ';'); k >= 0 {
+				params = params[k+1:]
+			} else {
+				params = ""
+			}
+		} else {
+			value, params, _ = strings.Cut(params, ";")
+			value = strings.TrimRight(value, httpWhitespace)
+		}
+		if strings.EqualFold(name, "charset") && value != "" {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// httpWhitespace holds the whitespace characters of HTTP headers.
+const httpWhitespace = "\t\n\r "
+
+// charsetRule returns the label of the @charset rule at the start of a
+// stylesheet. As required by the specification, the rule is only matched
+// if it is written exactly as @charset "label"; in the first 1024 bytes.
+func charsetRule(b []byte) (string, bool) {
+	const prefix = `@charset "`
+	if len(b) > 1024 {
+		b = b[:1024]
+	}
+	if !bytes.HasPrefix(b, []byte(prefix)) {
+		return "", false
+	}
+	b = b[len(prefix):]
+	i := bytes.IndexByte(b, '"')
+	if i < 0 || i+1 >= len(b) || b[i+1] != ';' {
+		return "", false
+	}
+	return string(b[:i]), true
+}
+
+// charsetFromLabel returns the encoding of a label, ignoring the whitespace
+// around it, and whether the label is known.
+func charsetFromLabel(label string) (charset, bool) {
+	return lookupCharset(strings.Trim(label, "\t\n\f\r "))
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build css_minimal
+
+package css
+
+import (
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// charset is UTF-8 or UTF-16, the only encodings supported when building
+// with the css_minimal tag.
+type charset int
+
+const (
+	utf8Charset charset = iota
+	utf16BE
+	utf16LE
+)
+
+// charsetNames are the names of the encodings.
+var charsetNames = [...]string{
+	utf8Charset: "utf-8",
+	utf16BE:     "utf-16be",
+	utf16LE:     "utf-16le",
+}
+
+// charsetLabels are the labels of the encodings, from the Encoding
+// specification.
+var charsetLabels = map[string]charset{
+	"unicode-1-1-utf-8": utf8Charset,
+	"unicode11utf8":     utf8Charset,
+	"unicode20utf8":     utf8Charset,
+	"utf-8":             utf8Charset,
+	"utf8":              utf8Charset,
+	"x-unicode20utf8":   utf8Charset,
+	"unicodefffe":       utf16BE,
+	"utf-16be":          utf16BE,
+	"csunicode":         utf16LE,
+	"iso-10646-ucs-2":   utf16LE,
+	"ucs-2":             utf16LE,
+	"unicode":           utf16LE,
+	"unicodefeff":       utf16LE,
+	"utf-16":            utf16LE,
+	"utf-16le":          utf16LE,
+}
+
+// lookupCharset returns the encoding of a label, and whether the label is
+// known. The labels of the other encodings are unknown.
+func lookupCharset(label string) (charset, bool) {
+	enc, ok := charsetLabels[strings.ToLower(label)]
+	return enc, ok
+}
+
+// charsetName returns the name of an encoding, such as "utf-8".
+func charsetName(enc charset) string {
+	return charsetNames[enc]
+}
+
+// decode decodes b from the encoding enc to UTF-8.
+func decode(b []byte, enc charset) (string, error) {
+	if enc == utf8Charset {
+		if utf8.Valid(b) {
+			return string(b), nil
+		}
+		var sb strings.Builder
+		for len(b) > 0 {
+			r, size := utf8.DecodeRune(b)
+			if r == utf8.RuneError && size == 1 {
+				size = invalidLength(b)
+			}
+			sb.WriteRune(r)
+			b = b[size:]
+		}
+		return sb.String(), nil
+	}
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		if enc == utf16BE {
+			units[i] = uint16(b[2*i])<<8 | uint16(b[2*i+1])
+		} else {
+			units[i] = uint16(b[2*i+1])<<8 | uint16(b[2*i])
+		}
+	}
+	var sb strings.Builder
+	for _, r := range utf16.Decode(units) {
+		sb.WriteRune(r)
+	}
+	if len(b)%2 == 1 {
+		sb.WriteRune(utf8.RuneError)
+	}
+	return sb.String(), nil
+}
+
+// invalidLength returns the length of the invalid UTF-8 sequence at the
+// start of b, which is replaced by a single U+FFFD: its first byte and the
+// bytes that could have continued it, as required by the Encoding
+// specification.
+func invalidLength(b []byte) int {
+	lo, hi := byte(0x80), byte(0xbf)
+	n := 0
+	switch c := b[0]; {
+	case c >= 0xc2 && c <= 0xdf:
+		n = 1
+	case c == 0xe0:
+		n, lo = 2, 0xa0
+	case c == 0xed:
+		n, hi = 2, 0x9f
+	case c >= 0xe1 && c <= 0xef:
+		n = 2
+	case c == 0xf0:
+		n, lo = 3, 0x90
+	case c == 0xf4:
+		n, hi = 3, 0x8f
+	case c >= 0xf1 && c <= 0xf3:
+		n = 3
+	}
+	i := 1
+	for i <= n && i < len(b) && b[i] >= lo && b[i] <= hi {
+		i++
+		lo, hi = 0x80, 0xbf
+	}
+	return i
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build css_minimal
+
+package css
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOpenStylesheetMinimal(t *testing.T) {
+	// The labels of legacy encodings are ignored.
+	tcs := []struct {
+		input       string
+		contentType string
+		encoding    string
+		expected    string
+	}{
+		{"a{content:'\xe9'}", "text/css; charset=ISO-8859-1", "utf-8", "a{content:'�'}"},
+		{"@charset \"Shift_JIS\";a{}", "", "utf-8", "@charset \"Shift_JIS\";a{}"},
+		{"\x00a", "text/css; charset=UTF-16", "utf-16le", "愀"},
+		{"@charset \"UTF8\";a{}", "text/css; charset=gbk", "utf-8", "@charset \"UTF8\";a{}"},
+	}
+	for _, tc := range tcs {
+		s, enc, err := OpenStylesheet(strings.NewReader(tc.input), tc.contentType)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.input, err)
+			continue
+		}
+		if enc != tc.encoding || s.Input() != tc.expected {
+			t.Errorf("%q: got %q decoded as %q, want %q decoded as %q", tc.input, enc, s.Input(), tc.encoding, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !css_minimal
+
+package css
+
+import (
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// charset is an encoding of the Encoding specification.
+type charset = encoding.Encoding
+
+// The UTF-8 and UTF-16 encodings, as returned by htmlindex.
+var (
+	utf8Charset      charset = unicode.UTF8
+	utf16BE, utf16LE         = mustEncoding("utf-16be"), mustEncoding("utf-16le")
+)
+
+// mustEncoding returns the encoding of a label known to htmlindex.
+func mustEncoding(label string) encoding.Encoding {
+	enc, err := htmlindex.Get(label)
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// lookupCharset returns the encoding of a label, and whether the label is
+// known.
+func lookupCharset(label string) (charset, bool) {
+	enc, err := htmlindex.Get(label)
+	return enc, err == nil
+}
+
+// charsetName returns the name of an encoding, such as "utf-8".
+func charsetName(enc charset) string {
+	name, err := htmlindex.Name(enc)
+	if err != nil {
+		// The encodings are those of htmlindex.
+		panic(err)
+	}
+	return name
+}
+
+// decode decodes b from the encoding enc to UTF-8.
+func decode(b []byte, enc charset) (string, error) {
+	b, err := enc.NewDecoder().Bytes(b)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
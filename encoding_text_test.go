@@ -0,0 +1,40 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !css_minimal
+
+package css
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOpenStylesheetLegacy(t *testing.T) {
+	tcs := []struct {
+		input       string
+		contentType string
+		encoding    string
+		expected    string
+	}{
+		{"a{content:'\xe9'}", "text/css; charset=ISO-8859-1", "windows-1252", "a{content:'é'}"},
+		{"a{content:'\x80'}", "text/css;charset=\"cp1252\"", "windows-1252", "a{content:'€'}"},
+		{"@charset \"latin1\";a{content:'\xe9'}", "", "windows-1252", "@charset \"latin1\";a{content:'é'}"},
+		{"@charset \"Shift_JIS\";.a:after{content:'\x93\xfa\x96\x7b'}", "", "shift_jis", "@charset \"Shift_JIS\";.a:after{content:'日本'}"},
+		{".a:after{content:'\x93\xfa\x96\x7b'}", "text/css; charset=x-sjis", "shift_jis", ".a:after{content:'日本'}"},
+		{"@charset \"gbk\";.a:after{content:'\xd6\xd0'}", "", "gbk", "@charset \"gbk\";.a:after{content:'中'}"},
+		// The Content-Type header wins over the @charset rule.
+		{"@charset \"utf-8\";.a:after{content:'\x93\xfa'}", "text/css; charset=shift_jis", "shift_jis", "@charset \"utf-8\";.a:after{content:'日'}"},
+	}
+	for _, tc := range tcs {
+		s, enc, err := OpenStylesheet(strings.NewReader(tc.input), tc.contentType)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.input, err)
+			continue
+		}
+		if enc != tc.encoding || s.Input() != tc.expected {
+			t.Errorf("%q: got %q decoded as %q, want %q decoded as %q", tc.input, enc, s.Input(), tc.encoding, tc.expected)
+		}
+	}
+}
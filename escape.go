@@ -0,0 +1,123 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package css
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// escapeIdent returns s escaped as an identifier.
+func escapeIdent(s string, opts *RenderOptions) string {
+	var b strings.Builder
+	for i, r := range s {
+		rest := s[i+utf8.RuneLen(r):]
+		switch {
+		case i == 0 && isDigit(r), i == 1 && s[0] == '-' && isDigit(r):
+			writeHexEscape(&b, r, nameNeedsSpace(rest), opts)
+		case s == "-":
+			b.WriteString(`\-`)
+		default:
+			writeNameRune(&b, r, rest, opts)
+		}
+	}
+	return b.String()
+}
+
+// escapeName returns s escaped as a name, which unlike an identifier may
+// start with a digit, as in hash tokens.
+func escapeName(s string, opts *RenderOptions) string {
+	var b strings.Builder
+	for i, r := range s {
+		writeNameRune(&b, r, s[i+utf8.RuneLen(r):], opts)
+	}
+	return b.String()
+}
+
+// writeNameRune writes to b a rune of an identifier or a name, escaped if
+// needed. The rest of the input follows the rune.
+func writeNameRune(b *strings.Builder, r rune, rest string, opts *RenderOptions) {
+	if r == 0 {
+		r = utf8.RuneError
+	}
+	switch {
+	case r < 0x20 || r == 0x7f || r >= 0x80 && opts.EscapeNonASCII || isHTMLSpecial(r, opts):
+		writeHexEscape(b, r, nameNeedsSpace(rest), opts)
+	case r >= 0x80 || r == '-' || r == '_' || isDigit(r) || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z':
+		b.WriteRune(r)
+	default:
+		b.WriteByte('\\')
+		b.WriteRune(r)
+	}
+}
+
+// escapeString returns s escaped as a string delimited by quote.
+func escapeString(s string, quote byte, opts *RenderOptions) string {
+	var b strings.Builder
+	b.WriteByte(quote)
+	for i, r := range s {
+		if r == 0 {
+			r = utf8.RuneError
+		}
+		switch {
+		case r < 0x20 || r == 0x7f || r >= 0x80 && opts.EscapeNonASCII || isHTMLSpecial(r, opts):
+			writeHexEscape(&b, r, stringNeedsSpace(s[i+utf8.RuneLen(r):]), opts)
+		case r == rune(quote) || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte(quote)
+	return b.String()
+}
+
+// isHTMLSpecial reports whether r must be escaped for the output to be used
+// in a double-quoted HTML attribute.
+func isHTMLSpecial(r rune, opts *RenderOptions) bool {
+	return opts.htmlAttr && (r == '"' || r == '&')
+}
+
+// writeHexEscape writes r to b as a hexadecimal escape sequence.
+//
+// The escape sequence ends with a space, which is omitted in minimal mode
+// unless space is true.
+func writeHexEscape(b *strings.Builder, r rune, space bool, opts *RenderOptions) {
+	hex := strconv.FormatInt(int64(r), 16)
+	if opts.UpperHex {
+		hex = strings.ToUpper(hex)
+	}
+	b.WriteByte('\\')
+	b.WriteString(hex)
+	if space || !opts.MinimalEscapes {
+		b.WriteByte(' ')
+	}
+}
+
+// nameNeedsSpace reports whether a hexadecimal escape sequence in a name
+// needs a space to end it, given the rest of the name. Whitespace is always
+// escaped in names, but the name may be followed by any character: the
+// space is kept at the end.
+func nameNeedsSpace(rest string) bool {
+	return rest == "" || isHex(rest[0])
+}
+
+// stringNeedsSpace reports whether a hexadecimal escape sequence in a string
+// needs a space to end it, given the rest of the string.
+func stringNeedsSpace(rest string) bool {
+	return rest != "" && (isHex(rest[0]) || rest[0] == ' ')
+}
+
+// isHex reports whether c is a hexadecimal digit.
+func isHex(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+// isDigit reports whether r is a decimal digit.
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
@@ -0,0 +1,215 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package gorilla/css/format pretty-prints stylesheets.
+
+Each declaration is written on its own line, blocks are indented and rules
+are separated by a blank line:
+
+	sheet, _ := css.ParseStylesheet("a{color:red;margin:0}")
+	fmt.Print(format.String(sheet, format.Options{}))
+	// a {
+	//   color: red;
+	//   margin: 0;
+	// }
+
+Rules nested in at-rules, such as @media, @supports or @layer, nested style
+rules and the margin rules of @page are indented one more level than their
+parent. With the CollapseSingle option, rules with a single declaration are
+written on one line.
+
+Declarations can also be sorted within each block, alphabetically or by
+groups of properties, with the Order option. See SortDeclarations for how
+vendor prefixes, custom properties and shorthands are handled.
+*/
+package format
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/scanner"
+	"github.com/gorilla/css/sourcemap"
+)
+
+// Options controls formatting.
+type Options struct {
+	// Indent is the indentation of each nesting level. It defaults to two
+	// spaces.
+	Indent string
+	// Order sorts the declarations of each block if it isn't nil.
+	Order Order
+	// CollapseSingle writes rules with a single declaration and no nested
+	// rules on one line, as in "a { color: red; }".
+	CollapseSingle bool
+	// SourceMap, if not nil, receives the mappings from positions in the
+	// output to the positions of the rules, declarations and tokens in the
+	// input, for source 0 of the map.
+	SourceMap *sourcemap.Map
+}
+
+// String returns the formatted CSS representation of a stylesheet.
+func String(s *css.Stylesheet, opts Options) string {
+	f := &formatter{opts: opts, line: 1, col: 1}
+	if f.opts.Indent == "" {
+		f.opts.Indent = "  "
+	}
+	f.rules(s.Rules, 0)
+	return f.b.String()
+}
+
+// Fprint writes the formatted CSS representation of a stylesheet to w. It
+// returns the number of bytes written and the error encountered, if any.
+func Fprint(w io.Writer, s *css.Stylesheet, opts Options) (int64, error) {
+	n, err := io.WriteString(w, String(s, opts))
+	return int64(n), err
+}
+
+// formatter builds the formatted representation of a stylesheet.
+type formatter struct {
+	b    strings.Builder
+	opts Options
+	// line and col are the output position at byte offset pos of b, for
+	// source maps.
+	line, col, pos int
+}
+
+// mark maps the current output position to a position in the input when
+// building a source map.
+func (f *formatter) mark(line, col int) {
+	if f.opts.SourceMap == nil {
+		return
+	}
+	text := f.b.String()[f.pos:]
+	if i := strings.LastIndexByte(text, '\n'); i >= 0 {
+		f.line += strings.Count(text, "\n")
+		f.col = utf8.RuneCountInString(text[i+1:]) + 1
+	} else {
+		f.col += utf8.RuneCountInString(text)
+	}
+	f.pos = f.b.Len()
+	f.opts.SourceMap.Add(sourcemap.Mapping{
+		Line:         f.line,
+		Column:       f.col,
+		SourceLine:   line,
+		SourceColumn: col,
+	})
+}
+
+// rules writes a list of rules at the given nesting level.
+func (f *formatter) rules(rules []*css.Rule, level int) {
+	for i, r := range rules {
+		if i > 0 {
+			f.b.WriteByte('\n')
+		}
+		f.rule(r, level)
+	}
+}
+
+// rule writes a rule at the given nesting level.
+func (f *formatter) rule(r *css.Rule, level int) {
+	f.comments(r.Comments, level)
+	f.indent(level)
+	f.mark(r.Line, r.Column)
+	if r.IsAtRule() {
+		f.b.WriteByte('@')
+		f.b.WriteString(r.AtKeyword)
+		if len(r.Prelude) > 0 {
+			f.b.WriteByte(' ')
+		}
+	}
+	f.values(r.Prelude)
+	if !r.HasBlock {
+		f.b.WriteString(";\n")
+		return
+	}
+	if len(r.Prelude) > 0 || r.IsAtRule() {
+		f.b.WriteByte(' ')
+	}
+	if len(r.Declarations) == 0 && len(r.Rules) == 0 {
+		f.b.WriteString("{}\n")
+		return
+	}
+	decls := r.Declarations
+	if f.opts.Order != nil {
+		decls = SortDeclarations(decls, f.opts.Order)
+	}
+	if f.opts.CollapseSingle && len(decls) == 1 && len(r.Rules) == 0 && len(decls[0].Comments) == 0 {
+		f.b.WriteString("{ ")
+		f.declaration(decls[0])
+		f.b.WriteString(" }\n")
+		return
+	}
+	f.b.WriteString("{\n")
+	for _, d := range decls {
+		f.comments(d.Comments, level+1)
+		f.indent(level + 1)
+		f.declaration(d)
+		f.b.WriteByte('\n')
+	}
+	if len(decls) > 0 && len(r.Rules) > 0 {
+		f.b.WriteByte('\n')
+	}
+	f.rules(r.Rules, level+1)
+	f.indent(level)
+	f.b.WriteString("}\n")
+}
+
+// declaration writes a declaration and its semicolon.
+func (f *formatter) declaration(d *css.Declaration) {
+	f.mark(d.Line, d.Column)
+	f.b.WriteString(d.Property)
+	f.b.WriteString(": ")
+	f.values(d.Value)
+	if d.Important {
+		f.b.WriteString(" !important")
+	}
+	f.b.WriteByte(';')
+}
+
+// comments writes comments, each on its own line.
+func (f *formatter) comments(comments []string, level int) {
+	for _, c := range comments {
+		f.indent(level)
+		f.b.WriteString(c)
+		f.b.WriteByte('\n')
+	}
+}
+
+// values writes component values, collapsing whitespace to a single space.
+func (f *formatter) values(values []*css.ComponentValue) {
+	for _, v := range values {
+		if v.Token.Type == scanner.TokenS {
+			f.b.WriteByte(' ')
+			continue
+		}
+		f.mark(v.Token.Line, v.Token.Column)
+		f.b.WriteString(v.Token.Value)
+		if v.IsFunction() || v.IsBlock() {
+			f.values(css.TrimSpace(v.Children))
+			f.b.WriteString(closing(v))
+		}
+	}
+}
+
+// closing returns the closing bracket of a function or a simple block.
+func closing(v *css.ComponentValue) string {
+	switch v.Token.Value {
+	case "[":
+		return "]"
+	case "{":
+		return "}"
+	}
+	return ")"
+}
+
+// indent writes the indentation of a nesting level.
+func (f *formatter) indent(level int) {
+	for i := 0; i < level; i++ {
+		f.b.WriteString(f.opts.Indent)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package format
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/sourcemap"
+)
+
+func TestString(t *testing.T) {
+	tcs := []struct {
+		opts     Options
+		input    string
+		expected string
+	}{
+		{
+			Options{},
+			"/* a */ a,b{color:red;margin:0   auto!important} @import  url(x.css);@media print{a{/* c */top:calc( 1px + 2px )}} b{}",
+			"/* a */\na,b {\n  color: red;\n  margin: 0 auto !important;\n}\n\n@import url(x.css);\n\n@media print {\n  a {\n    /* c */\n    top: calc(1px + 2px);\n  }\n}\n\nb {}\n",
+		},
+		{
+			Options{Indent: "\t"},
+			"a{color:red;&:hover{color:blue}}@font-face{src:url(a.woff)}",
+			"a {\n\tcolor: red;\n\n\t&:hover {\n\t\tcolor: blue;\n\t}\n}\n\n@font-face {\n\tsrc: url(a.woff);\n}\n",
+		},
+		{
+			Options{Order: Alphabetical},
+			"a{z-index:1;--b:0;color:red;/* t */-webkit-transition:none;transition:none;--a:0}",
+			"a {\n  --b: 0;\n  --a: 0;\n  color: red;\n  /* t */\n  -webkit-transition: none;\n  transition: none;\n  z-index: 1;\n}\n",
+		},
+		{
+			Options{},
+			"@media screen{@supports (display:grid){@layer base{a{b:c}}}}@page :first{margin:1in;@top-left{content:'x'}}",
+			"@media screen {\n  @supports (display:grid) {\n    @layer base {\n      a {\n        b: c;\n      }\n    }\n  }\n}\n\n@page :first {\n  margin: 1in;\n\n  @top-left {\n    content: 'x';\n  }\n}\n",
+		},
+		{
+			Options{CollapseSingle: true},
+			"@media print{a{color:red}b{color:red;top:0}c{/* x */left:0}}d{e:f;g{h:i}}",
+			"@media print {\n  a { color: red; }\n\n  b {\n    color: red;\n    top: 0;\n  }\n\n  c {\n    /* x */\n    left: 0;\n  }\n}\n\nd {\n  e: f;\n\n  g { h: i; }\n}\n",
+		},
+	}
+	for _, tc := range tcs {
+		s, err := css.ParseStylesheet(tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := String(s, tc.opts); got != tc.expected {
+			t.Errorf("%s:\ngot  %q\nwant %q", tc.input, got, tc.expected)
+		}
+	}
+}
+
+func TestSortDeclarations(t *testing.T) {
+	tcs := []struct {
+		order    Order
+		input    string
+		expected string
+	}{
+		{Alphabetical, "color:red;border-top-width:1px;border-width:0;all:unset;background:red", "border-top-width:1px;border-width:0;color:red;all:unset;background:red"},
+		{Alphabetical, "margin:0;margin-top:1px;color:red", "color:red;margin:0;margin-top:1px"},
+		{Alphabetical, "margin-top:1px;margin:0;color:red", "color:red;margin-top:1px;margin:0"},
+		{Concentric, "color:red;padding-top:0;margin:0;display:block;unknown:0;position:absolute", "display:block;position:absolute;margin:0;padding-top:0;color:red;unknown:0"},
+	}
+	for _, tc := range tcs {
+		s, err := css.ParseStylesheet("a{" + tc.input + "}")
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := s.Rules[0]
+		r.Declarations = SortDeclarations(r.Declarations, tc.order)
+		if got := r.String(); got != "a{"+tc.expected+"}" {
+			t.Errorf("%s:\ngot  %s\nwant a{%s}", tc.input, got, tc.expected)
+		}
+	}
+}
+
+func TestSourceMap(t *testing.T) {
+	s, err := css.ParseStylesheet("a{color:red}b{top:0}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &sourcemap.Map{}
+	String(s, Options{SourceMap: m})
+	var got []string
+	for _, mp := range m.Mappings {
+		got = append(got, fmt.Sprintf("%d:%d->%d:%d", mp.Line, mp.Column, mp.SourceLine, mp.SourceColumn))
+	}
+	want := "1:1->1:1 2:3->1:3 2:10->1:9 5:1->1:13 6:3->1:15 6:8->1:19"
+	if strings.Join(got, " ") != want {
+		t.Errorf("got  %s\nwant %s", strings.Join(got, " "), want)
+	}
+}
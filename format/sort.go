@@ -0,0 +1,151 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package format
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/prefix"
+	"github.com/gorilla/css/props"
+)
+
+// Order reports whether the property a sorts before the property b. Property
+// names are passed in lowercase and without vendor prefix.
+type Order func(a, b string) bool
+
+// Alphabetical sorts properties by name.
+func Alphabetical(a, b string) bool {
+	return a < b
+}
+
+// Groups returns an order that sorts properties by group, then by position
+// in the group. Properties that aren't listed belong to the group of their
+// shorthand, if listed, and otherwise come last in alphabetical order.
+func Groups(groups ...[]string) Order {
+	ranks := map[string]int{}
+	for _, g := range groups {
+		for _, name := range g {
+			if _, ok := ranks[name]; !ok {
+				ranks[name] = len(ranks)
+			}
+		}
+	}
+	var rank func(name string) int
+	rank = func(name string) int {
+		if r, ok := ranks[name]; ok {
+			return r
+		}
+		if p := props.Lookup(name); p != nil {
+			for _, s := range p.Shorthands {
+				if r := rank(s); r < len(ranks) {
+					return r
+				}
+			}
+		}
+		return len(ranks)
+	}
+	return func(a, b string) bool {
+		ra, rb := rank(a), rank(b)
+		if ra != rb {
+			return ra < rb
+		}
+		return ra == len(ranks) && a < b
+	}
+}
+
+// Concentric sorts properties from the outside of the box to the inside,
+// following concentric-css: positioning and layout first, then the box
+// model from margins to dimensions, then text.
+var Concentric = Groups(
+	[]string{"all", "box-sizing", "display", "position", "inset", "top", "right", "bottom", "left", "z-index", "float", "clear"},
+	[]string{"flex", "flex-direction", "flex-wrap", "flex-flow", "flex-grow", "flex-shrink", "flex-basis",
+		"grid", "grid-template", "grid-template-areas", "grid-template-rows", "grid-template-columns", "grid-area", "grid-row", "grid-column",
+		"gap", "align-content", "align-items", "align-self", "justify-content", "justify-items", "justify-self", "order"},
+	[]string{"columns", "column-count", "column-width", "column-gap", "column-rule", "column-fill", "column-span"},
+	[]string{"transform", "transition", "animation", "visibility", "opacity"},
+	[]string{"margin", "outline", "border", "border-radius", "box-shadow", "background", "cursor", "padding",
+		"width", "min-width", "max-width", "height", "min-height", "max-height", "overflow", "object-fit", "object-position",
+		"list-style", "caption-side", "table-layout", "border-collapse", "border-spacing", "empty-cells"},
+	[]string{"vertical-align", "text-align", "text-indent", "text-transform", "text-decoration", "text-shadow", "text-overflow",
+		"line-height", "word-spacing", "letter-spacing", "white-space", "color", "font", "font-family", "font-size",
+		"font-style", "font-weight", "content", "quotes"},
+)
+
+// SortDeclarations returns the declarations sorted with the given order.
+// The sort never changes which declarations win the cascade:
+//
+//   - Custom properties come first, in their original order.
+//   - Vendor-prefixed properties sort as their unprefixed name and keep
+//     their position relative to it.
+//   - A declaration never moves before an earlier one it overrides, such as
+//     a longhand following its shorthand, or "all".
+//
+// Comments attached to declarations move with them.
+func SortDeclarations(decls []*css.Declaration, order Order) []*css.Declaration {
+	names := make([]string, len(decls))
+	var unique []string
+	seen := map[string]bool{}
+	for i, d := range decls {
+		names[i] = strings.ToLower(prefix.Strip(d.Property))
+		if !seen[names[i]] {
+			seen[names[i]] = true
+			unique = append(unique, names[i])
+		}
+	}
+	sort.SliceStable(unique, func(i, j int) bool {
+		return order(unique[i], unique[j])
+	})
+	rank := map[string]int{}
+	for i, name := range unique {
+		rank[name] = i
+	}
+	keys := make([]int, len(decls))
+	for j := range decls {
+		if strings.HasPrefix(names[j], "--") {
+			keys[j] = -1
+			continue
+		}
+		keys[j] = rank[names[j]]
+		for i := 0; i < j; i++ {
+			if keys[i] > keys[j] && overlaps(names[i], names[j]) {
+				keys[j] = keys[i]
+			}
+		}
+	}
+	idx := make([]int, len(decls))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return keys[idx[i]] < keys[idx[j]]
+	})
+	sorted := make([]*css.Declaration, len(decls))
+	for i, k := range idx {
+		sorted[i] = decls[k]
+	}
+	return sorted
+}
+
+// overlaps reports whether two properties set a common longhand.
+func overlaps(a, b string) bool {
+	return a == b || a == "all" || b == "all" || isShorthandOf(a, b) || isShorthandOf(b, a)
+}
+
+// isShorthandOf reports whether s is a shorthand, at any depth, of the
+// property l.
+func isShorthandOf(s, l string) bool {
+	p := props.Lookup(l)
+	if p == nil {
+		return false
+	}
+	for _, name := range p.Shorthands {
+		if name == s || isShorthandOf(s, name) {
+			return true
+		}
+	}
+	return false
+}
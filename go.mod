@@ -1,3 +1,8 @@
 module github.com/gorilla/css
 
 go 1.20
+
+require (
+	golang.org/x/net v0.35.0
+	golang.org/x/text v0.22.0
+)
@@ -0,0 +1,252 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package htmlstyle finds the CSS of an HTML document, in style elements and
+style attributes, and rewrites it with the transforms of the other packages,
+such as sanitize or minify. It is only compiled in when imported, so that
+programs using the other packages don't depend on golang.org/x/net/html.
+
+Rewrite parses a document, transforms its CSS and writes it back:
+
+	p := sanitize.UserContent()
+	err := htmlstyle.Rewrite(w, r, htmlstyle.Options{
+		Stylesheet: func(s *css.Stylesheet) error {
+			p.Stylesheet(s)
+			return nil
+		},
+		Declarations: func(decls []*css.Declaration) ([]*css.Declaration, error) {
+			decls, _ = p.Declarations(decls)
+			return decls, nil
+		},
+	})
+
+The contents of a style element are raw text in HTML: they end at the first
+"</style", even inside a CSS string or comment, and character references
+aren't decoded. Rewritten stylesheets are escaped so that they can't end
+their element early, as in "content: '</style>'" becoming
+"content: '\3c /style>'", which CSS reads as the same string. Style
+attributes are parsed after decoding their character references, and
+escaped again when the document is written.
+*/
+package htmlstyle
+
+import (
+	"io"
+	"strings"
+
+	"github.com/gorilla/css"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Options are the transforms of Rewrite and RewriteNode.
+type Options struct {
+	// Stylesheet, if not nil, transforms the stylesheet of each style
+	// element in place.
+	Stylesheet func(s *css.Stylesheet) error
+	// Declarations, if not nil, transforms the declarations of each style
+	// attribute and returns the new ones. The attribute is removed if there
+	// are none left.
+	Declarations func(decls []*css.Declaration) ([]*css.Declaration, error)
+	// Limits bound the parsing of style elements and style attributes,
+	// which fails once one is exceeded. The limits that are zero are those
+	// of css.DefaultLimits, and negative ones mean no limit.
+	Limits css.Limits
+}
+
+// Style is the CSS of a style element or of a style attribute.
+type Style struct {
+	// Node is the style element, or the element with the style attribute.
+	Node *html.Node
+	// Inline reports whether the CSS is the style attribute of Node rather
+	// than the contents of a style element.
+	Inline bool
+	// CSS is the CSS, with the character references of style attributes
+	// decoded.
+	CSS string
+}
+
+// Styles returns the CSS of the style elements and style attributes of a
+// document, in document order. The style attribute of an element comes
+// before its contents.
+func Styles(n *html.Node) []Style {
+	var styles []Style
+	walk(n, func(n *html.Node) {
+		if i := styleAttr(n); i >= 0 {
+			styles = append(styles, Style{n, true, n.Attr[i].Val})
+		}
+		if isStyleElement(n) {
+			styles = append(styles, Style{n, false, text(n)})
+		}
+	})
+	return styles
+}
+
+// Rewrite parses an HTML document read from r, transforms its style
+// elements and style attributes, and writes the document to w.
+//
+// If the CSS of an element or attribute can't be parsed, or a transform
+// fails, nothing is written and the error is returned.
+func Rewrite(w io.Writer, r io.Reader, opts Options) error {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return err
+	}
+	if err := RewriteNode(doc, opts); err != nil {
+		return err
+	}
+	return html.Render(w, doc)
+}
+
+// RewriteNode transforms the style elements and style attributes of a
+// node and its descendants in place. It stops at the first error, which is
+// returned.
+func RewriteNode(n *html.Node, opts Options) error {
+	p := &css.Parser{Limits: opts.Limits.WithDefaults(css.DefaultLimits)}
+	var err error
+	walk(n, func(n *html.Node) {
+		if err != nil {
+			return
+		}
+		if i := styleAttr(n); i >= 0 && opts.Declarations != nil {
+			err = rewriteAttr(p, n, i, opts.Declarations)
+		}
+		if err == nil && isStyleElement(n) && opts.Stylesheet != nil {
+			err = rewriteElement(p, n, opts.Stylesheet)
+		}
+	})
+	return err
+}
+
+// rewriteAttr transforms the style attribute of n, at index i, parsed with
+// p.
+func rewriteAttr(p *css.Parser, n *html.Node, i int, fn func([]*css.Declaration) ([]*css.Declaration, error)) error {
+	// The declarations are parsed as the block of a rule. A closing brace
+	// in the attribute ends the block, and what follows is dropped.
+	s, err := p.ParseStylesheet("a{" + n.Attr[i].Val + "}")
+	if err != nil {
+		return err
+	}
+	decls, err := fn(s.Rules[0].Declarations)
+	if err != nil {
+		return err
+	}
+	if len(decls) == 0 {
+		n.Attr = append(n.Attr[:i], n.Attr[i+1:]...)
+		return nil
+	}
+	n.Attr[i].Val = css.RenderInline(decls)
+	return nil
+}
+
+// rewriteElement transforms the stylesheet of the style element n, parsed
+// with p.
+func rewriteElement(p *css.Parser, n *html.Node, fn func(*css.Stylesheet) error) error {
+	s, err := p.ParseStylesheet(text(n))
+	if err != nil {
+		return err
+	}
+	if err := fn(s); err != nil {
+		return err
+	}
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		n.RemoveChild(c)
+		c = next
+	}
+	out := s.String()
+	if n.Namespace == "" {
+		// Text in foreign elements, such as the style elements of SVG, is
+		// escaped when rendered.
+		out = escapeEndTags(out)
+	}
+	n.AppendChild(&html.Node{Type: html.TextNode, Data: out})
+	return nil
+}
+
+// escapeEndTags escapes the "<" of the "</style" sequences of a stylesheet,
+// which would end its style element. If the "<" is escaped in the CSS, as in
+// "\<", the whole escape sequence is replaced.
+func escapeEndTags(s string) string {
+	var b strings.Builder
+	for {
+		i := indexEndTag(s)
+		if i < 0 {
+			break
+		}
+		// The "<" is escaped if an odd number of backslashes precede it.
+		j := i
+		for j > 0 && s[j-1] == '\\' {
+			j--
+		}
+		start := i
+		if (i-j)%2 == 1 {
+			start--
+		}
+		b.WriteString(s[:start])
+		b.WriteString(`\3c `)
+		s = s[i+1:]
+	}
+	if b.Len() == 0 {
+		return s
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+// indexEndTag returns the index of the first "</style" in s, ignoring
+// case, or -1.
+func indexEndTag(s string) int {
+	const tag = "</style"
+	for i := 0; ; i++ {
+		j := strings.Index(s[i:], "</")
+		if j < 0 {
+			return -1
+		}
+		i += j
+		if len(s)-i >= len(tag) && strings.EqualFold(s[i:i+len(tag)], tag) {
+			return i
+		}
+	}
+}
+
+// walk calls fn for n and its descendants, in document order. The contents
+// of template elements are children of the template node in this parser, so
+// they are visited too.
+func walk(n *html.Node, fn func(*html.Node)) {
+	fn(n)
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		walk(c, fn)
+	}
+}
+
+// styleAttr returns the index of the style attribute of an element, or -1.
+func styleAttr(n *html.Node) int {
+	if n.Type != html.ElementNode {
+		return -1
+	}
+	for i, a := range n.Attr {
+		if a.Namespace == "" && a.Key == "style" {
+			return i
+		}
+	}
+	return -1
+}
+
+// isStyleElement reports whether n is a style element, of HTML or SVG.
+func isStyleElement(n *html.Node) bool {
+	return n.Type == html.ElementNode && n.DataAtom == atom.Style && (n.Namespace == "" || n.Namespace == "svg")
+}
+
+// text returns the text contents of n.
+func text(n *html.Node) string {
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.TextNode {
+			b.WriteString(c.Data)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,171 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package htmlstyle
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/minify"
+	"github.com/gorilla/css/sanitize"
+	"golang.org/x/net/html"
+)
+
+// sanitizeOptions sanitizes style elements and attributes with the user
+// content policy.
+func sanitizeOptions() Options {
+	p := sanitize.UserContent()
+	return Options{
+		Stylesheet: func(s *css.Stylesheet) error {
+			p.Stylesheet(s)
+			return nil
+		},
+		Declarations: func(decls []*css.Declaration) ([]*css.Declaration, error) {
+			decls, _ = p.Declarations(decls)
+			return decls, nil
+		},
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected string
+	}{
+		{
+			`<p style="color: red; behavior: url(x.htc)">a</p>`,
+			`<html><head></head><body><p style="color:red">a</p></body></html>`,
+		},
+		{
+			`<p style="position: fixed" class="a">a</p>`,
+			`<html><head></head><body><p class="a">a</p></body></html>`,
+		},
+		{
+			`<p style="font-family: &quot;a&amp;b&quot;; color: red}b{color: blue">a</p>`,
+			`<html><head></head><body><p style="font-family:&#39;a\26 b&#39;;color:red">a</p></body></html>`,
+		},
+		{
+			`<style>a { color: red; position: fixed } /* c */</style>`,
+			`<html><head><style>a{color:red}</style></head><body></body></html>`,
+		},
+		{
+			`<svg><style>a { color: red; font-family: "&lt;/style>" }</style></svg>`,
+			`<html><head></head><body><svg><style>a{color:red;font-family:&#34;&lt;/style&gt;&#34;}</style></svg></body></html>`,
+		},
+	}
+	for _, tc := range tcs {
+		var b strings.Builder
+		if err := Rewrite(&b, strings.NewReader(tc.input), sanitizeOptions()); err != nil {
+			t.Fatal(err)
+		}
+		if got := b.String(); got != tc.expected {
+			t.Errorf("%s:\ngot  %s\nwant %s", tc.input, got, tc.expected)
+		}
+	}
+}
+
+func TestEscapeEndTags(t *testing.T) {
+	tcs := []struct{ input, expected string }{
+		{`a{content:"</sty" "le>"}`, `a{content:"</sty" "le>"}`},
+		{`a{content:"</STYLE>" '</style'}`, `a{content:"\3c /STYLE>" '\3c /style'}`},
+		{`a{content:"\</style>" "\\</style>" "\\\</style>"}`, `a{content:"\3c /style>" "\\\3c /style>" "\\\3c /style>"}`},
+	}
+	for _, tc := range tcs {
+		if got := escapeEndTags(tc.input); got != tc.expected {
+			t.Errorf("%s: got %s, want %s", tc.input, got, tc.expected)
+		}
+	}
+}
+
+func TestRewriteRoundTrip(t *testing.T) {
+	// A transform writes end tags in strings, which are read back as the
+	// same strings once the document is rewritten.
+	value, _ := css.ParseComponentValues(`'</style><script>x</script>' \</style>`)
+	opts := Options{Stylesheet: func(s *css.Stylesheet) error {
+		minify.Stylesheet(s, minify.Options{})
+		s.Rules[0].Declarations[0].Value = value
+		return nil
+	}}
+	var b strings.Builder
+	if err := Rewrite(&b, strings.NewReader(`<style>a::after { content: "x" }</style>`), opts); err != nil {
+		t.Fatal(err)
+	}
+	doc, err := html.Parse(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	scripts := 0
+	walk(doc, func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "script" {
+			scripts++
+		}
+	})
+	styles := Styles(doc)
+	if len(styles) != 1 || scripts > 0 {
+		t.Fatalf("got %s", b.String())
+	}
+	got, err := css.ParseStylesheet(styles[0].CSS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := got.Rules[0].Declarations[0].Value
+	if s := v[0].Token.DecodedValue(); s != "</style><script>x</script>" {
+		t.Errorf("got string %q", s)
+	}
+	if s := v[2].Token.DecodedValue(); s != "<" {
+		t.Errorf("got identifier %q", s)
+	}
+}
+
+func TestStyles(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<style>a{}</style><p style="color: red">x<style>b{}</style></p><template><i style="top: 0"></i></template>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, s := range Styles(doc) {
+		got = append(got, s.Node.Data+" "+s.CSS)
+	}
+	want := "style a{}|p color: red|style b{}|i top: 0"
+	if strings.Join(got, "|") != want {
+		t.Errorf("got %q, want %q", strings.Join(got, "|"), want)
+	}
+}
+
+func TestRewriteError(t *testing.T) {
+	var b strings.Builder
+	err := Rewrite(&b, strings.NewReader(`<p style="content: 'a">x</p>`), sanitizeOptions())
+	if _, ok := err.(*css.ParseError); !ok || b.Len() > 0 {
+		t.Errorf("got %v and %q, want a parse error", err, b.String())
+	}
+	stop := errors.New("stop")
+	err = Rewrite(&b, strings.NewReader(`<style>a{}</style>`), Options{Stylesheet: func(*css.Stylesheet) error { return stop }})
+	if err != stop {
+		t.Errorf("got %v, want %v", err, stop)
+	}
+}
+
+func TestRewriteLimits(t *testing.T) {
+	// Deep nesting is rejected by default.
+	for _, doc := range []string{
+		`<style>a { b: ` + strings.Repeat("(", 1e5) + `</style>`,
+		`<p style="b: ` + strings.Repeat("(", 1e5) + `">x</p>`,
+	} {
+		var b strings.Builder
+		err := Rewrite(&b, strings.NewReader(doc), sanitizeOptions())
+		if !errors.Is(err, css.ErrLimitExceeded) || b.Len() > 0 {
+			t.Errorf("got %v and %d bytes, want an exceeded limit", err, b.Len())
+		}
+	}
+	opts := sanitizeOptions()
+	opts.Limits = css.Limits{MaxRules: 1}
+	err := Rewrite(io.Discard, strings.NewReader(`<style>a{} b{}</style>`), opts)
+	if e, ok := err.(*css.LimitExceededError); !ok || e.Limit != "MaxRules" {
+		t.Errorf("got %v, want MaxRules exceeded", err)
+	}
+}
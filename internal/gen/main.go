@@ -0,0 +1,367 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Gen generates the keyword, unit, property and pseudo-class tables of the
+// gorilla/css packages from the data files in internal/gen/data:
+//
+//	colors.txt       the named colors of package syntax
+//	units.txt        the units of package syntax and the length units of
+//	                 package minify
+//	properties.json  the properties of package props
+//	pseudo.txt       the pseudo-classes and pseudo-elements of package
+//	                 selector
+//
+// The text files list lowercase names separated by whitespace, with
+// comments starting with "#" and sections starting with a "[name]" line.
+// properties.json is a list of objects with the fields of props.Property,
+// sorted by name.
+//
+// It is run by go generate in the root package, and writes the tables to
+// the packages in the current directory. TestGenerated fails if they are
+// out of date.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// header starts the generated files.
+const header = `// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by internal/gen from internal/gen/data. DO NOT EDIT.
+
+`
+
+// dataDir is the directory of the data files, relative to the module root.
+var dataDir = filepath.Join("internal", "gen", "data")
+
+func main() {
+	files, err := generate(".")
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, f := range files {
+		if err := os.WriteFile(f.name, f.src, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// file is a generated file.
+type file struct {
+	// name is the path of the file, relative to the module root.
+	name string
+	src  []byte
+}
+
+// generate reads the data files of the module rooted at root and returns
+// the generated files.
+func generate(root string) ([]file, error) {
+	colors, err := readWords(filepath.Join(root, dataDir, "colors.txt"))
+	if err != nil {
+		return nil, err
+	}
+	units, err := readWords(filepath.Join(root, dataDir, "units.txt"))
+	if err != nil {
+		return nil, err
+	}
+	pseudos, err := readWords(filepath.Join(root, dataDir, "pseudo.txt"))
+	if err != nil {
+		return nil, err
+	}
+	properties, err := readProperties(filepath.Join(root, dataDir, "properties.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var files []file
+	add := func(name, pkg string, write func(b *bytes.Buffer) error) error {
+		b := &bytes.Buffer{}
+		b.WriteString(header + "package " + pkg + "\n")
+		if err := write(b); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		src, err := format.Source(b.Bytes())
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		files = append(files, file{filepath.Join(root, name), src})
+		return nil
+	}
+	err = add(filepath.Join("syntax", "table.go"), "syntax", func(b *bytes.Buffer) error {
+		writeSet(b, "namedColors is the set of color keywords, including the special keywords\n"+
+			"\"transparent\" and \"currentcolor\" and the system colors.", "namedColors", colors.all())
+		for _, typ := range []string{"length", "angle", "time", "resolution"} {
+			words, err := units.section(typ)
+			if err != nil {
+				return err
+			}
+			writeSet(b, typ+"Units is the set of "+typ+" units.", typ+"Units", words)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = add(filepath.Join("minify", "table.go"), "minify", func(b *bytes.Buffer) error {
+		words, err := units.section("length")
+		if err != nil {
+			return err
+		}
+		writeSet(b, "lengthUnits is the set of length units, in lowercase.", "lengthUnits", words)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = add(filepath.Join("props", "table.go"), "props", func(b *bytes.Buffer) error {
+		writeProperties(b, properties)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = add(filepath.Join("selector", "table.go"), "selector", func(b *bytes.Buffer) error {
+		for _, set := range []struct{ section, doc, name string }{
+			{"class", "pseudoClasses is the set of standard pseudo-classes.", "pseudoClasses"},
+			{"element", "pseudoElements is the set of standard pseudo-elements.", "pseudoElements"},
+			{"legacy", "legacyPseudoElements is the set of pseudo-elements that may be\n" +
+				"written with one colon.", "legacyPseudoElements"},
+		} {
+			words, err := pseudos.section(set.section)
+			if err != nil {
+				return err
+			}
+			writeSet(b, set.doc, set.name, words)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// wordList is the content of a text data file: the names of each section,
+// in order, the names before the first section having an empty section
+// name.
+type wordList struct {
+	name     string
+	sections []string
+	words    map[string][]string
+}
+
+// all returns the names of all the sections.
+func (l *wordList) all() []string {
+	var all []string
+	for _, s := range l.sections {
+		all = append(all, l.words[s]...)
+	}
+	return all
+}
+
+// section returns the names of a section, or an error if there is no such
+// section.
+func (l *wordList) section(name string) ([]string, error) {
+	if _, ok := l.words[name]; !ok {
+		return nil, fmt.Errorf("%s: missing section [%s]", l.name, name)
+	}
+	return l.words[name], nil
+}
+
+// readWords reads a text data file.
+func readWords(name string) (*wordList, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return parseWords(filepath.Base(name), data)
+}
+
+// parseWords parses the content of a text data file, whose name is used in
+// errors. The names must be unique in each section.
+func parseWords(name string, data []byte) (*wordList, error) {
+	l := &wordList{name: name, words: map[string][]string{}}
+	section := ""
+	seen := map[string]bool{}
+	for i, line := range strings.Split(string(data), "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("%s:%d: invalid section %q", name, i+1, line)
+			}
+			section = line[1 : len(line)-1]
+			if _, ok := l.words[section]; ok {
+				return nil, fmt.Errorf("%s:%d: duplicate section [%s]", name, i+1, section)
+			}
+			l.sections = append(l.sections, section)
+			l.words[section] = []string{}
+			seen = map[string]bool{}
+			continue
+		}
+		for _, w := range strings.Fields(line) {
+			if !isName(w) {
+				return nil, fmt.Errorf("%s:%d: invalid name %q", name, i+1, w)
+			}
+			if seen[w] {
+				return nil, fmt.Errorf("%s:%d: duplicate name %q", name, i+1, w)
+			}
+			seen[w] = true
+			if _, ok := l.words[section]; !ok {
+				l.sections = append(l.sections, section)
+			}
+			l.words[section] = append(l.words[section], w)
+		}
+	}
+	return l, nil
+}
+
+// isName reports whether s is a lowercase name, made of ASCII letters,
+// digits and hyphens.
+func isName(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// property is an entry of properties.json.
+type property struct {
+	Name       string   `json:"name"`
+	Syntax     string   `json:"syntax"`
+	Initial    string   `json:"initial"`
+	Inherited  bool     `json:"inherited"`
+	Animatable bool     `json:"animatable"`
+	Status     string   `json:"status"`
+	Longhands  []string `json:"longhands"`
+}
+
+// readProperties reads properties.json. The properties must be sorted by
+// name, and their longhands must be properties too.
+func readProperties(name string) ([]property, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.DisallowUnknownFields()
+	var properties []property
+	if err := d.Decode(&properties); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(name), err)
+	}
+	known := map[string]bool{}
+	for i, p := range properties {
+		switch {
+		case !isName(p.Name):
+			return nil, fmt.Errorf("%s: invalid property name %q", filepath.Base(name), p.Name)
+		case i > 0 && p.Name <= properties[i-1].Name:
+			return nil, fmt.Errorf("%s: property %q out of order", filepath.Base(name), p.Name)
+		case p.Status != "" && p.Status != "deprecated" && p.Status != "nonstandard":
+			return nil, fmt.Errorf("%s: invalid status %q of property %q", filepath.Base(name), p.Status, p.Name)
+		}
+		known[p.Name] = true
+	}
+	for _, p := range properties {
+		for _, l := range p.Longhands {
+			if !known[l] {
+				return nil, fmt.Errorf("%s: unknown longhand %q of property %q", filepath.Base(name), l, p.Name)
+			}
+		}
+	}
+	return properties, nil
+}
+
+// The widths the generated lists are wrapped to, with tabs counting as 8
+// columns. The longhands of the property table have longer lines, like the
+// rest of the table.
+const (
+	setWidth       = 80
+	longhandsWidth = 100
+)
+
+// writeSet writes a set of names, sorted, as a map[string]bool variable
+// documented by doc.
+func writeSet(b *bytes.Buffer, doc, name string, words []string) {
+	words = append([]string(nil), words...)
+	sort.Strings(words)
+	fmt.Fprintf(b, "\n// %s\nvar %s = map[string]bool{\n", strings.ReplaceAll(doc, "\n", "\n// "), name)
+	items := make([]string, len(words))
+	for i, w := range words {
+		items[i] = strconv.Quote(w) + ": true,"
+	}
+	writeWrapped(b, "\t", setWidth, items)
+	b.WriteString("}\n")
+}
+
+// writeProperties writes the property table of package props.
+func writeProperties(b *bytes.Buffer, properties []property) {
+	b.WriteString("\n// properties is the list of known properties, sorted by name.\nvar properties = []*Property{\n")
+	for _, p := range properties {
+		var flags []string
+		if p.Inherited {
+			flags = append(flags, "inherited")
+		}
+		if p.Animatable {
+			flags = append(flags, "animatable")
+		}
+		if p.Status != "" {
+			flags = append(flags, p.Status)
+		}
+		if flags == nil {
+			flags = []string{"0"}
+		}
+		fmt.Fprintf(b, "\tp(%q, %q, %q, %s", p.Name, p.Syntax, p.Initial, strings.Join(flags, "|"))
+		if p.Longhands == nil {
+			b.WriteString("),\n")
+			continue
+		}
+		b.WriteString(",\n")
+		items := make([]string, len(p.Longhands))
+		for i, l := range p.Longhands {
+			items[i] = strconv.Quote(l) + ","
+		}
+		items[len(items)-1] = strings.TrimSuffix(items[len(items)-1], ",") + "),"
+		writeWrapped(b, "\t\t", longhandsWidth, items)
+	}
+	b.WriteString("}\n")
+}
+
+// writeWrapped writes items separated by spaces, in lines starting with
+// indent and wrapped to width.
+func writeWrapped(b *bytes.Buffer, indent string, width int, items []string) {
+	start := len(indent) * 8
+	col := start
+	for i, item := range items {
+		switch {
+		case i == 0:
+			b.WriteString(indent)
+		case col+1+len(item) > width:
+			b.WriteString("\n" + indent)
+			col = start
+		default:
+			b.WriteString(" ")
+			col++
+		}
+		b.WriteString(item)
+		col += len(item)
+	}
+	if len(items) > 0 {
+		b.WriteString("\n")
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestGenerated(t *testing.T) {
+	files, err := generate("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		src, err := os.ReadFile(f.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(src, f.src) {
+			t.Errorf("%s is out of date; run go generate in the root package", f.name)
+		}
+	}
+}
+
+func TestParseWords(t *testing.T) {
+	tcs := []struct {
+		input    string
+		sections []string
+		words    map[string][]string
+		err      string
+	}{
+		{"a b # c\n\n[x]\nd\te\n[y]\n[z] # comment\nf", []string{"", "x", "y", "z"}, map[string][]string{"": {"a", "b"}, "x": {"d", "e"}, "y": {}, "z": {"f"}}, ""},
+		{"[x]\na\n[y]\na", []string{"x", "y"}, map[string][]string{"x": {"a"}, "y": {"a"}}, ""},
+		{"a b\na", nil, nil, `t.txt:2: duplicate name "a"`},
+		{"[x]\n[x]", nil, nil, "t.txt:2: duplicate section [x]"},
+		{"[x", nil, nil, `t.txt:1: invalid section "[x"`},
+		{"Red", nil, nil, `t.txt:1: invalid name "Red"`},
+	}
+	for _, tc := range tcs {
+		l, err := parseWords("t.txt", []byte(tc.input))
+		if tc.err != "" {
+			if err == nil || err.Error() != tc.err {
+				t.Errorf("%q: got error %v, want %q", tc.input, err, tc.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", tc.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(l.sections, tc.sections) || !reflect.DeepEqual(l.words, tc.words) {
+			t.Errorf("%q: got %q %q, want %q %q", tc.input, l.sections, l.words, tc.sections, tc.words)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package css
+
+import (
+	"fmt"
+
+	"github.com/gorilla/css/scanner"
+)
+
+// Limits bound the resources used by a Parser, to parse untrusted input
+// safely. Without them, the memory used by the parser and the depth of its
+// recursion grow with the input: a few kilobytes of opening brackets make
+// as many nested component values. Zero and negative values mean no limit.
+type Limits struct {
+	// MaxDepth is the maximum nesting depth of functions, simple blocks and
+	// the {}-blocks of rules.
+	MaxDepth int
+	// MaxRules is the maximum number of rules, including nested rules.
+	MaxRules int
+	// MaxDeclarations is the maximum number of declarations.
+	MaxDeclarations int
+	// MaxSelectorLength is the maximum length of the preludes of style
+	// rules, in bytes, as written in the input.
+	MaxSelectorLength int
+}
+
+// DefaultLimits are limits for untrusted input that real-world stylesheets
+// stay well within. They are used by the packages that parse untrusted
+// input, such as gorilla/css/sanitize, for the limits that aren't set.
+var DefaultLimits = Limits{
+	MaxDepth:          64,
+	MaxRules:          100000,
+	MaxDeclarations:   1000000,
+	MaxSelectorLength: 32 << 10,
+}
+
+// WithDefaults returns the limits with those that are zero replaced by
+// those of d. Negative limits are kept, so that they still mean no limit.
+func (l Limits) WithDefaults(d Limits) Limits {
+	if l.MaxDepth == 0 {
+		l.MaxDepth = d.MaxDepth
+	}
+	if l.MaxRules == 0 {
+		l.MaxRules = d.MaxRules
+	}
+	if l.MaxDeclarations == 0 {
+		l.MaxDeclarations = d.MaxDeclarations
+	}
+	if l.MaxSelectorLength == 0 {
+		l.MaxSelectorLength = d.MaxSelectorLength
+	}
+	return l
+}
+
+// LimitExceededError is the error returned when the input exceeds one of
+// the Limits of a Parser, and wraps ErrLimitExceeded. Parsing stops at the
+// first limit exceeded.
+type LimitExceededError struct {
+	// Limit is the name of the field of Limits that was exceeded, such as
+	// "MaxDepth", and Max its value.
+	Limit  string
+	Max    int
+	Line   int
+	Column int
+}
+
+// Error returns a string representation of the error.
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("css: %s of %d exceeded (line: %d, column: %d)", e.Limit, e.Max, e.Line, e.Column)
+}
+
+// Unwrap returns ErrLimitExceeded.
+func (e *LimitExceededError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+// exceed records that the limit named name, of value max, is exceeded by n
+// at the token t, if max is positive. It reports whether it is.
+func (p *parser) exceed(name string, n, max int, t *scanner.Token) bool {
+	if max <= 0 || n <= max {
+		return false
+	}
+	if p.limitErr == nil {
+		p.limitErr = &LimitExceededError{name, max, t.Line, t.Column}
+	}
+	return true
+}
@@ -0,0 +1,271 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package media
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/scanner"
+)
+
+// Env is a media environment, such as a device, against which media
+// queries are evaluated by Eval. Parts of the environment may be unknown,
+// so that a query can match on some devices only.
+type Env struct {
+	// Type is the lowercase media type, such as "screen" or "print", or
+	// empty if it is unknown.
+	Type string
+	// Features are the values of the known media features, by lowercase
+	// name without "min-" or "max-" prefix, as CSS text: {"width":
+	// "1024px", "resolution": "2dppx", "aspect-ratio": "16/9",
+	// "prefers-color-scheme": "dark"}.
+	Features map[string]string
+}
+
+// Result is the result of the evaluation of a media query.
+type Result int
+
+const (
+	// Unknown is the result of a query whose result depends on the unknown
+	// parts of the environment.
+	Unknown Result = iota
+	// False is the result of a query never matching the environment.
+	False
+	// True is the result of a query always matching the environment.
+	True
+)
+
+// String returns a string representation of the result.
+func (r Result) String() string {
+	switch r {
+	case False:
+		return "false"
+	case True:
+		return "true"
+	}
+	return "unknown"
+}
+
+// Not returns the negation of the result.
+func (r Result) Not() Result {
+	switch r {
+	case False:
+		return True
+	case True:
+		return False
+	}
+	return Unknown
+}
+
+// And returns the conjunction of the results.
+func (r Result) And(s Result) Result {
+	switch {
+	case r == False || s == False:
+		return False
+	case r == True && s == True:
+		return True
+	}
+	return Unknown
+}
+
+// Or returns the disjunction of the results.
+func (r Result) Or(s Result) Result {
+	switch {
+	case r == True || s == True:
+		return True
+	case r == False && s == False:
+		return False
+	}
+	return Unknown
+}
+
+// Eval evaluates the list against env: it matches if one of its queries
+// does. An empty list always matches.
+func (l List) Eval(env *Env) Result {
+	if len(l) == 0 {
+		return True
+	}
+	r := False
+	for _, q := range l {
+		r = r.Or(q.Eval(env))
+	}
+	return r
+}
+
+// Eval evaluates the query against env.
+//
+// Media features are compared as numbers once lengths are converted to
+// pixels, with 1em and 1rem being 16px, resolutions to dppx, and ratios to
+// numbers, and as case-insensitive identifiers otherwise. A feature is
+// unknown if env has no value for it, or if it can't be compared with it,
+// and so are general enclosed conditions.
+func (q *Query) Eval(env *Env) Result {
+	r := True
+	switch {
+	case q.Type == "" || q.Type == "all":
+	case env.Type == "":
+		r = Unknown
+	case env.Type != q.Type:
+		r = False
+	}
+	if q.Condition != nil {
+		r = r.And(q.Condition.eval(env))
+	}
+	if q.Not {
+		return r.Not()
+	}
+	return r
+}
+
+// eval evaluates the condition against env.
+func (c *Condition) eval(env *Env) Result {
+	switch c.Kind {
+	case Test:
+		return c.Feature.eval(env)
+	case Not:
+		return c.Conditions[0].eval(env).Not()
+	case And:
+		r := True
+		for _, o := range c.Conditions {
+			r = r.And(o.eval(env))
+		}
+		return r
+	case Or:
+		r := False
+		for _, o := range c.Conditions {
+			r = r.Or(o.eval(env))
+		}
+		return r
+	}
+	return Unknown
+}
+
+// eval evaluates the feature against env.
+func (f *Feature) eval(env *Env) Result {
+	name, ranges := f.Name, f.Ranges
+	if f.Value != nil {
+		op := "="
+		switch {
+		case strings.HasPrefix(name, "min-"):
+			name, op = name[4:], ">="
+		case strings.HasPrefix(name, "max-"):
+			name, op = name[4:], "<="
+		}
+		ranges = []Range{{op, f.Value}}
+	}
+	text, ok := env.Features[name]
+	if !ok {
+		return Unknown
+	}
+	value, err := css.ParseComponentValues(text)
+	if err != nil {
+		return Unknown
+	}
+	value = css.TrimSpace(value)
+	if len(ranges) == 0 {
+		// In a boolean context, a feature matches unless its value is
+		// zero or "none".
+		if n, _, ok := quantity(value); ok && n == 0 || valuesKey(value) == "none" {
+			return False
+		}
+		return True
+	}
+	r := True
+	for _, rg := range ranges {
+		r = r.And(compare(value, rg.Op, rg.Value))
+	}
+	return r
+}
+
+// compare compares the value of a feature with the value of a query.
+func compare(a []*css.ComponentValue, op string, b []*css.ComponentValue) Result {
+	x, xUnit, xOK := quantity(a)
+	y, yUnit, yOK := quantity(b)
+	if !xOK || !yOK {
+		if op != "=" || xOK != yOK {
+			return Unknown
+		}
+		return result(valuesKey(a) == valuesKey(b))
+	}
+	if xUnit != yUnit {
+		return Unknown
+	}
+	switch op {
+	case "<":
+		return result(x < y)
+	case "<=":
+		return result(x <= y)
+	case ">":
+		return result(x > y)
+	case ">=":
+		return result(x >= y)
+	}
+	return result(x == y)
+}
+
+// result returns True if b is true, and False otherwise.
+func result(b bool) Result {
+	if b {
+		return True
+	}
+	return False
+}
+
+// units are the factors converting the units of media features to pixels,
+// dppx, or numbers for the units of ratios, and the kinds of the units.
+var units = map[string]struct {
+	factor float64
+	kind   string
+}{
+	"":     {1, ""},
+	"px":   {1, "px"},
+	"em":   {16, "px"},
+	"rem":  {16, "px"},
+	"in":   {96, "px"},
+	"cm":   {96 / 2.54, "px"},
+	"mm":   {96 / 25.4, "px"},
+	"q":    {96 / 101.6, "px"},
+	"pt":   {96.0 / 72, "px"},
+	"pc":   {16, "px"},
+	"dppx": {1, "dppx"},
+	"x":    {1, "dppx"},
+	"dpi":  {1.0 / 96, "dppx"},
+	"dpcm": {2.54 / 96, "dppx"},
+}
+
+// quantity returns the number a value consists of, a number, a dimension
+// with a known unit or a ratio as "16/9", converted to the canonical unit
+// of its kind, and that unit, empty for numbers and ratios.
+func quantity(values []*css.ComponentValue) (float64, string, bool) {
+	values = significant(values)
+	switch {
+	case len(values) == 1:
+		t := values[0].Token
+		if t.Type != scanner.TokenNumber && t.Type != scanner.TokenDimension {
+			return 0, "", false
+		}
+		s := numberKey(t.Value)
+		end := len(s)
+		for end > 0 && strings.IndexByte("0123456789.", s[end-1]) < 0 {
+			end--
+		}
+		n, err := strconv.ParseFloat(s[:end], 64)
+		u, ok := units[s[end:]]
+		if err != nil || !ok {
+			return 0, "", false
+		}
+		return n * u.factor, u.kind, true
+	case len(values) == 3 && isChar(values[1], "/"):
+		a, aUnit, aOK := quantity(values[:1])
+		b, bUnit, bOK := quantity(values[2:])
+		if !aOK || !bOK || aUnit != "" || bUnit != "" || b == 0 {
+			return 0, "", false
+		}
+		return a / b, "", true
+	}
+	return 0, "", false
+}
@@ -0,0 +1,672 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package gorilla/css/media parses the media query lists of @media and
+@import rules, following the grammar of Media Queries Level 4:
+
+	https://www.w3.org/TR/mediaqueries-4/
+
+A media query list is parsed from the prelude of a rule, or from a string.
+It is a list of media queries, each with an optional media type and a
+condition made of media features combined with "not", "and" and "or":
+
+	list, err := media.ParseString("screen and (min-width: 600px), print")
+	list[0].Type                        // "screen"
+	list[0].Condition.Feature.Name      // "min-width"
+	list.String()                       // "screen and (min-width:600px),print"
+
+Range features, such as "(400px <= width < 700px)", are parsed into
+comparisons with the feature on the left side. Parenthesized expressions
+and functions that aren't media conditions or features are kept as general
+enclosed conditions, which never match but don't invalidate the query.
+
+Equal tells whether two lists are the same regardless of case, whitespace,
+the order of their queries and of the operands of "and" and "or", so that
+"(color) and SCREEN" rules can be found among "screen and (color)" ones.
+
+Eval evaluates a list against an Env, a media type and the values of media
+features, some of which may be unknown, as True, False or Unknown:
+
+	env := &media.Env{Type: "screen", Features: map[string]string{"width": "390px"}}
+	list.Eval(env) // media.False for "screen and (min-width: 600px)"
+*/
+package media
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/scanner"
+)
+
+// List is a media query list, such as "screen, print".
+type List []*Query
+
+// Query is a media query, such as "not screen and (color)".
+type Query struct {
+	// Not and Only report whether the query starts with "not" or "only".
+	// Only Not changes what the query matches.
+	Not, Only bool
+	// Type is the lowercase media type, such as "screen", or empty.
+	Type string
+	// Condition is the condition of the query, or nil.
+	Condition *Condition
+}
+
+// Kind is the kind of a media condition.
+type Kind int
+
+const (
+	// Test is a media feature, as in "(color)".
+	Test Kind = iota
+	// Not is the negation of a condition, as in "not (color)".
+	Not
+	// And is the conjunction of conditions, as in "(color) and (hover)".
+	And
+	// Or is the disjunction of conditions, as in "(color) or (hover)".
+	Or
+	// General is a general enclosed condition, an unknown expression in
+	// parentheses or function, as in "(x y z)", which never matches.
+	General
+)
+
+// String returns a string representation of the kind.
+func (k Kind) String() string {
+	switch k {
+	case Test:
+		return "test"
+	case Not:
+		return "not"
+	case And:
+		return "and"
+	case Or:
+		return "or"
+	}
+	return "general"
+}
+
+// Condition is a media condition.
+type Condition struct {
+	Kind Kind
+	// Feature is the media feature of Test conditions.
+	Feature *Feature
+	// Conditions are the operand of Not conditions, and the operands of And
+	// and Or conditions, in order.
+	Conditions []*Condition
+	// Values is the parenthesized block or the function of General
+	// conditions.
+	Values []*css.ComponentValue
+}
+
+// Feature is a media feature, in a boolean context as in "(color)", a
+// plain one as in "(min-width: 600px)", or a range one as in
+// "(400px <= width < 700px)".
+type Feature struct {
+	// Name is the lowercase name of the feature.
+	Name string
+	// Value is the value of plain features, without surrounding
+	// whitespace, and nil for the others.
+	Value []*css.ComponentValue
+	// Ranges are the comparisons of range features, with the feature on
+	// the left side: "(400px <= width < 700px)" has the comparisons
+	// "width >= 400px" and "width < 700px", in order.
+	Ranges []Range
+}
+
+// Range is a comparison of a range feature with a value.
+type Range struct {
+	// Op is the comparison: "<", "<=", ">", ">=" or "=".
+	Op string
+	// Value is the value the feature is compared to, without surrounding
+	// whitespace.
+	Value []*css.ComponentValue
+}
+
+// Error is the error returned for invalid media queries.
+type Error struct {
+	Msg    string
+	Line   int
+	Column int
+}
+
+// Error returns a string representation of the error.
+func (e *Error) Error() string {
+	return fmt.Sprintf("media: %s (line: %d, column: %d)", e.Msg, e.Line, e.Column)
+}
+
+// ParseString parses s as a media query list.
+func ParseString(s string) (List, error) {
+	values, err := css.ParseComponentValues(s)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(values)
+}
+
+// MustParseString is like ParseString but panics if s can't be parsed. It
+// simplifies the initialization of global variables and tests.
+func MustParseString(s string) List {
+	l, err := ParseString(s)
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+// Parse parses a media query list from component values, such as the
+// prelude of an @media rule. An empty list is valid, and matches all
+// media. It returns an *Error if a query is invalid, which browsers treat
+// as "not all" instead.
+func Parse(values []*css.ComponentValue) (List, error) {
+	values = css.TrimSpace(values)
+	if len(values) == 0 {
+		return List{}, nil
+	}
+	var list List
+	start := 0
+	for i := 0; i <= len(values); i++ {
+		if i < len(values) && !isChar(values[i], ",") {
+			continue
+		}
+		part := significant(values[start:i])
+		if len(part) == 0 {
+			at := values[len(values)-1]
+			if i < len(values) {
+				at = values[i]
+			}
+			return nil, errorAt(at, "empty media query")
+		}
+		q, err := parseQuery(part)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, q)
+		start = i + 1
+	}
+	return list, nil
+}
+
+// parseQuery parses a media query from its significant values.
+func parseQuery(values []*css.ComponentValue) (*Query, error) {
+	q := &Query{}
+	if !isIdent(values[0], "") || isIdent(values[0], "not") && (len(values) < 2 || values[1].Token.Type != scanner.TokenIdent) {
+		c, err := parseCondition(values, true)
+		if err != nil {
+			return nil, err
+		}
+		q.Condition = c
+		return q, nil
+	}
+	switch {
+	case isIdent(values[0], "not"):
+		q.Not = true
+		values = values[1:]
+	case isIdent(values[0], "only"):
+		if len(values) == 1 {
+			return nil, errorAt(values[0], "expected a media type")
+		}
+		q.Only = true
+		values = values[1:]
+	}
+	v := values[0]
+	if v.Token.Type != scanner.TokenIdent {
+		return nil, errorAt(v, "expected a media type")
+	}
+	q.Type = strings.ToLower(v.Token.DecodedValue())
+	switch q.Type {
+	case "not", "only", "and", "or", "layer":
+		return nil, errorAt(v, fmt.Sprintf("invalid media type %q", q.Type))
+	}
+	values = values[1:]
+	if len(values) == 0 {
+		return q, nil
+	}
+	if !isIdent(values[0], "and") {
+		return nil, errorAt(values[0], fmt.Sprintf("unexpected %s after media type", describe(values[0].Token)))
+	}
+	if len(values) == 1 {
+		return nil, errorAt(values[0], "expected a media condition")
+	}
+	c, err := parseCondition(values[1:], false)
+	if err != nil {
+		return nil, err
+	}
+	q.Condition = c
+	return q, nil
+}
+
+// parseCondition parses a media condition from its significant values. The
+// conditions following a media type can't be disjunctions, unless
+// parenthesized, so orAllowed is false for them.
+func parseCondition(values []*css.ComponentValue, orAllowed bool) (*Condition, error) {
+	if isIdent(values[0], "not") {
+		if len(values) != 2 {
+			return nil, errorAt(values[0], "expected a condition in parentheses after \"not\"")
+		}
+		c, err := parseInParens(values[1])
+		if err != nil {
+			return nil, err
+		}
+		return &Condition{Kind: Not, Conditions: []*Condition{c}}, nil
+	}
+	first, err := parseInParens(values[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 1 {
+		return first, nil
+	}
+	c := &Condition{Conditions: []*Condition{first}}
+	for i := 1; i < len(values); i += 2 {
+		v := values[i]
+		var k Kind
+		switch {
+		case isIdent(v, "and"):
+			k = And
+		case isIdent(v, "or") && orAllowed:
+			k = Or
+		default:
+			return nil, errorAt(v, fmt.Sprintf("unexpected %s in media condition", describe(v.Token)))
+		}
+		if i > 1 && k != c.Kind {
+			return nil, errorAt(v, "\"and\" and \"or\" mixed without parentheses")
+		}
+		c.Kind = k
+		if i+1 == len(values) {
+			return nil, errorAt(v, fmt.Sprintf("expected a condition after %q", k.String()))
+		}
+		operand, err := parseInParens(values[i+1])
+		if err != nil {
+			return nil, err
+		}
+		c.Conditions = append(c.Conditions, operand)
+	}
+	return c, nil
+}
+
+// parseInParens parses a parenthesized media condition or media feature,
+// or a general enclosed condition.
+func parseInParens(v *css.ComponentValue) (*Condition, error) {
+	general := &Condition{Kind: General, Values: []*css.ComponentValue{v}}
+	if v.IsFunction() {
+		return general, nil
+	}
+	if !v.IsBlock() || v.Token.Value != "(" {
+		return nil, errorAt(v, fmt.Sprintf("unexpected %s in media condition", describe(v.Token)))
+	}
+	inner := significant(v.Children)
+	if len(inner) == 0 {
+		return general, nil
+	}
+	if isIdent(inner[0], "not") || inner[0].IsBlock() && inner[0].Token.Value == "(" {
+		if c, err := parseCondition(inner, true); err == nil {
+			return c, nil
+		}
+		return general, nil
+	}
+	if f := parseFeature(css.TrimSpace(v.Children)); f != nil {
+		return &Condition{Kind: Test, Feature: f}, nil
+	}
+	return general, nil
+}
+
+// parseFeature parses the contents of the parentheses of a media feature,
+// without surrounding whitespace, and returns nil if they aren't one.
+func parseFeature(values []*css.ComponentValue) *Feature {
+	if len(values) == 1 {
+		if values[0].Token.Type != scanner.TokenIdent {
+			return nil
+		}
+		return &Feature{Name: strings.ToLower(values[0].Token.DecodedValue())}
+	}
+	if values[0].Token.Type == scanner.TokenIdent {
+		if rest := css.TrimSpace(values[1:]); isChar(rest[0], ":") {
+			value := css.TrimSpace(rest[1:])
+			if len(value) == 0 {
+				return nil
+			}
+			return &Feature{Name: strings.ToLower(values[0].Token.DecodedValue()), Value: value}
+		}
+	}
+	return parseRange(values)
+}
+
+// parseRange parses the contents of the parentheses of a range media
+// feature, without surrounding whitespace, and returns nil if they aren't
+// one.
+func parseRange(values []*css.ComponentValue) *Feature {
+	var parts [][]*css.ComponentValue
+	var ops []string
+	start := 0
+	for i := 0; i < len(values); i++ {
+		op := comparison(values, i)
+		if op == "" {
+			continue
+		}
+		parts = append(parts, css.TrimSpace(values[start:i]))
+		ops = append(ops, op)
+		i += len(op) - 1
+		start = i + 1
+	}
+	parts = append(parts, css.TrimSpace(values[start:]))
+	for _, p := range parts {
+		if len(p) == 0 {
+			return nil
+		}
+	}
+	name := func(p []*css.ComponentValue) string {
+		if len(p) != 1 || p[0].Token.Type != scanner.TokenIdent {
+			return ""
+		}
+		return strings.ToLower(p[0].Token.DecodedValue())
+	}
+	switch len(parts) {
+	case 2:
+		if n := name(parts[0]); n != "" {
+			return &Feature{Name: n, Ranges: []Range{{ops[0], parts[1]}}}
+		}
+		if n := name(parts[1]); n != "" {
+			return &Feature{Name: n, Ranges: []Range{{flip(ops[0]), parts[0]}}}
+		}
+	case 3:
+		n := name(parts[1])
+		if n == "" || ops[0][0] != ops[1][0] || ops[0] == "=" {
+			return nil
+		}
+		return &Feature{Name: n, Ranges: []Range{{flip(ops[0]), parts[0]}, {ops[1], parts[2]}}}
+	}
+	return nil
+}
+
+// comparison returns the comparison operator at index i of values, or an
+// empty string.
+func comparison(values []*css.ComponentValue, i int) string {
+	switch {
+	case isChar(values[i], "="):
+		return "="
+	case isChar(values[i], "<"), isChar(values[i], ">"):
+		if i+1 < len(values) && isChar(values[i+1], "=") {
+			return values[i].Token.Value + "="
+		}
+		return values[i].Token.Value
+	}
+	return ""
+}
+
+// flip returns the comparison operator with its sides swapped, so that
+// "400px <= width" becomes "width >= 400px".
+func flip(op string) string {
+	switch op[0] {
+	case '<':
+		return ">" + op[1:]
+	case '>':
+		return "<" + op[1:]
+	}
+	return op
+}
+
+// significant returns the values that aren't whitespace.
+func significant(values []*css.ComponentValue) []*css.ComponentValue {
+	var out []*css.ComponentValue
+	for _, v := range values {
+		if v.Token.Type != scanner.TokenS && v.Token.Type != scanner.TokenComment {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// isIdent reports whether v is the identifier name, ignoring case, or any
+// identifier if name is empty.
+func isIdent(v *css.ComponentValue, name string) bool {
+	return v.Token.Type == scanner.TokenIdent && (name == "" || strings.EqualFold(v.Token.DecodedValue(), name))
+}
+
+// isChar reports whether v is the delimiter c.
+func isChar(v *css.ComponentValue, c string) bool {
+	return v.Token.Type == scanner.TokenChar && v.Token.Value == c
+}
+
+// describe returns a short description of a token for error messages.
+func describe(t *scanner.Token) string {
+	if t.Type == scanner.TokenChar {
+		return fmt.Sprintf("%q", t.Value)
+	}
+	return t.Type.String()
+}
+
+// errorAt returns an error at the position of v.
+func errorAt(v *css.ComponentValue, msg string) *Error {
+	return &Error{msg, v.Token.Line, v.Token.Column}
+}
+
+// String returns the CSS representation of the list, with the queries
+// separated by commas. "all and" is omitted before a condition, as
+// browsers serialize queries.
+func (l List) String() string {
+	parts := make([]string, len(l))
+	for i, q := range l {
+		parts[i] = q.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// String returns the CSS representation of the query.
+func (q *Query) String() string {
+	var b strings.Builder
+	switch {
+	case q.Not:
+		b.WriteString("not ")
+	case q.Only:
+		b.WriteString("only ")
+	}
+	if q.Type != "" && (q.Type != "all" || q.Not || q.Only || q.Condition == nil) {
+		b.WriteString(q.Type)
+		if q.Condition != nil {
+			b.WriteString(" and ")
+		}
+	}
+	if q.Condition != nil {
+		q.Condition.write(&b, q.Type != "" && q.Condition.Kind == Or)
+	}
+	return b.String()
+}
+
+// String returns the CSS representation of the condition.
+func (c *Condition) String() string {
+	var b strings.Builder
+	c.write(&b, false)
+	return b.String()
+}
+
+// write appends the CSS representation of the condition to b, in
+// parentheses if parens is true and the condition isn't already
+// parenthesized.
+func (c *Condition) write(b *strings.Builder, parens bool) {
+	switch c.Kind {
+	case Test:
+		c.Feature.write(b)
+		return
+	case General:
+		css.WriteValues(b, c.Values)
+		return
+	}
+	if parens {
+		b.WriteByte('(')
+	}
+	if c.Kind == Not {
+		b.WriteString("not ")
+	}
+	for i, operand := range c.Conditions {
+		if i > 0 {
+			b.WriteString(" " + c.Kind.String() + " ")
+		}
+		operand.write(b, true)
+	}
+	if parens {
+		b.WriteByte(')')
+	}
+}
+
+// write appends the CSS representation of the feature to b.
+func (f *Feature) write(b *strings.Builder) {
+	b.WriteByte('(')
+	switch {
+	case f.Value != nil:
+		b.WriteString(f.Name + ":")
+		css.WriteValues(b, f.Value)
+	case len(f.Ranges) == 2:
+		css.WriteValues(b, f.Ranges[0].Value)
+		b.WriteString(flip(f.Ranges[0].Op) + f.Name + f.Ranges[1].Op)
+		css.WriteValues(b, f.Ranges[1].Value)
+	case len(f.Ranges) == 1:
+		b.WriteString(f.Name + f.Ranges[0].Op)
+		css.WriteValues(b, f.Ranges[0].Value)
+	default:
+		b.WriteString(f.Name)
+	}
+	b.WriteByte(')')
+}
+
+// Equal reports whether l and m are the same list of queries, ignoring
+// case, whitespace, the format of numbers, "only", "all and" before a
+// condition, the order and repetitions of the queries and of the operands
+// of "and" and "or". Lists that are equal match the same media, but lists
+// matching the same media may not be equal, as "(min-width: 1px)" and
+// "(width >= 1px)".
+func (l List) Equal(m List) bool {
+	a, b := l.keys(), m.keys()
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// keys returns the sorted keys of the queries of the list, without
+// repetitions.
+func (l List) keys() []string {
+	var keys []string
+	for _, q := range l {
+		keys = append(keys, q.key())
+	}
+	return sortedSet(keys)
+}
+
+// key returns a normalized representation of the query, for Equal.
+func (q *Query) key() string {
+	var b strings.Builder
+	if q.Not {
+		b.WriteString("not ")
+	}
+	t := q.Type
+	if t == "" {
+		t = "all"
+	}
+	b.WriteString(t)
+	if q.Condition != nil {
+		b.WriteString(" and ")
+		b.WriteString(q.Condition.key())
+	}
+	return b.String()
+}
+
+// key returns a normalized representation of the condition, for Equal.
+func (c *Condition) key() string {
+	switch c.Kind {
+	case Test:
+		var b strings.Builder
+		b.WriteString("(" + c.Feature.Name)
+		if c.Feature.Value != nil {
+			b.WriteString(":" + valuesKey(c.Feature.Value))
+		}
+		for _, r := range c.Feature.Ranges {
+			b.WriteString(r.Op + valuesKey(r.Value))
+		}
+		b.WriteByte(')')
+		return b.String()
+	case General:
+		return valuesKey(c.Values)
+	case Not:
+		return "not " + c.Conditions[0].key()
+	}
+	keys := c.operandKeys(c.Kind, nil)
+	return "[" + strings.Join(sortedSet(keys), " "+c.Kind.String()+" ") + "]"
+}
+
+// operandKeys appends the keys of the operands of a condition of kind k to
+// keys, flattening the operands of the same kind, as in
+// "(a) and ((b) and (c))", and returns the result.
+func (c *Condition) operandKeys(k Kind, keys []string) []string {
+	if c.Kind != k {
+		return append(keys, c.key())
+	}
+	for _, operand := range c.Conditions {
+		keys = operand.operandKeys(k, keys)
+	}
+	return keys
+}
+
+// valuesKey returns a normalized representation of component values, with
+// lowercase identifiers, units and functions, numbers in their shortest
+// form and tokens separated by single spaces.
+func valuesKey(values []*css.ComponentValue) string {
+	var parts []string
+	for _, v := range significant(values) {
+		t := v.Token
+		var s string
+		switch t.Type {
+		case scanner.TokenIdent, scanner.TokenFunction:
+			s = strings.ToLower(t.DecodedValue())
+		case scanner.TokenNumber, scanner.TokenPercentage, scanner.TokenDimension:
+			s = numberKey(t.Value)
+		default:
+			s = t.Value
+		}
+		if v.IsFunction() || v.IsBlock() {
+			end := ")"
+			if v.IsBlock() && t.Value == "[" {
+				end = "]"
+			}
+			s += valuesKey(v.Children) + end
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " ")
+}
+
+// numberKey returns a number, percentage or dimension with the number in
+// its shortest form and a lowercase unit.
+func numberKey(s string) string {
+	end := 0
+	for end < len(s) && (strings.IndexByte("+-.0123456789", s[end]) >= 0 ||
+		(s[end] == 'e' || s[end] == 'E') && end+1 < len(s) && strings.IndexByte("+-0123456789", s[end+1]) >= 0) {
+		end++
+	}
+	n, err := strconv.ParseFloat(s[:end], 64)
+	if err != nil {
+		return strings.ToLower(s)
+	}
+	return strconv.FormatFloat(n, 'g', -1, 64) + strings.ToLower(s[end:])
+}
+
+// sortedSet sorts a list of strings and removes its repetitions.
+func sortedSet(list []string) []string {
+	sort.Strings(list)
+	out := list[:0]
+	for i, s := range list {
+		if i == 0 || s != list[i-1] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package media
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gorilla/css"
+)
+
+// dump returns a compact description of a condition.
+func dump(c *Condition) string {
+	switch c.Kind {
+	case Test:
+		s := "test " + c.Feature.Name
+		if c.Feature.Value != nil {
+			s += ":" + css.ValuesString(c.Feature.Value)
+		}
+		for _, r := range c.Feature.Ranges {
+			s += " " + r.Op + css.ValuesString(r.Value)
+		}
+		return s
+	case General:
+		return "general " + css.ValuesString(c.Values)
+	}
+	var parts []string
+	for _, operand := range c.Conditions {
+		parts = append(parts, dump(operand))
+	}
+	return c.Kind.String() + "(" + strings.Join(parts, ", ") + ")"
+}
+
+func TestParse(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected string
+		str      string
+	}{
+		{"SCREEN", "type screen", "screen"},
+		{"only screen and (Min-Width: 600PX)", "only type screen, test min-width:600PX", "only screen and (min-width:600PX)"},
+		{"not print and (color) and (hover)", "not type print, and(test color, test hover)", "not print and (color) and (hover)"},
+		{"(color) or (not (hover))", "or(test color, not(test hover))", "(color) or (not (hover))"},
+		{"screen and ((color) or (hover))", "type screen, or(test color, test hover)", "screen and ((color) or (hover))"},
+		{"not (color)", "not(test color)", "not (color)"},
+		{"all and (orientation: landscape)", "type all, test orientation:landscape", "(orientation:landscape)"},
+		{"(400px <= width < 700px)", "test width >=400px <700px", "(400px<=width<700px)"},
+		{"(height > 10em) and (aspect-ratio = 16 / 9)", "and(test height >10em, test aspect-ratio =16 / 9)", "(height>10em) and (aspect-ratio=16 / 9)"},
+		{"(600px < width)", "test width >600px", "(width>600px)"},
+		{"(x y z), foo(bar)", "general (x y z); general foo(bar)", "(x y z),foo(bar)"},
+	}
+	for _, tc := range tcs {
+		l, err := ParseString(tc.input)
+		if err != nil {
+			t.Errorf("%s: %v", tc.input, err)
+			continue
+		}
+		var parts []string
+		for _, q := range l {
+			var fields []string
+			if q.Type != "" {
+				modifier := ""
+				switch {
+				case q.Not:
+					modifier = "not "
+				case q.Only:
+					modifier = "only "
+				}
+				fields = append(fields, modifier+"type "+q.Type)
+			}
+			if q.Condition != nil {
+				fields = append(fields, dump(q.Condition))
+			}
+			parts = append(parts, strings.Join(fields, ", "))
+		}
+		if got := strings.Join(parts, "; "); got != tc.expected {
+			t.Errorf("%s:\ngot  %q\nwant %q", tc.input, got, tc.expected)
+		}
+		if got := l.String(); got != tc.str {
+			t.Errorf("%s: got string %q, want %q", tc.input, got, tc.str)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected string
+	}{
+		{"screen,", "media: empty media query (line: 1, column: 7)"},
+		{"only", "media: expected a media type (line: 1, column: 1)"},
+		{"screen print", `media: unexpected IDENT after media type (line: 1, column: 8)`},
+		{"screen and (color) or (hover)", `media: unexpected IDENT in media condition (line: 1, column: 20)`},
+		{"(color) and (hover) or (x)", `media: "and" and "or" mixed without parentheses (line: 1, column: 21)`},
+		{"and", `media: invalid media type "and" (line: 1, column: 1)`},
+		{"not (a) (b)", `media: expected a condition in parentheses after "not" (line: 1, column: 1)`},
+		{"(color) and", `media: expected a condition after "and" (line: 1, column: 9)`},
+	}
+	for _, tc := range tcs {
+		_, err := ParseString(tc.input)
+		if err == nil {
+			t.Errorf("%s: expected an error", tc.input)
+			continue
+		}
+		if got := err.Error(); got != tc.expected {
+			t.Errorf("%s: got %q, want %q", tc.input, got, tc.expected)
+		}
+	}
+}
+
+func TestEqual(t *testing.T) {
+	tcs := []struct {
+		a, b  string
+		equal bool
+	}{
+		{"screen and (min-width: 600px)", "SCREEN  AND (MIN-WIDTH:600.0PX)", true},
+		{"all and (color)", "(color)", true},
+		{"only screen", "screen", true},
+		{"print, screen", "screen, print, screen", true},
+		{"(a) and (b) and (c)", "(c) and ((b) and (a))", true},
+		{"(a) or (b)", "(b) or (a)", true},
+		{"(a) or (b)", "(a) and (b)", false},
+		{"not screen", "screen", false},
+		{"(min-width: 1px)", "(width >= 1px)", false},
+		{"(400px <= width)", "(width >= 400px)", true},
+		{"(aspect-ratio: 16/9)", "(aspect-ratio: 16 / 9)", true},
+		{"", "all", false},
+	}
+	for _, tc := range tcs {
+		a, b := MustParseString(tc.a), MustParseString(tc.b)
+		if got := a.Equal(b); got != tc.equal {
+			t.Errorf("%q and %q: got %v, want %v", tc.a, tc.b, got, tc.equal)
+		}
+	}
+}
+
+func TestEval(t *testing.T) {
+	env := &Env{Type: "screen", Features: map[string]string{
+		"width":                "1024px",
+		"resolution":           "2dppx",
+		"aspect-ratio":         "16/9",
+		"color":                "8",
+		"monochrome":           "0",
+		"hover":                "hover",
+		"prefers-color-scheme": "Dark",
+	}}
+	tcs := []struct {
+		input    string
+		expected Result
+	}{
+		{"", True},
+		{"all", True},
+		{"screen", True},
+		{"print", False},
+		{"not print", True},
+		{"only screen and (min-width: 600px)", True},
+		{"(max-width: 40em)", False},
+		{"(max-width: 64em)", True},
+		{"(600px <= width < 1024px)", False},
+		{"(600px <= width <= 1024px)", True},
+		{"(width > 10in)", True},
+		{"(min-resolution: 192dpi) and (max-resolution: 2x)", True},
+		{"(min-aspect-ratio: 4/3)", True},
+		{"(aspect-ratio: 16 / 9)", True},
+		{"(color) and (not (monochrome))", True},
+		{"(prefers-color-scheme: dark)", True},
+		{"(hover: none)", False},
+		{"(height > 600px)", Unknown},
+		{"(monochrome) or (height > 600px)", Unknown},
+		{"(color) or (height > 600px)", True},
+		{"print, (height > 600px)", Unknown},
+		{"screen, (height > 600px)", True},
+		{"print and (height > 600px)", False},
+		{"(width: dark)", Unknown},
+		{"(width > 3dppx)", Unknown},
+		{"(x y)", Unknown},
+		{"not (x y)", Unknown},
+	}
+	for _, tc := range tcs {
+		if got := MustParseString(tc.input).Eval(env); got != tc.expected {
+			t.Errorf("%q: got %v, want %v", tc.input, got, tc.expected)
+		}
+	}
+	if got := MustParseString("print").Eval(&Env{}); got != Unknown {
+		t.Errorf("print with an unknown type: got %v, want unknown", got)
+	}
+}
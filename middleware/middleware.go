@@ -0,0 +1,344 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package gorilla/css/middleware transforms the stylesheets served by an HTTP
+handler on the fly, such as to minify them.
+
+Handler wraps a handler and passes its text/css responses through a chain
+of transforms, leaving the other responses untouched:
+
+	h := middleware.Handler(http.FileServer(dir), middleware.Options{
+		Transforms: []middleware.Transform{
+			middleware.StripPrefixes(),
+			middleware.RewriteURLs(func(u string) string {
+				if strings.HasPrefix(u, "/img/") {
+					return "https://cdn.example.com" + u
+				}
+				return u
+			}),
+			middleware.Minify(minify.Options{}),
+		},
+	})
+
+The ETag of a transformed response is recomputed from its content, and
+conditional requests with If-None-Match are answered with 304 Not Modified.
+
+A transformed response is buffered, since its headers depend on the whole
+transformed stylesheet. Responses larger than Options.MaxSize, compressed
+responses and stylesheets that can't be parsed, such as those with an
+unclosed comment or exceeding Options.Limits, are served untransformed.
+*/
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/minify"
+	"github.com/gorilla/css/prefix"
+	"github.com/gorilla/css/transform"
+)
+
+// DefaultMaxSize is the maximum size of the responses transformed by
+// default, in bytes.
+const DefaultMaxSize = 8 << 20
+
+// Transform transforms a stylesheet in place.
+type Transform func(s *css.Stylesheet)
+
+// Minify returns a transform minifying stylesheets with minify.Stylesheet.
+func Minify(opts minify.Options) Transform {
+	return func(s *css.Stylesheet) {
+		minify.Stylesheet(s, opts)
+	}
+}
+
+// StripPrefixes returns a transform removing the prefixed declarations and
+// at-rules that duplicate an unprefixed sibling, with
+// prefix.StripDuplicates.
+func StripPrefixes() Transform {
+	return func(s *css.Stylesheet) {
+		prefix.StripDuplicates(s)
+	}
+}
+
+// RewriteURLs returns a transform replacing the URLs referenced by
+// stylesheets with transform.RewriteURLs.
+func RewriteURLs(fn func(u string) string) Transform {
+	return func(s *css.Stylesheet) {
+		transform.RewriteURLs(s, fn)
+	}
+}
+
+// Options controls Handler.
+type Options struct {
+	// Transforms are applied in order to the stylesheets.
+	Transforms []Transform
+	// MaxSize is the maximum size of the responses to transform, in bytes.
+	// Larger responses are served untransformed. If it is zero,
+	// DefaultMaxSize is used, and if it is negative there is no limit.
+	MaxSize int64
+	// Limits bound the parsing of the stylesheets, which are served
+	// untransformed if one is exceeded. The limits that are zero are those
+	// of css.DefaultLimits, and negative ones mean no limit.
+	Limits css.Limits
+}
+
+// Handler returns a handler serving the responses of next, with the
+// stylesheets passed through the transforms of opts.
+//
+// The successful responses to GET requests with a text/css Content-Type
+// and no Content-Encoding are transformed. Their Content-Length is set and
+// their ETag is replaced by a strong ETag computed from the transformed
+// stylesheet, whether next set one or not. Stylesheets in other encodings
+// than UTF-8 are converted to UTF-8, as OpenStylesheet does.
+//
+// Since the ranges of a transformed stylesheet can't be served from those
+// of the original, a partial response to a range request is discarded if
+// it holds ranges of a stylesheet, and the request is served again without
+// its Range and If-Range headers, so that the whole stylesheet is
+// transformed. The partial responses for other resources are passed
+// through. The Accept-Ranges header of transformed responses is removed,
+// and so are the ETag, Content-Length and Accept-Ranges headers of the
+// responses to HEAD requests for stylesheets, since they describe the
+// original.
+func Handler(next http.Handler, opts Options) http.Handler {
+	if opts.MaxSize == 0 {
+		opts.MaxSize = DefaultMaxSize
+	}
+	opts.Limits = opts.Limits.WithDefaults(css.DefaultLimits)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+		var header http.Header
+		if r.Header.Get("Range") != "" {
+			header = w.Header().Clone()
+		}
+		rw := &responseWriter{ResponseWriter: w, req: r, opts: &opts}
+		next.ServeHTTP(rw, r)
+		if rw.partial && rw.partialStylesheet() {
+			// Discard the headers of the partial response.
+			h := w.Header()
+			for name := range h {
+				delete(h, name)
+			}
+			for name, values := range header {
+				h[name] = values
+			}
+			r = r.Clone(r.Context())
+			r.Header.Del("Range")
+			r.Header.Del("If-Range")
+			rw = &responseWriter{ResponseWriter: w, req: r, opts: &opts}
+			next.ServeHTTP(rw, r)
+		}
+		rw.finish()
+	})
+}
+
+// responseWriter buffers the stylesheets written by a handler to transform
+// them, and passes the other responses through.
+type responseWriter struct {
+	http.ResponseWriter
+	req  *http.Request
+	opts *Options
+	// status is the status code of the response once the header was
+	// written, and buffering reports whether the body is buffered. partial
+	// reports whether the buffered response is a partial response to a
+	// range request, which may hold ranges of a stylesheet.
+	status    int
+	buffering bool
+	partial   bool
+	buf       bytes.Buffer
+}
+
+// WriteHeader decides whether the response is transformed, and writes the
+// header right away if it isn't.
+func (w *responseWriter) WriteHeader(status int) {
+	if w.status != 0 {
+		return
+	}
+	if status >= 100 && status < 200 {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	w.status = status
+	h := w.Header()
+	if status == http.StatusPartialContent && w.req.Header.Get("Range") != "" && (isStylesheet(h) || isMultipart(h)) {
+		w.buffering = true
+		w.partial = true
+		return
+	}
+	if isStylesheet(h) {
+		if w.req.Method == http.MethodHead {
+			h.Del("Etag")
+			h.Del("Content-Length")
+			h.Del("Accept-Ranges")
+		} else if status == http.StatusOK && h.Get("Content-Encoding") == "" {
+			w.buffering = true
+			return
+		}
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write buffers b if the response is transformed, and writes it otherwise.
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.buffering {
+		return w.ResponseWriter.Write(b)
+	}
+	if w.opts.MaxSize >= 0 && int64(w.buf.Len()+len(b)) > w.opts.MaxSize {
+		// Too large: write what was buffered and pass the rest through.
+		w.buffering = false
+		w.partial = false
+		w.ResponseWriter.WriteHeader(w.status)
+		if _, err := w.ResponseWriter.Write(w.buf.Bytes()); err != nil {
+			return 0, err
+		}
+		w.buf = bytes.Buffer{}
+		return w.ResponseWriter.Write(b)
+	}
+	return w.buf.Write(b)
+}
+
+// Flush flushes the response if it isn't buffered.
+func (w *responseWriter) Flush() {
+	if w.buffering {
+		return
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the wrapped writer, for http.ResponseController.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// finish transforms and writes the buffered stylesheet, if any.
+func (w *responseWriter) finish() {
+	if !w.buffering {
+		return
+	}
+	if w.partial {
+		// Ranges of another resource.
+		w.ResponseWriter.WriteHeader(w.status)
+		w.ResponseWriter.Write(w.buf.Bytes())
+		return
+	}
+	h := w.Header()
+	h.Del("Accept-Ranges")
+	body := w.buf.Bytes()
+	if out, enc, ok := w.transform(body, h.Get("Content-Type")); ok {
+		body = out
+		if enc != "utf-8" {
+			contentType := "text/css; charset=utf-8"
+			if mediaType, params, err := mime.ParseMediaType(h.Get("Content-Type")); err == nil {
+				params["charset"] = "utf-8"
+				contentType = mime.FormatMediaType(mediaType, params)
+			}
+			h.Set("Content-Type", contentType)
+		}
+	}
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	h.Set("Etag", etag)
+	if etagMatch(w.req.Header.Get("If-None-Match"), etag) {
+		h.Del("Content-Type")
+		h.Del("Content-Length")
+		w.ResponseWriter.WriteHeader(http.StatusNotModified)
+		return
+	}
+	h.Set("Content-Length", strconv.Itoa(len(body)))
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.Write(body)
+}
+
+// transform decodes, parses and transforms a stylesheet, and returns it
+// along with the name of its original encoding. It reports false if the
+// stylesheet can't be parsed.
+func (w *responseWriter) transform(body []byte, contentType string) ([]byte, string, bool) {
+	sc, enc, err := css.OpenStylesheet(bytes.NewReader(body), contentType)
+	if err != nil {
+		return nil, "", false
+	}
+	p := css.Parser{Limits: w.opts.Limits}
+	s, err := p.ParseStylesheet(sc.Input())
+	if err != nil {
+		return nil, "", false
+	}
+	if enc != "utf-8" {
+		// The @charset rule would be wrong once converted.
+		kept := s.Rules[:0]
+		for _, r := range s.Rules {
+			if !strings.EqualFold(r.AtKeyword, "charset") {
+				kept = append(kept, r)
+			}
+		}
+		s.Rules = kept
+	}
+	for _, t := range w.opts.Transforms {
+		t(s)
+	}
+	var b bytes.Buffer
+	if _, err := s.WriteTo(&b); err != nil {
+		return nil, "", false
+	}
+	return b.Bytes(), enc, true
+}
+
+// isStylesheet reports whether a response header has a text/css
+// Content-Type.
+func isStylesheet(h http.Header) bool {
+	mediaType, _, _ := strings.Cut(h.Get("Content-Type"), ";")
+	return strings.EqualFold(strings.TrimSpace(mediaType), "text/css")
+}
+
+// isMultipart reports whether a response header has a multipart
+// Content-Type, such as multipart/byteranges.
+func isMultipart(h http.Header) bool {
+	mediaType, _, _ := strings.Cut(h.Get("Content-Type"), ";")
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(mediaType)), "multipart/")
+}
+
+// partialStylesheet reports whether the buffered partial response holds
+// ranges of a stylesheet: whether it has a text/css Content-Type, or the
+// first part of a multipart/byteranges response does.
+func (w *responseWriter) partialStylesheet() bool {
+	h := w.Header()
+	if isStylesheet(h) {
+		return true
+	}
+	_, params, err := mime.ParseMediaType(h.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	part, err := multipart.NewReader(bytes.NewReader(w.buf.Bytes()), params["boundary"]).NextPart()
+	return err == nil && isStylesheet(http.Header(part.Header))
+}
+
+// etagMatch reports whether an If-None-Match header matches etag, with the
+// weak comparison of RFC 9110.
+func etagMatch(ifNoneMatch, etag string) bool {
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
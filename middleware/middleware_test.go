@@ -0,0 +1,151 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/minify"
+)
+
+// serve returns a handler writing body with the given Content-Type, in two
+// writes.
+func serve(contentType, body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Etag", `"original"`)
+		w.Header().Set("Content-Length", "1000")
+		if r.Header.Get("Range") != "" {
+			w.WriteHeader(http.StatusPartialContent)
+		}
+		w.Write([]byte(body[:len(body)/2]))
+		w.Write([]byte(body[len(body)/2:]))
+	})
+}
+
+func TestHandler(t *testing.T) {
+	minified := Options{Transforms: []Transform{Minify(minify.Options{})}}
+	tcs := []struct {
+		name        string
+		opts        Options
+		method      string
+		header      string
+		contentType string
+		body        string
+		status      int
+		expected    string
+		etag        bool
+	}{
+		{"minify", minified, "GET", "", "text/css; charset=utf-8", "a { color : red ; }", 200, "a{color:red}", true},
+		{"uppercase type", minified, "GET", "", "Text/CSS", "a { color : red ; }", 200, "a{color:red}", true},
+		{"no transform", Options{}, "GET", "", "text/css", "a { color : red ; }", 200, "a{color:red}", true},
+		{"not css", minified, "GET", "", "text/plain", "a { color : red ; }", 200, "a { color : red ; }", false},
+		{"unclosed comment", minified, "GET", "", "text/css", "a { color: red } /*", 200, "a { color: red } /*", true},
+		{"deep nesting", minified, "GET", "", "text/css", "a { b: " + strings.Repeat("(", 1e5) + " }", 200, "a { b: " + strings.Repeat("(", 1e5) + " }", true},
+		{"limits", Options{Transforms: minified.Transforms, Limits: css.Limits{MaxRules: 1}}, "GET", "", "text/css", "a { } b { }", 200, "a { } b { }", true},
+		{"range", minified, "GET", "Range: bytes=0-1", "text/css", "a { color : red ; }", 200, "a{color:red}", true},
+		{"too large", Options{Transforms: minified.Transforms, MaxSize: 10}, "GET", "", "text/css", "a { color : red ; }", 200, "a { color : red ; }", false},
+		{"head", minified, "HEAD", "", "text/css", "", 200, "", false},
+		{"not modified", minified, "GET", `If-None-Match: "x", W/"ea159630e705fa625eb1224662198ada"`, "text/css", "a { color : red ; }", 304, "", true},
+		{"modified", minified, "GET", `If-None-Match: "original"`, "text/css", "a { color : red ; }", 200, "a{color:red}", true},
+		{"latin1", minified, "GET", "", "text/css; charset=iso-8859-1", "@charset \"iso-8859-1\"; a { content: '\xe9' }", 200, "a{content:'é'}", true},
+		{"rewrite", Options{Transforms: []Transform{RewriteURLs(func(u string) string {
+			if strings.HasPrefix(u, "/") {
+				return "https://cdn.example.com" + u
+			}
+			return u
+		})}}, "GET", "", "text/css", `@import "/a.css"; a { background: url(/b.png), url(c.png); cursor: image-set("/d\").png" 1x) }`, 200, `@import "https://cdn.example.com/a.css";a{background:url("https://cdn.example.com/b.png"), url(c.png);cursor:image-set("https://cdn.example.com/d\22).png" 1x)}`, true},
+	}
+	for _, tc := range tcs {
+		req := httptest.NewRequest(tc.method, "/a.css", nil)
+		if name, value, ok := strings.Cut(tc.header, ": "); ok {
+			req.Header.Set(name, value)
+		}
+		rec := httptest.NewRecorder()
+		Handler(serve(tc.contentType, tc.body), tc.opts).ServeHTTP(rec, req)
+		if rec.Code != tc.status || rec.Body.String() != tc.expected {
+			t.Errorf("%s: got %d %q, want %d %q", tc.name, rec.Code, rec.Body.String(), tc.status, tc.expected)
+		}
+		etag := rec.Header().Get("Etag")
+		if tc.etag && (etag == `"original"` || etag == "") || !tc.etag && tc.method != "HEAD" && etag != `"original"` {
+			t.Errorf("%s: unexpected ETag %q", tc.name, etag)
+		}
+		if tc.method == "HEAD" && (etag != "" || rec.Header().Get("Content-Length") != "") {
+			t.Errorf("%s: unexpected ETag %q or Content-Length %q", tc.name, etag, rec.Header().Get("Content-Length"))
+		}
+	}
+}
+
+func TestHandlerHeaders(t *testing.T) {
+	h := Handler(serve("text/css; charset=windows-1252", "a{content:'\x93'}"), Options{})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/a.css", nil))
+	if got, want := rec.Header().Get("Content-Type"), "text/css; charset=utf-8"; got != want {
+		t.Errorf("got Content-Type %q, want %q", got, want)
+	}
+	if got, want := rec.Header().Get("Content-Length"), "16"; got != want {
+		t.Errorf("got Content-Length %q, want %q", got, want)
+	}
+	etag := rec.Header().Get("Etag")
+
+	// The ETag only depends on the transformed stylesheet.
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/a.css", nil)
+	req.Header.Set("If-None-Match", etag)
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified || rec.Header().Get("Etag") != etag {
+		t.Errorf("got %d with ETag %q, want 304 with ETag %q", rec.Code, rec.Header().Get("Etag"), etag)
+	}
+}
+
+func TestHandlerRange(t *testing.T) {
+	files := map[string]string{"/a.css": "a { color : red ; }", "/a.txt": "abcdef"}
+	h := Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, r.URL.Path, time.Time{}, strings.NewReader(files[r.URL.Path]))
+	}), Options{Transforms: []Transform{Minify(minify.Options{})}})
+	tcs := []struct {
+		path, method, rng string
+		status            int
+		contentType       string
+		expected          string
+	}{
+		{"/a.css", "GET", "bytes=0-1", 200, "text/css; charset=utf-8", "a{color:red}"},
+		{"/a.css", "GET", "bytes=0-1,4-5", 200, "text/css; charset=utf-8", "a{color:red}"},
+		{"/a.css", "HEAD", "bytes=0-1", 200, "text/css; charset=utf-8", ""},
+		{"/a.txt", "GET", "bytes=1-2", 206, "text/plain; charset=utf-8", "bc"},
+		{"/a.txt", "GET", "bytes=0-0,2-2", 206, "multipart/byteranges", ""},
+	}
+	for _, tc := range tcs {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		req.Header.Set("Range", tc.rng)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != tc.status || !strings.HasPrefix(rec.Header().Get("Content-Type"), tc.contentType) || tc.expected != "" && rec.Body.String() != tc.expected {
+			t.Errorf("%s %s: got %d %q %q, want %d %q %q", tc.path, tc.rng, rec.Code, rec.Header().Get("Content-Type"), rec.Body.String(), tc.status, tc.contentType, tc.expected)
+		}
+		if got := rec.Header().Get("Content-Range"); tc.status == 200 && got != "" {
+			t.Errorf("%s %s: unexpected Content-Range %q", tc.path, tc.rng, got)
+		}
+		if got := rec.Header().Get("Accept-Ranges"); tc.status == 200 && got != "" {
+			t.Errorf("%s %s: unexpected Accept-Ranges %q", tc.path, tc.rng, got)
+		}
+	}
+}
+
+func TestRewriteURLs(t *testing.T) {
+	s, err := css.ParseStylesheet(`@namespace url(/ns); a { b: url( "/x" ) src("/y"); c: url(/z) }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	RewriteURLs(func(u string) string { return strings.Replace(u, "/", "/v1/", 1) })(s)
+	if got, want := s.String(), `@namespace url(/ns);a{b:url("/v1/x") src("/v1/y");c:url("/v1/z")}`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package minify
+
+import (
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/prefix"
+	"github.com/gorilla/css/props"
+	"github.com/gorilla/css/scanner"
+	"github.com/gorilla/css/selector"
+)
+
+// mergeRules merges the style rules of a list of minified rules into
+// previous ones, and returns the rules left:
+//
+//   - A rule with the same selectors as a previous rule has its
+//     declarations appended to those of the previous rule, as in
+//     "a{color:red}a{margin:0}" becoming "a{color:red;margin:0}".
+//   - A rule with the same declarations as a previous rule has its
+//     selectors appended to those of the previous rule, as in
+//     "a{color:red}b{color:red}" becoming "a,b{color:red}".
+//
+// Both move the declarations of the rule before the rules between the two,
+// which doesn't change the cascade unless one of those rules sets the same
+// properties; the rule is only merged otherwise. Shorthands, longhands,
+// vendor-prefixed properties and flow-relative properties are taken into
+// account, so that "margin-left" conflicts with "margin" and
+// "margin-inline-start". At-rules and rules with nested rules are not
+// looked past, and rules with license comments are not merged. Selectors
+// are only combined into a list if they are all standard, since a browser
+// drops a list with a selector it doesn't support, such as
+// "::-moz-selection".
+func mergeRules(rules []*css.Rule) []*css.Rule {
+	m := &merger{
+		barrier:    -1,
+		lastSet:    map[string]int{},
+		bySelector: map[string]int{},
+		byBlock:    map[string]int{},
+	}
+	for _, r := range rules {
+		if !mergeable(r) {
+			m.barrier = len(m.out)
+			m.out = append(m.out, r)
+			continue
+		}
+		if len(r.Comments) > 0 || !m.merge(r) {
+			m.add(len(m.out), r)
+			m.out = append(m.out, r)
+		}
+	}
+	return m.out
+}
+
+// merger merges the rules of a list of rules.
+type merger struct {
+	// out are the rules kept.
+	out []*css.Rule
+	// barrier is the index in out of the last rule not looked past, or -1.
+	barrier int
+	// lastSet maps longhands to the index in out of the last rule setting
+	// them.
+	lastSet map[string]int
+	// bySelector and byBlock map the selectors and the declarations of the
+	// rules of out to the index of the last one, for finding the rules to
+	// merge into. Merged rules leave their previous keys behind, which
+	// merge checks.
+	bySelector map[string]int
+	byBlock    map[string]int
+}
+
+// mergeable reports whether a rule can be merged into a previous one, or
+// be merged into.
+func mergeable(r *css.Rule) bool {
+	return !r.IsAtRule() && r.HasBlock && len(r.Rules) == 0
+}
+
+// add records the keys and the longhands of the rule at index i of out.
+func (m *merger) add(i int, r *css.Rule) {
+	m.bySelector[css.ValuesString(r.Prelude)] = i
+	if len(r.Declarations) > 0 {
+		m.byBlock[declarationsKey(r.Declarations)] = i
+	}
+	for _, d := range r.Declarations {
+		// The all shorthand sets every property but custom ones.
+		if strings.EqualFold(d.Property, "all") && i > m.barrier {
+			m.barrier = i
+		}
+		for _, l := range longhands(d.Property) {
+			if j, ok := m.lastSet[l]; !ok || j < i {
+				m.lastSet[l] = i
+			}
+		}
+	}
+}
+
+// merge merges r into one of the rules of out, and reports whether it did.
+func (m *merger) merge(r *css.Rule) bool {
+	// The rule to merge into must come after the barrier and the rules
+	// setting the same properties as r, but may set them itself.
+	after := m.barrier
+	for _, d := range r.Declarations {
+		if strings.EqualFold(d.Property, "all") {
+			after = len(m.out) - 1
+		}
+		for _, l := range longhands(d.Property) {
+			if j, ok := m.lastSet[l]; ok && j > after {
+				after = j
+			}
+		}
+	}
+	text := css.ValuesString(r.Prelude)
+	if i, ok := m.bySelector[text]; ok && i >= after && css.ValuesString(m.out[i].Prelude) == text {
+		p := m.out[i]
+		p.Declarations = shorthands(dropRepeated(append(p.Declarations, r.Declarations...)))
+		m.add(i, p)
+		return true
+	}
+	if len(r.Declarations) == 0 {
+		return false
+	}
+	i, ok := m.byBlock[declarationsKey(r.Declarations)]
+	if !ok || i < after || !sameDeclarations(m.out[i].Declarations, r.Declarations) {
+		return false
+	}
+	p := m.out[i]
+	if !standard(p.Prelude) || !standard(r.Prelude) {
+		return false
+	}
+	prelude := make([]*css.ComponentValue, 0, len(p.Prelude)+1+len(r.Prelude))
+	prelude = append(prelude, p.Prelude...)
+	prelude = append(prelude, &css.ComponentValue{Token: &scanner.Token{Type: scanner.TokenChar, Value: ","}})
+	p.Prelude = append(prelude, r.Prelude...)
+	m.add(i, p)
+	return true
+}
+
+// declarationsKey returns a key of minified declarations, for finding the
+// rules with the same declarations.
+func declarationsKey(decls []*css.Declaration) string {
+	var b strings.Builder
+	for _, d := range decls {
+		b.WriteString(d.Property)
+		b.WriteByte(':')
+		css.WriteValues(&b, d.Value)
+		if d.Important {
+			b.WriteString("!important")
+		}
+		b.WriteByte(';')
+	}
+	return b.String()
+}
+
+// sameDeclarations reports whether two lists of minified declarations are
+// the same.
+func sameDeclarations(a, b []*css.Declaration) bool {
+	if len(a) != len(b) || len(a) == 0 {
+		return false
+	}
+	for i := range a {
+		if !sameDeclaration(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameDeclaration reports whether two minified declarations are the same.
+func sameDeclaration(a, b *css.Declaration) bool {
+	return a.Property == b.Property && a.Important == b.Important &&
+		css.ValuesString(a.Value) == css.ValuesString(b.Value)
+}
+
+// dropRepeated removes the declarations repeated later in a block, which
+// are overridden by their repetition whether a browser supports their
+// value or not.
+func dropRepeated(decls []*css.Declaration) []*css.Declaration {
+	out := decls[:0]
+	for i, d := range decls {
+		repeated := false
+		for _, e := range decls[i+1:] {
+			if sameDeclaration(d, e) {
+				repeated = true
+				break
+			}
+		}
+		if !repeated {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// standard reports whether a selector list can be parsed and only has
+// standard pseudo-classes and pseudo-elements.
+func standard(prelude []*css.ComponentValue) bool {
+	list, err := selector.Parse(prelude)
+	if err != nil {
+		list, err = selector.ParseRelative(prelude)
+	}
+	return err == nil && list.Walk((*selector.Simple).IsStandard)
+}
+
+// writingModes are the writing modes for which the flow-relative properties
+// are mapped to physical properties by longhands.
+var writingModes = []props.WritingMode{props.HorizontalTB, props.VerticalRL, props.VerticalLR, props.SidewaysRL, props.SidewaysLR}
+
+// longhands returns the longhands set by a property, without vendor prefix,
+// and the physical properties they may map to, so that two properties
+// conflict if they have a longhand in common. Custom properties and unknown
+// properties are their own longhand.
+func longhands(property string) []string {
+	if strings.HasPrefix(property, "--") {
+		return []string{property}
+	}
+	name := strings.ToLower(prefix.Strip(property))
+	list := []string{name}
+	if p := props.Lookup(name); p != nil && p.IsShorthand() {
+		list = p.Longhands
+	}
+	out := append([]string(nil), list...)
+	for _, l := range list {
+		for _, mode := range writingModes {
+			for _, dir := range []props.Direction{props.LTR, props.RTL} {
+				out = append(out, props.Physical(l, mode, dir)...)
+			}
+		}
+	}
+	return out
+}
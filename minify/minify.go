@@ -0,0 +1,215 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package gorilla/css/minify produces minimal CSS from a parsed stylesheet.
+
+The serializer already omits the optional whitespace around blocks and the
+final semicolon of a block. Stylesheet removes comments and the remaining
+whitespace that isn't significant, and shortens numbers and units:
+
+	sheet, _ := css.ParseStylesheet("a , b > c { margin : 0.50em  0px ; }")
+	minify.Stylesheet(sheet, minify.Options{})
+	sheet.String() // "a,b>c{margin:.5em 0}"
+
+String does the same for a stylesheet source.
+
+License comments, which start with "/*!" or "@license", are kept unless
+Options.DropLicenses is set.
+
+With Options.MergeRules, rules with the same selectors are merged, and rules
+with the same declarations are combined into selector lists, which shortens
+generated stylesheets further:
+
+	a{color:red}b{margin:0}a{padding:0}c{margin:0} // a{color:red;padding:0}b,c{margin:0}
+
+Rules are only moved past rules that don't set the same properties, so that
+the cascade is unchanged.
+
+Minified stylesheets keep the positions of their rules, declarations and
+tokens, so rendering them with a css.RenderOptions.SourceMap maps the
+output back to the input.
+*/
+package minify
+
+import (
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/scanner"
+)
+
+// Options controls minification.
+type Options struct {
+	// DropLicenses removes license comments too.
+	DropLicenses bool
+	// MergeRules merges the style rules with the same selectors, and
+	// combines the style rules with the same declarations into selector
+	// lists, where it doesn't change the cascade.
+	MergeRules bool
+}
+
+// String parses a stylesheet, minifies it and returns its CSS
+// representation.
+func String(input string, opts Options) (string, error) {
+	s, err := css.ParseStylesheet(input)
+	if err != nil {
+		return "", err
+	}
+	Stylesheet(s, opts)
+	return s.String(), nil
+}
+
+// Stylesheet minifies a stylesheet in place.
+//
+// Comments are removed, except license comments unless opts.DropLicenses is
+// set. Runs of whitespace are collapsed to a single space, which is removed next
+// to delimiters where it can't be significant: commas and combinators in
+// selectors, commas and colons in at-rule preludes, and commas and slashes
+// in declaration values. Numbers in declaration values lose their redundant
+// zeros and signs, as in "+0.50em" becoming ".5em", and times use the
+// shorter of "s" and "ms", as in "500ms" becoming ".5s". Zero lengths lose
+// their unit where the grammar of the property accepts lengths but no bare
+// numbers that a unitless zero could be mistaken for. The values of custom
+// properties are left untouched. Finally, where the cascaded values stay the
+// same, longhands overridden by a later shorthand are removed and the side
+// longhands of shorthands such as margin are merged into them.
+func Stylesheet(s *css.Stylesheet, opts Options) {
+	m := &minifier{opts: opts}
+	s.Rules = m.rules(s.Rules)
+}
+
+// minifier minifies stylesheets.
+type minifier struct {
+	opts Options
+}
+
+// Delimiters next to which whitespace is removed, per context.
+const (
+	selectorDelims = ",>+~"
+	atRuleDelims   = ",:"
+	valueDelims    = ",/"
+)
+
+// rules minifies a list of rules and their contents, and returns them.
+func (m *minifier) rules(rules []*css.Rule) []*css.Rule {
+	for _, r := range rules {
+		r.Comments = m.comments(r.Comments)
+		if r.IsAtRule() {
+			r.Prelude = minifySpace(r.Prelude, atRuleDelims)
+		} else {
+			r.Prelude = minifySpace(r.Prelude, selectorDelims)
+		}
+		for _, d := range r.Declarations {
+			d.Comments = m.comments(d.Comments)
+			if strings.HasPrefix(d.Property, "--") {
+				continue
+			}
+			d.Value = minifySpace(d.Value, valueDelims)
+			shortenNumbers(d.Value)
+			shortenTimes(d.Value)
+			if zeroLengthsAllowed(d.Property) {
+				shortenZeroLengths(d.Value)
+			}
+		}
+		r.Declarations = shorthands(r.Declarations)
+		r.Rules = m.rules(r.Rules)
+	}
+	if m.opts.MergeRules {
+		rules = mergeRules(rules)
+	}
+	return rules
+}
+
+// comments returns the comments to keep.
+func (m *minifier) comments(comments []string) []string {
+	if m.opts.DropLicenses {
+		return nil
+	}
+	var kept []string
+	for _, c := range comments {
+		if isLicense(c) {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
+// isLicense reports whether a comment is a license comment, which starts
+// with "/*!" or "@license".
+func isLicense(comment string) bool {
+	return strings.HasPrefix(comment, "/*!") ||
+		strings.HasPrefix(strings.TrimSpace(comment[2:]), "@license")
+}
+
+// minifySpace collapses and removes whitespace in values and their children,
+// and returns the resulting values.
+func minifySpace(values []*css.ComponentValue, delims string) []*css.ComponentValue {
+	values = css.TrimSpace(values)
+	out := values[:0]
+	for i, v := range values {
+		if v.Token.Type == scanner.TokenS {
+			if isDelim(values[i-1], delims) || isDelim(values[i+1], delims) {
+				continue
+			}
+			v.Token.Value = " "
+		}
+		v.Children = minifySpace(v.Children, delims)
+		out = append(out, v)
+	}
+	return out
+}
+
+// isDelim reports whether v is one of the delimiters.
+func isDelim(v *css.ComponentValue, delims string) bool {
+	t := v.Token
+	return t.Type == scanner.TokenChar && len(t.Value) == 1 && strings.Contains(delims, t.Value)
+}
+
+// shortenNumbers shortens the numbers, percentages and dimensions in values
+// and their children.
+func shortenNumbers(values []*css.ComponentValue) {
+	for _, v := range values {
+		switch v.Token.Type {
+		case scanner.TokenNumber, scanner.TokenPercentage, scanner.TokenDimension:
+			v.Token.Value = shortenNumber(v.Token.Value)
+		}
+		shortenNumbers(v.Children)
+	}
+}
+
+// shortenNumber removes the redundant zeros and plus sign of a number,
+// percentage or dimension.
+func shortenNumber(s string) string {
+	unit := strings.TrimLeft(s, "+-.0123456789")
+	// A unit such as "e1" would be read as an exponent.
+	if len(unit) > 1 && (unit[0] == 'e' || unit[0] == 'E') && strings.ContainsAny(unit[1:2], "+-0123456789") {
+		return s
+	}
+	num := s[:len(s)-len(unit)]
+	var sign string
+	switch num[0] {
+	case '-':
+		sign = "-"
+		num = num[1:]
+	case '+':
+		num = num[1:]
+	}
+	integer, fraction := num, ""
+	if i := strings.IndexByte(num, '.'); i >= 0 {
+		integer, fraction = num[:i], num[i+1:]
+	}
+	integer = strings.TrimLeft(integer, "0")
+	fraction = strings.TrimRight(fraction, "0")
+	if fraction != "" {
+		num = integer + "." + fraction
+	} else {
+		num = integer
+	}
+	if num == "" {
+		// Negative zero is zero.
+		return "0" + unit
+	}
+	return sign + num + unit
+}
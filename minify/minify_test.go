@@ -0,0 +1,168 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package minify
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/props"
+)
+
+func TestString(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected string
+	}{
+		{"a , b > c { margin : 0.50em  1.0px ; }", "a,b>c{margin:.5em 1px}"},
+		{"/* comment */ a   .b + c ~ d { color : red ; }", "a .b+c~d{color:red}"},
+		{"a:not( .b ) {}", "a:not(.b){}"},
+		{"a { font: 12px / 1.5 Arial , sans-serif; width: calc( 100% - 10px ) }", "a{font:12px/1.5 Arial,sans-serif;width:calc(100% - 10px)}"},
+		{"a { margin: -0.0px +1.50% 010 -.5e1; --x: 0.50 ,  1 }", "a{margin:0 1.5% 10 -.5e1;--x:0.50 ,  1}"},
+		{"a { padding: 0px 0.0em 0% 1px; width: calc(0px + 1em); border: 0PX solid }", "a{padding:0 0 0% 1px;width:calc(0px + 1em);border:0 solid}"},
+		{"a { margin: 0svmin 0dvi 0cqb 0lvh }", "a{margin:0 0 0 0}"},
+		{"a { line-height: 0px; flex: 1 1 0px; transition: top 500ms 1.5s, left 0ms 1ms }", "a{line-height:0px;flex:1 1 0px;transition:top .5s 1.5s,left 0s 1ms}"},
+		{"a { content: '0px 500ms'; --d: 500ms; animation-delay: 0.001s }", "a{content:'0px 500ms';--d:500ms;animation-delay:1ms}"},
+		{"@media screen and (min-width : 100px) , print { a { top: 0 } }", "@media screen and (min-width:100px),print{a{top:0}}"},
+		{"/*! MIT */ /* a */ a { /* @license x */ color: red; /* b */ top: 0 }", "/*! MIT */a{/* @license x */color:red;top:0}"},
+		{"@import url(a.css) screen;\n\n@font-face { src: url(a.woff) format( 'woff' ) }", "@import url(a.css) screen;@font-face{src:url(a.woff) format('woff')}"},
+	}
+	for _, tc := range tcs {
+		got, err := String(tc.input, Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.expected {
+			t.Errorf("%s:\ngot  %q\nwant %q", tc.input, got, tc.expected)
+		}
+	}
+}
+
+func TestDropLicenses(t *testing.T) {
+	got, err := String("/*! MIT */ a { /* @license x */ color: red }", Options{DropLicenses: true})
+	if want := "a{color:red}"; got != want || err != nil {
+		t.Errorf("got %q, %v, want %q", got, err, want)
+	}
+}
+
+func TestMergeRules(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected string
+	}{
+		{"a{color:red}b{margin:0}a{padding:0}c{margin:0}", "a{color:red;padding:0}b,c{margin:0}"},
+		{"a { color: red } a { color: red; top: 0 }", "a{color:red;top:0}"},
+		{"a{margin-top:0;margin-right:0}a{margin-bottom:0;margin-left:0}", "a{margin:0}"},
+		{"a{color:red}b{color:blue}a{color:green}", "a{color:red}b{color:blue}a{color:green}"},
+		{"a{color:red}b{color:blue}c{color:red}", "a{color:red}b{color:blue}c{color:red}"},
+		{"a{margin-left:0}b{margin:1px}a{top:0}c{margin-left:0}", "a{margin-left:0;top:0}b{margin:1px}c{margin-left:0}"},
+		{"a{margin-left:0}b{margin-inline-start:1px}c{margin-left:0}", "a{margin-left:0}b{margin-inline-start:1px}c{margin-left:0}"},
+		{"a{transition:none}b{-webkit-transition:none}c{transition:none}", "a{transition:none}b{-webkit-transition:none}c{transition:none}"},
+		{"a{color:red}b{all:unset}a{top:0}", "a{color:red}b{all:unset}a{top:0}"},
+		{"a{color:red}::-moz-selection{color:red}", "a{color:red}::-moz-selection{color:red}"},
+		{"a{color:red}@media print{b{top:0}}a{top:0}", "a{color:red}@media print{b{top:0}}a{top:0}"},
+		{"@media print{a{top:0}a{left:0}b{top:0}}", "@media print{a{top:0;left:0}b{top:0}}"},
+		{"a{color:red}/*! MIT */a{top:0}", "a{color:red}/*! MIT */a{top:0}"},
+		{"a{color:red}b{color:red!important}", "a{color:red}b{color:red!important}"},
+		{"a{color:red;color:rgb(0 0 0 / 50%)}a{color:red}", "a{color:rgb(0 0 0/50%);color:red}"},
+	}
+	for _, tc := range tcs {
+		got, err := String(tc.input, Options{MergeRules: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.expected {
+			t.Errorf("%s:\ngot  %q\nwant %q", tc.input, got, tc.expected)
+		}
+	}
+}
+
+func TestShorthands(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected string
+	}{
+		{"a{margin-top:0;margin-right:1px;margin-bottom:0;margin-left:1px}", "a{margin:0 1px}"},
+		{"a{padding-left:4px;padding-top:1px;color:red;padding-right:2px;padding-bottom:3px}", "a{color:red;padding:1px 2px 3px 4px}"},
+		{"a{border-top-width:1px;border-right-width:1px;border-bottom-width:1px;border-left-width:1px}", "a{border-width:1px}"},
+		{"a{top:0;right:0;bottom:auto;left:0}", "a{inset:0 0 auto}"},
+		{"a{margin:0;margin-top:1px}", "a{margin:1px 0 0}"},
+		{"a{margin:0 1px;margin-left:2px;margin-right:2px}", "a{margin:0 2px}"},
+		{"a{margin-top:1px;color:red;margin:0}", "a{color:red;margin:0}"},
+		{"a{font-size:12px;font:bold 14px serif}", "a{font:bold 14px serif}"},
+		{"a{border-top-color:red;border:0}", "a{border:0}"},
+		// Not safe.
+		{"a{margin-top:1px!important;margin:0}", "a{margin-top:1px!important;margin:0}"},
+		{"a{margin:0;margin-top:1px!important}", "a{margin:0;margin-top:1px!important}"},
+		{"a{margin:0;margin-block-start:2px;margin-top:1px}", "a{margin:0;margin-block-start:2px;margin-top:1px}"},
+		{"a{margin:var(--m);margin-top:1px}", "a{margin:var(--m);margin-top:1px}"},
+		{"a{margin-top:0;margin-right:0;margin-inline-start:1px;margin-bottom:0;margin-left:0}", "a{margin-top:0;margin-right:0;margin-inline-start:1px;margin-bottom:0;margin-left:0}"},
+		{"a{margin-top:0;margin-top:1vmax;margin-right:0;margin-bottom:0;margin-left:0}", "a{margin-top:0;margin-top:1vmax;margin-right:0;margin-bottom:0;margin-left:0}"},
+		{"a{margin-top:inherit;margin-right:0;margin-bottom:0;margin-left:0}", "a{margin-top:inherit;margin-right:0;margin-bottom:0;margin-left:0}"},
+		{"a{margin-top:0;margin-right:0;margin-bottom:0;margin-left:0!important}", "a{margin-top:0;margin-right:0;margin-bottom:0;margin-left:0!important}"},
+	}
+	for _, tc := range tcs {
+		got, err := String(tc.input, Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.expected {
+			t.Errorf("%s:\ngot  %q\nwant %q", tc.input, got, tc.expected)
+		}
+		in, _ := css.ParseStylesheet(tc.input)
+		out, _ := css.ParseStylesheet(got)
+		if a, b := cascade(in.Rules[0].Declarations), cascade(out.Rules[0].Declarations); !reflect.DeepEqual(a, b) {
+			t.Errorf("%s: cascaded values differ:\ninput  %v\noutput %v", tc.input, a, b)
+		}
+	}
+}
+
+// cascade returns the cascaded value of each longhand set by declarations,
+// in physical terms for a horizontal left-to-right writing mode.
+func cascade(decls []*css.Declaration) map[string]string {
+	values := map[string]string{}
+	important := map[string]bool{}
+	set := func(name, value string, imp bool) {
+		if important[name] && !imp {
+			return
+		}
+		values[name], important[name] = value, imp
+	}
+	var longhands func(name string) []string
+	longhands = func(name string) []string {
+		p := props.Lookup(name)
+		if p == nil || !p.IsShorthand() {
+			return []string{name}
+		}
+		var list []string
+		for _, l := range p.Longhands {
+			list = append(list, longhands(l)...)
+		}
+		return list
+	}
+	for _, d := range decls {
+		name := d.Property
+		if physical := props.Physical(name, props.HorizontalTB, props.LTR); len(physical) == 1 {
+			name = physical[0]
+		}
+		value := css.ValuesString(d.Value)
+		if p := boxShorthand(name); p != nil {
+			if sides := sideValues(d.Value); sides != nil {
+				for i, l := range p.Longhands {
+					set(l, css.ValuesString(sides[i]), d.Important)
+				}
+				continue
+			}
+		}
+		if l := longhands(name); len(l) > 1 {
+			value = name + ":" + value
+		}
+		for _, l := range longhands(name) {
+			set(l, value, d.Important)
+		}
+	}
+	return values
+}
@@ -0,0 +1,337 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package minify
+
+import (
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/props"
+	"github.com/gorilla/css/scanner"
+)
+
+// boxShorthands lists, sorted by name, the shorthands that take one to four
+// values for the top, right, bottom and left sides, such as margin.
+var boxShorthands []*props.Property
+
+func init() {
+	for _, p := range props.All() {
+		if len(p.Longhands) == 4 && strings.HasSuffix(p.Value, "{1,4}") &&
+			strings.Contains(p.Longhands[0], "top") {
+			boxShorthands = append(boxShorthands, p)
+		}
+	}
+}
+
+// boxShorthand returns the box shorthand with the given name, or nil.
+func boxShorthand(name string) *props.Property {
+	for _, p := range boxShorthands {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// shorthands rewrites the declarations of a block with fewer declarations
+// and returns them. The cascaded value of every property is unchanged:
+//
+//   - A longhand followed by one of its shorthands, which overrides it, is
+//     removed.
+//   - A side longhand following a box shorthand, as in "margin: 0;
+//     margin-top: 1px", is folded into it.
+//   - The four side longhands of a box shorthand are replaced by the
+//     shorthand.
+//
+// Declarations are only moved or merged when no declaration between them
+// sets one of the same properties, and only when they have the same
+// importance and no var() references.
+func shorthands(decls []*css.Declaration) []*css.Declaration {
+	decls = dropOverridden(decls)
+	decls = foldLonghands(decls)
+	return mergeLonghands(decls)
+}
+
+// dropOverridden removes the longhands followed by one of their shorthands
+// with at least the same importance.
+func dropOverridden(decls []*css.Declaration) []*css.Declaration {
+	out := decls[:0]
+	for i, d := range decls {
+		overridden := false
+		for _, e := range decls[i+1:] {
+			if (e.Important || !d.Important) && isShorthandOf(name(e), name(d)) {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// foldLonghands folds the side longhands following a box shorthand into
+// it.
+func foldLonghands(decls []*css.Declaration) []*css.Declaration {
+	for i := 0; i < len(decls); i++ {
+		s := decls[i]
+		p := boxShorthand(name(s))
+		if p == nil {
+			continue
+		}
+		sides := sideValues(s.Value)
+		if sides == nil {
+			continue
+		}
+		folded := false
+		for j := i + 1; j < len(decls); j++ {
+			d := decls[j]
+			k := indexOf(p.Longhands, name(d))
+			if k < 0 {
+				if blocks(d, p.Longhands) {
+					break
+				}
+				continue
+			}
+			v := sideValues(d.Value)
+			if v == nil || d.Important != s.Important || len(css.TrimSpace(d.Value)) != len(v[0]) {
+				break
+			}
+			sides[k] = v[0]
+			decls = append(decls[:j], decls[j+1:]...)
+			j--
+			folded = true
+		}
+		if folded {
+			s.Value = joinSides(sides)
+		}
+	}
+	return decls
+}
+
+// mergeLonghands replaces the four side longhands of box shorthands with the
+// shorthand, at the position of the last one.
+func mergeLonghands(decls []*css.Declaration) []*css.Declaration {
+	for _, p := range boxShorthands {
+		var idx [4]int
+		count := 0
+		for i := range idx {
+			idx[i] = -1
+		}
+		for i, d := range decls {
+			if k := indexOf(p.Longhands, name(d)); k >= 0 {
+				if idx[k] >= 0 {
+					// Repeated longhands may be fallbacks.
+					count = -1
+					break
+				}
+				idx[k] = i
+				count++
+			}
+		}
+		if count != 4 {
+			continue
+		}
+		first, last := len(decls), -1
+		sides := make([][]*css.ComponentValue, 4)
+		ok := true
+		for k, i := range idx {
+			d := decls[i]
+			v := sideValues(d.Value)
+			if v == nil || len(css.TrimSpace(d.Value)) != len(v[0]) || d.Important != decls[idx[0]].Important {
+				ok = false
+				break
+			}
+			sides[k] = v[0]
+			if i < first {
+				first = i
+			}
+			if i > last {
+				last = i
+			}
+		}
+		if !ok {
+			continue
+		}
+		for i := first; i <= last && ok; i++ {
+			if indexOf(p.Longhands, name(decls[i])) < 0 && blocks(decls[i], p.Longhands) {
+				ok = false
+			}
+		}
+		if !ok {
+			continue
+		}
+		l := decls[last]
+		merged := &css.Declaration{
+			Property:  p.Name,
+			Value:     joinSides(sides),
+			Important: l.Important,
+			Line:      l.Line,
+			Column:    l.Column,
+		}
+		out := make([]*css.Declaration, 0, len(decls)-3)
+		for i, d := range decls {
+			switch {
+			case i == last:
+				out = append(out, merged)
+			case indexOf(p.Longhands, name(d)) < 0:
+				out = append(out, d)
+			}
+		}
+		decls = out
+	}
+	return decls
+}
+
+// sideValues splits the value of a box shorthand or side longhand into the
+// values of the top, right, bottom and left sides. It returns nil if the
+// value doesn't have one to four space-separated parts, or has a var()
+// reference or a CSS-wide keyword.
+func sideValues(values []*css.ComponentValue) [][]*css.ComponentValue {
+	var parts [][]*css.ComponentValue
+	start := 0
+	values = css.TrimSpace(values)
+	for i := 0; i <= len(values); i++ {
+		if i < len(values) && values[i].Token.Type != scanner.TokenS {
+			if hasVar(values[i]) || isWideKeyword(values[i]) || isDelim(values[i], valueDelims) {
+				return nil
+			}
+			continue
+		}
+		if i > start {
+			parts = append(parts, values[start:i])
+		}
+		start = i + 1
+	}
+	switch len(parts) {
+	case 1:
+		return [][]*css.ComponentValue{parts[0], parts[0], parts[0], parts[0]}
+	case 2:
+		return [][]*css.ComponentValue{parts[0], parts[1], parts[0], parts[1]}
+	case 3:
+		return [][]*css.ComponentValue{parts[0], parts[1], parts[2], parts[1]}
+	case 4:
+		return parts
+	}
+	return nil
+}
+
+// joinSides returns the shortest value of a box shorthand for the values of
+// the four sides.
+func joinSides(sides [][]*css.ComponentValue) []*css.ComponentValue {
+	s := make([]string, 4)
+	for i, v := range sides {
+		s[i] = css.ValuesString(v)
+	}
+	n := 4
+	if s[3] == s[1] {
+		n = 3
+		if s[2] == s[0] {
+			n = 2
+			if s[1] == s[0] {
+				n = 1
+			}
+		}
+	}
+	var out []*css.ComponentValue
+	for i, v := range sides[:n] {
+		if i > 0 {
+			out = append(out, &css.ComponentValue{Token: &scanner.Token{Type: scanner.TokenS, Value: " "}})
+		}
+		out = append(out, css.CloneValues(v)...)
+	}
+	return out
+}
+
+// hasVar reports whether a component value is or contains a var() or env()
+// reference.
+func hasVar(v *css.ComponentValue) bool {
+	switch strings.ToLower(v.Name()) {
+	case "var", "env":
+		return true
+	}
+	for _, c := range v.Children {
+		if hasVar(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWideKeyword reports whether a component value is a CSS-wide keyword,
+// which a shorthand only accepts alone.
+func isWideKeyword(v *css.ComponentValue) bool {
+	if v.Token.Type != scanner.TokenIdent {
+		return false
+	}
+	switch strings.ToLower(v.Token.Value) {
+	case "initial", "inherit", "unset", "revert", "revert-layer":
+		return true
+	}
+	return false
+}
+
+// blocks reports whether a declaration sets one of the longhands, which
+// prevents moving declarations of these longhands across it.
+func blocks(d *css.Declaration, longhands []string) bool {
+	n := name(d)
+	if strings.HasPrefix(n, "--") {
+		return false
+	}
+	if strings.HasPrefix(n, "-") {
+		// Vendor-prefixed properties may be aliases.
+		n = n[strings.IndexByte(n[1:], '-')+2:]
+	}
+	physical := []string{n}
+	for mode := props.HorizontalTB; mode <= props.SidewaysLR; mode++ {
+		for _, dir := range []props.Direction{props.LTR, props.RTL} {
+			physical = append(physical, props.Physical(n, mode, dir)...)
+		}
+	}
+	for _, l := range longhands {
+		for _, p := range physical {
+			if overlaps(p, l) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// overlaps reports whether two properties set a common longhand.
+func overlaps(a, b string) bool {
+	return a == b || a == "all" || b == "all" || isShorthandOf(a, b) || isShorthandOf(b, a)
+}
+
+// isShorthandOf reports whether s is a shorthand, at any depth, of the
+// property l.
+func isShorthandOf(s, l string) bool {
+	p := props.Lookup(l)
+	if p == nil {
+		return false
+	}
+	for _, name := range p.Shorthands {
+		if name == s || isShorthandOf(s, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// name returns the lowercase property name of a declaration.
+func name(d *css.Declaration) string {
+	return strings.ToLower(d.Property)
+}
+
+// indexOf returns the index of s in list, or -1.
+func indexOf(list []string, s string) int {
+	for i, e := range list {
+		if e == s {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package minify
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/props"
+	"github.com/gorilla/css/scanner"
+)
+
+// numberTypes lists the value definition types that accept a bare number
+// where a length could also appear, which makes a unitless zero mean
+// something else.
+var numberTypes = []string{
+	"number", "integer", "ratio", "opacity-value", "counter",
+	"font-weight-absolute", "feature-tag-value", "single-animation",
+	"single-animation-iteration-count",
+}
+
+// zeroLengthsAllowed reports whether zero lengths in the top level of a
+// value of the property can lose their unit.
+func zeroLengthsAllowed(property string) bool {
+	p := props.Lookup(property)
+	if p == nil || !p.AcceptsType("length") && !p.AcceptsType("length-percentage") {
+		return false
+	}
+	for _, t := range numberTypes {
+		if p.AcceptsType(t) {
+			return false
+		}
+	}
+	return true
+}
+
+// shortenZeroLengths removes the unit of the zero lengths in values. Values
+// nested in functions are left alone, since math functions such as calc()
+// need units to tell lengths from numbers.
+func shortenZeroLengths(values []*css.ComponentValue) {
+	for _, v := range values {
+		if v.Token.Type != scanner.TokenDimension {
+			continue
+		}
+		unit := strings.TrimLeft(v.Token.Value, "+-.0123456789")
+		num := v.Token.Value[:len(v.Token.Value)-len(unit)]
+		if lengthUnits[strings.ToLower(unit)] && strings.Trim(num, "+-.0") == "" {
+			v.Token.Value = "0"
+		}
+	}
+}
+
+// shortenTimes rewrites the times in values and their children with
+// whichever of the "s" and "ms" units is shorter, as in "500ms" becoming
+// ".5s".
+func shortenTimes(values []*css.ComponentValue) {
+	for _, v := range values {
+		if v.Token.Type == scanner.TokenDimension {
+			v.Token.Value = shortenTime(v.Token.Value)
+		}
+		shortenTimes(v.Children)
+	}
+}
+
+// shortenTime returns the shorter form of a time dimension, which is
+// returned unchanged if it isn't a time.
+func shortenTime(s string) string {
+	unit := strings.TrimLeft(s, "+-.0123456789")
+	num := s[:len(s)-len(unit)]
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return s
+	}
+	var alt string
+	switch strings.ToLower(unit) {
+	case "s":
+		alt = shortenNumber(strconv.FormatFloat(f*1000, 'f', -1, 64)) + "ms"
+	case "ms":
+		alt = shortenNumber(strconv.FormatFloat(f/1000, 'f', -1, 64)) + "s"
+	default:
+		return s
+	}
+	if len(alt) < len(s) {
+		return alt
+	}
+	return s
+}
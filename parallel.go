@@ -0,0 +1,379 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package css
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/gorilla/css/scanner"
+)
+
+// bom is the byte order mark.
+const bom = "\uFEFF"
+
+// minChunkSize is the minimum size of the parts of the input parsed
+// concurrently, below which the overhead outweighs the gain.
+const minChunkSize = 16 << 10
+
+// ParseStylesheetParallel parses the input as a stylesheet like
+// ParseStylesheet, using up to workers goroutines. If workers is zero or
+// less, GOMAXPROCS goroutines are used.
+//
+// The input is split between top-level rules with a quick scan that only
+// tracks brackets, strings, comments and urls, and the parts are tokenized
+// and parsed concurrently. The result, including the positions of rules,
+// declarations and tokens, is the same as with ParseStylesheet. Small
+// inputs, and inputs the quick scan can't split safely, are parsed
+// sequentially.
+func ParseStylesheetParallel(input string, workers int) (*Stylesheet, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	input = scanner.Normalize(input)
+	if workers == 1 || len(input) < 2*minChunkSize {
+		return ParseStylesheet(input)
+	}
+	chunkSize := len(input) / (4 * workers)
+	if chunkSize < minChunkSize {
+		chunkSize = minChunkSize
+	}
+	return parseParallel(input, workers, chunkSize)
+}
+
+// parseParallel parses a normalized input in parts of about size bytes
+// using workers goroutines.
+func parseParallel(input string, workers, size int) (*Stylesheet, error) {
+	bounds := splitRules(input, size)
+	if len(bounds) < 2 {
+		return ParseStylesheet(input)
+	}
+
+	type result struct {
+		rules []*Rule
+		err   *ParseError
+	}
+	results := make([]result, len(bounds))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				start, end := bounds[i], len(input)
+				if i+1 < len(bounds) {
+					end = bounds[i+1]
+				}
+				p := newParser(input[start:end])
+				results[i] = result{p.parseRules(), p.err}
+			}
+		}()
+	}
+	for i := range bounds {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	s := &Stylesheet{}
+	line, col := 1, 1
+	for i, r := range results {
+		if i > 0 {
+			line, col = advancePosition(line, col, input[bounds[i-1]:bounds[i]], i == 1)
+		}
+		if r.err != nil {
+			r.err.Line, r.err.Column = shift(r.err.Line, r.err.Column, line, col)
+			return nil, r.err
+		}
+		if i > 0 {
+			shiftRules(r.rules, line, col)
+		}
+		s.Rules = append(s.Rules, r.rules...)
+	}
+	setChecksums(s.Rules)
+	return s, nil
+}
+
+// advancePosition returns the position after text, starting from line and
+// col, counting like the scanner: columns count runes, except for a byte
+// order mark at the start of the input, which counts as three.
+func advancePosition(line, col int, text string, first bool) (int, int) {
+	if first && strings.HasPrefix(text, bom) {
+		col += 2
+	}
+	if i := strings.LastIndexByte(text, '\n'); i >= 0 {
+		return line + strings.Count(text, "\n"), utf8.RuneCountInString(text[i:])
+	}
+	return line, col + utf8.RuneCountInString(text)
+}
+
+// shift returns a position relative to a part of the input that starts at
+// line and col as a position in the whole input.
+func shift(l, c, line, col int) (int, int) {
+	if l == 1 {
+		c += col - 1
+	}
+	return l + line - 1, c
+}
+
+// shiftRules shifts the positions of rules parsed from a part of the input
+// that starts at line and col.
+func shiftRules(rules []*Rule, line, col int) {
+	for _, r := range rules {
+		r.Line, r.Column = shift(r.Line, r.Column, line, col)
+		shiftValues(r.Prelude, line, col)
+		for _, d := range r.Declarations {
+			d.Line, d.Column = shift(d.Line, d.Column, line, col)
+			shiftValues(d.Value, line, col)
+		}
+		shiftRules(r.Rules, line, col)
+	}
+}
+
+// shiftValues shifts the positions of the tokens of component values.
+func shiftValues(values []*ComponentValue, line, col int) {
+	for _, v := range values {
+		v.Token.Line, v.Token.Column = shift(v.Token.Line, v.Token.Column, line, col)
+		shiftValues(v.Children, line, col)
+	}
+}
+
+// splitRules returns the offsets at which the input can be split into parts
+// of about size bytes that are parsed independently, between top-level
+// rules. The first offset is 0. It returns nil if the input has something
+// the quick scan doesn't handle, such as an unclosed string or comment.
+func splitRules(input string, size int) []int {
+	bounds := []int{0}
+	sp := newRuleSplitter()
+	for i := 0; ; {
+		end, _, ok := sp.next(input, i, true)
+		if !ok {
+			return nil
+		}
+		if end == 0 {
+			return bounds
+		}
+		if end-bounds[len(bounds)-1] >= size && end < len(input) {
+			bounds = append(bounds, end)
+		}
+		i = end
+	}
+}
+
+// ruleSplitter finds the ends of top-level rules with a quick scan that
+// only tracks brackets, strings, comments and urls, following the rules of
+// the scanner and the parser closely enough that the input before and after
+// each end can be parsed independently.
+type ruleSplitter struct {
+	// closers is the stack of expected closing brackets.
+	closers []byte
+	// atRule reports whether the current top-level rule is an at-rule,
+	// which may end with a semicolon.
+	atRule    bool
+	ruleStart bool
+}
+
+// newRuleSplitter returns a splitter for the start of an input.
+func newRuleSplitter() *ruleSplitter {
+	return &ruleSplitter{ruleStart: true}
+}
+
+// next scans the input from offset i and returns the offset after the next
+// top-level rule. If there is none, it returns 0 and the offset at which
+// to resume once more input is available; at the end of the input atEOF is
+// true and the returned offset is the length of the input. It reports false
+// if the input has something the quick scan doesn't handle, such as an
+// unclosed string or comment, after which it can't be used anymore.
+func (sp *ruleSplitter) next(input string, i int, atEOF bool) (end, resume int, ok bool) {
+	for ; i < len(input); i++ {
+		c := input[i]
+		if sp.ruleStart && c != ' ' && c != '\t' && c != '\n' && c != '/' {
+			if c == '@' && !atEOF && len(input)-i < 3 {
+				return 0, i, true
+			}
+			sp.ruleStart = false
+			sp.atRule = c == '@' && isAtKeyword(input[i+1:])
+		}
+		switch c {
+		case '/':
+			if i+1 == len(input) && !atEOF {
+				return 0, i, true
+			}
+			if i+1 < len(input) && input[i+1] == '*' {
+				n := strings.Index(input[i+2:], "*/")
+				if n < 0 {
+					return 0, i, !atEOF
+				}
+				i += n + 3
+			} else if sp.ruleStart {
+				sp.ruleStart = false
+				sp.atRule = false
+			}
+		case '"', '\'':
+			n := quotedLen(input[i:])
+			if n <= 0 {
+				return 0, i, n == 0 && !atEOF
+			}
+			i += n - 1
+		case '\\':
+			if i+1 == len(input) && !atEOF {
+				return 0, i, true
+			}
+			i++
+		case 'u', 'U':
+			n := urlLen(input, i)
+			if n < 0 && !atEOF {
+				return 0, i, true
+			}
+			if n > 0 {
+				i += n - 1
+			}
+		case '(':
+			sp.closers = append(sp.closers, ')')
+		case '[':
+			sp.closers = append(sp.closers, ']')
+		case '{':
+			sp.closers = append(sp.closers, '}')
+		case ')', ']', '}':
+			if len(sp.closers) == 0 || sp.closers[len(sp.closers)-1] != c {
+				continue
+			}
+			if c != '}' || len(sp.closers) > 1 {
+				sp.closers = sp.closers[:len(sp.closers)-1]
+				continue
+			}
+			if !atEOF && len(input)-i-1 < len(bom) {
+				return 0, i, true
+			}
+			sp.closers = sp.closers[:0]
+			if sp.endRule(input, i+1) {
+				return i + 1, i + 1, true
+			}
+		case ';':
+			if len(sp.closers) > 0 || !sp.atRule {
+				continue
+			}
+			if !atEOF && len(input)-i-1 < len(bom) {
+				return 0, i, true
+			}
+			if sp.endRule(input, i+1) {
+				return i + 1, i + 1, true
+			}
+		}
+	}
+	return 0, len(input), true
+}
+
+// endRule starts a new rule at offset i and reports whether the input can
+// be split there: a byte order mark is only skipped at the start of the
+// input.
+func (sp *ruleSplitter) endRule(input string, i int) bool {
+	sp.ruleStart = true
+	return !strings.HasPrefix(input[i:], bom)
+}
+
+// quotedLen returns the length of the string starting at the beginning of
+// input, 0 if the input ends before it is closed, or -1 if it isn't closed
+// on the same line.
+func quotedLen(input string) int {
+	quote := input[0]
+	for i := 1; i < len(input); i++ {
+		switch input[i] {
+		case quote:
+			return i + 1
+		case '\\':
+			i++
+		case '\n':
+			return -1
+		}
+	}
+	return 0
+}
+
+// urlLen returns the length of the url token starting at offset i of the
+// input, 0 if there isn't one, or -1 if the input ends before it is known,
+// following the url production of the scanner.
+func urlLen(input string, i int) int {
+	if len(input)-i < 4 {
+		if strings.HasPrefix("url(", strings.ToLower(input[i:])) {
+			return -1
+		}
+		return 0
+	}
+	if !strings.EqualFold(input[i:i+4], "url(") {
+		return 0
+	}
+	if i > 0 && (isNameByte(input[i-1]) || strings.IndexByte("@#\\", input[i-1]) >= 0) {
+		// The url is part of a longer name.
+		return 0
+	}
+	j := skipSpace(input, i+4)
+	if j < len(input) && (input[j] == '"' || input[j] == '\'') {
+		n := quotedLen(input[j:])
+		if n == 0 {
+			return -1
+		}
+		if n < 0 {
+			return 0
+		}
+		j = skipSpace(input, j+n)
+		if j == len(input) {
+			return -1
+		}
+		if input[j] == ')' {
+			return j + 1 - i
+		}
+		return 0
+	}
+	for {
+		k := skipSpace(input, j)
+		if k == len(input) {
+			return -1
+		}
+		if input[k] == ')' {
+			return k + 1 - i
+		}
+		c := input[j]
+		switch {
+		case c == '\\':
+			if j+1 == len(input) {
+				return -1
+			}
+			if input[j+1] < 0x20 || input[j+1] == 0x7f {
+				return 0
+			}
+			j += 2
+		case c >= 0x80 || c == 0x21 || c >= 0x23 && c <= 0x26 || c >= 0x28 && c <= 0x7e:
+			j++
+		default:
+			return 0
+		}
+	}
+}
+
+// isAtKeyword reports whether the input after an "@" starts with a name
+// that makes it an at-keyword. It may return false for unusual at-keywords,
+// which only prevents splitting after them.
+func isAtKeyword(rest string) bool {
+	rest = strings.TrimPrefix(rest, "-")
+	return rest != "" && (rest[0] == '_' || rest[0] >= 'a' && rest[0] <= 'z' || rest[0] >= 'A' && rest[0] <= 'Z')
+}
+
+// isNameByte reports whether c may be part of a name.
+func isNameByte(c byte) bool {
+	return c >= 0x80 || c == '-' || c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// skipSpace returns the offset of the first non-whitespace byte of the input
+// at or after i.
+func skipSpace(input string, i int) int {
+	for i < len(input) && strings.IndexByte(" \t\n", input[i]) >= 0 {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,51 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package css
+
+import "github.com/gorilla/css/diag"
+
+// Parser parses stylesheets and component values like ParseStylesheet and
+// ParseComponentValues, reusing its scanner and internal buffers from one
+// call to the next, which saves allocations when parsing many small inputs.
+// The zero value is ready to use. A Parser must not be used concurrently.
+type Parser struct {
+	// Arena, if not nil, allocates the parsed nodes.
+	Arena *Arena
+	// Limits bound the resources used to parse an input. If one is
+	// exceeded, a *LimitExceededError is returned.
+	Limits Limits
+	// OnWarning, if not nil, is called by ParseStylesheet for each problem
+	// the parser recovers from, in the order of the input: declarations
+	// dropped because they don't start with a property name and a colon,
+	// with the code "invalid-declaration", and rules dropped because the
+	// input ends before their block, with the code "incomplete-rule".
+	// They don't make parsing fail, and aren't reported once it has, such
+	// as after an exceeded limit.
+	OnWarning func(diag.Diagnostic)
+
+	p parser
+}
+
+// ParseStylesheet parses the input as a stylesheet like the ParseStylesheet
+// function.
+func (p *Parser) ParseStylesheet(input string) (*Stylesheet, error) {
+	p.reset(input)
+	return p.p.parseStylesheet()
+}
+
+// ParseComponentValues parses the input as a list of component values like
+// the ParseComponentValues function.
+func (p *Parser) ParseComponentValues(input string) ([]*ComponentValue, error) {
+	p.reset(input)
+	return p.p.parseComponentValues()
+}
+
+// reset prepares the parser for a new input.
+func (p *Parser) reset(input string) {
+	p.p.reset(input)
+	p.p.arena = p.Arena
+	p.p.limits = p.Limits
+	p.p.onWarning = p.OnWarning
+}
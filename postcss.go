@@ -0,0 +1,354 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !css_minimal
+
+package css
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gorilla/css/scanner"
+)
+
+// postcssNode is a node of the PostCSS AST, as encoded in JSON by the
+// toJSON method of PostCSS nodes:
+//
+//	https://postcss.org/api/#node
+//
+// Only the fields used by this package are decoded.
+type postcssNode struct {
+	Type      string         `json:"type"`
+	Nodes     []*postcssNode `json:"nodes"`
+	Selector  string         `json:"selector"`
+	Name      string         `json:"name"`
+	Params    string         `json:"params"`
+	Prop      string         `json:"prop"`
+	Value     string         `json:"value"`
+	Important bool           `json:"important"`
+	Text      string         `json:"text"`
+	Raws      postcssRaws    `json:"raws"`
+	Source    *postcssSource `json:"source"`
+}
+
+// postcssRaws are the raws of a node that are decoded.
+type postcssRaws struct {
+	Left     string           `json:"left"`
+	Right    string           `json:"right"`
+	Selector *postcssRawValue `json:"selector"`
+	Params   *postcssRawValue `json:"params"`
+	Value    *postcssRawValue `json:"value"`
+}
+
+// postcssRawValue is the raw text of a selector, parameters or value with
+// comments, which PostCSS strips from the text of the node.
+type postcssRawValue struct {
+	Raw string `json:"raw"`
+}
+
+// postcssSource is the source of a node.
+type postcssSource struct {
+	Start *postcssPosition `json:"start,omitempty"`
+	End   *postcssPosition `json:"end,omitempty"`
+}
+
+// postcssPosition is a position in the source of a node. PostCSS columns
+// count UTF-16 code units, which are runes for most stylesheets.
+type postcssPosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// MarshalPostCSS returns the JSON encoding of the stylesheet as a PostCSS
+// AST, the output of JSON.stringify(root) in PostCSS, so that it can be
+// used by JavaScript tooling with postcss.fromJSON.
+//
+// Rules become "rule" and "atrule" nodes, declarations "decl" nodes, and
+// the comments preceding them "comment" nodes. Selectors, parameters and
+// values are written as found in the input. The source positions and the
+// raws are those that can be recovered from the positions and raw text of
+// the rules and declarations: the whitespace between the parts of a node,
+// such as raws.between, but not the whitespace before it. PostCSS infers
+// the missing raws from the other nodes when stringifying.
+func (s *Stylesheet) MarshalPostCSS() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"type":  "root",
+		"raws":  map[string]interface{}{},
+		"nodes": postcssRules(s.Rules, nil),
+	})
+}
+
+// UnmarshalPostCSS decodes a PostCSS AST encoded in JSON by MarshalPostCSS
+// or by JSON.stringify in PostCSS. Selectors, parameters and values are
+// parsed as component values, comments become the Comments of the next
+// node, and the positions are those of the source of the nodes, if any.
+// Comments that are the last nodes of the root or of a block are dropped,
+// as ParseStylesheet drops them.
+//
+// An error is returned if the JSON isn't a PostCSS root, if it holds null
+// nodes, declarations at the root or "rule" nodes without a block, or if a
+// selector, parameters or value has an unclosed quotation mark or comment
+// or unbalanced brackets. Selectors and parameters can't hold a {}-block
+// or a semicolon either, since they would end the prelude of the rule.
+func UnmarshalPostCSS(data []byte) (*Stylesheet, error) {
+	var root postcssNode
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	if root.Type != "root" {
+		return nil, fmt.Errorf("css: PostCSS node of type %q isn't a root", root.Type)
+	}
+	rules, decls, err := fromPostCSS(root.Nodes)
+	if err != nil {
+		return nil, err
+	}
+	if len(decls) > 0 {
+		return nil, fmt.Errorf("css: PostCSS declaration of %q at the root", decls[0].Property)
+	}
+	return &Stylesheet{Rules: rules}, nil
+}
+
+// postcssRules appends the nodes of rules and of their comments to nodes.
+func postcssRules(rules []*Rule, nodes []interface{}) []interface{} {
+	if nodes == nil {
+		nodes = []interface{}{}
+	}
+	for _, r := range rules {
+		nodes = postcssComments(r.Comments, nodes)
+		nodes = append(nodes, postcssRule(r))
+	}
+	return nodes
+}
+
+// postcssRule returns the node of a rule. The declarations of its block
+// come before its nested rules, as in the Rule.
+func postcssRule(r *Rule) map[string]interface{} {
+	prelude := ValuesString(r.Prelude)
+	raws := map[string]interface{}{}
+	n := map[string]interface{}{"raws": raws}
+	if r.IsAtRule() {
+		n["type"], n["name"], n["params"] = "atrule", r.AtKeyword, prelude
+	} else {
+		n["type"], n["selector"] = "rule", prelude
+	}
+	if source := postcssSourceOf(r.Line, r.Column, r.Raw); source != nil {
+		n["source"] = source
+	}
+	if r.HasBlock {
+		nodes := []interface{}{}
+		for _, d := range r.Declarations {
+			nodes = postcssComments(d.Comments, nodes)
+			nodes = append(nodes, postcssDeclaration(d))
+		}
+		n["nodes"] = postcssRules(r.Rules, nodes)
+	}
+
+	// The raws are recovered from the raw text of the rule, if the prelude
+	// is found in it as is.
+	rest := r.Raw
+	if r.IsAtRule() {
+		if !strings.HasPrefix(rest, "@"+r.AtKeyword) {
+			return n
+		}
+		rest = rest[len(r.AtKeyword)+1:]
+		// Without parameters, the whitespace is before the block.
+		raws["afterName"] = ""
+		if prelude != "" {
+			raws["afterName"] = leadingSpace(rest)
+			rest = rest[len(leadingSpace(rest)):]
+		}
+	}
+	if !strings.HasPrefix(rest, prelude) {
+		return n
+	}
+	rest = rest[len(prelude):]
+	if i := strings.IndexByte(rest, '{'); i >= 0 && r.HasBlock && strings.HasSuffix(rest, "}") {
+		block := rest[i+1 : len(rest)-1]
+		inner := strings.TrimRight(block, " \t\n")
+		raws["between"] = rest[:i]
+		raws["after"] = block[len(inner):]
+		raws["semicolon"] = strings.HasSuffix(inner, ";")
+	}
+	return n
+}
+
+// postcssDeclaration returns the node of a declaration.
+func postcssDeclaration(d *Declaration) map[string]interface{} {
+	raws := map[string]interface{}{}
+	n := map[string]interface{}{
+		"type":  "decl",
+		"prop":  d.Property,
+		"value": ValuesString(d.Value),
+		"raws":  raws,
+	}
+	if d.Important {
+		n["important"] = true
+	}
+	if rest := strings.TrimPrefix(d.Raw, d.Property); len(rest) < len(d.Raw) {
+		if i := strings.IndexByte(rest, ':'); i >= 0 {
+			raws["between"] = rest[:i+1] + leadingSpace(rest[i+1:])
+		}
+		if d.Important {
+			value := ValuesString(d.Value)
+			if i := strings.LastIndex(rest, value); i >= 0 && value != "" {
+				raws["important"] = strings.TrimRight(rest[i+len(value):], " \t\n")
+			}
+		}
+	}
+	if source := postcssSourceOf(d.Line, d.Column, d.Raw); source != nil {
+		n["source"] = source
+	}
+	return n
+}
+
+// postcssComments appends the nodes of comments to nodes.
+func postcssComments(comments []string, nodes []interface{}) []interface{} {
+	for _, c := range comments {
+		text := strings.TrimSuffix(strings.TrimPrefix(c, "/*"), "*/")
+		trimmed := strings.TrimSpace(text)
+		left := leadingSpace(text)
+		nodes = append(nodes, map[string]interface{}{
+			"type": "comment",
+			"text": trimmed,
+			"raws": map[string]interface{}{
+				"left":  left,
+				"right": text[len(left)+len(trimmed):],
+			},
+		})
+	}
+	return nodes
+}
+
+// postcssSourceOf returns the source of a node at line and col whose raw
+// text is raw, or nil if the node has no position. The end is the position
+// of the last character, as in PostCSS.
+func postcssSourceOf(line, col int, raw string) *postcssSource {
+	if line == 0 {
+		return nil
+	}
+	s := &postcssSource{Start: &postcssPosition{line, col}}
+	if raw != "" {
+		endLine, endCol := advance(line, col, raw)
+		s.End = &postcssPosition{endLine, endCol - 1}
+	}
+	return s
+}
+
+// leadingSpace returns the whitespace at the start of s.
+func leadingSpace(s string) string {
+	return s[:len(s)-len(strings.TrimLeft(s, " \t\n"))]
+}
+
+// fromPostCSS returns the rules and the declarations of a list of PostCSS
+// nodes.
+func fromPostCSS(nodes []*postcssNode) ([]*Rule, []*Declaration, error) {
+	var rules []*Rule
+	var decls []*Declaration
+	var comments []string
+	for _, n := range nodes {
+		if n == nil {
+			return nil, nil, errors.New("css: null PostCSS node")
+		}
+		line, col := 0, 0
+		if n.Source != nil && n.Source.Start != nil {
+			line, col = n.Source.Start.Line, n.Source.Start.Column
+		}
+		switch n.Type {
+		case "comment":
+			comments = append(comments, "/*"+n.Raws.Left+n.Text+n.Raws.Right+"*/")
+		case "decl":
+			value, err := parsePostCSSValue("value", n.Value, n.Raws.Value)
+			if err != nil {
+				return nil, nil, err
+			}
+			decls = append(decls, &Declaration{
+				Property:  n.Prop,
+				Value:     value,
+				Important: n.Important,
+				Line:      line,
+				Column:    col,
+				Comments:  comments,
+			})
+			comments = nil
+		case "rule", "atrule":
+			if n.Type == "rule" && n.Nodes == nil {
+				return nil, nil, fmt.Errorf("css: PostCSS rule %q without a block", n.Selector)
+			}
+			r := &Rule{Line: line, Column: col, Comments: comments, HasBlock: n.Nodes != nil}
+			comments = nil
+			var err error
+			if n.Type == "rule" {
+				r.Prelude, err = parsePostCSSValue("selector", n.Selector, n.Raws.Selector)
+			} else {
+				r.AtKeyword = n.Name
+				r.Prelude, err = parsePostCSSValue("params", n.Params, n.Raws.Params)
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, v := range r.Prelude {
+				if v.IsBlock() && v.Token.Value == "{" || v.Token.Type == scanner.TokenChar && v.Token.Value == ";" {
+					return nil, nil, fmt.Errorf("css: PostCSS %s %q with a {}-block or a semicolon", n.Type, ValuesString(r.Prelude))
+				}
+			}
+			if r.Rules, r.Declarations, err = fromPostCSS(n.Nodes); err != nil {
+				return nil, nil, err
+			}
+			rules = append(rules, r)
+		default:
+			return nil, nil, fmt.Errorf("css: unknown PostCSS node type %q", n.Type)
+		}
+	}
+	return rules, decls, nil
+}
+
+// parsePostCSSValue parses a selector, parameters or value, as told by
+// what, preferring its raw text with comments, if any.
+func parsePostCSSValue(what, text string, raw *postcssRawValue) ([]*ComponentValue, error) {
+	if raw != nil && raw.Raw != "" {
+		text = raw.Raw
+	}
+	values, err := ParseComponentValues(text)
+	if err != nil {
+		return nil, err
+	}
+	if !balanced(text) {
+		return nil, fmt.Errorf("css: PostCSS %s %q with unbalanced brackets", what, text)
+	}
+	return TrimSpace(values), nil
+}
+
+// balanced reports whether the brackets of the text, outside of strings
+// and comments, are balanced. ParseComponentValues closes the blocks left
+// open implicitly, so that they would be written back differently.
+func balanced(text string) bool {
+	var open []byte
+	s := scanner.New(text)
+	for {
+		t := s.Next()
+		switch t.Type {
+		case scanner.TokenEOF, scanner.TokenError:
+			return len(open) == 0
+		case scanner.TokenFunction:
+			open = append(open, ')')
+		case scanner.TokenChar:
+			switch t.Value {
+			case "(":
+				open = append(open, ')')
+			case "[":
+				open = append(open, ']')
+			case "{":
+				open = append(open, '}')
+			case ")", "]", "}":
+				if len(open) == 0 || open[len(open)-1] != t.Value[0] {
+					return false
+				}
+				open = open[:len(open)-1]
+			}
+		}
+	}
+}
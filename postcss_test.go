@@ -0,0 +1,154 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !css_minimal
+
+package css
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMarshalPostCSS(t *testing.T) {
+	s, err := ParseStylesheet("/* a */\na {\n  color : red !important;\n  b { x: y }\n}\n@media  print{c{}}\n@import url(d.css);\n@font-face {}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := s.MarshalPostCSS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var root map[string]interface{}
+	if err := json.Unmarshal(b, &root); err != nil {
+		t.Fatal(err)
+	}
+	tcs := []struct {
+		path     []interface{}
+		expected interface{}
+	}{
+		{[]interface{}{0, "type"}, "comment"},
+		{[]interface{}{0, "text"}, "a"},
+		{[]interface{}{0, "raws", "left"}, " "},
+		{[]interface{}{1, "type"}, "rule"},
+		{[]interface{}{1, "selector"}, "a"},
+		{[]interface{}{1, "raws", "between"}, " "},
+		{[]interface{}{1, "raws", "after"}, "\n"},
+		{[]interface{}{1, "source", "start", "line"}, 2.0},
+		{[]interface{}{1, "source", "end", "line"}, 5.0},
+		{[]interface{}{1, "source", "end", "column"}, 1.0},
+		{[]interface{}{1, "nodes", 0, "type"}, "decl"},
+		{[]interface{}{1, "nodes", 0, "prop"}, "color"},
+		{[]interface{}{1, "nodes", 0, "value"}, "red"},
+		{[]interface{}{1, "nodes", 0, "important"}, true},
+		{[]interface{}{1, "nodes", 0, "raws", "between"}, " : "},
+		{[]interface{}{1, "nodes", 0, "raws", "important"}, " !important"},
+		{[]interface{}{1, "nodes", 1, "selector"}, "b"},
+		{[]interface{}{1, "nodes", 1, "raws", "semicolon"}, false},
+		{[]interface{}{2, "type"}, "atrule"},
+		{[]interface{}{2, "name"}, "media"},
+		{[]interface{}{2, "params"}, "print"},
+		{[]interface{}{2, "raws", "afterName"}, "  "},
+		{[]interface{}{2, "raws", "between"}, ""},
+		{[]interface{}{3, "params"}, "url(d.css)"},
+		{[]interface{}{3, "nodes"}, nil},
+		{[]interface{}{4, "params"}, ""},
+		{[]interface{}{4, "raws", "afterName"}, ""},
+		{[]interface{}{4, "raws", "between"}, " "},
+		{[]interface{}{4, "nodes"}, []interface{}{}},
+	}
+	if nodes, ok := root["nodes"].([]interface{}); root["type"] != "root" || !ok || len(nodes) != 5 {
+		t.Fatalf("got %s, want a root with 5 nodes", b)
+	}
+	for _, tc := range tcs {
+		v := root["nodes"]
+		for _, p := range tc.path {
+			switch p := p.(type) {
+			case int:
+				v = v.([]interface{})[p]
+			case string:
+				v = v.(map[string]interface{})[p]
+			}
+		}
+		if !reflect.DeepEqual(v, tc.expected) {
+			t.Errorf("%v: got %#v, want %#v", tc.path, v, tc.expected)
+		}
+	}
+}
+
+func TestUnmarshalPostCSS(t *testing.T) {
+	// The output of JSON.stringify(postcss.parse(...)) for
+	// "/* a */ a, b { color: red /* b */ !important } @media print { c {} } @charset \"utf-8\";".
+	input := `{"raws":{"semicolon":false,"after":""},"type":"root","nodes":[
+		{"raws":{"before":"","left":" ","right":" "},"type":"comment","text":"a","source":{"inputId":0,"start":{"offset":0,"line":1,"column":1},"end":{"offset":6,"line":1,"column":7}}},
+		{"raws":{"before":" ","between":" ","semicolon":false,"after":" "},"type":"rule","nodes":[
+			{"raws":{"before":" ","between":": ","value":{"value":"red","raw":"red /* b */"}},"type":"decl","source":{"inputId":0,"start":{"offset":15,"line":1,"column":16},"end":{"offset":45,"line":1,"column":46}},"prop":"color","value":"red","important":true}
+		],"source":{"inputId":0,"start":{"offset":8,"line":1,"column":9},"end":{"offset":46,"line":1,"column":47}},"selector":"a, b","lastEach":1,"indexes":{}},
+		{"raws":{"before":" ","between":"","afterName":" ","semicolon":false,"after":" "},"type":"atrule","name":"media","source":{"inputId":0,"start":{"offset":48,"line":1,"column":49}},"params":"print","nodes":[
+			{"raws":{"before":" ","between":" ","semicolon":false,"after":""},"type":"rule","nodes":[],"selector":"c"}
+		]},
+		{"raws":{"before":" ","between":"","afterName":" "},"type":"atrule","name":"charset","params":"\"utf-8\""}
+	],"source":{"inputId":0,"start":{"offset":0,"line":1,"column":1}},"inputs":[{"hasBOM":false,"css":"","id":"<input css 1>"}]}`
+	s, err := UnmarshalPostCSS([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `/* a */a, b{color:red!important}@media print{c{}}@charset "utf-8";`
+	if s.String() != expected {
+		t.Errorf("got %q, want %q", s.String(), expected)
+	}
+	if r := s.Rules[0]; r.Line != 1 || r.Column != 9 || r.Declarations[0].Column != 16 {
+		t.Errorf("got positions %d:%d and %d:%d, want 1:9 and 1:16", r.Line, r.Column, r.Declarations[0].Line, r.Declarations[0].Column)
+	}
+
+	// Stylesheets round-trip through the PostCSS AST.
+	for name, input := range corpus(t) {
+		s, err := ParseStylesheet(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := s.MarshalPostCSS()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		again, err := UnmarshalPostCSS(b)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if again.String() != s.String() {
+			t.Errorf("%s: the stylesheet doesn't round-trip", name)
+		}
+	}
+
+	// Trailing comments are dropped, as by ParseStylesheet.
+	s, err = UnmarshalPostCSS([]byte(`{"type":"root","nodes":[{"type":"rule","selector":"a","nodes":[{"type":"comment","text":"b"}]},{"type":"comment","text":"c"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.String() != "a{}" {
+		t.Errorf("got %q, want %q", s.String(), "a{}")
+	}
+
+	for _, input := range []string{
+		`{"type":"decl"}`,
+		`{"type":"root","nodes":[{"type":"document"}]}`,
+		`{"type":"root","nodes":[{"type":"rule","selector":"'a","nodes":[]}]}`,
+		`{"type":"root","nodes":[null]}`,
+		`{"type":"root","nodes":[{"type":"rule","selector":"a","nodes":[null]}]}`,
+		`{"type":"root","nodes":[{"type":"rule","selector":"a{","nodes":[]}]}`,
+		`{"type":"root","nodes":[{"type":"rule","selector":"a{}","nodes":[]}]}`,
+		`{"type":"root","nodes":[{"type":"rule","selector":"a;b","nodes":[]}]}`,
+		`{"type":"root","nodes":[{"type":"rule","selector":"a)","nodes":[]}]}`,
+		`{"type":"root","nodes":[{"type":"atrule","name":"media","params":"(print","nodes":[]}]}`,
+		`{"type":"root","nodes":[{"type":"rule","selector":"a","nodes":[{"type":"decl","prop":"b","value":"c}"}]}]}`,
+		`{"type":"root","nodes":[{"type":"rule","selector":"a"}]}`,
+		`{"type":"root","nodes":[{"type":"decl","prop":"color","value":"red"}]}`,
+		`[`,
+	} {
+		if _, err := UnmarshalPostCSS([]byte(input)); err == nil {
+			t.Errorf("%s: expected an error", input)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prefix
+
+import (
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/scanner"
+)
+
+// Add inserts the prefixed alternatives from the Features table that are
+// needed by the targets, and returns the number of inserted declarations and
+// rules.
+//
+// Alternatives are inserted before the unprefixed declaration or rule so that
+// browsers supporting the standard syntax use it. Alternatives already
+// present in the stylesheet are not duplicated.
+func Add(s *css.Stylesheet, targets Targets) int {
+	return AddFeatures(s, targets, Features)
+}
+
+// AddFeatures is like Add but uses the given feature table.
+func AddFeatures(s *css.Stylesheet, targets Targets, features []Feature) int {
+	a := &adder{}
+	seen := map[Feature]bool{}
+	for _, f := range features {
+		// Only the first entry for an alternative is kept.
+		key := f
+		key.Browser, key.Until = "", 0
+		if targets.needs(f.Browser, f.Until) && !seen[key] {
+			seen[key] = true
+			a.features = append(a.features, f)
+		}
+	}
+	s.Rules = a.rules(s.Rules)
+	return a.n
+}
+
+// adder inserts prefixed alternatives for a list of features.
+type adder struct {
+	features []Feature
+	n        int
+}
+
+// rules inserts prefixed alternatives in a list of sibling rules and their
+// contents.
+func (a *adder) rules(rules []*css.Rule) []*css.Rule {
+	var out []*css.Rule
+	for _, r := range rules {
+		r.Declarations = a.declarations(r.Declarations)
+		r.Rules = a.rules(r.Rules)
+		for _, f := range a.features {
+			if c := ruleAlternative(r, f); c != nil && !hasRule(rules, c) {
+				out = append(out, c)
+				a.n++
+			}
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// declarations inserts prefixed alternatives in a list of sibling
+// declarations.
+func (a *adder) declarations(decls []*css.Declaration) []*css.Declaration {
+	var out []*css.Declaration
+	for _, d := range decls {
+		for _, f := range a.features {
+			if c := declarationAlternative(d, f); c != nil && !hasDeclaration(decls, c, f.Kind) {
+				out = append(out, c)
+				a.n++
+			}
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+// ruleAlternative returns the prefixed alternative of r for the feature f, or
+// nil if the feature doesn't apply to r.
+func ruleAlternative(r *css.Rule, f Feature) *css.Rule {
+	switch {
+	case f.Kind == AtRule && strings.EqualFold(r.AtKeyword, f.Name):
+		c := r.Clone()
+		c.AtKeyword = f.Prefixed
+		return c
+	case f.Kind == Selector && !r.IsAtRule():
+		if prelude := replacePseudo(r.Prelude, f.Name, f.Prefixed); prelude != nil {
+			c := r.Clone()
+			c.Prelude = prelude
+			return c
+		}
+	}
+	return nil
+}
+
+// declarationAlternative returns the prefixed alternative of d for the
+// feature f, or nil if the feature doesn't apply to d.
+func declarationAlternative(d *css.Declaration, f Feature) *css.Declaration {
+	switch f.Kind {
+	case Property:
+		if strings.EqualFold(d.Property, f.Name) {
+			c := d.Clone()
+			c.Property = f.Prefixed
+			return c
+		}
+	case Value:
+		if strings.EqualFold(d.Property, f.Name) && len(d.Value) == 1 &&
+			d.Value[0].Token.Type == scanner.TokenIdent &&
+			strings.EqualFold(d.Value[0].Token.Value, f.Value) {
+			c := d.Clone()
+			c.Value[0].Token.Value = f.Prefixed
+			return c
+		}
+	case Function:
+		c := d.Clone()
+		if renameFunction(c.Value, f.Name, f.Prefixed) {
+			return c
+		}
+	}
+	return nil
+}
+
+// hasRule reports whether a rule equivalent to r is in the list.
+func hasRule(rules []*css.Rule, r *css.Rule) bool {
+	for _, sibling := range rules {
+		if strings.EqualFold(sibling.AtKeyword, r.AtKeyword) &&
+			css.ValuesString(sibling.Prelude) == css.ValuesString(r.Prelude) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDeclaration reports whether a declaration equivalent to d is in the
+// list. For Property features any declaration of the same property is
+// equivalent.
+func hasDeclaration(decls []*css.Declaration, d *css.Declaration, k Kind) bool {
+	for _, sibling := range decls {
+		if strings.EqualFold(sibling.Property, d.Property) &&
+			(k == Property || css.ValuesString(sibling.Value) == css.ValuesString(d.Value)) {
+			return true
+		}
+	}
+	return false
+}
+
+// renameFunction renames the functions called name, at any depth, and
+// reports whether any was found.
+func renameFunction(values []*css.ComponentValue, name, prefixed string) bool {
+	var found bool
+	for _, v := range values {
+		if strings.EqualFold(v.Name(), name) {
+			v.Token.Value = prefixed + "("
+			found = true
+		}
+		if renameFunction(v.Children, name, prefixed) {
+			found = true
+		}
+	}
+	return found
+}
+
+// replacePseudo returns a copy of a selector list where the top-level
+// pseudo-class or pseudo-element pseudo, such as "::placeholder", is
+// replaced by prefixed. It returns nil if the selector list doesn't have it.
+func replacePseudo(prelude []*css.ComponentValue, pseudo, prefixed string) []*css.ComponentValue {
+	colons := len(pseudo) - len(strings.TrimLeft(pseudo, ":"))
+	name := pseudo[colons:]
+	var out []*css.ComponentValue
+	var found bool
+	for i := 0; i < len(prelude); i++ {
+		if matchPseudo(prelude, i, colons, name) {
+			values, _ := css.ParseComponentValues(prefixed)
+			out = append(out, values...)
+			i += colons
+			found = true
+			continue
+		}
+		out = append(out, prelude[i].Clone())
+	}
+	if !found {
+		return nil
+	}
+	return out
+}
+
+// matchPseudo reports whether the values at position i are the given number
+// of colons followed by the identifier name, and are not preceded by another
+// colon.
+func matchPseudo(values []*css.ComponentValue, i, colons int, name string) bool {
+	if i > 0 && isColon(values[i-1]) || i+colons >= len(values) {
+		return false
+	}
+	for j := i; j < i+colons; j++ {
+		if !isColon(values[j]) {
+			return false
+		}
+	}
+	t := values[i+colons].Token
+	return t.Type == scanner.TokenIdent && strings.EqualFold(t.Value, name)
+}
+
+// isColon reports whether v is a colon.
+func isColon(v *css.ComponentValue) bool {
+	return v.Token.Type == scanner.TokenChar && v.Token.Value == ":"
+}
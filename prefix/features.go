@@ -0,0 +1,160 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prefix
+
+// Browser identifies a browser family.
+type Browser string
+
+// Browsers known by the feature table.
+const (
+	Chrome  Browser = "chrome"
+	Edge    Browser = "edge"
+	Firefox Browser = "firefox"
+	IE      Browser = "ie"
+	IOS     Browser = "ios"
+	Safari  Browser = "safari"
+)
+
+// Targets maps browsers to the oldest version that must be supported.
+// Browsers that are not in the map are not supported.
+type Targets map[Browser]float64
+
+// needs reports whether the targets include a version of the browser b older
+// than until. An until version of zero means all versions.
+func (t Targets) needs(b Browser, until float64) bool {
+	v, ok := t[b]
+	return ok && (until == 0 || v < until)
+}
+
+// Kind is the kind of name a feature applies to.
+type Kind int
+
+const (
+	// Property features add a declaration with a prefixed property.
+	Property Kind = iota
+	// Value features add a declaration with a prefixed keyword value for
+	// a property.
+	Value
+	// Function features add a declaration with a prefixed function in the
+	// value.
+	Function
+	// AtRule features add a prefixed copy of an at-rule.
+	AtRule
+	// Selector features add a copy of a style rule with a prefixed
+	// pseudo-class or pseudo-element.
+	Selector
+)
+
+// Feature is an entry of the prefix table: a prefixed alternative for a name
+// that is needed by versions of a browser older than a given version.
+type Feature struct {
+	Kind Kind
+	// Name is the unprefixed property, function or at-rule name, or the
+	// pseudo-class or pseudo-element including its colons. For Value
+	// features it is the property name.
+	Name string
+	// Value is the unprefixed keyword of Value features.
+	Value string
+	// Prefixed is the alternative to insert. It replaces Value for Value
+	// features and Name for the other kinds.
+	Prefixed string
+	Browser  Browser
+	// Until is the first version that doesn't need the prefixed
+	// alternative. Zero means that all versions need it.
+	Until float64
+}
+
+// Features is the default prefix table used by Add. It is a conservative
+// subset of the features that still need prefixes in supported browsers.
+var Features = []Feature{
+	// Properties.
+	{Property, "appearance", "", "-webkit-appearance", Chrome, 84},
+	{Property, "appearance", "", "-webkit-appearance", Safari, 15.4},
+	{Property, "appearance", "", "-webkit-appearance", IOS, 15.4},
+	{Property, "appearance", "", "-moz-appearance", Firefox, 80},
+	{Property, "backdrop-filter", "", "-webkit-backdrop-filter", Safari, 18},
+	{Property, "backdrop-filter", "", "-webkit-backdrop-filter", IOS, 18},
+	{Property, "box-decoration-break", "", "-webkit-box-decoration-break", Chrome, 130},
+	{Property, "box-decoration-break", "", "-webkit-box-decoration-break", Safari, 0},
+	{Property, "box-decoration-break", "", "-webkit-box-decoration-break", IOS, 0},
+	{Property, "hyphens", "", "-webkit-hyphens", Safari, 17},
+	{Property, "hyphens", "", "-webkit-hyphens", IOS, 17},
+	{Property, "hyphens", "", "-ms-hyphens", IE, 0},
+	{Property, "text-size-adjust", "", "-webkit-text-size-adjust", IOS, 0},
+	{Property, "user-select", "", "-webkit-user-select", Safari, 0},
+	{Property, "user-select", "", "-webkit-user-select", IOS, 0},
+	{Property, "user-select", "", "-moz-user-select", Firefox, 69},
+	{Property, "user-select", "", "-ms-user-select", IE, 0},
+	{Property, "user-select", "", "-ms-user-select", Edge, 79},
+	{Property, "mask", "", "-webkit-mask", Chrome, 120},
+	{Property, "mask", "", "-webkit-mask", Edge, 120},
+	{Property, "mask", "", "-webkit-mask", Safari, 15.4},
+	{Property, "mask", "", "-webkit-mask", IOS, 15.4},
+	{Property, "mask-image", "", "-webkit-mask-image", Chrome, 120},
+	{Property, "mask-image", "", "-webkit-mask-image", Edge, 120},
+	{Property, "mask-image", "", "-webkit-mask-image", Safari, 15.4},
+	{Property, "mask-image", "", "-webkit-mask-image", IOS, 15.4},
+	{Property, "mask-position", "", "-webkit-mask-position", Chrome, 120},
+	{Property, "mask-position", "", "-webkit-mask-position", Edge, 120},
+	{Property, "mask-position", "", "-webkit-mask-position", Safari, 15.4},
+	{Property, "mask-position", "", "-webkit-mask-position", IOS, 15.4},
+	{Property, "mask-repeat", "", "-webkit-mask-repeat", Chrome, 120},
+	{Property, "mask-repeat", "", "-webkit-mask-repeat", Edge, 120},
+	{Property, "mask-repeat", "", "-webkit-mask-repeat", Safari, 15.4},
+	{Property, "mask-repeat", "", "-webkit-mask-repeat", IOS, 15.4},
+	{Property, "mask-size", "", "-webkit-mask-size", Chrome, 120},
+	{Property, "mask-size", "", "-webkit-mask-size", Edge, 120},
+	{Property, "mask-size", "", "-webkit-mask-size", Safari, 15.4},
+	{Property, "mask-size", "", "-webkit-mask-size", IOS, 15.4},
+	// Legacy flexbox.
+	{Value, "display", "flex", "-webkit-box", Safari, 6.1},
+	{Value, "display", "flex", "-webkit-box", IOS, 7},
+	{Value, "display", "flex", "-webkit-flex", Safari, 9},
+	{Value, "display", "flex", "-webkit-flex", IOS, 9},
+	{Value, "display", "flex", "-ms-flexbox", IE, 11},
+	{Value, "display", "inline-flex", "-webkit-inline-box", Safari, 6.1},
+	{Value, "display", "inline-flex", "-webkit-inline-box", IOS, 7},
+	{Value, "display", "inline-flex", "-webkit-inline-flex", Safari, 9},
+	{Value, "display", "inline-flex", "-webkit-inline-flex", IOS, 9},
+	{Value, "display", "inline-flex", "-ms-inline-flexbox", IE, 11},
+	{Property, "flex", "", "-webkit-flex", Safari, 9},
+	{Property, "flex", "", "-webkit-flex", IOS, 9},
+	{Property, "flex", "", "-ms-flex", IE, 11},
+	{Property, "flex-direction", "", "-webkit-flex-direction", Safari, 9},
+	{Property, "flex-direction", "", "-webkit-flex-direction", IOS, 9},
+	{Property, "flex-direction", "", "-ms-flex-direction", IE, 11},
+	{Property, "flex-wrap", "", "-webkit-flex-wrap", Safari, 9},
+	{Property, "flex-wrap", "", "-webkit-flex-wrap", IOS, 9},
+	{Property, "flex-wrap", "", "-ms-flex-wrap", IE, 11},
+	{Property, "order", "", "-webkit-order", Safari, 9},
+	{Property, "order", "", "-webkit-order", IOS, 9},
+	{Property, "order", "", "-ms-flex-order", IE, 11},
+	// Other values.
+	{Value, "position", "sticky", "-webkit-sticky", Safari, 13},
+	{Value, "position", "sticky", "-webkit-sticky", IOS, 13},
+	{Function, "image-set", "", "-webkit-image-set", Chrome, 113},
+	{Function, "image-set", "", "-webkit-image-set", Edge, 113},
+	{Function, "image-set", "", "-webkit-image-set", Safari, 14},
+	{Function, "image-set", "", "-webkit-image-set", IOS, 14},
+	// At-rules.
+	{AtRule, "keyframes", "", "-webkit-keyframes", Chrome, 43},
+	{AtRule, "keyframes", "", "-webkit-keyframes", Safari, 9},
+	{AtRule, "keyframes", "", "-webkit-keyframes", IOS, 9},
+	// Selectors.
+	{Selector, "::placeholder", "", "::-webkit-input-placeholder", Chrome, 57},
+	{Selector, "::placeholder", "", "::-webkit-input-placeholder", Safari, 10.1},
+	{Selector, "::placeholder", "", "::-webkit-input-placeholder", IOS, 10.3},
+	{Selector, "::placeholder", "", "::-moz-placeholder", Firefox, 51},
+	{Selector, "::placeholder", "", ":-ms-input-placeholder", IE, 0},
+	{Selector, "::placeholder", "", "::-ms-input-placeholder", Edge, 79},
+	{Selector, ":fullscreen", "", ":-webkit-full-screen", Safari, 16.4},
+	{Selector, ":fullscreen", "", ":-webkit-full-screen", IOS, 16.4},
+	{Selector, ":fullscreen", "", ":-moz-full-screen", Firefox, 64},
+	{Selector, ":fullscreen", "", ":-ms-fullscreen", IE, 0},
+	{Selector, "::selection", "", "::-moz-selection", Firefox, 62},
+	{Selector, "::file-selector-button", "", "::-webkit-file-upload-button", Chrome, 89},
+	{Selector, "::file-selector-button", "", "::-webkit-file-upload-button", Safari, 14.1},
+	{Selector, "::file-selector-button", "", "::-webkit-file-upload-button", IOS, 14.5},
+}
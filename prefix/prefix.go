@@ -0,0 +1,176 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package gorilla/css/prefix detects and removes vendor prefixes such as
+"-webkit-" or "-moz-".
+
+Names can be properties, at-rules, functions or pseudo-classes. The leading
+"@" or colons and the trailing parenthesis are kept in the unprefixed name:
+
+	prefix.Split("-webkit-transition")      // "-webkit-", "transition"
+	prefix.Split("@-moz-keyframes")         // "-moz-", "@keyframes"
+	prefix.Split("::-moz-selection")        // "-moz-", "::selection"
+	prefix.Split("-webkit-linear-gradient(") // "-webkit-", "linear-gradient("
+
+StripDuplicates removes prefixed declarations from a stylesheet when an
+unprefixed equivalent follows them in the same block, and prefixed at-rules
+with an unprefixed sibling.
+
+Add does the opposite: it inserts the prefixed alternatives that browsers in
+a target matrix still need, based on the Features table:
+
+	prefix.Add(sheet, prefix.Targets{prefix.Safari: 15, prefix.Chrome: 110})
+*/
+package prefix
+
+import (
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/scanner"
+)
+
+// Vendor prefixes.
+const (
+	Webkit = "-webkit-"
+	Moz    = "-moz-"
+	MS     = "-ms-"
+	O      = "-o-"
+)
+
+// prefixes is the list of known vendor prefixes.
+var prefixes = []string{Webkit, Moz, MS, O}
+
+// Split returns the lowercase vendor prefix of a name and the name without
+// it. If the name has no known vendor prefix, the prefix is empty and the
+// name is returned unchanged.
+func Split(name string) (prefix, unprefixed string) {
+	lead := len(name) - len(strings.TrimLeft(name, "@:"))
+	for _, p := range prefixes {
+		if len(name)-lead > len(p) && strings.EqualFold(name[lead:lead+len(p)], p) {
+			return p, name[:lead] + name[lead+len(p):]
+		}
+	}
+	return "", name
+}
+
+// Has reports whether the name has a known vendor prefix.
+func Has(name string) bool {
+	p, _ := Split(name)
+	return p != ""
+}
+
+// Strip returns the name without its vendor prefix.
+func Strip(name string) string {
+	_, unprefixed := Split(name)
+	return unprefixed
+}
+
+// HasValue reports whether any identifier or function in the values, at any
+// depth, has a vendor prefix.
+func HasValue(values []*css.ComponentValue) bool {
+	for _, v := range values {
+		switch v.Token.Type {
+		case scanner.TokenIdent, scanner.TokenFunction:
+			if Has(v.Token.Value) {
+				return true
+			}
+		}
+		if HasValue(v.Children) {
+			return true
+		}
+	}
+	return false
+}
+
+// StripDuplicates removes prefixed declarations and at-rules that duplicate
+// an unprefixed sibling, and returns the number of removed items.
+//
+// A declaration is prefixed if its property or any identifier or function in
+// its value is prefixed, as in "-webkit-transition: none" or
+// "display: -webkit-box". It is removed if the same block declares the
+// unprefixed property with an unprefixed value after it, which overrides it
+// in browsers supporting both; a prefixed declaration following the
+// unprefixed one is kept, since it overrides it. A prefixed at-rule is removed
+// if a sibling unprefixed at-rule has the same prelude, as with
+// "@-webkit-keyframes spin" and "@keyframes spin".
+func StripDuplicates(s *css.Stylesheet) int {
+	var n int
+	s.Rules, n = stripRules(s.Rules)
+	return n
+}
+
+// stripRules strips duplicates from a list of sibling rules and their
+// contents.
+func stripRules(rules []*css.Rule) ([]*css.Rule, int) {
+	unprefixed := map[string]bool{}
+	for _, r := range rules {
+		if r.IsAtRule() && !Has(r.AtKeyword) {
+			unprefixed[atRuleKey(r.AtKeyword, r)] = true
+		}
+	}
+	var n int
+	kept := rules[:0]
+	for _, r := range rules {
+		if r.IsAtRule() && Has(r.AtKeyword) && unprefixed[atRuleKey(Strip(r.AtKeyword), r)] {
+			n++
+			continue
+		}
+		var m int
+		r.Declarations, m = stripDeclarations(r.Declarations)
+		n += m
+		r.Rules, m = stripRules(r.Rules)
+		n += m
+		kept = append(kept, r)
+	}
+	return kept, n
+}
+
+// atRuleKey returns a key identifying an at-rule with the given name and the
+// prelude of r.
+func atRuleKey(name string, r *css.Rule) string {
+	return strings.ToLower(name) + " " + css.ValuesString(r.Prelude)
+}
+
+// stripDeclarations strips duplicates from a list of sibling declarations.
+// A prefixed declaration is only a duplicate if an unprefixed one follows
+// it, with at least its importance: one following the unprefixed
+// declaration overrides it, as in "width: 100%; width: -webkit-fill-available".
+func stripDeclarations(decls []*css.Declaration) ([]*css.Declaration, int) {
+	// overridden[i] reports whether an unprefixed declaration of the
+	// property of decls[i] follows it.
+	overridden := make([]bool, len(decls))
+	later := map[string]bool{}
+	for i := len(decls) - 1; i >= 0; i-- {
+		d := decls[i]
+		if !isPrefixed(d) {
+			if d.Important {
+				later[strings.ToLower(d.Property)+"!"] = true
+			}
+			later[strings.ToLower(d.Property)] = true
+			continue
+		}
+		key := strings.ToLower(Strip(d.Property))
+		if d.Important {
+			key += "!"
+		}
+		overridden[i] = later[key]
+	}
+	var n int
+	kept := decls[:0]
+	for i, d := range decls {
+		if overridden[i] {
+			n++
+			continue
+		}
+		kept = append(kept, d)
+	}
+	return kept, n
+}
+
+// isPrefixed reports whether a declaration has a prefixed property or value.
+func isPrefixed(d *css.Declaration) bool {
+	return Has(d.Property) || HasValue(d.Value)
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prefix
+
+import (
+	"testing"
+
+	"github.com/gorilla/css"
+)
+
+func TestSplit(t *testing.T) {
+	tcs := []struct{ name, prefix, unprefixed string }{
+		{"-webkit-transition", Webkit, "transition"},
+		{"-MOZ-box-sizing", Moz, "box-sizing"},
+		{"@-o-keyframes", O, "@keyframes"},
+		{"::-moz-selection", Moz, "::selection"},
+		{":-ms-input-placeholder", MS, ":input-placeholder"},
+		{"-webkit-linear-gradient(", Webkit, "linear-gradient("},
+		{"transition", "", "transition"},
+		{"-webkit-", "", "-webkit-"},
+		{"--webkit-x", "", "--webkit-x"},
+		{"-khtml-x", "", "-khtml-x"},
+	}
+	for _, tc := range tcs {
+		p, u := Split(tc.name)
+		if p != tc.prefix || u != tc.unprefixed {
+			t.Errorf("%s: got (%q, %q), want (%q, %q)", tc.name, p, u, tc.prefix, tc.unprefixed)
+		}
+	}
+}
+
+func TestStripDuplicates(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected string
+		removed  int
+	}{
+		{
+			"a { -webkit-transition: none; -moz-transition: none; transition: none }",
+			"a{transition:none}",
+			2,
+		},
+		{
+			"a { display: -webkit-box; display: -ms-flexbox; display: flex }",
+			"a{display:flex}",
+			2,
+		},
+		{
+			"a { background: -webkit-linear-gradient(red, blue); background: linear-gradient(red, blue) }",
+			"a{background:linear-gradient(red, blue)}",
+			1,
+		},
+		{
+			// Without an unprefixed sibling the declarations are kept.
+			"a { -webkit-appearance: none; -webkit-tap-highlight-color: red }",
+			"a{-webkit-appearance:none;-webkit-tap-highlight-color:red}",
+			0,
+		},
+		{
+			"@-webkit-keyframes spin { to { rotate: 1turn } } @keyframes spin { to { rotate: 1turn } } @-webkit-keyframes other {}",
+			"@keyframes spin{to{rotate:1turn}}@-webkit-keyframes other{}",
+			1,
+		},
+		{
+			// A prefixed declaration after the unprefixed one overrides it.
+			"a { width: 100%; width: -webkit-fill-available; -webkit-transition: none; transition: none; -moz-transition: all }",
+			"a{width:100%;width:-webkit-fill-available;transition:none;-moz-transition:all}",
+			1,
+		},
+		{
+			"a { display: -webkit-box !important; display: flex; -webkit-box-shadow: none; box-shadow: none !important }",
+			"a{display:-webkit-box!important;display:flex;box-shadow:none!important}",
+			1,
+		},
+		{
+			"@media print { a { -webkit-box-shadow: none; box-shadow: none } }",
+			"@media print{a{box-shadow:none}}",
+			1,
+		},
+	}
+	for _, tc := range tcs {
+		s, err := css.ParseStylesheet(tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n := StripDuplicates(s)
+		if got := s.String(); got != tc.expected || n != tc.removed {
+			t.Errorf("%s: got %q (%d removed), want %q (%d removed)", tc.input, got, n, tc.expected, tc.removed)
+		}
+	}
+}
+
+func TestAdd(t *testing.T) {
+	tcs := []struct {
+		targets  Targets
+		input    string
+		expected string
+	}{
+		{
+			Targets{Safari: 15, Chrome: 100},
+			"a { mask: url(m.svg); color: red }",
+			"a{-webkit-mask:url(m.svg);mask:url(m.svg);color:red}",
+		},
+		{
+			Targets{Safari: 16},
+			"a { mask: url(m.svg) }",
+			"a{mask:url(m.svg)}",
+		},
+		{
+			Targets{IE: 10, Safari: 8},
+			"a { display: flex; flex: 1 }",
+			"a{display:-webkit-flex;display:-ms-flexbox;display:flex;-webkit-flex:1;-ms-flex:1;flex:1}",
+		},
+		{
+			Targets{Firefox: 50, IE: 11},
+			"input::placeholder { color: gray }",
+			"input::-moz-placeholder{color:gray}input:-ms-input-placeholder{color:gray}input::placeholder{color:gray}",
+		},
+		{
+			Targets{Chrome: 40},
+			"@keyframes spin { to { rotate: 1turn } }",
+			"@-webkit-keyframes spin{to{rotate:1turn}}@keyframes spin{to{rotate:1turn}}",
+		},
+		{
+			Targets{Safari: 13},
+			"a { background: image-set(url(a.png) 1x) }",
+			"a{background:-webkit-image-set(url(a.png) 1x);background:image-set(url(a.png) 1x)}",
+		},
+		{
+			// Existing alternatives are not duplicated.
+			Targets{Safari: 12},
+			"a { -webkit-user-select: none; user-select: none; position: -webkit-sticky; position: sticky }",
+			"a{-webkit-user-select:none;user-select:none;position:-webkit-sticky;position:sticky}",
+		},
+		{
+			Targets{Firefox: 60},
+			"::selection, a:fullscreen { color: red } a::fullscreen {}",
+			"::selection, a:-moz-full-screen{color:red}::-moz-selection, a:fullscreen{color:red}::selection, a:fullscreen{color:red}a::fullscreen{}",
+		},
+	}
+	for _, tc := range tcs {
+		s, err := css.ParseStylesheet(tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		Add(s, tc.targets)
+		if got := s.String(); got != tc.expected {
+			t.Errorf("%s:\ngot  %q\nwant %q", tc.input, got, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package props
+
+import (
+	"strings"
+)
+
+// customIdentTypes is the set of value definition types that accept
+// author-defined identifiers, directly or through their own grammar.
+var customIdentTypes = map[string]bool{
+	"animateable-feature":        true,
+	"auto-track-list":            true,
+	"counter":                    true,
+	"counter-name":               true,
+	"counter-style":              true,
+	"custom-ident":               true,
+	"dashed-ident":               true,
+	"explicit-track-list":        true,
+	"family-name":                true,
+	"feature-value-name":         true,
+	"grid-line":                  true,
+	"keyframes-name":             true,
+	"line-name-list":             true,
+	"line-names":                 true,
+	"reversed-counter-name":      true,
+	"single-animation":           true,
+	"single-transition":          true,
+	"single-transition-property": true,
+	"track-list":                 true,
+}
+
+// hasCustomIdents reports whether a value definition syntax accepts
+// author-defined identifiers.
+func hasCustomIdents(grammar string, depth int) bool {
+	if depth > 8 {
+		return true
+	}
+	for _, t := range grammarToken.FindAllString(grammar, -1) {
+		switch {
+		case strings.HasPrefix(t, "<'"):
+			p := byName[t[2:len(t)-2]]
+			if p == nil || hasCustomIdents(p.Value, depth+1) {
+				return true
+			}
+		case strings.HasPrefix(t, "<"):
+			name := strings.TrimSuffix(strings.SplitN(t[1:len(t)-1], " ", 2)[0], "()")
+			if customIdentTypes[name] {
+				return true
+			}
+			if g, ok := keywordTypes[name]; ok && hasCustomIdents(g, depth+1) {
+				return true
+			}
+		}
+	}
+	return false
+}
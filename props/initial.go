@@ -0,0 +1,24 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package props
+
+import (
+	"github.com/gorilla/css"
+)
+
+// InitialValue returns the initial value of a property as component values.
+// It returns nil if the property is unknown, is a shorthand or has no fixed
+// initial value. The returned values are a new copy that may be modified.
+func InitialValue(name string) []*css.ComponentValue {
+	p := Lookup(name)
+	if p == nil || p.Initial == "" {
+		return nil
+	}
+	values, err := css.ParseComponentValues(p.Initial)
+	if err != nil {
+		return nil
+	}
+	return values
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package props
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// keywordTypes maps value definition types that only accept keywords to
+// their grammar.
+var keywordTypes = map[string]string{
+	"attachment":                  "scroll | fixed | local",
+	"baseline-position":           "[ first | last ]? && baseline",
+	"bg-clip":                     "<visual-box> | border-area | text",
+	"blend-mode":                  "normal | multiply | screen | overlay | darken | lighten | color-dodge | color-burn | hard-light | soft-light | difference | exclusion | hue | saturation | color | luminosity",
+	"common-lig-values":           "common-ligatures | no-common-ligatures",
+	"compositing-operator":        "add | subtract | intersect | exclude",
+	"content-distribution":        "space-between | space-around | space-evenly | stretch",
+	"content-position":            "center | start | end | flex-start | flex-end",
+	"contextual-alt-values":       "contextual | no-contextual",
+	"coord-box":                   "content-box | padding-box | border-box | fill-box | stroke-box | view-box",
+	"discretionary-lig-values":    "discretionary-ligatures | no-discretionary-ligatures",
+	"display-box":                 "contents | none",
+	"display-inside":              "flow | flow-root | table | flex | grid | ruby",
+	"display-internal":            "table-row-group | table-header-group | table-footer-group | table-row | table-cell | table-column-group | table-column | table-caption | ruby-base | ruby-text | ruby-base-container | ruby-text-container",
+	"display-legacy":              "inline-block | inline-table | inline-flex | inline-grid",
+	"display-listitem":            "<display-outside>? && [ flow | flow-root ]? && list-item",
+	"display-outside":             "block | inline | run-in",
+	"east-asian-variant-values":   "jis78 | jis83 | jis90 | jis04 | simplified | traditional",
+	"east-asian-width-values":     "full-width | proportional-width",
+	"historical-lig-values":       "historical-ligatures | no-historical-ligatures",
+	"line-style":                  "none | hidden | dotted | dashed | solid | double | groove | ridge | inset | outset",
+	"masking-mode":                "alpha | luminance | match-source",
+	"numeric-figure-values":       "lining-nums | oldstyle-nums",
+	"numeric-fraction-values":     "diagonal-fractions | stacked-fractions",
+	"numeric-spacing-values":      "proportional-nums | tabular-nums",
+	"outline-line-style":          "none | dotted | dashed | solid | double | groove | ridge | inset | outset",
+	"overflow-position":           "unsafe | safe",
+	"repeat-style":                "repeat-x | repeat-y | [ repeat | space | round | no-repeat ]{1,2}",
+	"self-position":               "center | start | end | self-start | self-end | flex-start | flex-end",
+	"single-animation-direction":  "normal | reverse | alternate | alternate-reverse",
+	"single-animation-fill-mode":  "none | forwards | backwards | both",
+	"single-animation-play-state": "running | paused",
+	"visual-box":                  "content-box | padding-box | border-box",
+}
+
+// grammarToken matches the parts of a value definition syntax: type
+// references, property references, functions, keywords and the remaining
+// combinators and multipliers.
+var grammarToken = regexp.MustCompile(`<'[a-z-]+'>|<[a-z-]+(?:\(\))?(?: \[[^\]]*\])?>|[a-zA-Z][a-zA-Z0-9-]*\(?|\{[0-9,]+\}|\|\||&&|[|\[\]?#+*/,]`)
+
+// keywords returns the sorted keywords of a value definition syntax, or nil
+// if the syntax accepts anything other than keywords.
+func keywords(grammar string) []string {
+	set := map[string]bool{}
+	if !collectKeywords(grammar, set, 0) {
+		return nil
+	}
+	list := make([]string, 0, len(set))
+	for k := range set {
+		list = append(list, k)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// collectKeywords adds the keywords of a value definition syntax to set. It
+// returns false if the syntax accepts anything other than keywords.
+func collectKeywords(grammar string, set map[string]bool, depth int) bool {
+	if depth > 8 {
+		return false
+	}
+	end := 0
+	for _, loc := range grammarToken.FindAllStringIndex(grammar, -1) {
+		// Anything that isn't recognized, other than whitespace, is unknown.
+		if strings.TrimSpace(grammar[end:loc[0]]) != "" {
+			return false
+		}
+		end = loc[1]
+		t := grammar[loc[0]:loc[1]]
+		switch {
+		case strings.HasPrefix(t, "<'"):
+			p := byName[t[2:len(t)-2]]
+			if p == nil || !collectKeywords(p.Value, set, depth+1) {
+				return false
+			}
+		case strings.HasPrefix(t, "<"):
+			g, ok := keywordTypes[t[1:len(t)-1]]
+			if !ok || !collectKeywords(g, set, depth+1) {
+				return false
+			}
+		case strings.HasSuffix(t, "("):
+			return false
+		case t[0] >= 'a' && t[0] <= 'z' || t[0] >= 'A' && t[0] <= 'Z':
+			set[strings.ToLower(t)] = true
+		}
+	}
+	return strings.TrimSpace(grammar[end:]) == ""
+}
@@ -0,0 +1,175 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package props
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WritingMode is a value of the writing-mode property.
+type WritingMode int
+
+const (
+	HorizontalTB WritingMode = iota
+	VerticalRL
+	VerticalLR
+	SidewaysRL
+	SidewaysLR
+)
+
+// Direction is a value of the direction property.
+type Direction int
+
+const (
+	LTR Direction = iota
+	RTL
+)
+
+// sideFamilies lists the properties that have one longhand per side, as
+// format strings for the logical and physical names. The logical name is
+// formatted with an axis, as in "block", or an axis and a side, as in
+// "block-start". The physical name is formatted with a side, as in "top".
+var sideFamilies = []struct{ logical, physical string }{
+	{"border-%s", "border-%s"},
+	{"border-%s-color", "border-%s-color"},
+	{"border-%s-style", "border-%s-style"},
+	{"border-%s-width", "border-%s-width"},
+	{"inset-%s", "%s"},
+	{"margin-%s", "margin-%s"},
+	{"padding-%s", "padding-%s"},
+	{"scroll-margin-%s", "scroll-margin-%s"},
+	{"scroll-padding-%s", "scroll-padding-%s"},
+}
+
+// sizes maps the logical size properties to their physical names in a
+// horizontal writing mode.
+var sizes = map[string]string{
+	"block-size":      "height",
+	"inline-size":     "width",
+	"max-block-size":  "max-height",
+	"max-inline-size": "max-width",
+	"min-block-size":  "min-height",
+	"min-inline-size": "min-width",
+}
+
+// Physical returns the physical properties equivalent to a flow-relative
+// property, such as margin-inline-start or block-size, in the given writing
+// mode and direction. For shorthands of an axis, such as margin-block, the
+// properties for the start and end sides are returned in that order. It
+// returns nil if the property isn't flow-relative.
+func Physical(name string, mode WritingMode, dir Direction) []string {
+	name = strings.ToLower(name)
+	if physical, ok := sizes[name]; ok {
+		if mode != HorizontalTB {
+			physical = swapAxis(physical)
+		}
+		return []string{physical}
+	}
+	for _, block := range []string{"start", "end"} {
+		for _, inline := range []string{"start", "end"} {
+			if name == "border-"+block+"-"+inline+"-radius" {
+				return []string{corner(
+					physicalSide("block-"+block, mode, dir),
+					physicalSide("inline-"+inline, mode, dir))}
+			}
+		}
+	}
+	for _, f := range sideFamilies {
+		for _, axis := range []string{"block", "inline"} {
+			if name == fmt.Sprintf(f.logical, axis) {
+				return []string{
+					fmt.Sprintf(f.physical, physicalSide(axis+"-start", mode, dir)),
+					fmt.Sprintf(f.physical, physicalSide(axis+"-end", mode, dir)),
+				}
+			}
+			for _, side := range []string{"start", "end"} {
+				if name == fmt.Sprintf(f.logical, axis+"-"+side) {
+					return []string{fmt.Sprintf(f.physical, physicalSide(axis+"-"+side, mode, dir))}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Logical returns the flow-relative property equivalent to a physical
+// property, such as margin-left or width, in the given writing mode and
+// direction. It returns an empty string if the property isn't a physical
+// longhand with a flow-relative equivalent.
+func Logical(name string, mode WritingMode, dir Direction) string {
+	name = strings.ToLower(name)
+	for logical := range sizes {
+		if Physical(logical, mode, dir)[0] == name {
+			return logical
+		}
+	}
+	for _, block := range []string{"start", "end"} {
+		for _, inline := range []string{"start", "end"} {
+			logical := "border-" + block + "-" + inline + "-radius"
+			if Physical(logical, mode, dir)[0] == name {
+				return logical
+			}
+		}
+	}
+	for _, f := range sideFamilies {
+		for _, side := range []string{"block-start", "block-end", "inline-start", "inline-end"} {
+			if name == fmt.Sprintf(f.physical, physicalSide(side, mode, dir)) {
+				return fmt.Sprintf(f.logical, side)
+			}
+		}
+	}
+	return ""
+}
+
+// physicalSide returns the physical side, such as "top", of a logical side
+// such as "inline-start".
+func physicalSide(side string, mode WritingMode, dir Direction) string {
+	var start, end string
+	switch {
+	case strings.HasPrefix(side, "block-"):
+		switch mode {
+		case HorizontalTB:
+			start, end = "top", "bottom"
+		case VerticalRL, SidewaysRL:
+			start, end = "right", "left"
+		default:
+			start, end = "left", "right"
+		}
+	default:
+		switch mode {
+		case HorizontalTB:
+			start, end = "left", "right"
+		case SidewaysLR:
+			start, end = "bottom", "top"
+		default:
+			start, end = "top", "bottom"
+		}
+		if dir == RTL {
+			start, end = end, start
+		}
+	}
+	if strings.HasSuffix(side, "-start") {
+		return start
+	}
+	return end
+}
+
+// corner returns the physical border radius property for the corner between
+// two physical sides.
+func corner(a, b string) string {
+	if a == "left" || a == "right" {
+		a, b = b, a
+	}
+	return "border-" + a + "-" + b + "-radius"
+}
+
+// swapAxis swaps width and height in a property name.
+func swapAxis(name string) string {
+	if strings.HasSuffix(name, "width") {
+		return strings.TrimSuffix(name, "width") + "height"
+	}
+	return strings.TrimSuffix(name, "height") + "width"
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package gorilla/css/props provides metadata about known CSS properties: their
+value grammar, initial value, whether they are inherited or animatable, and
+the relationships between shorthands and longhands.
+
+The data follows the property index of the CSS specifications located at:
+
+	https://www.w3.org/Style/CSS/all-properties.en.html
+
+It is kept in internal/gen/data/properties.json, from which go generate in
+the root package writes the table of this package.
+
+Properties are looked up by name, ignoring ASCII case:
+
+	if p := props.Lookup("margin"); p != nil {
+		fmt.Println(p.Longhands) // [margin-top margin-right margin-bottom margin-left]
+	}
+
+Custom properties and vendor-prefixed properties are not part of the table.
+*/
+package props
+
+import (
+	"strings"
+)
+
+// Property describes a CSS property.
+type Property struct {
+	// Name is the lowercase name of the property.
+	Name string
+	// Value is the value definition syntax of the property, as written in
+	// the specification.
+	Value string
+	// Initial is the initial value of the property. It is empty for
+	// shorthands and for properties whose initial value depends on the
+	// user agent.
+	Initial string
+	// Inherited reports whether the property is inherited by default.
+	Inherited bool
+	// Animatable reports whether values of the property are interpolated
+	// by animations and transitions. It is false for properties that only
+	// animate discretely.
+	Animatable bool
+	// Longhands lists the properties set by a shorthand, in canonical order.
+	// It is empty for longhands.
+	Longhands []string
+	// Shorthands lists the shorthands that set the property.
+	Shorthands []string
+	// Keywords lists, in lowercase and sorted, the keywords accepted by a
+	// property whose values only consist of keywords, such as display or
+	// position. It is empty for other properties.
+	Keywords []string
+	// CustomIdents reports whether values of the property may contain
+	// author-defined identifiers, such as animation or grid line names.
+	// Unlike keywords, these identifiers are case-sensitive.
+	CustomIdents bool
+	// Types lists, sorted, the value definition types referenced by the
+	// value grammar, such as "length" or "color", including those reached
+	// through other properties.
+	Types []string
+	// Status tells whether the property is standard, deprecated or
+	// nonstandard.
+	Status Status
+	// Replacement is what to use instead of a deprecated or nonstandard
+	// property, if anything.
+	Replacement string
+}
+
+// IsShorthand reports whether the property is a shorthand.
+func (p *Property) IsShorthand() bool {
+	return len(p.Longhands) > 0
+}
+
+// byName maps property names to properties.
+var byName = map[string]*Property{}
+
+func init() {
+	for _, p := range properties {
+		byName[p.Name] = p
+	}
+	for _, p := range properties {
+		for _, name := range p.Longhands {
+			l := byName[name]
+			l.Shorthands = append(l.Shorthands, p.Name)
+		}
+		p.Keywords = keywords(p.Value)
+		p.CustomIdents = hasCustomIdents(p.Value, 0)
+		p.Types = types(p.Value)
+		p.Replacement = replacements[p.Name]
+	}
+}
+
+// Lookup returns the property with the given name, or nil if the property is
+// unknown. The returned value is shared and must not be modified.
+func Lookup(name string) *Property {
+	return byName[strings.ToLower(name)]
+}
+
+// IsInherited reports whether the property is known and inherited by
+// default. Custom properties are always inherited.
+func IsInherited(name string) bool {
+	if strings.HasPrefix(name, "--") {
+		return true
+	}
+	p := Lookup(name)
+	return p != nil && p.Inherited
+}
+
+// All returns the known properties, sorted by name. The returned values are
+// shared and must not be modified.
+func All() []*Property {
+	return append([]*Property(nil), properties...)
+}
+
+// Flags for the property table.
+const (
+	inherited = 1 << iota
+	animatable
+	deprecated
+	nonstandard
+)
+
+// p returns a property for the table.
+func p(name, value, initial string, flags int, longhands ...string) *Property {
+	p := &Property{
+		Name:       name,
+		Value:      value,
+		Initial:    initial,
+		Inherited:  flags&inherited != 0,
+		Animatable: flags&animatable != 0,
+		Longhands:  longhands,
+	}
+	switch {
+	case flags&deprecated != 0:
+		p.Status = Deprecated
+	case flags&nonstandard != 0:
+		p.Status = Nonstandard
+	}
+	return p
+}
@@ -0,0 +1,209 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package props
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/gorilla/css"
+)
+
+func TestLookup(t *testing.T) {
+	p := Lookup("Color")
+	if p == nil {
+		t.Fatal("color: not found")
+	}
+	if p.Name != "color" || !p.Inherited || !p.Animatable || p.Initial != "canvastext" {
+		t.Errorf("color: bad metadata: %+v", p)
+	}
+	if Lookup("--custom") != nil || Lookup("-webkit-box-flex") != nil {
+		t.Error("unexpected custom or prefixed property")
+	}
+	m := Lookup("margin")
+	want := []string{"margin-top", "margin-right", "margin-bottom", "margin-left"}
+	if !m.IsShorthand() || !reflect.DeepEqual(m.Longhands, want) {
+		t.Errorf("margin: got longhands %v, want %v", m.Longhands, want)
+	}
+	if got := Lookup("border-top-color").Shorthands; !reflect.DeepEqual(got, []string{"border", "border-color", "border-top"}) {
+		t.Errorf("border-top-color: bad shorthands %v", got)
+	}
+}
+
+func TestTable(t *testing.T) {
+	if !sort.SliceIsSorted(properties, func(i, j int) bool {
+		return properties[i].Name < properties[j].Name
+	}) {
+		t.Error("properties are not sorted")
+	}
+	seen := map[string]bool{}
+	for _, p := range properties {
+		if seen[p.Name] {
+			t.Errorf("%s: duplicated", p.Name)
+		}
+		seen[p.Name] = true
+		if p.Value == "" {
+			t.Errorf("%s: missing value", p.Name)
+		}
+		for _, l := range p.Longhands {
+			if Lookup(l) == nil {
+				t.Errorf("%s: unknown longhand %s", p.Name, l)
+			}
+		}
+	}
+}
+
+func TestKeywords(t *testing.T) {
+	tcs := []struct {
+		name     string
+		keywords []string
+	}{
+		{"position", []string{"absolute", "fixed", "relative", "static", "sticky"}},
+		{"overflow", []string{"auto", "clip", "hidden", "scroll", "visible"}},
+		{"pointer-events", []string{"all", "auto", "bounding-box", "fill", "none", "painted", "stroke", "visible", "visiblefill", "visiblepainted", "visiblestroke"}},
+		{"width", nil},
+		{"margin", nil},
+		{"color", nil},
+	}
+	for _, tc := range tcs {
+		if got := Lookup(tc.name).Keywords; !reflect.DeepEqual(got, tc.keywords) {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.keywords)
+		}
+	}
+	display := Lookup("display").Keywords
+	for _, k := range []string{"block", "flex", "inline-grid", "list-item", "contents", "table-cell"} {
+		if sort.SearchStrings(display, k) == len(display) || display[sort.SearchStrings(display, k)] != k {
+			t.Errorf("display: missing %s in %v", k, display)
+		}
+	}
+}
+
+func TestStatus(t *testing.T) {
+	if p := Lookup("clip"); p.Status != Deprecated || p.Replacement != "clip-path" {
+		t.Errorf("clip: got %v %q", p.Status, p.Replacement)
+	}
+	if p := Lookup("zoom"); p.Status != Nonstandard {
+		t.Errorf("zoom: got %v", p.Status)
+	}
+	if k := Lookup("word-break").KeywordStatus("Break-Word"); k.Status != Deprecated {
+		t.Errorf("word-break: break-word: got %v", k.Status)
+	}
+	if a := LookupAtRule("VIEWPORT"); a == nil || a.Status != Deprecated {
+		t.Errorf("@viewport: got %v", a)
+	}
+	if a := LookupAtRule("media"); a == nil || a.Status != Standard {
+		t.Errorf("@media: got %v", a)
+	}
+}
+
+func TestCustomIdents(t *testing.T) {
+	for name, want := range map[string]bool{
+		"display":        false,
+		"color":          false,
+		"margin":         false,
+		"animation":      true,
+		"animation-name": true,
+		"font":           true,
+		"grid-area":      true,
+		"list-style":     true,
+		"transition":     true,
+	} {
+		if got := Lookup(name).CustomIdents; got != want {
+			t.Errorf("%s: got %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestAcceptsType(t *testing.T) {
+	for _, tc := range []struct {
+		name, typ string
+		want      bool
+	}{
+		{"margin", "length-percentage", true},
+		{"border-width", "length", true},
+		{"border", "length", true},
+		{"line-height", "number", true},
+		{"flex", "number", true},
+		{"color", "length", false},
+		{"display", "length", false},
+	} {
+		if got := Lookup(tc.name).AcceptsType(tc.typ); got != tc.want {
+			t.Errorf("%s %s: got %v, want %v", tc.name, tc.typ, got, tc.want)
+		}
+	}
+}
+
+func TestPhysical(t *testing.T) {
+	tcs := []struct {
+		name     string
+		mode     WritingMode
+		dir      Direction
+		physical []string
+	}{
+		{"margin-inline-start", HorizontalTB, LTR, []string{"margin-left"}},
+		{"margin-inline-start", HorizontalTB, RTL, []string{"margin-right"}},
+		{"inset-block-end", HorizontalTB, LTR, []string{"bottom"}},
+		{"inset-block-end", VerticalRL, LTR, []string{"left"}},
+		{"padding-inline-end", SidewaysLR, LTR, []string{"padding-top"}},
+		{"Border-Block-Start-Width", VerticalLR, LTR, []string{"border-left-width"}},
+		{"margin-block", HorizontalTB, LTR, []string{"margin-top", "margin-bottom"}},
+		{"padding-inline", VerticalRL, RTL, []string{"padding-bottom", "padding-top"}},
+		{"inline-size", HorizontalTB, LTR, []string{"width"}},
+		{"max-block-size", VerticalRL, LTR, []string{"max-width"}},
+		{"border-start-end-radius", HorizontalTB, LTR, []string{"border-top-right-radius"}},
+		{"border-end-start-radius", VerticalRL, LTR, []string{"border-top-left-radius"}},
+		{"margin-top", HorizontalTB, LTR, nil},
+	}
+	for _, tc := range tcs {
+		if got := Physical(tc.name, tc.mode, tc.dir); !reflect.DeepEqual(got, tc.physical) {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.physical)
+		}
+	}
+	for _, p := range properties {
+		for _, name := range Physical(p.Name, VerticalRL, RTL) {
+			if Lookup(name) == nil {
+				t.Errorf("%s: unknown physical property %s", p.Name, name)
+			}
+		}
+		physical := Physical(p.Name, SidewaysLR, RTL)
+		if len(physical) == 1 && Logical(physical[0], SidewaysLR, RTL) != p.Name {
+			t.Errorf("%s: got %q from %s", p.Name, Logical(physical[0], SidewaysLR, RTL), physical[0])
+		}
+	}
+	if got := Logical("margin", HorizontalTB, LTR); got != "" {
+		t.Errorf("margin: got %q", got)
+	}
+}
+
+func TestInitialValue(t *testing.T) {
+	for name, want := range map[string]string{
+		"background-position": "0% 0%",
+		"Color":               "canvastext",
+		"margin":              "",
+		"unknown":             "",
+	} {
+		if got := css.ValuesString(InitialValue(name)); got != want {
+			t.Errorf("%s: got %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestIsInherited(t *testing.T) {
+	for name, want := range map[string]bool{
+		"color":   true,
+		"Font":    true,
+		"--x":     true,
+		"margin":  false,
+		"unknown": false,
+	} {
+		if got := IsInherited(name); got != want {
+			t.Errorf("%s: got %v, want %v", name, got, want)
+		}
+	}
+	if all := All(); len(all) != len(properties) || all[0] != properties[0] {
+		t.Errorf("All: got %d properties", len(all))
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package props
+
+import (
+	"strings"
+)
+
+// Status is the standardization status of a property, keyword or at-rule.
+type Status int
+
+const (
+	Standard Status = iota
+	Deprecated
+	Nonstandard
+)
+
+// String returns a string representation of the status.
+func (s Status) String() string {
+	switch s {
+	case Deprecated:
+		return "deprecated"
+	case Nonstandard:
+		return "nonstandard"
+	}
+	return "standard"
+}
+
+// replacements maps deprecated and nonstandard properties to what should be
+// used instead.
+var replacements = map[string]string{
+	"clip":              "clip-path",
+	"grid-column-gap":   "column-gap",
+	"grid-gap":          "gap",
+	"grid-row-gap":      "row-gap",
+	"page-break-after":  "break-after",
+	"page-break-before": "break-before",
+	"page-break-inside": "break-inside",
+	"word-wrap":         "overflow-wrap",
+	"zoom":              "transform: scale()",
+}
+
+// Keyword describes a deprecated or nonstandard keyword value.
+type Keyword struct {
+	Status Status
+	// Replacement is what to use instead of the keyword, if anything.
+	Replacement string
+}
+
+// keywordStatus maps property names and keywords to their status.
+var keywordStatus = map[string]map[string]Keyword{
+	"image-rendering": {
+		"optimizespeed":   {Deprecated, "pixelated"},
+		"optimizequality": {Deprecated, "smooth"},
+	},
+	"overflow":   {"overlay": {Deprecated, "auto"}},
+	"overflow-x": {"overlay": {Deprecated, "auto"}},
+	"overflow-y": {"overlay": {Deprecated, "auto"}},
+	"user-select": {
+		"element": {Nonstandard, "contain"},
+	},
+	"word-break": {
+		"break-word": {Deprecated, "overflow-wrap: anywhere"},
+	},
+}
+
+// KeywordStatus returns the status of a keyword value of the property, and
+// the replacement for deprecated and nonstandard keywords.
+func (p *Property) KeywordStatus(keyword string) Keyword {
+	return keywordStatus[p.Name][strings.ToLower(keyword)]
+}
+
+// AtRule describes an at-rule.
+type AtRule struct {
+	// Name is the lowercase name of the at-rule, without the "@".
+	Name   string
+	Status Status
+	// Replacement is what to use instead of a deprecated or nonstandard
+	// at-rule, if anything.
+	Replacement string
+}
+
+// atRules is the list of known at-rules.
+var atRules = map[string]*AtRule{}
+
+func init() {
+	for _, r := range []*AtRule{
+		{"charset", Standard, ""},
+		{"color-profile", Standard, ""},
+		{"container", Standard, ""},
+		{"counter-style", Standard, ""},
+		{"document", Nonstandard, "@supports or @media"},
+		{"font-face", Standard, ""},
+		{"font-feature-values", Standard, ""},
+		{"font-palette-values", Standard, ""},
+		{"import", Standard, ""},
+		{"keyframes", Standard, ""},
+		{"layer", Standard, ""},
+		{"media", Standard, ""},
+		{"namespace", Standard, ""},
+		{"page", Standard, ""},
+		{"position-try", Standard, ""},
+		{"property", Standard, ""},
+		{"scope", Standard, ""},
+		{"starting-style", Standard, ""},
+		{"supports", Standard, ""},
+		{"view-transition", Standard, ""},
+		{"viewport", Deprecated, `<meta name="viewport">`},
+	} {
+		atRules[r.Name] = r
+	}
+}
+
+// LookupAtRule returns the at-rule with the given name, without the "@", or
+// nil if the at-rule is unknown. The returned value is shared and must not be
+// modified.
+func LookupAtRule(name string) *AtRule {
+	return atRules[strings.ToLower(name)]
+}
@@ -0,0 +1,419 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by internal/gen from internal/gen/data. DO NOT EDIT.
+
+package props
+
+// properties is the list of known properties, sorted by name.
+var properties = []*Property{
+	p("accent-color", "auto | <color>", "auto", inherited|animatable),
+	p("align-content", "normal | <baseline-position> | <content-distribution> | <overflow-position>? <content-position>", "normal", 0),
+	p("align-items", "normal | stretch | <baseline-position> | [ <overflow-position>? <self-position> ]", "normal", 0),
+	p("align-self", "auto | normal | stretch | <baseline-position> | <overflow-position>? <self-position>", "auto", 0),
+	p("all", "initial | inherit | unset | revert | revert-layer", "", 0),
+	p("animation", "<single-animation>#", "", 0,
+		"animation-name", "animation-duration", "animation-timing-function",
+		"animation-delay", "animation-iteration-count", "animation-direction",
+		"animation-fill-mode", "animation-play-state"),
+	p("animation-delay", "<time>#", "0s", 0),
+	p("animation-direction", "<single-animation-direction>#", "normal", 0),
+	p("animation-duration", "<time [0s,∞]>#", "0s", 0),
+	p("animation-fill-mode", "<single-animation-fill-mode>#", "none", 0),
+	p("animation-iteration-count", "<single-animation-iteration-count>#", "1", 0),
+	p("animation-name", "[ none | <keyframes-name> ]#", "none", 0),
+	p("animation-play-state", "<single-animation-play-state>#", "running", 0),
+	p("animation-timing-function", "<easing-function>#", "ease", 0),
+	p("appearance", "none | auto | <compat-auto> | <compat-special>", "none", 0),
+	p("aspect-ratio", "auto || <ratio>", "auto", animatable),
+	p("backdrop-filter", "none | <filter-value-list>", "none", animatable),
+	p("backface-visibility", "visible | hidden", "visible", 0),
+	p("background", "<bg-layer>#? , <final-bg-layer>", "", animatable,
+		"background-image", "background-position", "background-size", "background-repeat",
+		"background-attachment", "background-origin", "background-clip",
+		"background-color"),
+	p("background-attachment", "<attachment>#", "scroll", 0),
+	p("background-blend-mode", "<blend-mode>#", "normal", 0),
+	p("background-clip", "<bg-clip>#", "border-box", 0),
+	p("background-color", "<color>", "transparent", animatable),
+	p("background-image", "<bg-image>#", "none", 0),
+	p("background-origin", "<visual-box>#", "padding-box", 0),
+	p("background-position", "<bg-position>#", "0% 0%", animatable),
+	p("background-repeat", "<repeat-style>#", "repeat", 0),
+	p("background-size", "<bg-size>#", "auto", animatable),
+	p("block-size", "<'width'>", "auto", animatable),
+	p("border", "<line-width> || <line-style> || <color>", "", animatable,
+		"border-top-width", "border-right-width", "border-bottom-width",
+		"border-left-width", "border-top-style", "border-right-style",
+		"border-bottom-style", "border-left-style", "border-top-color",
+		"border-right-color", "border-bottom-color", "border-left-color"),
+	p("border-block", "<'border-block-start'>", "", animatable,
+		"border-block-start-width", "border-block-start-style", "border-block-start-color",
+		"border-block-end-width", "border-block-end-style", "border-block-end-color"),
+	p("border-block-end", "<line-width> || <line-style> || <color>", "", animatable,
+		"border-block-end-width", "border-block-end-style", "border-block-end-color"),
+	p("border-block-end-color", "<color>", "currentcolor", animatable),
+	p("border-block-end-style", "<line-style>", "none", 0),
+	p("border-block-end-width", "<line-width>", "medium", animatable),
+	p("border-block-start", "<line-width> || <line-style> || <color>", "", animatable,
+		"border-block-start-width", "border-block-start-style", "border-block-start-color"),
+	p("border-block-start-color", "<color>", "currentcolor", animatable),
+	p("border-block-start-style", "<line-style>", "none", 0),
+	p("border-block-start-width", "<line-width>", "medium", animatable),
+	p("border-bottom", "<line-width> || <line-style> || <color>", "", animatable,
+		"border-bottom-width", "border-bottom-style", "border-bottom-color"),
+	p("border-bottom-color", "<color>", "currentcolor", animatable),
+	p("border-bottom-left-radius", "<length-percentage [0,∞]>{1,2}", "0", animatable),
+	p("border-bottom-right-radius", "<length-percentage [0,∞]>{1,2}", "0", animatable),
+	p("border-bottom-style", "<line-style>", "none", 0),
+	p("border-bottom-width", "<line-width>", "medium", animatable),
+	p("border-collapse", "separate | collapse", "separate", inherited),
+	p("border-color", "<color>{1,4}", "", animatable,
+		"border-top-color", "border-right-color", "border-bottom-color",
+		"border-left-color"),
+	p("border-end-end-radius", "<length-percentage [0,∞]>{1,2}", "0", animatable),
+	p("border-end-start-radius", "<length-percentage [0,∞]>{1,2}", "0", animatable),
+	p("border-image", "<'border-image-source'> || <'border-image-slice'> [ / <'border-image-width'> | / <'border-image-width'>? / <'border-image-outset'> ]? || <'border-image-repeat'>", "", 0,
+		"border-image-source", "border-image-slice", "border-image-width",
+		"border-image-outset", "border-image-repeat"),
+	p("border-image-outset", "[ <length [0,∞]> | <number [0,∞]> ]{1,4}", "0", animatable),
+	p("border-image-repeat", "[ stretch | repeat | round | space ]{1,2}", "stretch", 0),
+	p("border-image-slice", "[ <number [0,∞]> | <percentage [0,∞]> ]{1,4} && fill?", "100%", animatable),
+	p("border-image-source", "none | <image>", "none", 0),
+	p("border-image-width", "[ <length-percentage [0,∞]> | <number [0,∞]> | auto ]{1,4}", "1", animatable),
+	p("border-inline", "<'border-block-start'>", "", animatable,
+		"border-inline-start-width", "border-inline-start-style",
+		"border-inline-start-color", "border-inline-end-width", "border-inline-end-style",
+		"border-inline-end-color"),
+	p("border-inline-end", "<line-width> || <line-style> || <color>", "", animatable,
+		"border-inline-end-width", "border-inline-end-style", "border-inline-end-color"),
+	p("border-inline-end-color", "<color>", "currentcolor", animatable),
+	p("border-inline-end-style", "<line-style>", "none", 0),
+	p("border-inline-end-width", "<line-width>", "medium", animatable),
+	p("border-inline-start", "<line-width> || <line-style> || <color>", "", animatable,
+		"border-inline-start-width", "border-inline-start-style",
+		"border-inline-start-color"),
+	p("border-inline-start-color", "<color>", "currentcolor", animatable),
+	p("border-inline-start-style", "<line-style>", "none", 0),
+	p("border-inline-start-width", "<line-width>", "medium", animatable),
+	p("border-left", "<line-width> || <line-style> || <color>", "", animatable,
+		"border-left-width", "border-left-style", "border-left-color"),
+	p("border-left-color", "<color>", "currentcolor", animatable),
+	p("border-left-style", "<line-style>", "none", 0),
+	p("border-left-width", "<line-width>", "medium", animatable),
+	p("border-radius", "<length-percentage [0,∞]>{1,4} [ / <length-percentage [0,∞]>{1,4} ]?", "", animatable,
+		"border-top-left-radius", "border-top-right-radius", "border-bottom-right-radius",
+		"border-bottom-left-radius"),
+	p("border-right", "<line-width> || <line-style> || <color>", "", animatable,
+		"border-right-width", "border-right-style", "border-right-color"),
+	p("border-right-color", "<color>", "currentcolor", animatable),
+	p("border-right-style", "<line-style>", "none", 0),
+	p("border-right-width", "<line-width>", "medium", animatable),
+	p("border-spacing", "<length>{1,2}", "0", inherited|animatable),
+	p("border-start-end-radius", "<length-percentage [0,∞]>{1,2}", "0", animatable),
+	p("border-start-start-radius", "<length-percentage [0,∞]>{1,2}", "0", animatable),
+	p("border-style", "<line-style>{1,4}", "", 0,
+		"border-top-style", "border-right-style", "border-bottom-style",
+		"border-left-style"),
+	p("border-top", "<line-width> || <line-style> || <color>", "", animatable,
+		"border-top-width", "border-top-style", "border-top-color"),
+	p("border-top-color", "<color>", "currentcolor", animatable),
+	p("border-top-left-radius", "<length-percentage [0,∞]>{1,2}", "0", animatable),
+	p("border-top-right-radius", "<length-percentage [0,∞]>{1,2}", "0", animatable),
+	p("border-top-style", "<line-style>", "none", 0),
+	p("border-top-width", "<line-width>", "medium", animatable),
+	p("border-width", "<line-width>{1,4}", "", animatable,
+		"border-top-width", "border-right-width", "border-bottom-width",
+		"border-left-width"),
+	p("bottom", "auto | <length-percentage>", "auto", animatable),
+	p("box-decoration-break", "slice | clone", "slice", 0),
+	p("box-shadow", "none | <shadow>#", "none", animatable),
+	p("box-sizing", "content-box | border-box", "content-box", 0),
+	p("break-after", "auto | avoid | always | all | avoid-page | page | left | right | recto | verso | avoid-column | column | avoid-region | region", "auto", 0),
+	p("break-before", "auto | avoid | always | all | avoid-page | page | left | right | recto | verso | avoid-column | column | avoid-region | region", "auto", 0),
+	p("break-inside", "auto | avoid | avoid-page | avoid-column | avoid-region", "auto", 0),
+	p("caption-side", "top | bottom", "top", inherited),
+	p("caret-color", "auto | <color>", "auto", inherited|animatable),
+	p("clear", "inline-start | inline-end | block-start | block-end | left | right | top | bottom | both-inline | both-block | both | none", "none", 0),
+	p("clip", "<shape> | auto", "auto", animatable|deprecated),
+	p("clip-path", "<clip-source> | [ <basic-shape> || <geometry-box> ] | none", "none", animatable),
+	p("color", "<color>", "canvastext", inherited|animatable),
+	p("column-count", "auto | <integer [1,∞]>", "auto", animatable),
+	p("column-fill", "auto | balance | balance-all", "balance", 0),
+	p("column-gap", "normal | <length-percentage [0,∞]>", "normal", animatable),
+	p("column-rule", "<'column-rule-width'> || <'column-rule-style'> || <'column-rule-color'>", "", animatable,
+		"column-rule-width", "column-rule-style", "column-rule-color"),
+	p("column-rule-color", "<color>", "currentcolor", animatable),
+	p("column-rule-style", "<line-style>", "none", 0),
+	p("column-rule-width", "<line-width>", "medium", animatable),
+	p("column-span", "none | all", "none", 0),
+	p("column-width", "auto | <length [0,∞]>", "auto", animatable),
+	p("columns", "<'column-width'> || <'column-count'>", "", animatable,
+		"column-width", "column-count"),
+	p("contain", "none | strict | content | [ [ size | inline-size ] || layout || style || paint ]", "none", 0),
+	p("container", "<'container-name'> [ / <'container-type'> ]?", "", 0,
+		"container-name", "container-type"),
+	p("container-name", "none | <custom-ident>+", "none", 0),
+	p("container-type", "normal | size | inline-size", "normal", 0),
+	p("content", "normal | none | [ <content-replacement> | <content-list> ] [ / [ <string> | <counter> ]+ ]?", "normal", 0),
+	p("content-visibility", "visible | auto | hidden", "visible", 0),
+	p("counter-increment", "[ <counter-name> <integer>? ]+ | none", "none", animatable),
+	p("counter-reset", "[ <counter-name> <integer>? | <reversed-counter-name> <integer>? ]+ | none", "none", animatable),
+	p("counter-set", "[ <counter-name> <integer>? ]+ | none", "none", animatable),
+	p("cursor", "[ [ <url> | <url-set> ] [ <x> <y> ]? ]#? [ auto | default | none | context-menu | help | pointer | progress | wait | cell | crosshair | text | vertical-text | alias | copy | move | no-drop | not-allowed | grab | grabbing | e-resize | n-resize | ne-resize | nw-resize | s-resize | se-resize | sw-resize | w-resize | ew-resize | ns-resize | nesw-resize | nwse-resize | col-resize | row-resize | all-scroll | zoom-in | zoom-out ]", "auto", inherited),
+	p("direction", "ltr | rtl", "ltr", inherited),
+	p("display", "[ <display-outside> || <display-inside> ] | <display-listitem> | <display-internal> | <display-box> | <display-legacy>", "inline", 0),
+	p("empty-cells", "show | hide", "show", inherited),
+	p("filter", "none | <filter-value-list>", "none", animatable),
+	p("flex", "none | [ <'flex-grow'> <'flex-shrink'>? || <'flex-basis'> ]", "", animatable,
+		"flex-grow", "flex-shrink", "flex-basis"),
+	p("flex-basis", "content | <'width'>", "auto", animatable),
+	p("flex-direction", "row | row-reverse | column | column-reverse", "row", 0),
+	p("flex-flow", "<'flex-direction'> || <'flex-wrap'>", "", 0,
+		"flex-direction", "flex-wrap"),
+	p("flex-grow", "<number [0,∞]>", "0", animatable),
+	p("flex-shrink", "<number [0,∞]>", "1", animatable),
+	p("flex-wrap", "nowrap | wrap | wrap-reverse", "nowrap", 0),
+	p("float", "block-start | block-end | inline-start | inline-end | snap-block | <snap-block()> | snap-inline | <snap-inline()> | left | right | top | bottom | none", "none", 0),
+	p("font", "[ [ <'font-style'> || <font-variant-css2> || <'font-weight'> || <font-width-css3> ]? <'font-size'> [ / <'line-height'> ]? <'font-family'># ] | <system-family-name>", "", inherited|animatable,
+		"font-style", "font-variant-caps", "font-weight", "font-stretch", "font-size",
+		"line-height", "font-family"),
+	p("font-family", "[ <family-name> | <generic-family> ]#", "", inherited),
+	p("font-feature-settings", "normal | <feature-tag-value>#", "normal", inherited),
+	p("font-kerning", "auto | normal | none", "auto", inherited),
+	p("font-optical-sizing", "auto | none", "auto", inherited),
+	p("font-size", "<absolute-size> | <relative-size> | <length-percentage [0,∞]> | math", "medium", inherited|animatable),
+	p("font-size-adjust", "none | [ ex-height | cap-height | ch-width | ic-width | ic-height ]? [ from-font | <number [0,∞]> ]", "none", inherited|animatable),
+	p("font-stretch", "normal | <percentage [0,∞]> | ultra-condensed | extra-condensed | condensed | semi-condensed | semi-expanded | expanded | extra-expanded | ultra-expanded", "normal", inherited|animatable),
+	p("font-style", "normal | italic | oblique <angle [-90deg,90deg]>?", "normal", inherited|animatable),
+	p("font-variant", "normal | none | [ [ <common-lig-values> || <discretionary-lig-values> || <historical-lig-values> || <contextual-alt-values> ] || [ small-caps | all-small-caps | petite-caps | all-petite-caps | unicase | titling-caps ] || [ stylistic( <feature-value-name> ) || historical-forms || styleset( <feature-value-name># ) || character-variant( <feature-value-name># ) || swash( <feature-value-name> ) || ornaments( <feature-value-name> ) || annotation( <feature-value-name> ) ] || [ <numeric-figure-values> || <numeric-spacing-values> || <numeric-fraction-values> || ordinal || slashed-zero ] || [ <east-asian-variant-values> || <east-asian-width-values> || ruby ] || [ sub | super ] || [ text | emoji | unicode ] ]", "", inherited,
+		"font-variant-ligatures", "font-variant-caps", "font-variant-alternates",
+		"font-variant-numeric", "font-variant-east-asian", "font-variant-position"),
+	p("font-variant-alternates", "normal | [ stylistic( <feature-value-name> ) || historical-forms || styleset( <feature-value-name># ) || character-variant( <feature-value-name># ) || swash( <feature-value-name> ) || ornaments( <feature-value-name> ) || annotation( <feature-value-name> ) ]", "normal", inherited),
+	p("font-variant-caps", "normal | small-caps | all-small-caps | petite-caps | all-petite-caps | unicase | titling-caps", "normal", inherited),
+	p("font-variant-east-asian", "normal | [ <east-asian-variant-values> || <east-asian-width-values> || ruby ]", "normal", inherited),
+	p("font-variant-ligatures", "normal | none | [ <common-lig-values> || <discretionary-lig-values> || <historical-lig-values> || <contextual-alt-values> ]", "normal", inherited),
+	p("font-variant-numeric", "normal | [ <numeric-figure-values> || <numeric-spacing-values> || <numeric-fraction-values> || ordinal || slashed-zero ]", "normal", inherited),
+	p("font-variant-position", "normal | sub | super", "normal", inherited),
+	p("font-variation-settings", "normal | [ <opentype-tag> <number> ]#", "normal", inherited|animatable),
+	p("font-weight", "<font-weight-absolute> | bolder | lighter", "normal", inherited|animatable),
+	p("gap", "<'row-gap'> <'column-gap'>?", "", animatable,
+		"row-gap", "column-gap"),
+	p("grid", "<'grid-template'> | <'grid-template-rows'> / [ auto-flow && dense? ] <'grid-auto-columns'>? | [ auto-flow && dense? ] <'grid-auto-rows'>? / <'grid-template-columns'>", "", animatable,
+		"grid-template-rows", "grid-template-columns", "grid-template-areas",
+		"grid-auto-rows", "grid-auto-columns", "grid-auto-flow"),
+	p("grid-area", "<grid-line> [ / <grid-line> ]{0,3}", "", 0,
+		"grid-row-start", "grid-column-start", "grid-row-end", "grid-column-end"),
+	p("grid-auto-columns", "<track-size>+", "auto", animatable),
+	p("grid-auto-flow", "[ row | column ] || dense", "row", 0),
+	p("grid-auto-rows", "<track-size>+", "auto", animatable),
+	p("grid-column", "<grid-line> [ / <grid-line> ]?", "", 0,
+		"grid-column-start", "grid-column-end"),
+	p("grid-column-end", "<grid-line>", "auto", 0),
+	p("grid-column-gap", "<length-percentage [0,∞]>", "0", animatable|deprecated),
+	p("grid-column-start", "<grid-line>", "auto", 0),
+	p("grid-gap", "<'grid-row-gap'> <'grid-column-gap'>?", "", animatable|deprecated),
+	p("grid-row", "<grid-line> [ / <grid-line> ]?", "", 0,
+		"grid-row-start", "grid-row-end"),
+	p("grid-row-end", "<grid-line>", "auto", 0),
+	p("grid-row-gap", "<length-percentage [0,∞]>", "0", animatable|deprecated),
+	p("grid-row-start", "<grid-line>", "auto", 0),
+	p("grid-template", "none | [ <'grid-template-rows'> / <'grid-template-columns'> ] | [ <line-names>? <string> <track-size>? <line-names>? ]+ [ / <explicit-track-list> ]?", "", animatable,
+		"grid-template-rows", "grid-template-columns", "grid-template-areas"),
+	p("grid-template-areas", "none | <string>+", "none", 0),
+	p("grid-template-columns", "none | <track-list> | <auto-track-list> | subgrid <line-name-list>?", "none", animatable),
+	p("grid-template-rows", "none | <track-list> | <auto-track-list> | subgrid <line-name-list>?", "none", animatable),
+	p("hanging-punctuation", "none | [ first || [ force-end | allow-end ] || last ]", "none", inherited),
+	p("height", "auto | <length-percentage [0,∞]> | min-content | max-content | fit-content( <length-percentage [0,∞]> )", "auto", animatable),
+	p("hyphens", "none | manual | auto", "manual", inherited),
+	p("image-rendering", "auto | smooth | high-quality | pixelated | crisp-edges", "auto", inherited),
+	p("ime-mode", "auto | normal | active | inactive | disabled", "auto", deprecated),
+	p("inline-size", "<'width'>", "auto", animatable),
+	p("inset", "<'top'>{1,4}", "", animatable,
+		"top", "right", "bottom", "left"),
+	p("inset-block", "<'top'>{1,2}", "", animatable,
+		"inset-block-start", "inset-block-end"),
+	p("inset-block-end", "auto | <length-percentage>", "auto", animatable),
+	p("inset-block-start", "auto | <length-percentage>", "auto", animatable),
+	p("inset-inline", "<'top'>{1,2}", "", animatable,
+		"inset-inline-start", "inset-inline-end"),
+	p("inset-inline-end", "auto | <length-percentage>", "auto", animatable),
+	p("inset-inline-start", "auto | <length-percentage>", "auto", animatable),
+	p("isolation", "auto | isolate", "auto", 0),
+	p("justify-content", "normal | <content-distribution> | <overflow-position>? [ <content-position> | left | right ]", "normal", 0),
+	p("justify-items", "normal | stretch | <baseline-position> | <overflow-position>? [ <self-position> | left | right ] | legacy | legacy && [ left | right | center ]", "legacy", 0),
+	p("justify-self", "auto | normal | stretch | <baseline-position> | <overflow-position>? [ <self-position> | left | right ]", "auto", 0),
+	p("left", "auto | <length-percentage>", "auto", animatable),
+	p("letter-spacing", "normal | <length-percentage>", "normal", inherited|animatable),
+	p("line-break", "auto | loose | normal | strict | anywhere", "auto", inherited),
+	p("line-height", "normal | <number [0,∞]> | <length-percentage [0,∞]>", "normal", inherited|animatable),
+	p("list-style", "<'list-style-position'> || <'list-style-image'> || <'list-style-type'>", "", inherited,
+		"list-style-position", "list-style-image", "list-style-type"),
+	p("list-style-image", "<image> | none", "none", inherited),
+	p("list-style-position", "inside | outside", "outside", inherited),
+	p("list-style-type", "<counter-style> | <string> | none", "disc", inherited),
+	p("margin", "<'margin-top'>{1,4}", "", animatable,
+		"margin-top", "margin-right", "margin-bottom", "margin-left"),
+	p("margin-block", "<'margin-top'>{1,2}", "", animatable,
+		"margin-block-start", "margin-block-end"),
+	p("margin-block-end", "<'margin-top'>", "0", animatable),
+	p("margin-block-start", "<'margin-top'>", "0", animatable),
+	p("margin-bottom", "<length-percentage> | auto", "0", animatable),
+	p("margin-inline", "<'margin-top'>{1,2}", "", animatable,
+		"margin-inline-start", "margin-inline-end"),
+	p("margin-inline-end", "<'margin-top'>", "0", animatable),
+	p("margin-inline-start", "<'margin-top'>", "0", animatable),
+	p("margin-left", "<length-percentage> | auto", "0", animatable),
+	p("margin-right", "<length-percentage> | auto", "0", animatable),
+	p("margin-top", "<length-percentage> | auto", "0", animatable),
+	p("mask", "<mask-layer>#", "", animatable,
+		"mask-image", "mask-mode", "mask-repeat", "mask-position", "mask-clip",
+		"mask-origin", "mask-size", "mask-composite"),
+	p("mask-clip", "[ <coord-box> | no-clip ]#", "border-box", 0),
+	p("mask-composite", "<compositing-operator>#", "add", 0),
+	p("mask-image", "<mask-reference>#", "none", 0),
+	p("mask-mode", "<masking-mode>#", "match-source", 0),
+	p("mask-origin", "<coord-box>#", "border-box", 0),
+	p("mask-position", "<position>#", "0% 0%", animatable),
+	p("mask-repeat", "<repeat-style>#", "repeat", 0),
+	p("mask-size", "<bg-size>#", "auto", animatable),
+	p("max-block-size", "<'max-width'>", "none", animatable),
+	p("max-height", "none | <length-percentage [0,∞]> | min-content | max-content | fit-content( <length-percentage [0,∞]> )", "none", animatable),
+	p("max-inline-size", "<'max-width'>", "none", animatable),
+	p("max-width", "none | <length-percentage [0,∞]> | min-content | max-content | fit-content( <length-percentage [0,∞]> )", "none", animatable),
+	p("min-block-size", "<'min-width'>", "auto", animatable),
+	p("min-height", "auto | <length-percentage [0,∞]> | min-content | max-content | fit-content( <length-percentage [0,∞]> )", "auto", animatable),
+	p("min-inline-size", "<'min-width'>", "auto", animatable),
+	p("min-width", "auto | <length-percentage [0,∞]> | min-content | max-content | fit-content( <length-percentage [0,∞]> )", "auto", animatable),
+	p("mix-blend-mode", "<blend-mode> | plus-darker | plus-lighter", "normal", 0),
+	p("object-fit", "fill | none | [ contain | cover ] || scale-down", "fill", 0),
+	p("object-position", "<position>", "50% 50%", animatable),
+	p("opacity", "<opacity-value>", "1", animatable),
+	p("order", "<integer>", "0", animatable),
+	p("orphans", "<integer [1,∞]>", "2", inherited|animatable),
+	p("outline", "<'outline-width'> || <'outline-style'> || <'outline-color'>", "", animatable,
+		"outline-width", "outline-style", "outline-color"),
+	p("outline-color", "auto | <color>", "auto", animatable),
+	p("outline-offset", "<length>", "0", animatable),
+	p("outline-style", "auto | <outline-line-style>", "none", 0),
+	p("outline-width", "<line-width>", "medium", animatable),
+	p("overflow", "[ visible | hidden | clip | scroll | auto ]{1,2}", "", 0,
+		"overflow-x", "overflow-y"),
+	p("overflow-wrap", "normal | break-word | anywhere", "normal", inherited),
+	p("overflow-x", "visible | hidden | clip | scroll | auto", "visible", 0),
+	p("overflow-y", "visible | hidden | clip | scroll | auto", "visible", 0),
+	p("overscroll-behavior", "[ contain | none | auto ]{1,2}", "", 0,
+		"overscroll-behavior-x", "overscroll-behavior-y"),
+	p("overscroll-behavior-x", "contain | none | auto", "auto", 0),
+	p("overscroll-behavior-y", "contain | none | auto", "auto", 0),
+	p("padding", "<'padding-top'>{1,4}", "", animatable,
+		"padding-top", "padding-right", "padding-bottom", "padding-left"),
+	p("padding-block", "<'padding-top'>{1,2}", "", animatable,
+		"padding-block-start", "padding-block-end"),
+	p("padding-block-end", "<'padding-top'>", "0", animatable),
+	p("padding-block-start", "<'padding-top'>", "0", animatable),
+	p("padding-bottom", "<length-percentage [0,∞]>", "0", animatable),
+	p("padding-inline", "<'padding-top'>{1,2}", "", animatable,
+		"padding-inline-start", "padding-inline-end"),
+	p("padding-inline-end", "<'padding-top'>", "0", animatable),
+	p("padding-inline-start", "<'padding-top'>", "0", animatable),
+	p("padding-left", "<length-percentage [0,∞]>", "0", animatable),
+	p("padding-right", "<length-percentage [0,∞]>", "0", animatable),
+	p("padding-top", "<length-percentage [0,∞]>", "0", animatable),
+	p("page-break-after", "auto | always | avoid | left | right", "auto", deprecated),
+	p("page-break-before", "auto | always | avoid | left | right", "auto", deprecated),
+	p("page-break-inside", "auto | avoid", "auto", deprecated),
+	p("paint-order", "normal | [ fill || stroke || markers ]", "normal", inherited),
+	p("perspective", "none | <length [0,∞]>", "none", animatable),
+	p("perspective-origin", "<position>", "50% 50%", animatable),
+	p("place-content", "<'align-content'> <'justify-content'>?", "", 0,
+		"align-content", "justify-content"),
+	p("place-items", "<'align-items'> <'justify-items'>?", "", 0,
+		"align-items", "justify-items"),
+	p("place-self", "<'align-self'> <'justify-self'>?", "", 0,
+		"align-self", "justify-self"),
+	p("pointer-events", "auto | bounding-box | visiblePainted | visibleFill | visibleStroke | visible | painted | fill | stroke | all | none", "auto", inherited),
+	p("position", "static | relative | absolute | sticky | fixed", "static", 0),
+	p("quotes", "auto | none | match-parent | [ <string> <string> ]+", "auto", inherited),
+	p("resize", "none | both | horizontal | vertical | block | inline", "none", 0),
+	p("right", "auto | <length-percentage>", "auto", animatable),
+	p("rotate", "none | <angle> | [ x | y | z | <number>{3} ] && <angle>", "none", animatable),
+	p("row-gap", "normal | <length-percentage [0,∞]>", "normal", animatable),
+	p("scale", "none | [ <number> | <percentage> ]{1,3}", "none", animatable),
+	p("scroll-behavior", "auto | smooth", "auto", 0),
+	p("scroll-margin", "<length>{1,4}", "", animatable,
+		"scroll-margin-top", "scroll-margin-right", "scroll-margin-bottom",
+		"scroll-margin-left"),
+	p("scroll-margin-bottom", "<length>", "0", animatable),
+	p("scroll-margin-left", "<length>", "0", animatable),
+	p("scroll-margin-right", "<length>", "0", animatable),
+	p("scroll-margin-top", "<length>", "0", animatable),
+	p("scroll-padding", "[ auto | <length-percentage [0,∞]> ]{1,4}", "", animatable,
+		"scroll-padding-top", "scroll-padding-right", "scroll-padding-bottom",
+		"scroll-padding-left"),
+	p("scroll-padding-bottom", "auto | <length-percentage [0,∞]>", "auto", animatable),
+	p("scroll-padding-left", "auto | <length-percentage [0,∞]>", "auto", animatable),
+	p("scroll-padding-right", "auto | <length-percentage [0,∞]>", "auto", animatable),
+	p("scroll-padding-top", "auto | <length-percentage [0,∞]>", "auto", animatable),
+	p("scroll-snap-align", "[ none | start | end | center ]{1,2}", "none", 0),
+	p("scroll-snap-stop", "normal | always", "normal", 0),
+	p("scroll-snap-type", "none | [ x | y | block | inline | both ] [ mandatory | proximity ]?", "none", 0),
+	p("scrollbar-color", "auto | <color>{2}", "auto", inherited|animatable),
+	p("scrollbar-gutter", "auto | stable && both-edges?", "auto", 0),
+	p("scrollbar-width", "auto | thin | none", "auto", 0),
+	p("shape-outside", "none | [ <basic-shape> || <shape-box> ] | <image>", "none", animatable),
+	p("tab-size", "<number [0,∞]> | <length [0,∞]>", "8", inherited|animatable),
+	p("table-layout", "auto | fixed", "auto", 0),
+	p("text-align", "start | end | left | right | center | <string> | justify | match-parent | justify-all", "start", inherited),
+	p("text-align-last", "auto | start | end | left | right | center | justify | match-parent", "auto", inherited),
+	p("text-decoration", "<'text-decoration-line'> || <'text-decoration-thickness'> || <'text-decoration-style'> || <'text-decoration-color'>", "", animatable,
+		"text-decoration-line", "text-decoration-thickness", "text-decoration-style",
+		"text-decoration-color"),
+	p("text-decoration-color", "<color>", "currentcolor", animatable),
+	p("text-decoration-line", "none | [ underline || overline || line-through || blink ] | spelling-error | grammar-error", "none", 0),
+	p("text-decoration-style", "solid | double | dotted | dashed | wavy", "solid", 0),
+	p("text-decoration-thickness", "auto | from-font | <length-percentage>", "auto", animatable),
+	p("text-emphasis", "<'text-emphasis-style'> || <'text-emphasis-color'>", "", inherited|animatable,
+		"text-emphasis-style", "text-emphasis-color"),
+	p("text-emphasis-color", "<color>", "currentcolor", inherited|animatable),
+	p("text-emphasis-position", "[ over | under ] && [ right | left ]?", "over right", inherited),
+	p("text-emphasis-style", "none | [ [ filled | open ] || [ dot | circle | double-circle | triangle | sesame ] ] | <string>", "none", inherited),
+	p("text-indent", "[ <length-percentage> ] && hanging? && each-line?", "0", inherited|animatable),
+	p("text-orientation", "mixed | upright | sideways", "mixed", inherited),
+	p("text-overflow", "[ clip | ellipsis | <string> | fade | <fade()> ]{1,2}", "clip", 0),
+	p("text-rendering", "auto | optimizeSpeed | optimizeLegibility | geometricPrecision", "auto", inherited),
+	p("text-shadow", "none | <shadow>#", "none", inherited|animatable),
+	p("text-transform", "none | [ capitalize | uppercase | lowercase ] || full-width || full-size-kana | math-auto", "none", inherited),
+	p("text-underline-offset", "auto | <length-percentage>", "auto", inherited|animatable),
+	p("text-underline-position", "auto | from-font | [ under || [ left | right ] ]", "auto", inherited),
+	p("top", "auto | <length-percentage>", "auto", animatable),
+	p("touch-action", "auto | none | [ [ pan-x | pan-left | pan-right ] || [ pan-y | pan-up | pan-down ] || pinch-zoom ] | manipulation", "auto", 0),
+	p("transform", "none | <transform-list>", "none", animatable),
+	p("transform-box", "content-box | border-box | fill-box | stroke-box | view-box", "view-box", 0),
+	p("transform-origin", "[ left | center | right | top | bottom | <length-percentage> ] | [ left | center | right | <length-percentage> ] [ top | center | bottom | <length-percentage> ] <length>? | [ [ center | left | right ] && [ center | top | bottom ] ] <length>?", "50% 50%", animatable),
+	p("transform-style", "flat | preserve-3d", "flat", 0),
+	p("transition", "<single-transition>#", "", 0,
+		"transition-property", "transition-duration", "transition-timing-function",
+		"transition-delay", "transition-behavior"),
+	p("transition-behavior", "<transition-behavior-value>#", "normal", 0),
+	p("transition-delay", "<time>#", "0s", 0),
+	p("transition-duration", "<time [0s,∞]>#", "0s", 0),
+	p("transition-property", "none | <single-transition-property>#", "all", 0),
+	p("transition-timing-function", "<easing-function>#", "ease", 0),
+	p("translate", "none | <length-percentage> [ <length-percentage> <length>? ]?", "none", animatable),
+	p("unicode-bidi", "normal | embed | isolate | bidi-override | isolate-override | plaintext", "normal", 0),
+	p("user-select", "auto | text | none | contain | all", "auto", 0),
+	p("vertical-align", "[ first | last ] || <'alignment-baseline'> || <'baseline-shift'>", "baseline", animatable),
+	p("visibility", "visible | hidden | collapse", "visible", inherited|animatable),
+	p("white-space", "normal | pre | pre-wrap | pre-line | <'white-space-collapse'> || <'text-wrap-mode'> || <'white-space-trim'>", "normal", inherited),
+	p("widows", "<integer [1,∞]>", "2", inherited|animatable),
+	p("width", "auto | <length-percentage [0,∞]> | min-content | max-content | fit-content( <length-percentage [0,∞]> )", "auto", animatable),
+	p("will-change", "auto | <animateable-feature>#", "auto", 0),
+	p("word-break", "normal | break-all | keep-all | manual | auto-phrase | break-word", "normal", inherited),
+	p("word-spacing", "normal | <length-percentage>", "normal", inherited|animatable),
+	p("word-wrap", "normal | break-word | anywhere", "normal", inherited|deprecated),
+	p("writing-mode", "horizontal-tb | vertical-rl | vertical-lr | sideways-rl | sideways-lr", "horizontal-tb", inherited),
+	p("z-index", "auto | <integer>", "auto", animatable),
+	p("zoom", "normal | reset | <number [0,∞]> | <percentage [0,∞]>", "1", animatable|nonstandard),
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package props
+
+import (
+	"sort"
+	"strings"
+)
+
+// valueTypes maps value definition types that aren't keyword-only to the
+// parts of their grammar that matter for type lookups.
+var valueTypes = map[string]string{
+	"line-width": "<length [0,∞]> | thin | medium | thick",
+}
+
+// types returns the sorted value definition types referenced by a value
+// definition syntax, following property references and known type grammars.
+func types(grammar string) []string {
+	set := map[string]bool{}
+	collectTypes(grammar, set, 0)
+	list := make([]string, 0, len(set))
+	for t := range set {
+		list = append(list, t)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// collectTypes adds the types referenced by a value definition syntax to set.
+func collectTypes(grammar string, set map[string]bool, depth int) {
+	if depth > 8 {
+		return
+	}
+	for _, t := range grammarToken.FindAllString(grammar, -1) {
+		switch {
+		case strings.HasPrefix(t, "<'"):
+			if p := byName[t[2:len(t)-2]]; p != nil {
+				collectTypes(p.Value, set, depth+1)
+			}
+		case strings.HasPrefix(t, "<"):
+			name := strings.TrimSuffix(strings.SplitN(t[1:len(t)-1], " ", 2)[0], "()")
+			set[name] = true
+			if g, ok := keywordTypes[name]; ok {
+				collectTypes(g, set, depth+1)
+			} else if g, ok := valueTypes[name]; ok {
+				collectTypes(g, set, depth+1)
+			}
+		}
+	}
+}
+
+// AcceptsType reports whether the value grammar of the property refers to the
+// value definition type, such as "length" or "color", directly or through
+// another property or type.
+func (p *Property) AcceptsType(name string) bool {
+	i := sort.SearchStrings(p.Types, name)
+	return i < len(p.Types) && p.Types[i] == name
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package css
+
+import (
+	"strings"
+
+	"github.com/gorilla/css/scanner"
+)
+
+// Match is a rule, declaration or component value found in a stylesheet by
+// one of the Find methods, with the rules holding it, so that it can be
+// modified or removed in place.
+type Match struct {
+	// Parents are the rules holding Rule, from the outermost one.
+	Parents []*Rule
+	// Rule is the rule found, or the rule holding the declaration or value
+	// found.
+	Rule *Rule
+	// Declaration is the declaration found, or the declaration holding the
+	// value found. It is nil for rules and for values of preludes.
+	Declaration *Declaration
+	// Value is the component value found, or nil.
+	Value *ComponentValue
+}
+
+// Position returns the position of the node found in the input: that of
+// Value if it isn't nil, then of Declaration, then of Rule.
+func (m Match) Position() (line, column int) {
+	switch {
+	case m.Value != nil:
+		return m.Value.Token.Line, m.Value.Token.Column
+	case m.Declaration != nil:
+		return m.Declaration.Line, m.Declaration.Column
+	}
+	return m.Rule.Line, m.Rule.Column
+}
+
+// FindRules returns the rules of the stylesheet, including nested rules,
+// for which fn returns true, in the order of the input:
+//
+//	fonts := s.FindRules(func(r *css.Rule) bool {
+//		return strings.EqualFold(r.AtKeyword, "font-face")
+//	})
+//
+// The selector package finds style rules by selector.
+func (s *Stylesheet) FindRules(fn func(r *Rule) bool) []Match {
+	var matches []Match
+	walkRules(s.Rules, nil, func(parents []*Rule, r *Rule) {
+		if fn(r) {
+			matches = append(matches, Match{Parents: parents, Rule: r})
+		}
+	})
+	return matches
+}
+
+// FindDeclarations returns the declarations of a property in the
+// stylesheet, in the order of the input. Property names are compared
+// ignoring case, except custom property names.
+func (s *Stylesheet) FindDeclarations(property string) []Match {
+	var matches []Match
+	walkRules(s.Rules, nil, func(parents []*Rule, r *Rule) {
+		for _, d := range r.Declarations {
+			if d.Property == property || !strings.HasPrefix(property, "--") && strings.EqualFold(d.Property, property) {
+				matches = append(matches, Match{Parents: parents, Rule: r, Declaration: d})
+			}
+		}
+	})
+	return matches
+}
+
+// FindFunctions returns the functions named name, ignoring case, such as
+// "calc" or "url", in the values of the declarations and in the preludes of
+// the stylesheet, including the functions nested in the arguments of
+// others, in the order of the input.
+func (s *Stylesheet) FindFunctions(name string) []Match {
+	return s.findValues(func(v *ComponentValue) bool {
+		return v.IsFunction() && strings.EqualFold(scanner.Unescape(v.Name()), name)
+	})
+}
+
+// FindVars returns the var() functions referencing the custom property
+// name, such as "--main-color", in the stylesheet, including those in the
+// fallback values of others, in the order of the input.
+func (s *Stylesheet) FindVars(name string) []Match {
+	return s.findValues(func(v *ComponentValue) bool {
+		if !v.IsFunction() || !strings.EqualFold(v.Name(), "var") {
+			return false
+		}
+		for _, arg := range v.Children {
+			if arg.Token.Type != scanner.TokenS {
+				return arg.Token.Type == scanner.TokenIdent && arg.Token.DecodedValue() == name
+			}
+		}
+		return false
+	})
+}
+
+// findValues returns the component values of the preludes and declarations
+// of the stylesheet for which fn returns true.
+func (s *Stylesheet) findValues(fn func(v *ComponentValue) bool) []Match {
+	var matches []Match
+	walkRules(s.Rules, nil, func(parents []*Rule, r *Rule) {
+		walkValues(r.Prelude, func(v *ComponentValue) {
+			if fn(v) {
+				matches = append(matches, Match{Parents: parents, Rule: r, Value: v})
+			}
+		})
+		for _, d := range r.Declarations {
+			walkValues(d.Value, func(v *ComponentValue) {
+				if fn(v) {
+					matches = append(matches, Match{Parents: parents, Rule: r, Declaration: d, Value: v})
+				}
+			})
+		}
+	})
+	return matches
+}
+
+// walkRules calls fn for each rule of a list of rules and their nested
+// rules, in order, with the rules holding it after parents. The parents
+// passed to fn aren't modified by later calls.
+func walkRules(rules []*Rule, parents []*Rule, fn func(parents []*Rule, r *Rule)) {
+	for _, r := range rules {
+		fn(parents, r)
+		if len(r.Rules) > 0 {
+			walkRules(r.Rules, append(parents[:len(parents):len(parents)], r), fn)
+		}
+	}
+}
+
+// walkValues calls fn for each component value of a list and their
+// children, in order.
+func walkValues(values []*ComponentValue, fn func(v *ComponentValue)) {
+	for _, v := range values {
+		fn(v)
+		walkValues(v.Children, fn)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package css
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// describeMatches returns the positions and the text of the nodes found,
+// with the number of parents of their rule.
+func describeMatches(matches []Match) []string {
+	var out []string
+	for _, m := range matches {
+		line, col := m.Position()
+		var text string
+		switch {
+		case m.Value != nil:
+			text = m.Value.String()
+		case m.Declaration != nil:
+			text = m.Declaration.String()
+		default:
+			text = "@" + m.Rule.AtKeyword
+		}
+		out = append(out, fmt.Sprintf("%d:%d %s %d", line, col, text, len(m.Parents)))
+	}
+	return out
+}
+
+func TestFind(t *testing.T) {
+	s, err := ParseStylesheet(`a { COLOR: red; --color: var(--main, var(--x)) }
+@media (width < calc(10px + 1em)) {
+  b { color: var(--main); width: CALC(1px + calc(2px)) }
+  @supports (x: y) { c { --Color: blue } }
+}
+@font-face { src: url(a.woff) }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tcs := []struct {
+		desc     string
+		matches  []Match
+		expected []string
+	}{
+		{"declarations", s.FindDeclarations("color"), []string{"1:5 COLOR:red 0", "3:7 color:var(--main) 1"}},
+		{"custom property", s.FindDeclarations("--color"), []string{"1:17 --color:var(--main, var(--x)) 0"}},
+		{"functions", s.FindFunctions("calc"), []string{"2:17 calc(10px + 1em) 0", "3:34 CALC(1px + calc(2px)) 1", "3:45 calc(2px) 1"}},
+		{"vars", s.FindVars("--main"), []string{"1:26 var(--main, var(--x)) 0", "3:14 var(--main) 1"}},
+		{"nested vars", s.FindVars("--x"), []string{"1:38 var(--x) 0"}},
+		{"rules", s.FindRules(func(r *Rule) bool { return r.IsAtRule() }), []string{"2:1 @media 0", "4:3 @supports 1", "6:1 @font-face 0"}},
+	}
+	for _, tc := range tcs {
+		if got := describeMatches(tc.matches); !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("%s:\ngot  %q\nwant %q", tc.desc, got, tc.expected)
+		}
+	}
+
+	// The matches point into the stylesheet, for codemods.
+	for _, m := range s.FindVars("--main") {
+		m.Declaration.Value, _ = ParseComponentValues("var(--primary)")
+	}
+	if got := s.String(); strings.Contains(got, "--main") || strings.Count(got, "var(--primary)") != 2 {
+		t.Errorf("got %q, want the var(--main) declarations replaced", got)
+	}
+	nested := s.FindRules(func(r *Rule) bool { return r.AtKeyword == "" && r.Prelude[0].Token.Value == "c" })
+	if len(nested) != 1 || !reflect.DeepEqual(nested[0].Parents, []*Rule{s.Rules[1], s.Rules[1].Rules[1]}) {
+		t.Errorf("got %+v, want the parents of c", nested)
+	}
+}
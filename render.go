@@ -0,0 +1,194 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package css
+
+import (
+	"io"
+	"strings"
+
+	"github.com/gorilla/css/scanner"
+	"github.com/gorilla/css/sourcemap"
+)
+
+// RenderOptions controls how strings, urls and names are escaped by the
+// Render methods. Unlike WriteTo and String, which write tokens as they were
+// found in the input, Render decodes their escape sequences and escapes them
+// again following the serialization rules of the CSSOM specification, so
+// that equivalent inputs render identically.
+type RenderOptions struct {
+	// Quote is the quotation mark of strings and quoted urls, '"' or '\''.
+	// If it is zero, strings keep their quotation mark.
+	Quote byte
+	// UpperHex writes hexadecimal escape sequences in uppercase.
+	UpperHex bool
+	// EscapeNonASCII writes non-ASCII characters as hexadecimal escape
+	// sequences.
+	EscapeNonASCII bool
+	// MinimalEscapes omits the space that ends hexadecimal escape sequences
+	// when the next character can't be mistaken for part of the sequence,
+	// as in "\31 x" becoming "\31x".
+	MinimalEscapes bool
+	// PreserveRaw writes the rules and declarations that weren't modified
+	// since they were parsed as found in the input, including comments and
+	// whitespace, so that only modified parts of a stylesheet change.
+	PreserveRaw bool
+	// SourceMap, if not nil, receives the mappings from positions in the
+	// output to the positions of the rules, declarations and tokens in the
+	// input, for source 0 of the map.
+	SourceMap *sourcemap.Map
+	// Canonical writes the canonical form of the CSS, in which the other
+	// options are ignored except SourceMap. See the package documentation.
+	Canonical bool
+
+	// htmlAttr escapes double quotes and ampersands, and quotes urls, so
+	// that the output can be used in an HTML attribute as is.
+	htmlAttr bool
+}
+
+// newRenderWriter returns a writer rendering to w according to opts.
+func newRenderWriter(w io.Writer, opts RenderOptions) *writer {
+	if opts.Canonical {
+		opts = RenderOptions{Canonical: true, Quote: '"', SourceMap: opts.SourceMap}
+	}
+	return &writer{w: w, opts: &opts}
+}
+
+// Render writes the CSS representation of the stylesheet to w, escaped
+// according to opts. It returns the number of bytes written and the first
+// error encountered, if any.
+func (s *Stylesheet) Render(w io.Writer, opts RenderOptions) (int64, error) {
+	cw := newRenderWriter(w, opts)
+	writeRules(cw, s.Rules)
+	return cw.n, cw.err
+}
+
+// Render writes the CSS representation of the rule to w, escaped according
+// to opts. It returns the number of bytes written and the first error
+// encountered, if any.
+func (r *Rule) Render(w io.Writer, opts RenderOptions) (int64, error) {
+	cw := newRenderWriter(w, opts)
+	writeRule(cw, r)
+	return cw.n, cw.err
+}
+
+// Render writes the CSS representation of the declaration to w, escaped
+// according to opts. It returns the number of bytes written and the first
+// error encountered, if any.
+func (d *Declaration) Render(w io.Writer, opts RenderOptions) (int64, error) {
+	cw := newRenderWriter(w, opts)
+	writeDeclaration(cw, d)
+	return cw.n, cw.err
+}
+
+// Render writes the CSS representation of the component value to w, escaped
+// according to opts. It returns the number of bytes written and the first
+// error encountered, if any.
+func (v *ComponentValue) Render(w io.Writer, opts RenderOptions) (int64, error) {
+	cw := newRenderWriter(w, opts)
+	writeValue(cw, v)
+	return cw.n, cw.err
+}
+
+// RenderInline returns the CSS representation of a list of declarations for
+// the style attribute of an HTML element, as in "color:red;margin:0".
+//
+// Declarations are separated by semicolons, without a trailing one, and
+// comments are dropped. Strings and urls are quoted with single quotes, and
+// double quotes and ampersands in them are escaped. Declarations that
+// [InlineSafe] reports as unsafe are dropped, so the result can be written
+// in a double-quoted attribute without HTML escaping.
+func RenderInline(decls []*Declaration) string {
+	var b strings.Builder
+	for _, d := range decls {
+		s := renderInline(d)
+		if !inlineSafe(s) {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte(';')
+		}
+		b.WriteString(s)
+	}
+	return b.String()
+}
+
+// InlineSafe reports whether RenderInline writes a declaration. A
+// declaration with an ampersand outside of its strings and urls, as in
+// "--x: &#34", isn't written, since HTML would decode it as a character
+// reference and change the meaning of the attribute.
+func InlineSafe(d *Declaration) bool {
+	return inlineSafe(renderInline(d))
+}
+
+// renderInline returns the CSS representation of a declaration for a style
+// attribute.
+func renderInline(d *Declaration) string {
+	var b strings.Builder
+	writeDeclaration(&writer{w: &b, opts: &RenderOptions{Quote: '\'', htmlAttr: true}}, d)
+	return b.String()
+}
+
+// inlineSafe reports whether the CSS representation of a declaration can be
+// written in a double-quoted attribute without HTML escaping.
+func inlineSafe(s string) bool {
+	return !strings.ContainsAny(s, `"&`)
+}
+
+// renderToken returns the CSS representation of a token, escaped according
+// to opts.
+func renderToken(t *scanner.Token, opts *RenderOptions) string {
+	v := t.Value
+	switch t.Type {
+	case scanner.TokenIdent:
+		return escapeIdent(t.DecodedValue(), opts)
+	case scanner.TokenFunction:
+		return escapeIdent(canonicalName(t.DecodedValue(), opts), opts) + "("
+	case scanner.TokenAtKeyword:
+		return "@" + escapeIdent(canonicalName(t.DecodedValue(), opts), opts)
+	case scanner.TokenHash:
+		return "#" + escapeName(t.DecodedValue(), opts)
+	case scanner.TokenDimension:
+		unit := strings.TrimLeft(v, "+-.0123456789")
+		return v[:len(v)-len(unit)] + escapeIdent(canonicalName(scanner.Unescape(unit), opts), opts)
+	case scanner.TokenString:
+		return renderString(v, opts)
+	case scanner.TokenURI:
+		// Only quoted urls are rendered again; the escaping rules of
+		// unquoted ones differ.
+		prefix := v[:4]
+		if opts.Canonical {
+			prefix = "url("
+		}
+		inner := strings.Trim(v[4:len(v)-1], " \t\n\r\f")
+		if inner != "" && (inner[0] == '"' || inner[0] == '\'') {
+			return prefix + renderString(inner, opts) + ")"
+		}
+		if opts.htmlAttr && inner != "" {
+			return prefix + escapeString(scanner.Unescape(inner), opts.Quote, opts) + ")"
+		}
+		if opts.Canonical {
+			return prefix + inner + ")"
+		}
+	}
+	return v
+}
+
+// canonicalName returns a case-insensitive name in lowercase in canonical
+// mode. Custom names, which start with two dashes, are case-sensitive.
+func canonicalName(name string, opts *RenderOptions) string {
+	if !opts.Canonical || strings.HasPrefix(name, "--") {
+		return name
+	}
+	return strings.ToLower(name)
+}
+
+// renderString returns a quoted string escaped according to opts.
+func renderString(s string, opts *RenderOptions) string {
+	quote := opts.Quote
+	if quote == 0 {
+		quote = s[0]
+	}
+	return escapeString(scanner.Unescape(s[1:len(s)-1]), quote, opts)
+}
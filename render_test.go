@@ -0,0 +1,222 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package css
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/gorilla/css/scanner"
+	"github.com/gorilla/css/sourcemap"
+)
+
+func TestRender(t *testing.T) {
+	tcs := []struct {
+		opts     RenderOptions
+		input    string
+		expected string
+	}{
+		{
+			RenderOptions{},
+			`a { content: 'it\'s "x"'; font-family: "caf\e9" }`,
+			`a{content:'it\'s "x"';font-family:"café"}`,
+		},
+		{
+			RenderOptions{Quote: '"'},
+			`a { content: 'it\'s "x"'; background: url( 'a.png' ) }`,
+			`a{content:"it's \"x\"";background:url("a.png")}`,
+		},
+		{
+			RenderOptions{Quote: '\'', EscapeNonASCII: true, UpperHex: true},
+			`.caf\e9 { content: "é\a" }`,
+			`.caf\E9 {content:'\E9 \A '}`,
+		},
+		{
+			RenderOptions{EscapeNonASCII: true},
+			`#\31 0, .\-, .a\.b { width: 1\70x }`,
+			`#10, .\-, .a\.b{width:1px}`,
+		},
+		{
+			RenderOptions{},
+			`.\31 0 { c\olor: red }`,
+			`.\31 0{color:red}`,
+		},
+	}
+	for _, tc := range tcs {
+		s, err := ParseStylesheet(tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var b strings.Builder
+		if _, err := s.Render(&b, tc.opts); err != nil {
+			t.Fatal(err)
+		}
+		if got := b.String(); got != tc.expected {
+			t.Errorf("%s:\ngot  %s\nwant %s", tc.input, got, tc.expected)
+		}
+	}
+}
+
+func TestMinimalEscapes(t *testing.T) {
+	opts := &RenderOptions{MinimalEscapes: true}
+	tcs := []struct{ input, expected string }{
+		{"1x", `\31x`},
+		{"1a", `\31 a`},
+		{"1 x", `\31\ x`},
+		{"-1", `-\31 `},
+		{"a\x01b", `a\1 b`},
+		{"a\x01z", `a\1z`},
+	}
+	for _, tc := range tcs {
+		if got := escapeIdent(tc.input, opts); got != tc.expected {
+			t.Errorf("%q: got %s, want %s", tc.input, got, tc.expected)
+		}
+	}
+}
+
+func FuzzEscape(f *testing.F) {
+	for _, s := range []string{"a", "10", "-1", "-", "--x", "a b", "caf\u00e9\x01", "a\uffff", `\`, `"'`, "\n9"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		if !utf8.ValidString(s) || strings.ContainsRune(s, 0) {
+			t.Skip()
+		}
+		for _, opts := range []RenderOptions{{}, {MinimalEscapes: true, EscapeNonASCII: true, UpperHex: true}} {
+			// The parser doesn't read "--" as an identifier.
+			if s != "" && s != "--" {
+				ident := escapeIdent(s, &opts)
+				if got := scanner.Unescape(ident); got != s {
+					t.Errorf("%+v: %q escaped as %q unescapes to %q", opts, s, ident, got)
+				}
+				if values, err := ParseComponentValues(ident); err != nil || len(values) != 1 ||
+					values[0].Token.Type != scanner.TokenIdent || values[0].Token.Value != ident {
+					t.Errorf("%+v: %q escaped as %q parses as %v", opts, s, ident, values)
+				}
+			}
+			str := escapeString(s, '"', &opts)
+			if got := scanner.Unescape(str[1 : len(str)-1]); got != s {
+				t.Errorf("%+v: %q escaped as %q unescapes to %q", opts, s, str, got)
+			}
+			if tok := scanner.New(str).Next(); tok.Type != scanner.TokenString || tok.Value != str {
+				t.Errorf("%+v: %q escaped as %q scans as %s", opts, s, str, tok)
+			}
+		}
+	})
+}
+
+func TestPreserveRaw(t *testing.T) {
+	input := "a { color : RED /* red */ ; margin:0 }\n@import 'x.css' ;\nb {\n  top: 0;\n  c { left: 0 }\n}"
+	s, err := ParseStylesheet(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.Rules[0].Declarations[0].Raw, "color : RED"; got != want {
+		t.Errorf("got raw %q, want %q", got, want)
+	}
+	render := func() string {
+		var b strings.Builder
+		s.Render(&b, RenderOptions{PreserveRaw: true, Quote: '"'})
+		return b.String()
+	}
+	if got, want := render(), "a { color : RED /* red */ ; margin:0 }@import 'x.css';b {\n  top: 0;\n  c { left: 0 }\n}"; got != want {
+		t.Errorf("unmodified:\ngot  %q\nwant %q", got, want)
+	}
+	s.Rules[0].Declarations[1].Value[0].Token.Value = "1px"
+	s.Rules[2].Declarations[0].Property = "bottom"
+	if got, want := render(), "a{color : RED;margin:1px}@import 'x.css';b{bottom:0;c { left: 0 }}"; got != want {
+		t.Errorf("modified:\ngot  %q\nwant %q", got, want)
+	}
+}
+
+func TestSourceMap(t *testing.T) {
+	s, err := ParseStylesheet("a {\n  color: red;\n  margin: 0 1px\n}\n\nb{top:0}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &sourcemap.Map{}
+	var b strings.Builder
+	s.Render(&b, RenderOptions{SourceMap: m})
+	if got, want := b.String(), "a{color:red;margin:0 1px}b{top:0}"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	var got []string
+	for _, mp := range m.Mappings {
+		got = append(got, fmt.Sprintf("%d:%d->%d:%d", mp.Line, mp.Column, mp.SourceLine, mp.SourceColumn))
+	}
+	want := "1:1->1:1 1:3->2:3 1:9->2:10 1:13->3:3 1:20->3:11 1:21->3:12 1:22->3:13 1:26->6:1 1:28->6:3 1:32->6:7"
+	if strings.Join(got, " ") != want {
+		t.Errorf("got  %s\nwant %s", strings.Join(got, " "), want)
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	inputs := []string{
+		"/* x */ a , b { COLOR : Red ; --Foo: Bar; width: CALC( 1PX + 2Em ) }\n@MEDIA print { a { content: 'a\\62 c' } }",
+		"a,b{color:Red;--Foo:Bar;width:calc(1px + 2em)}@media print{a{content:\"abc\"}}",
+		"a,  b{ /* c */ Color:Red; --Foo:Bar ;Width:Calc(1px  +  2EM)}@Media print{a{CONTENT:'\\61 bc'}}",
+	}
+	want := `a,b{color:Red;--Foo:Bar;width:calc(1px + 2em)}@media print{a{content:"abc"}}`
+	for _, input := range inputs {
+		s, err := ParseStylesheet(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var b strings.Builder
+		s.Render(&b, RenderOptions{Canonical: true, Quote: '\'', UpperHex: true})
+		if b.String() != want {
+			t.Errorf("%q:\ngot  %q\nwant %q", input, b.String(), want)
+		}
+	}
+}
+
+func TestRenderInline(t *testing.T) {
+	s, err := ParseStylesheet(`a { /* c */ color : red ; font-family: "A&B \"Sans\"", serif; background: url(a.png?x=1&y=2) !important; content: '\26' }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := RenderInline(s.Rules[0].Declarations)
+	want := `color:red;font-family:'A\26 B \22 Sans\22 ', serif;background:url('a.png?x=1\26 y=2')!important;content:'\26 '`
+	if got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+	if strings.ContainsAny(got, `"&`) {
+		t.Errorf("%s is not safe in an HTML attribute", got)
+	}
+}
+
+func TestRenderInlineHTMLDecoding(t *testing.T) {
+	tcs := []struct {
+		input, want string
+	}{
+		{`color: &#39 'x; position:fixed; top:0; left:0 '`, ``},
+		{`--x: &#34`, ``},
+		{`--x: &quot; color: red`, `color:red`},
+		{`--x: a&b; margin: 0`, `margin:0`},
+		{`--x: {a: &amp}; margin: 0`, `margin:0`},
+		{`content: "&#39"; margin: 0`, `content:'\26 #39';margin:0`},
+	}
+	for _, tc := range tcs {
+		s, err := ParseStylesheet("a{" + tc.input + "}")
+		if err != nil {
+			t.Fatalf("%s: %v", tc.input, err)
+		}
+		got := RenderInline(s.Rules[0].Declarations)
+		if got != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.input, got, tc.want)
+		}
+		// The attribute means the same once decoded by HTML.
+		decoded, err := ParseStylesheet("a{" + html.UnescapeString(got) + "}")
+		if err != nil {
+			t.Fatalf("%s: %v", tc.input, err)
+		}
+		if again := RenderInline(decoded.Rules[0].Declarations); again != got {
+			t.Errorf("%s: decoded as %q, want %q", tc.input, again, got)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sanitize
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/diag"
+	"github.com/gorilla/css/scanner"
+	"github.com/gorilla/css/selector"
+)
+
+// Risk is a kind of construct that injected CSS can use to fingerprint
+// users or to exfiltrate the contents of a page.
+type Risk int
+
+const (
+	// AttributeLeak is a style rule whose selector matches the value of an
+	// attribute, such as input[value^=a], and whose declarations fetch an
+	// external URL, which tells a server that the value matches.
+	AttributeLeak Risk = iota
+	// VisitedStyling is a style rule with :visited in its selector, which
+	// can reveal the browsing history through timing or rendering.
+	VisitedStyling
+	// LocalFontProbe is a @font-face rule whose src tries a local() font
+	// before an external URL, which is only fetched if the font isn't
+	// installed. Many of them enumerate the installed fonts.
+	LocalFontProbe
+	// UnicodeRangeProbe is a @font-face rule with an external URL and a
+	// unicode-range of a single code point, which is only fetched if the
+	// page has that character. Many of them leak the text of a page.
+	UnicodeRangeProbe
+)
+
+// String returns a string representation of the risk.
+func (r Risk) String() string {
+	switch r {
+	case AttributeLeak:
+		return "attribute leak"
+	case VisitedStyling:
+		return "visited styling"
+	case LocalFontProbe:
+		return "local font probe"
+	}
+	return "unicode-range probe"
+}
+
+// Finding is a construct found by Audit.
+type Finding struct {
+	Risk Risk
+	// Detail is the selector of style rules, or the src of @font-face
+	// rules.
+	Detail string
+	// URLs are the external URLs fetched by the rule.
+	URLs   []string
+	Line   int
+	Column int
+}
+
+// String returns a string representation of the finding.
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %q (line: %d, column: %d)", f.Risk, f.Detail, f.Line, f.Column)
+}
+
+// Diagnostic returns the finding as a warning whose code is its risk, such
+// as "attribute-leak", and whose message has its detail and URLs.
+func (f Finding) Diagnostic() diag.Diagnostic {
+	msg := fmt.Sprintf("%s: %s", f.Risk, f.Detail)
+	if len(f.URLs) > 0 {
+		msg += " fetches " + strings.Join(f.URLs, ", ")
+	}
+	pos := diag.Position{Line: f.Line, Column: f.Column}
+	return diag.Diagnostic{
+		Code:     strings.ReplaceAll(f.Risk.String(), " ", "-"),
+		Severity: diag.Warning,
+		Message:  msg,
+		Span:     diag.Span{Start: pos, End: pos},
+	}
+}
+
+// Audit reports the constructs of a stylesheet that are commonly used to
+// fingerprint users or to exfiltrate data, in the order of the stylesheet.
+// They are legitimate in trusted stylesheets, but are worth a review in
+// stylesheets written by third parties. Audit doesn't modify the
+// stylesheet.
+//
+// External URLs are those that aren't data URLs or fragments, such as
+// url(#a).
+func Audit(s *css.Stylesheet) []Finding {
+	var findings []Finding
+	auditRules(s.Rules, &findings)
+	return findings
+}
+
+// auditRules appends the findings of a list of rules to findings.
+func auditRules(rules []*css.Rule, findings *[]Finding) {
+	for _, r := range rules {
+		switch {
+		case !r.IsAtRule():
+			auditStyleRule(r, findings)
+		case strings.EqualFold(scanner.Unescape(r.AtKeyword), "font-face"):
+			auditFontFace(r, findings)
+		}
+		auditRules(r.Rules, findings)
+	}
+}
+
+// auditStyleRule appends the findings of a style rule to findings.
+func auditStyleRule(r *css.Rule, findings *[]Finding) {
+	list, err := selector.Parse(r.Prelude)
+	if err != nil {
+		// Nested style rules may have relative selectors.
+		if list, err = selector.ParseRelative(r.Prelude); err != nil {
+			return
+		}
+	}
+	attr, visited := false, false
+	list.Walk(func(s *selector.Simple) bool {
+		attr = attr || s.Kind == selector.Attribute && s.Operator != ""
+		visited = visited || s.Kind == selector.PseudoClass && s.Name == "visited"
+		return true
+	})
+	add := func(risk Risk, urls []string) {
+		*findings = append(*findings, Finding{risk, css.ValuesString(r.Prelude), urls, r.Line, r.Column})
+	}
+	if urls := externalURLs(r.Declarations); attr && len(urls) > 0 {
+		add(AttributeLeak, urls)
+	}
+	if visited {
+		add(VisitedStyling, externalURLs(r.Declarations))
+	}
+}
+
+// auditFontFace appends the findings of a @font-face rule to findings.
+func auditFontFace(r *css.Rule, findings *[]Finding) {
+	urls := externalURLs(r.Declarations)
+	if len(urls) == 0 {
+		return
+	}
+	for _, d := range r.Declarations {
+		switch strings.ToLower(scanner.Unescape(d.Property)) {
+		case "src":
+			if hasLocalFallback(d.Value) {
+				*findings = append(*findings, Finding{LocalFontProbe, css.ValuesString(d.Value), urls, r.Line, r.Column})
+			}
+		case "unicode-range":
+			if isSingleCodePoint(d.Value) {
+				*findings = append(*findings, Finding{UnicodeRangeProbe, css.ValuesString(d.Value), urls, r.Line, r.Column})
+			}
+		}
+	}
+}
+
+// externalURLs returns the external URLs of a list of declarations.
+func externalURLs(decls []*css.Declaration) []string {
+	if len(decls) == 0 {
+		return nil
+	}
+	var urls []string
+	for _, ref := range css.ExtractURLs(&css.Stylesheet{Rules: []*css.Rule{{Declarations: decls}}}) {
+		u := cleanURL(ref.URL)
+		if u != "" && u[0] != '#' && !strings.EqualFold(urlScheme(u), "data") {
+			urls = append(urls, ref.URL)
+		}
+	}
+	return urls
+}
+
+// hasLocalFallback reports whether the value of a src descriptor has a
+// local() font followed by a URL.
+func hasLocalFallback(values []*css.ComponentValue) bool {
+	local := false
+	for _, v := range values {
+		switch {
+		case v.IsFunction() && strings.EqualFold(v.Token.DecodedValue(), "local"):
+			local = true
+		case local && (v.Token.Type == scanner.TokenURI || v.IsFunction() && strings.EqualFold(v.Token.DecodedValue(), "url")):
+			return true
+		}
+	}
+	return false
+}
+
+// isSingleCodePoint reports whether the value of a unicode-range descriptor
+// covers a single code point.
+func isSingleCodePoint(values []*css.ComponentValue) bool {
+	values = css.TrimSpace(values)
+	if len(values) != 1 || values[0].Token.Type != scanner.TokenUnicodeRange {
+		return false
+	}
+	r := values[0].Token.Value[2:]
+	if strings.Contains(r, "?") {
+		return false
+	}
+	start, end, ok := strings.Cut(r, "-")
+	if !ok {
+		return true
+	}
+	a, err1 := strconv.ParseUint(start, 16, 32)
+	b, err2 := strconv.ParseUint(end, 16, 32)
+	return err1 == nil && err2 == nil && a == b
+}
@@ -0,0 +1,270 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sanitize
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/scanner"
+)
+
+// ImportMode is the handling of @import rules by a policy.
+type ImportMode int
+
+const (
+	// KeepImports keeps the @import rules whose URL and conditions are
+	// allowed, if "import" is in the at-rules of the policy.
+	KeepImports ImportMode = iota
+	// StripImports removes all @import rules.
+	StripImports
+	// ResolveImports replaces @import rules with the rules of the
+	// stylesheets they import, loaded with the Loader of the policy and
+	// sanitized with the policy. "import" doesn't need to be in the
+	// at-rules of the policy.
+	ResolveImports
+)
+
+// The budgets of ResolveImports when the policy doesn't set them.
+const (
+	DefaultMaxImportDepth = 4
+	DefaultMaxImportSize  = 1 << 20
+)
+
+// importer is the state of the resolution of imports by a checker.
+type importer struct {
+	// at is the top-level @import rule whose stylesheet is being checked, or
+	// nil. Removals in imported stylesheets are reported at its position.
+	at *css.Rule
+	// chain are the URLs of the stylesheets being imported, outermost
+	// first, and size is the total size of the loaded stylesheets.
+	chain []string
+	size  int
+}
+
+// importRules returns the rules replacing an @import rule with the import
+// mode of the policy, which isn't KeepImports.
+func (c *checker) importRules(r *css.Rule) []*css.Rule {
+	if c.p.Imports == StripImports {
+		c.remove(AtRule, "import", r.Line, r.Column)
+		return nil
+	}
+	u, conds, ok := importURL(r.Prelude)
+	if !ok {
+		c.remove(AtRule, "import", r.Line, r.Column)
+		return nil
+	}
+	if !c.url(u, r.Prelude[0].Token) {
+		return nil
+	}
+	wrap, ok := c.importConditions(r, conds)
+	if !ok {
+		return nil
+	}
+	s := c.load(r, u)
+	if s == nil {
+		return nil
+	}
+	at := c.imports.at
+	if at == nil {
+		c.imports.at = r
+	}
+	c.imports.chain = append(c.imports.chain, u)
+	nested := c.nested
+	c.nested = false
+	rules := c.rules(s.Rules)
+	c.nested = nested
+	c.imports.chain = c.imports.chain[:len(c.imports.chain)-1]
+	c.imports.at = at
+	for i := len(wrap) - 1; i >= 0 && len(rules) > 0; i-- {
+		wrap[i].Rules = rules
+		rules = []*css.Rule{wrap[i]}
+	}
+	return rules
+}
+
+// load loads and parses the stylesheet at u for the @import rule r, within
+// the budgets of the policy, and rebases its URLs. It records the removal
+// of r and returns nil if it can't.
+func (c *checker) load(r *css.Rule, u string) *css.Stylesheet {
+	maxDepth, maxSize := c.p.MaxImportDepth, c.p.MaxImportSize
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxImportDepth
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultMaxImportSize
+	}
+	fail := func() *css.Stylesheet {
+		c.remove(Import, u, r.Line, r.Column)
+		return nil
+	}
+	if c.p.Loader == nil || len(c.imports.chain) >= maxDepth {
+		return fail()
+	}
+	for _, parent := range c.imports.chain {
+		if parent == u {
+			return fail()
+		}
+	}
+	text, err := c.p.Loader(u)
+	if err != nil || c.imports.size+len(text) > maxSize {
+		return fail()
+	}
+	c.imports.size += len(text)
+	s, err := c.p.parser().ParseStylesheet(text)
+	if err != nil {
+		return fail()
+	}
+	rebaseURLs(s.Rules, u)
+	return s
+}
+
+// importURL returns the URL of an @import rule and the conditions that
+// follow it.
+func importURL(prelude []*css.ComponentValue) (string, []*css.ComponentValue, bool) {
+	if len(prelude) == 0 {
+		return "", nil, false
+	}
+	v := prelude[0]
+	switch {
+	case v.Token.Type == scanner.TokenString || v.Token.Type == scanner.TokenURI:
+		return v.Token.DecodedValue(), css.TrimSpace(prelude[1:]), true
+	case v.IsFunction() && strings.EqualFold(v.Token.DecodedValue(), "url"):
+		if args := css.TrimSpace(v.Children); len(args) == 1 && args[0].Token.Type == scanner.TokenString {
+			return args[0].Token.DecodedValue(), css.TrimSpace(prelude[1:]), true
+		}
+	}
+	return "", nil, false
+}
+
+// importConditions returns the at-rules, outermost first, that wrap the
+// rules of an imported stylesheet for the conditions of its @import rule:
+// @supports, @media and @layer, as in "@import url(a.css) layer(x)
+// supports(display: grid) print". It records the removal of the rule if
+// one of them isn't allowed.
+func (c *checker) importConditions(r *css.Rule, conds []*css.ComponentValue) ([]*css.Rule, bool) {
+	var layer, supports *css.Rule
+	if len(conds) > 0 {
+		if v := conds[0]; v.Token.Type == scanner.TokenIdent && strings.EqualFold(v.Token.Value, "layer") {
+			layer = &css.Rule{AtKeyword: "layer", HasBlock: true}
+			conds = css.TrimSpace(conds[1:])
+		} else if v.IsFunction() && strings.EqualFold(v.Token.DecodedValue(), "layer") {
+			layer = &css.Rule{AtKeyword: "layer", Prelude: css.TrimSpace(v.Children), HasBlock: true}
+			conds = css.TrimSpace(conds[1:])
+		}
+	}
+	if len(conds) > 0 {
+		if v := conds[0]; v.IsFunction() && strings.EqualFold(v.Token.DecodedValue(), "supports") {
+			block := &css.ComponentValue{Token: &scanner.Token{Type: scanner.TokenChar, Value: "("}, Children: v.Children}
+			supports = &css.Rule{AtKeyword: "supports", Prelude: []*css.ComponentValue{block}, HasBlock: true}
+			conds = css.TrimSpace(conds[1:])
+		}
+	}
+	var wrap []*css.Rule
+	if supports != nil {
+		wrap = append(wrap, supports)
+	}
+	if len(conds) > 0 {
+		wrap = append(wrap, &css.Rule{AtKeyword: "media", Prelude: conds, HasBlock: true})
+	}
+	if layer != nil {
+		wrap = append(wrap, layer)
+	}
+	for _, w := range wrap {
+		if !c.atRules[w.AtKeyword] {
+			c.remove(AtRule, w.AtKeyword, r.Line, r.Column)
+			return nil, false
+		}
+		if !c.values(w.Prelude) {
+			return nil, false
+		}
+		w.Line, w.Column = r.Line, r.Column
+	}
+	return wrap, true
+}
+
+// rebaseURLs resolves the relative URLs of a list of rules against the URL
+// of their stylesheet, base, so that they keep referencing the same
+// resources once the rules are moved to another stylesheet.
+func rebaseURLs(rules []*css.Rule, base string) {
+	for _, r := range rules {
+		rebaseValues(r.Prelude, base)
+		if r.IsAtRule() && strings.EqualFold(r.AtKeyword, "import") && len(r.Prelude) > 0 {
+			// The URL of @import may be a string, which rebaseValues
+			// leaves untouched.
+			if t := r.Prelude[0].Token; t.Type == scanner.TokenString {
+				rebaseString(t, base)
+			}
+		}
+		for _, d := range r.Declarations {
+			rebaseValues(d.Value, base)
+		}
+		rebaseURLs(r.Rules, base)
+	}
+}
+
+// rebaseValues resolves the relative URLs of a list of component values
+// against base.
+func rebaseValues(values []*css.ComponentValue, base string) {
+	for _, v := range values {
+		t := v.Token
+		switch {
+		case t.Type == scanner.TokenURI:
+			if u := t.DecodedValue(); isRelative(u) {
+				t.Value = "url('" + EscapeCSSString(resolveURL(base, u)) + "')"
+			}
+		case v.IsFunction():
+			switch strings.ToLower(t.DecodedValue()) {
+			case "url", "src", "image-set", "-webkit-image-set", "image":
+				for _, arg := range v.Children {
+					if arg.Token.Type == scanner.TokenString {
+						rebaseString(arg.Token, base)
+					}
+				}
+			}
+		}
+		rebaseValues(v.Children, base)
+	}
+}
+
+// rebaseString resolves the URL in the string token t against base.
+func rebaseString(t *scanner.Token, base string) {
+	if u := t.DecodedValue(); isRelative(u) {
+		t.Value = "'" + EscapeCSSString(resolveURL(base, u)) + "'"
+	}
+}
+
+// isRelative reports whether a decoded URL is relative to its stylesheet.
+// URLs with only a fragment, such as "#a", reference the document.
+func isRelative(u string) bool {
+	u = cleanURL(u)
+	return u != "" && u[0] != '#' && urlScheme(u) == ""
+}
+
+// resolveURL resolves the URL ref against the URL base, which may be
+// relative too. It returns ref if one of them isn't a valid URL.
+func resolveURL(base, ref string) string {
+	// ResolveReference makes paths absolute, so relative bases are resolved
+	// against a root that is removed afterwards.
+	const root = "base://base/"
+	relative := urlScheme(base) == "" && !strings.HasPrefix(base, "/")
+	if relative {
+		base = root + base
+	}
+	b, err := url.Parse(base)
+	if err != nil {
+		return ref
+	}
+	r, err := url.Parse(cleanURL(ref))
+	if err != nil {
+		return ref
+	}
+	u := b.ResolveReference(r).String()
+	if relative && strings.HasPrefix(u, root) {
+		u = u[len(root):]
+	}
+	return u
+}
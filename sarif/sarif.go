@@ -0,0 +1,252 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package gorilla/css/sarif writes the problems found in stylesheets as a SARIF
+2.1.0 log, the format ingested by GitHub code scanning and most CI
+dashboards:
+
+	log := &sarif.Log{ToolName: "csslint"}
+	if _, err := css.ParseStylesheet(input); err != nil {
+		log.AddError("site.css", err)
+	}
+	for _, d := range validate.Validate(decl) {
+		log.AddDiagnostic("site.css", d)
+	}
+	for _, f := range sanitize.Audit(sheet) {
+		log.AddFinding("site.css", f)
+	}
+	b, err := json.Marshal(log)
+
+The rule of a result is the code of a diagnostic, the risk of a finding, such
+as "attribute-leak", or "parse-error" and "limit-exceeded" for errors.
+Columns are counted in runes, which the log declares.
+*/
+package sarif
+
+import (
+	"encoding/json"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/diag"
+	"github.com/gorilla/css/sanitize"
+)
+
+// Version is the version of SARIF written by Log, and Schema its JSON
+// schema.
+const (
+	Version = "2.1.0"
+	Schema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// Rule identifiers of the results of errors.
+const (
+	RuleParseError    = diag.CodeParseError
+	RuleLimitExceeded = diag.CodeLimitExceeded
+)
+
+// Log is a SARIF log with a single run of a tool. The zero value is an
+// empty log of a tool named "gorilla/css".
+type Log struct {
+	// ToolName and ToolVersion describe the tool that found the problems.
+	ToolName    string
+	ToolVersion string
+	// InformationURI is the URL of the documentation of the tool, if any.
+	InformationURI string
+
+	results []result
+}
+
+// Len returns the number of results of the log.
+func (l *Log) Len() int {
+	return len(l.results)
+}
+
+// AddError adds a result for an error returned while parsing the file at
+// uri, as given by css.ErrorDiagnostic. The position of a *css.ParseError
+// or a *css.LimitExceededError is the location of the result; other errors
+// have no location in the file.
+func (l *Log) AddError(uri string, err error) {
+	l.AddDiagnostic(uri, css.ErrorDiagnostic(err))
+}
+
+// AddDiagnostic adds a result for a diagnostic of the file at uri. The
+// suggestion of the diagnostic, if any, is a fix replacing its span, and
+// its related spans are related locations. A span ending where it starts
+// is written as a position.
+func (l *Log) AddDiagnostic(uri string, d diag.Diagnostic) {
+	level := "error"
+	if d.Severity == diag.Warning {
+		level = "warning"
+	}
+	r := result{
+		RuleID:  d.Code,
+		Level:   level,
+		Message: message{d.Message},
+	}
+	if uri != "" {
+		r.Locations = []location{newLocation(uri, d.Span)}
+		for _, rel := range d.Related {
+			loc := newLocation(uri, rel.Span)
+			loc.Message = &message{rel.Message}
+			r.RelatedLocations = append(r.RelatedLocations, loc)
+		}
+	}
+	if d.Suggestion != "" {
+		r.Fixes = []fix{{
+			Description: message{"Replace with " + d.Suggestion},
+			ArtifactChanges: []artifactChange{{
+				ArtifactLocation: artifactLocation{uri},
+				Replacements: []replacement{{
+					DeletedRegion:   newRegion(d.Span),
+					InsertedContent: &content{d.Suggestion},
+				}},
+			}},
+		}}
+	}
+	l.results = append(l.results, r)
+}
+
+// AddFinding adds a warning for a finding of sanitize.Audit in the file at
+// uri, as given by its Diagnostic method.
+func (l *Log) AddFinding(uri string, f sanitize.Finding) {
+	l.AddDiagnostic(uri, f.Diagnostic())
+}
+
+// MarshalJSON returns the JSON encoding of the log, in the SARIF format.
+// The rules of the tool are those of the results, in the order they first
+// appear.
+func (l *Log) MarshalJSON() ([]byte, error) {
+	d := driver{Name: l.ToolName, Version: l.ToolVersion, InformationURI: l.InformationURI, Rules: []rule{}}
+	if d.Name == "" {
+		d.Name = "gorilla/css"
+	}
+	results := make([]result, len(l.results))
+	indexes := map[string]int{}
+	for i, r := range l.results {
+		index, ok := indexes[r.RuleID]
+		if !ok {
+			index = len(d.Rules)
+			indexes[r.RuleID] = index
+			d.Rules = append(d.Rules, rule{ID: r.RuleID})
+		}
+		r.RuleIndex = index
+		results[i] = r
+	}
+	return json.Marshal(log{
+		Schema:  Schema,
+		Version: Version,
+		Runs: []run{{
+			Tool:       tool{d},
+			ColumnKind: "unicodeCodePoints",
+			Results:    results,
+		}},
+	})
+}
+
+// The types below are those of the SARIF format, of which only the
+// properties written by Log are declared:
+//
+//	https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+
+type log struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []run  `json:"runs"`
+}
+
+type run struct {
+	Tool       tool     `json:"tool"`
+	ColumnKind string   `json:"columnKind"`
+	Results    []result `json:"results"`
+}
+
+type tool struct {
+	Driver driver `json:"driver"`
+}
+
+type driver struct {
+	Name           string `json:"name"`
+	Version        string `json:"version,omitempty"`
+	InformationURI string `json:"informationUri,omitempty"`
+	Rules          []rule `json:"rules"`
+}
+
+type rule struct {
+	ID string `json:"id"`
+}
+
+type result struct {
+	RuleID           string     `json:"ruleId"`
+	RuleIndex        int        `json:"ruleIndex"`
+	Level            string     `json:"level"`
+	Message          message    `json:"message"`
+	Locations        []location `json:"locations,omitempty"`
+	RelatedLocations []location `json:"relatedLocations,omitempty"`
+	Fixes            []fix      `json:"fixes,omitempty"`
+}
+
+type message struct {
+	Text string `json:"text"`
+}
+
+type location struct {
+	PhysicalLocation physicalLocation `json:"physicalLocation"`
+	Message          *message         `json:"message,omitempty"`
+}
+
+type physicalLocation struct {
+	ArtifactLocation artifactLocation `json:"artifactLocation"`
+	Region           *region          `json:"region,omitempty"`
+}
+
+type artifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type region struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// newLocation returns the location of a span of the file at uri, without a
+// region if the span has no position.
+func newLocation(uri string, s diag.Span) location {
+	loc := location{PhysicalLocation: physicalLocation{ArtifactLocation: artifactLocation{uri}}}
+	if s.Start.Line > 0 {
+		loc.PhysicalLocation.Region = newRegion(s)
+	}
+	return loc
+}
+
+// newRegion returns the region of a span, without an end if the span has
+// none or ends where it starts.
+func newRegion(s diag.Span) *region {
+	r := &region{StartLine: s.Start.Line, StartColumn: s.Start.Column}
+	if s.End.Line > 0 && s.End != s.Start {
+		r.EndLine, r.EndColumn = s.End.Line, s.End.Column
+	}
+	return r
+}
+
+type fix struct {
+	Description     message          `json:"description"`
+	ArtifactChanges []artifactChange `json:"artifactChanges"`
+}
+
+type artifactChange struct {
+	ArtifactLocation artifactLocation `json:"artifactLocation"`
+	Replacements     []replacement    `json:"replacements"`
+}
+
+type replacement struct {
+	DeletedRegion   *region  `json:"deletedRegion"`
+	InsertedContent *content `json:"insertedContent,omitempty"`
+}
+
+type content struct {
+	Text string `json:"text"`
+}
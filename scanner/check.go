@@ -0,0 +1,83 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scanner
+
+import "strings"
+
+// Check scans the rest of the input without building tokens and returns
+// the error token that Next would return, with the position of the unclosed
+// quotation mark or comment, or nil if the input is well-formed. It is much
+// faster than calling Next until the end of the input, since only the
+// tokens that may hold a quotation mark or "/*" are matched: strings,
+// comments, urls and escape sequences. The scanner is at the end of the input
+// afterwards.
+func (s *Scanner) Check() *Token {
+	if s.err != nil {
+		if s.err.Type == TokenError {
+			return s.err
+		}
+		return nil
+	}
+	if s.pos == 0 && strings.HasPrefix(s.input, "\uFEFF") {
+		s.emitSimple(TokenBOM, "\uFEFF")
+	}
+	input := s.input[s.pos:]
+	// inName reports whether the previous byte is part of a name, which a
+	// url can't follow.
+	inName := false
+	for i := 0; i < len(input); i++ {
+		c := input[i]
+		msg := ""
+		switch {
+		case c == '"' || c == '\'':
+			if n := stringLen(input[i:]); n > 0 {
+				i += n - 1
+			} else {
+				msg = "unclosed quotation mark"
+			}
+		case c == '/' && strings.HasPrefix(input[i+1:], "*"):
+			if n := strings.Index(input[i+2:], "*/"); n >= 0 {
+				i += n + 3
+			} else {
+				msg = "unclosed comment"
+			}
+		case c == '\\':
+			if i+1 == len(input) || input[i+1] < 0x20 || input[i+1] == 0x7f {
+				// Not an escape sequence but a Char token.
+				inName = false
+				continue
+			}
+			i++
+			if isHexDigit(input[i]) {
+				n := 1
+				for n < 6 && i+n < len(input) && isHexDigit(input[i+n]) {
+					n++
+				}
+				if i+n < len(input) && strings.IndexByte("\t\n ", input[i+n]) >= 0 {
+					n++
+				}
+				i += n - 1
+			}
+			inName = true
+			continue
+		case !inName && (c == 'u' || c == 'U') && len(input)-i >= 4 && strings.EqualFold(input[i:i+4], "url("):
+			if match := matchers[TokenURI].FindString(input[i:]); match != "" {
+				i += len(match) - 1
+				inName = false
+				continue
+			}
+		}
+		if msg != "" {
+			s.updatePosition(input[:i])
+			s.err = &Token{TokenError, msg, s.row, s.col}
+			return s.err
+		}
+		inName = c >= 0x80 || c == '-' || c == '_' || c == '@' || c == '#' ||
+			c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+	}
+	s.updatePosition(input)
+	s.err = &Token{TokenEOF, "", s.row, s.col}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scanner
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// DecodedValue returns the value of the token as defined by the CSS Syntax
+// specification, with its escape sequences replaced by the characters they
+// stand for: the name of identifiers, functions, at-keywords and hashes,
+// without their delimiters, the contents of strings and urls, and the
+// number and unit of dimensions. Other tokens return their value.
+//
+// The scanner doesn't decode tokens itself, since most values are never
+// looked at, and a value without escape sequences is returned without
+// copying.
+func (t *Token) DecodedValue() string {
+	v := t.Value
+	switch t.Type {
+	case TokenIdent:
+		return Unescape(v)
+	case TokenFunction:
+		return Unescape(v[:len(v)-1])
+	case TokenAtKeyword, TokenHash:
+		return Unescape(v[1:])
+	case TokenString:
+		return Unescape(v[1 : len(v)-1])
+	case TokenURI:
+		inner := strings.Trim(v[4:len(v)-1], " \t\n\r\f")
+		if inner != "" && (inner[0] == '"' || inner[0] == '\'') {
+			inner = inner[1 : len(inner)-1]
+		}
+		return Unescape(inner)
+	case TokenDimension:
+		unit := strings.TrimLeft(v, "+-.0123456789")
+		if strings.ContainsRune(unit, '\\') {
+			return v[:len(v)-len(unit)] + Unescape(unit)
+		}
+	}
+	return v
+}
+
+// Unescape replaces the escape sequences of an identifier or the contents of
+// a string with the characters they stand for. Escaped newlines, which
+// continue strings on the next line, are removed.
+func Unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] != '\\' {
+			b.WriteByte(s[i])
+			i++
+			continue
+		}
+		i++
+		j := i
+		for j < len(s) && j-i < 6 && isHexDigit(s[j]) {
+			j++
+		}
+		switch {
+		case j > i:
+			r, _ := strconv.ParseUint(s[i:j], 16, 32)
+			if r == 0 || r > utf8.MaxRune || r >= 0xD800 && r <= 0xDFFF {
+				r = utf8.RuneError
+			}
+			b.WriteRune(rune(r))
+			// A single whitespace ends the escape sequence.
+			if strings.HasPrefix(s[j:], "\r\n") {
+				j++
+			}
+			if j < len(s) && isSpace(s[j]) {
+				j++
+			}
+			i = j
+		case strings.HasPrefix(s[i:], "\r\n"):
+			i += 2
+		case i < len(s) && (s[i] == '\n' || s[i] == '\r' || s[i] == '\f'):
+			i++
+		case i < len(s):
+			r, size := utf8.DecodeRuneInString(s[i:])
+			b.WriteRune(r)
+			i += size
+		}
+	}
+	return b.String()
+}
+
+// isSpace reports whether c is a whitespace character.
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
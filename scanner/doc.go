@@ -5,9 +5,11 @@
 /*
 Package gorilla/css/scanner generates tokens for a CSS3 input.
 
-It follows the CSS3 specification located at:
+It keeps the tokens of the first version of the package, which followed
+the 2003 CSS3 grammar, for the inputs that version read, and otherwise
+follows the tokenization of CSS Syntax Module Level 3:
 
-	http://www.w3.org/TR/css3-syntax/
+	https://www.w3.org/TR/css-syntax-3/#tokenization
 
 To use it, create a new scanner for a given CSS string and call Next() until
 the token returned has type TokenEOF or TokenError:
@@ -26,6 +28,48 @@ finds an unclosed quote or unclosed comment. In these cases the text becomes
 "untokenizable". Everything else is tokenizable and it is up to a parser
 to make sense of the token stream (or ignore nonsensical token sequences).
 
+Token values are the text found in the input, escape sequences included.
+DecodedValue decodes them on demand, which costs nothing for the vast
+majority of tokens that have none.
+
+A Writer writes tokens back as CSS, separating with an empty comment the
+tokens that would otherwise run together. With PreserveWhitespace set, the
+tokens of a Scanner are written exactly as its input:
+
+	w := scanner.NewWriter(os.Stdout)
+	w.PreserveWhitespace = true
+	for token := s.Next(); token.Type != scanner.TokenEOF; token = s.Next() {
+		w.WriteToken(token)
+	}
+
+Pipe does all of this for a stream, applying token transforms on the way
+without reading the whole input first.
+
+MarshalTokens and UnmarshalTokens encode a list of tokens in a compact,
+versioned and checksummed binary format, to cache the tokens of an input.
+
+# Changes from the first version
+
+The API of the first version, New, Scanner.Next and the fields of Token, is
+unchanged, and so are the tokens of most inputs. These differ:
+
+  - Strings may contain tabs: "'a<tab>b'" is a STRING instead of an
+    "unclosed quotation mark" error.
+  - U+FFFE and U+FFFF are non-ASCII characters like the others: "a\uFFFEb"
+    is an IDENT instead of an IDENT, a CHAR and an IDENT, and strings may
+    contain them.
+  - Columns are counted in characters after every token. The first version
+    counted bytes after an ATKEYWORD or a CHAR, so the columns that follow
+    non-ASCII text on the same line differ. As in the first version,
+    invalid UTF-8 that isn't part of a token is a CHAR "\uFFFD".
+
+Signs, custom names and urls are read as in the first version: "-42px" is
+a CHAR "-" and a DIMENSION "42px", "2n+1" a DIMENSION, a CHAR "+" and a
+NUMBER, "--x" a CHAR "-" and an IDENT "-x", and "URL(a)", which isn't in
+lowercase, a FUNCTION, an IDENT and a CHAR, where CSS Syntax Level 3 reads
+"-42px", "+1", "--x" and "URL(a)" as single tokens. The parser of
+gorilla/css joins them.
+
 Note: the scanner doesn't perform lexical analysis or, in other words, it
 doesn't care about the token context. It is intended to be used by a
 lexer or parser.
@@ -0,0 +1,185 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scanner
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// TokenTransform transforms a token of a stream. It returns the tokens that
+// replace it: none to drop it, or the token itself to keep it.
+type TokenTransform func(t *Token) []*Token
+
+// pipeChunkSize is the number of bytes read from the source at a time.
+const pipeChunkSize = 32 << 10
+
+// pipeLookback is the number of tokens at the end of a read that more input
+// may change. At most three tokens are joined by the characters that follow
+// them, as "<", "!" and "-" are by a second "-", or "1", "-" and "\" by a
+// hexadecimal digit.
+const pipeLookback = 3
+
+// Pipe tokenizes the CSS read from src, passes each token through the
+// transforms in order and writes the result to dst with a Writer that
+// preserves whitespace.
+//
+// The input is processed as it is read: only the last tokens of a read,
+// which the next read may continue, are held back and scanned again with
+// it, so memory use is bounded by the size of a read and of the longest
+// token, even for minified input. An unclosed url( function is held back
+// until its closing parenthesis, since the text up to there may turn out to
+// be a url token. Token positions refer to the whole input.
+//
+// An error is returned if the input has an unclosed quotation mark or
+// comment, or if reading or writing fails.
+func Pipe(dst io.Writer, src io.Reader, transforms ...TokenTransform) error {
+	w := NewWriter(dst)
+	w.PreserveWhitespace = true
+	p := &pipe{w: w, transforms: transforms, row: 1, col: 1}
+	buf := make([]byte, pipeChunkSize)
+	for {
+		n, err := src.Read(buf)
+		eof := err == io.EOF
+		if err != nil && !eof {
+			return err
+		}
+		if err := p.scan(buf[:n], eof); err != nil {
+			return err
+		}
+		if eof {
+			return nil
+		}
+	}
+}
+
+// pipe holds the state of Pipe between reads.
+type pipe struct {
+	w          *Writer
+	transforms []TokenTransform
+	s          Scanner
+	// held is the preprocessed text held back, and row and col its position.
+	held     string
+	row, col int
+	// rest is the end of the last read, before preprocessing, that the next
+	// read may continue: a carriage return or an incomplete UTF-8 sequence.
+	rest string
+	// tokens and ends are the tokens of a read and their end offsets.
+	tokens []*Token
+	ends   []int
+}
+
+// scan writes the tokens of the held back text followed by chunk that more
+// input can't change, and holds back the others. At the end of the input
+// everything is written.
+func (p *pipe) scan(chunk []byte, eof bool) error {
+	text := p.rest + string(chunk)
+	p.rest = ""
+	if !eof {
+		text, p.rest = splitRest(text)
+	}
+	p.s.Reset(p.held + text)
+	p.s.row, p.s.col = p.row, p.col
+	p.tokens, p.ends = p.tokens[:0], p.ends[:0]
+	var scanErr error
+	for t := p.s.Next(); t.Type != TokenEOF; t = p.s.Next() {
+		if t.Type == TokenError {
+			// At the end of the input it is an error, otherwise the
+			// quotation mark or comment may be closed by the next read.
+			if eof {
+				scanErr = errors.New(t.Value)
+			}
+			break
+		}
+		p.tokens = append(p.tokens, t)
+		p.ends = append(p.ends, p.s.pos)
+	}
+	cut := len(p.tokens)
+	if !eof {
+		cut = finalTokens(p.tokens)
+	}
+	p.emit(p.tokens[:cut])
+	if p.w.err != nil {
+		return p.w.err
+	}
+	if scanErr != nil {
+		return scanErr
+	}
+	if cut > 0 {
+		p.held = p.s.input[p.ends[cut-1]:]
+		if cut < len(p.tokens) {
+			p.row, p.col = p.tokens[cut].Line, p.tokens[cut].Column
+		} else {
+			p.row, p.col = p.s.row, p.s.col
+		}
+	} else {
+		p.held = p.s.input
+	}
+	return nil
+}
+
+// finalTokens returns the number of tokens at the start of tokens, the end
+// of a read, that more input can't change.
+func finalTokens(tokens []*Token) int {
+	cut := len(tokens) - pipeLookback
+	// The text after "url(" is a url token once it is closed, unless it has
+	// whitespace or quotation marks in the wrong place, so it is held back
+	// until the closing parenthesis.
+	for i := len(tokens) - 1; i >= 0; i-- {
+		t := tokens[i]
+		if t.Type == TokenChar && t.Value == ")" {
+			break
+		}
+		if t.Type == TokenFunction && t.Value == "url(" && i < cut {
+			cut = i
+		}
+	}
+	// A byte order mark is only read as such at the start of the input, so
+	// the text held back must not start with one.
+	for cut > 0 && cut < len(tokens) && strings.HasPrefix(tokens[cut].Value, "\uFEFF") {
+		cut--
+	}
+	if cut < 0 {
+		cut = 0
+	}
+	return cut
+}
+
+// splitRest splits text before a final carriage return, which may be
+// followed by a line feed in the next read, or a final incomplete UTF-8
+// sequence.
+func splitRest(text string) (string, string) {
+	if strings.HasSuffix(text, "\r") {
+		return text[:len(text)-1], "\r"
+	}
+	for i := len(text) - 1; i >= 0 && i >= len(text)-utf8.UTFMax+1; i-- {
+		if utf8.RuneStart(text[i]) {
+			if !utf8.FullRuneInString(text[i:]) {
+				return text[:i], text[i:]
+			}
+			break
+		}
+	}
+	return text, ""
+}
+
+// emit passes tokens through the transforms and writes them.
+func (p *pipe) emit(tokens []*Token) {
+	for _, t := range tokens {
+		out := []*Token{t}
+		for _, tf := range p.transforms {
+			var next []*Token
+			for _, t := range out {
+				next = append(next, tf(t)...)
+			}
+			out = next
+		}
+		for _, t := range out {
+			p.w.WriteToken(t)
+		}
+	}
+}
@@ -6,10 +6,12 @@ package scanner
 
 import (
 	"fmt"
+	"io"
 	"regexp"
 	"strings"
 	"unicode"
 	"unicode/utf8"
+	"unsafe"
 )
 
 // tokenType identifies the type of lexical tokens.
@@ -38,6 +40,20 @@ func (t *Token) String() string {
 		t.Type, t.Line, t.Column, t.Value)
 }
 
+// WriteTo writes the value of the token, as found in the input, to w. It
+// returns the number of bytes written and the error encountered, if any.
+func (t *Token) WriteTo(w io.Writer) (int64, error) {
+	n, err := io.WriteString(w, t.Value)
+	return int64(n), err
+}
+
+// AppendTo appends the value of the token, as found in the input, to b and
+// returns the extended buffer. It doesn't allocate when b has enough
+// capacity.
+func (t *Token) AppendTo(b []byte) []byte {
+	return append(b, t.Value...)
+}
+
 // All tokens -----------------------------------------------------------------
 
 // The complete list of tokens in CSS3.
@@ -70,7 +86,7 @@ const (
 )
 
 // tokenNames maps tokenType's to their names. Used for conversion to string.
-var tokenNames = map[tokenType]string{
+var tokenNames = [...]string{
 	TokenError:          "error",
 	TokenEOF:            "EOF",
 	TokenIdent:          "IDENT",
@@ -107,13 +123,13 @@ var macros = map[string]string{
 	"ident":      `-?{nmstart}{nmchar}*`,
 	"name":       `{nmchar}+`,
 	"nmstart":    `[a-zA-Z_]|{nonascii}|{escape}`,
-	"nonascii":   "[\u0080-\uD7FF\uE000-\uFFFD\U00010000-\U0010FFFF]",
+	"nonascii":   "[\u0080-\uD7FF\uE000-\U0010FFFF]",
 	"unicode":    `\\[0-9a-fA-F]{1,6}{wc}?`,
-	"escape":     "{unicode}|\\\\[\u0020-\u007E\u0080-\uD7FF\uE000-\uFFFD\U00010000-\U0010FFFF]",
+	"escape":     "{unicode}|\\\\[\u0020-\u007E\u0080-\uD7FF\uE000-\U0010FFFF]",
 	"nmchar":     `[a-zA-Z0-9_-]|{nonascii}|{escape}`,
 	"num":        `[0-9]*\.[0-9]+|[0-9]+`,
 	"string":     `"(?:{stringchar}|')*"|'(?:{stringchar}|")*'`,
-	"stringchar": `{urlchar}|[ ]|\\{nl}`,
+	"stringchar": `{urlchar}|[\t ]|\\{nl}`,
 	"nl":         `[\n\r\f]|\r\n`,
 	"w":          `{wc}*`,
 	"wc":         `[\t\n\f\r ]`,
@@ -154,24 +170,13 @@ var productions = map[tokenType]string{
 	//TokenBOM:            "\uFEFF",
 }
 
-// matchers maps the list of tokens to compiled regular expressions.
+// matchers maps the list of tokens to compiled regular expressions. It is
+// an array indexed by token type rather than a map because it is used for
+// almost every token.
 //
-// The map is filled on init() using the macros and productions defined in
+// The array is filled on init() using the macros and productions defined in
 // the CSS specification.
-var matchers = map[tokenType]*regexp.Regexp{}
-
-// matchOrder is the order to test regexps when first-char shortcuts
-// can't be used.
-var matchOrder = []tokenType{
-	TokenURI,
-	TokenFunction,
-	TokenUnicodeRange,
-	TokenIdent,
-	TokenDimension,
-	TokenPercentage,
-	TokenNumber,
-	TokenCDC,
-}
+var matchers [len(tokenNames)]*regexp.Regexp
 
 func init() {
 	// replace macros and compile regexps for productions.
@@ -189,20 +194,78 @@ func init() {
 // Scanner --------------------------------------------------------------------
 
 // New returns a new CSS scanner for the given input.
+//
+// Token values are substrings of the input after preprocessing, which only
+// makes a copy if the input has carriage returns, form feeds or NUL
+// characters.
 func New(input string) *Scanner {
-	// Normalize newlines.
-	// https://www.w3.org/TR/css-syntax-3/#input-preprocessing
-	input = strings.Replace(input, "\r\n", "\n", -1)
-	input = strings.Replace(input, "\r", "\n", -1)
-	input = strings.Replace(input, "\f", "\n", -1)
-	input = strings.Replace(input, "\u0000", "\ufffd", -1)
 	return &Scanner{
-		input: input,
+		input: Normalize(input),
 		row:   1,
 		col:   1,
 	}
 }
 
+// Normalize returns the input with newlines normalized to "\n" and NUL
+// characters replaced by U+FFFD, as required by the preprocessing rules of
+// the CSS Syntax specification:
+//
+//	https://www.w3.org/TR/css-syntax-3/#input-preprocessing
+//
+// It is done in a single pass, and returns the input itself if there is
+// nothing to replace.
+func Normalize(input string) string {
+	i := strings.IndexAny(input, "\r\f\x00")
+	if i < 0 {
+		return input
+	}
+	var b strings.Builder
+	b.Grow(len(input) + 2*strings.Count(input[i:], "\x00"))
+	for i >= 0 {
+		b.WriteString(input[:i])
+		switch input[i] {
+		case '\r':
+			if i+1 < len(input) && input[i+1] == '\n' {
+				i++
+			}
+			b.WriteByte('\n')
+		case '\f':
+			b.WriteByte('\n')
+		case 0:
+			b.WriteString("\ufffd")
+		}
+		input = input[i+1:]
+		i = strings.IndexAny(input, "\r\f\x00")
+	}
+	b.WriteString(input)
+	return b.String()
+}
+
+// NewBytes returns a new CSS scanner for the given input. The input is
+// copied, so the caller may modify it afterwards; NewBytesNoCopy avoids the
+// copy.
+func NewBytes(input []byte) *Scanner {
+	return New(string(input))
+}
+
+// NewBytesNoCopy returns a new CSS scanner for the given input without
+// copying it: token values are substrings sharing the memory of input,
+// unless the input has carriage returns, form feeds or NUL characters,
+// which preprocessing replaces in a copy.
+//
+// The caller must not modify input for as long as the scanner or any of its
+// tokens is in use, since that would change the token values, which are
+// strings and expected to be immutable. Use NewBytes unless the allocation
+// of the copy matters and input is never modified, as when it was read
+// only to be scanned.
+func NewBytesNoCopy(input []byte) *Scanner {
+	if len(input) == 0 {
+		return New("")
+	}
+	// #nosec G103 -- the caller must not modify input, as documented.
+	return New(unsafe.String(&input[0], len(input)))
+}
+
 // Scanner scans an input and emits tokens following the CSS3 specification.
 type Scanner struct {
 	input string
@@ -210,6 +273,23 @@ type Scanner struct {
 	row   int
 	col   int
 	err   *Token
+	// batch holds the tokens allocated in advance.
+	batch []Token
+}
+
+// Reset makes the scanner scan a new input, as returned by New. The tokens
+// it allocated in advance are kept, so that scanning many small inputs with
+// the same scanner allocates less; tokens already returned are never
+// reused.
+func (s *Scanner) Reset(input string) {
+	*s = Scanner{input: Normalize(input), row: 1, col: 1, batch: s.batch}
+}
+
+// Input returns the input of the scanner after preprocessing, in which
+// newlines are normalized to "\n". Token values are contiguous substrings of
+// it.
+func (s *Scanner) Input() string {
+	return s.input
 }
 
 // Next returns the next token from the input.
@@ -218,6 +298,13 @@ type Scanner struct {
 //
 // If the input can't be tokenized the token type is TokenError. This occurs
 // in case of unclosed quotation marks or comments.
+//
+// Tokens carry no data besides their value and position: the value is a
+// substring of the input and numeric details, such as the unit of a
+// dimension, are derived from it on demand, so no extra allocation is made
+// per token. Tokens are allocated in batches and never reused by the
+// scanner, so callers may keep and modify them without copying; a kept
+// token keeps its batch in memory.
 func (s *Scanner) Next() *Token {
 	if s.err != nil {
 		return s.err
@@ -238,7 +325,12 @@ func (s *Scanner) Next() *Token {
 	switch input[0] {
 	case '\t', '\n', ' ':
 		// Whitespace.
-		return s.emitToken(TokenS, matchers[TokenS].FindString(input))
+		// The input is preprocessed, so there is no "\r" or "\f".
+		n := 1
+		for n < len(input) && (input[n] == ' ' || input[n] == '\t' || input[n] == '\n') {
+			n++
+		}
+		return s.emitToken(TokenS, input[:n])
 	case '.':
 		// Dot is too common to not have a quick check.
 		// We'll test if this is a Char; if it is followed by a number it is a
@@ -255,17 +347,16 @@ func (s *Scanner) Next() *Token {
 	case '@':
 		// Another common one: AtKeyword or Char.
 		if match := matchers[TokenAtKeyword].FindString(input); match != "" {
-			return s.emitSimple(TokenAtKeyword, match)
+			return s.emitToken(TokenAtKeyword, match)
 		}
 		return s.emitSimple(TokenChar, "@")
 	case ':', ',', ';', '%', '&', '+', '=', '>', '(', ')', '[', ']', '{', '}':
 		// More common chars.
-		return s.emitSimple(TokenChar, string(input[0]))
+		return s.emitSimple(TokenChar, input[:1])
 	case '"', '\'':
 		// String or error.
-		match := matchers[TokenString].FindString(input)
-		if match != "" {
-			return s.emitToken(TokenString, match)
+		if n := stringLen(input); n > 0 {
+			return s.emitToken(TokenString, input[:n])
 		}
 
 		s.err = &Token{TokenError, "unclosed quotation mark", s.row, s.col}
@@ -273,49 +364,146 @@ func (s *Scanner) Next() *Token {
 	case '/':
 		// Comment, error or Char.
 		if len(input) > 1 && input[1] == '*' {
-			match := matchers[TokenComment].FindString(input)
-			if match != "" {
-				return s.emitToken(TokenComment, match)
-			} else {
-				s.err = &Token{TokenError, "unclosed comment", s.row, s.col}
-				return s.err
+			if i := strings.Index(input[2:], "*/"); i >= 0 {
+				return s.emitToken(TokenComment, input[:i+4])
 			}
+			s.err = &Token{TokenError, "unclosed comment", s.row, s.col}
+			return s.err
 		}
 		return s.emitSimple(TokenChar, "/")
 	case '~':
 		// Includes or Char.
-		return s.emitPrefixOrChar(TokenIncludes, "~=")
+		return s.emitPrefixOrChar(input, TokenIncludes, "~=")
 	case '|':
 		// DashMatch or Char.
-		return s.emitPrefixOrChar(TokenDashMatch, "|=")
+		return s.emitPrefixOrChar(input, TokenDashMatch, "|=")
 	case '^':
 		// PrefixMatch or Char.
-		return s.emitPrefixOrChar(TokenPrefixMatch, "^=")
+		return s.emitPrefixOrChar(input, TokenPrefixMatch, "^=")
 	case '$':
 		// SuffixMatch or Char.
-		return s.emitPrefixOrChar(TokenSuffixMatch, "$=")
+		return s.emitPrefixOrChar(input, TokenSuffixMatch, "$=")
 	case '*':
 		// SubstringMatch or Char.
-		return s.emitPrefixOrChar(TokenSubstringMatch, "*=")
+		return s.emitPrefixOrChar(input, TokenSubstringMatch, "*=")
 	case '<':
 		// CDO or Char.
-		return s.emitPrefixOrChar(TokenCDO, "<!--")
+		return s.emitPrefixOrChar(input, TokenCDO, "<!--")
 	}
-	// Test all regexps, in order.
-	for _, token := range matchOrder {
-		if match := matchers[token].FindString(input); match != "" {
-			return s.emitToken(token, match)
-		}
+	if t, match := matchRegexps(input); match != "" {
+		return s.emitToken(t, match)
 	}
 	// We already handled unclosed quotation marks and comments,
 	// so this can only be a Char.
 	r, width := utf8.DecodeRuneInString(input)
-	token := &Token{TokenChar, string(r), s.row, s.col}
-	s.col += width
+	v := input[:width]
+	if r == utf8.RuneError && width == 1 {
+		// Invalid UTF-8 becomes the replacement character.
+		v = string(r)
+	}
+	token := s.newToken(TokenChar, v)
+	s.col++
 	s.pos += width
 	return token
 }
 
+// matchRegexps returns the type and value of the token at the start of
+// input for the tokens matched with regexps, or an empty value. It only runs
+// the regexps that can match: a function is an identifier followed by "(",
+// and dimensions and percentages are numbers followed by an identifier or
+// "%", so each token takes one or two matches.
+func matchRegexps(input string) (tokenType, string) {
+	if strings.HasPrefix(input, "url(") {
+		if match := matchers[TokenURI].FindString(input); match != "" {
+			return TokenURI, match
+		}
+	}
+	if strings.HasPrefix(input, "U+") {
+		if match := matchers[TokenUnicodeRange].FindString(input); match != "" {
+			return TokenUnicodeRange, match
+		}
+	}
+	if c := input[0]; c != '.' && (c < '0' || c > '9') {
+		if match := matchers[TokenIdent].FindString(input); match != "" {
+			if strings.HasPrefix(input[len(match):], "(") {
+				return TokenFunction, input[:len(match)+1]
+			}
+			return TokenIdent, match
+		}
+	}
+	if match := matchers[TokenNumber].FindString(input); match != "" {
+		rest := input[len(match):]
+		if unit := matchers[TokenIdent].FindString(rest); unit != "" {
+			return TokenDimension, input[:len(match)+len(unit)]
+		}
+		if strings.HasPrefix(rest, "%") {
+			return TokenPercentage, input[:len(match)+1]
+		}
+		return TokenNumber, match
+	}
+	if strings.HasPrefix(input, "-->") {
+		return TokenCDC, input[:3]
+	}
+	return TokenChar, ""
+}
+
+// stringLen returns the length of the string token at the start of input,
+// or 0 if the string is unclosed or has a character that must be escaped.
+// It matches the {string} macro without a regexp: the bytes up to the next
+// special character are skipped at once.
+func stringLen(input string) int {
+	quote := input[0]
+	for i := 1; i < len(input); {
+		// Skip the characters that are allowed as is.
+		j := i
+		for j < len(input) && isStringChar(input[j], quote) {
+			j++
+		}
+		if j == len(input) {
+			return 0
+		}
+		switch c := input[j]; {
+		case c == quote:
+			return j + 1
+		case c != '\\' || j+1 == len(input):
+			return 0
+		case isHexDigit(input[j+1]):
+			// A hexadecimal escape, which may be followed by whitespace.
+			k := j + 1
+			for k < len(input) && k < j+7 && isHexDigit(input[k]) {
+				k++
+			}
+			if k < len(input) && strings.IndexByte("\t\n\f\r ", input[k]) >= 0 {
+				k++
+			}
+			i = k
+		case input[j+1] == '\n' || input[j+1] == '\f':
+			i = j + 2
+		case input[j+1] == '\r':
+			i = j + 2
+			if strings.HasPrefix(input[i:], "\n") {
+				i++
+			}
+		case input[j+1] >= 0x20 && input[j+1] != 0x7f:
+			i = j + 2
+		default:
+			return 0
+		}
+	}
+	return 0
+}
+
+// isStringChar reports whether c may appear unescaped in a string delimited
+// by quote. Bytes of non-ASCII characters are all allowed, and so are tabs.
+func isStringChar(c, quote byte) bool {
+	return c >= 0x80 || c == '\t' || c >= 0x20 && c < 0x7f && c != '\\' && c != quote
+}
+
+// isHexDigit reports whether c is a hexadecimal digit.
+func isHexDigit(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
 // updatePosition updates input coordinates based on the consumed text.
 func (s *Scanner) updatePosition(text string) {
 	width := utf8.RuneCountInString(text)
@@ -331,30 +519,45 @@ func (s *Scanner) updatePosition(text string) {
 
 // emitToken returns a Token for the string v and updates the scanner position.
 func (s *Scanner) emitToken(t tokenType, v string) *Token {
-	token := &Token{t, v, s.row, s.col}
+	token := s.newToken(t, v)
 	s.updatePosition(v)
 	return token
 }
 
+// tokenBatch is the number of tokens allocated at once.
+const tokenBatch = 64
+
+// newToken returns a Token at the current position. Tokens are allocated in
+// batches to reduce the number of allocations.
+func (s *Scanner) newToken(t tokenType, v string) *Token {
+	if len(s.batch) == 0 {
+		s.batch = make([]Token, tokenBatch)
+	}
+	token := &s.batch[0]
+	s.batch = s.batch[1:]
+	*token = Token{t, v, s.row, s.col}
+	return token
+}
+
 // emitSimple returns a Token for the string v and updates the scanner
 // position in a simplified manner.
 //
 // The string is known to have only ASCII characters and to not have a newline.
 func (s *Scanner) emitSimple(t tokenType, v string) *Token {
-	token := &Token{t, v, s.row, s.col}
+	token := s.newToken(t, v)
 	s.col += len(v)
 	s.pos += len(v)
 	return token
 }
 
-// emitPrefixOrChar returns a Token for type t if the current position
-// matches the given prefix. Otherwise it returns a Char token using the
+// emitPrefixOrChar returns a Token for type t if the input at the current
+// position starts with the given prefix. Otherwise it returns a Char token using the
 // first character from the prefix.
 //
 // The prefix is known to have only ASCII characters and to not have a newline.
-func (s *Scanner) emitPrefixOrChar(t tokenType, prefix string) *Token {
-	if strings.HasPrefix(s.input[s.pos:], prefix) {
+func (s *Scanner) emitPrefixOrChar(input string, t tokenType, prefix string) *Token {
+	if strings.HasPrefix(input, prefix) {
 		return s.emitSimple(t, prefix)
 	}
-	return s.emitSimple(TokenChar, string(prefix[0]))
+	return s.emitSimple(TokenChar, prefix[:1])
 }
@@ -5,7 +5,15 @@
 package scanner
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
 	"testing"
+	"testing/iotest"
+	"unicode/utf8"
+	"unsafe"
 )
 
 func TestMatchers(t *testing.T) {
@@ -30,6 +38,8 @@ func TestMatchers(t *testing.T) {
 	}
 
 	checkMatch("abcd", TokenIdent, "abcd")
+	checkMatch("--custom-prop", TokenChar, "-", TokenIdent, "-custom-prop")
+	checkMatch("a\uffff", TokenIdent, "a\uffff")
 	checkMatch(`"abcd"`, TokenString, `"abcd"`)
 	checkMatch(`"ab'cd"`, TokenString, `"ab'cd"`)
 	checkMatch(`"ab\"cd"`, TokenString, `"ab\"cd"`)
@@ -38,6 +48,8 @@ func TestMatchers(t *testing.T) {
 	checkMatch(`'ab"cd'`, TokenString, `'ab"cd'`)
 	checkMatch(`'ab\'cd'`, TokenString, `'ab\'cd'`)
 	checkMatch(`'ab\\cd'`, TokenString, `'ab\\cd'`)
+	checkMatch("'a\tb'", TokenString, "'a\tb'")
+	checkMatch("url('a \\\n\tb')", TokenURI, "url('a \\\n\tb')")
 	checkMatch("#name", TokenHash, "#name")
 	checkMatch("42''", TokenNumber, "42", TokenString, "''")
 	checkMatch("4.2", TokenNumber, "4.2")
@@ -46,6 +58,11 @@ func TestMatchers(t *testing.T) {
 	checkMatch("4.2%", TokenPercentage, "4.2%")
 	checkMatch(".42%", TokenPercentage, ".42%")
 	checkMatch("42px", TokenDimension, "42px")
+	checkMatch("-42px", TokenChar, "-", TokenDimension, "42px")
+	checkMatch("+.5", TokenChar, "+", TokenNumber, ".5")
+	checkMatch("-4.2%", TokenChar, "-", TokenPercentage, "4.2%")
+	checkMatch("a+b", TokenIdent, "a", TokenChar, "+", TokenIdent, "b")
+	checkMatch("2n+1", TokenDimension, "2n", TokenChar, "+", TokenNumber, "1")
 	checkMatch("url(http://domain.com)", TokenURI, "url(http://domain.com)")
 	checkMatch("url( http://domain.com/uri/between/space )", TokenURI, "url( http://domain.com/uri/between/space )")
 	checkMatch("url('http://domain.com/uri/between/single/quote')", TokenURI, "url('http://domain.com/uri/between/single/quote')")
@@ -58,6 +75,7 @@ func TestMatchers(t *testing.T) {
 		TokenURI, "url(http://domain.com/uri/1)",
 		TokenURI, "url(http://domain.com/uri/2)",
 	)
+	checkMatch("URL(a.png)", TokenFunction, "URL(", TokenIdent, "a", TokenChar, ".", TokenIdent, "png", TokenChar, ")")
 	checkMatch("U+0042", TokenUnicodeRange, "U+0042")
 	checkMatch("<!--", TokenCDO, "<!--")
 	checkMatch("-->", TokenCDC, "-->")
@@ -109,3 +127,608 @@ func TestPreprocess(t *testing.T) {
 		}
 	}
 }
+
+func TestWriter(t *testing.T) {
+	// Token streams from the scanner are written back exactly.
+	for _, input := range []string{
+		"a { color : red ; }\n\n/* c */ b>c{margin:1px -2px}",
+		"2n+1 a-b -a #a.b 1.5 +.5 1-1 url(x) U+0042 <!-- -->",
+		"\t@media  screen\n{ a[b|=c]{} }",
+	} {
+		var tokens []*Token
+		s := New(input)
+		for tok := s.Next(); tok.Type != TokenEOF; tok = s.Next() {
+			tokens = append(tokens, tok)
+		}
+		var b strings.Builder
+		if _, err := WriteTokens(&b, tokens, true); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != input {
+			t.Errorf("got %q, want %q", b.String(), input)
+		}
+	}
+
+	tok := func(tt tokenType, v string) *Token { return &Token{Type: tt, Value: v} }
+	for _, tc := range []struct {
+		tokens []*Token
+		want   string
+	}{
+		{[]*Token{tok(TokenIdent, "a"), tok(TokenIdent, "b")}, "a/**/b"},
+		{[]*Token{tok(TokenNumber, "1"), tok(TokenIdent, "px")}, "1/**/px"},
+		{[]*Token{tok(TokenNumber, "1"), tok(TokenNumber, ".5")}, "1/**/.5"},
+		{[]*Token{tok(TokenDimension, "2n"), tok(TokenChar, "+"), tok(TokenNumber, "1")}, "2n+1"},
+		{[]*Token{tok(TokenChar, "-"), tok(TokenNumber, "1")}, "-1"},
+		{[]*Token{tok(TokenChar, "-"), tok(TokenIdent, "a")}, "-/**/a"},
+		{[]*Token{tok(TokenChar, "-"), tok(TokenIdent, "-a")}, "--a"},
+		{[]*Token{tok(TokenIdent, "a"), tok(TokenS, "\n\t"), tok(TokenIdent, "b")}, "a b"},
+		{[]*Token{tok(TokenChar, "/"), tok(TokenChar, "*")}, "//**/*"},
+		{[]*Token{tok(TokenIdent, "a"), tok(TokenChar, ",")}, "a,"},
+	} {
+		var b strings.Builder
+		if _, err := WriteTokens(&b, tc.tokens, false); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tc.want {
+			t.Errorf("got %q, want %q", b.String(), tc.want)
+		}
+	}
+}
+
+func TestWriterState(t *testing.T) {
+	a := &Token{Type: TokenIdent, Value: "a"}
+	b := &Token{Type: TokenIdent, Value: "b"}
+
+	// The zero value discards its output but keeps track of it.
+	var w Writer
+	if w.Last() != nil {
+		t.Errorf("zero value: got last token %v", w.Last())
+	}
+	w.WriteToken(a)
+	w.WriteToken(b)
+	if w.Last() != b || w.Written() != 6 {
+		t.Errorf("zero value: got last token %v and %d bytes", w.Last(), w.Written())
+	}
+
+	var out strings.Builder
+	w.Reset(&out)
+	w.WriteToken(b)
+	if out.String() != "b" || w.Written() != 1 || w.Last() != b {
+		t.Errorf("after reset: got %q, %d bytes", out.String(), w.Written())
+	}
+
+	out.Reset()
+	w2 := NewWriterAfter(&out, a)
+	w2.WriteToken(b)
+	if out.String() != "/**/b" {
+		t.Errorf("seeded: got %q, want %q", out.String(), "/**/b")
+	}
+}
+
+func TestPipe(t *testing.T) {
+	input := "\uFEFFa { color : red ; }\r\n/* c d */ b>c{background:url( x.png ) 'e f'}\r\n.d{margin:1px -2px} url( a" +
+		"<!--.e\uFEFF{width:1-\\6;content:'\u00e9'}@-\\6{x:url(a)b)}U+1-2 -->"
+	var want []string
+	s := New(input)
+	for tok := s.Next(); tok.Type != TokenEOF; tok = s.Next() {
+		want = append(want, fmt.Sprintf("%s:%d:%d", tok, tok.Line, tok.Column))
+	}
+	for _, r := range []io.Reader{strings.NewReader(input), iotest.OneByteReader(strings.NewReader(input))} {
+		var got []string
+		record := func(tok *Token) []*Token {
+			got = append(got, fmt.Sprintf("%s:%d:%d", tok, tok.Line, tok.Column))
+			return []*Token{tok}
+		}
+		var b strings.Builder
+		if err := Pipe(&b, r, record); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != s.Input() {
+			t.Errorf("got %q, want %q", b.String(), s.Input())
+		}
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Errorf("got tokens\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+		}
+	}
+
+	// Transforms see tokens with their positions in the whole input.
+	var positions []string
+	upper := func(tok *Token) []*Token {
+		if tok.Type == TokenIdent {
+			positions = append(positions, fmt.Sprintf("%s:%d:%d", tok.Value, tok.Line, tok.Column))
+			return []*Token{{Type: TokenIdent, Value: strings.ToUpper(tok.Value)}}
+		}
+		return []*Token{tok}
+	}
+	dropSpace := func(tok *Token) []*Token {
+		if tok.Type == TokenS {
+			return nil
+		}
+		return []*Token{tok}
+	}
+	var b strings.Builder
+	err := Pipe(&b, iotest.OneByteReader(strings.NewReader("a b\n  c")), upper, dropSpace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != "A/**/B/**/C" {
+		t.Errorf("got %q", b.String())
+	}
+	if got := strings.Join(positions, " "); got != "a:1:1 b:1:3 c:2:3" {
+		t.Errorf("got positions %q", got)
+	}
+
+	if err := Pipe(io.Discard, iotest.OneByteReader(strings.NewReader("a { content: 'b }"))); err == nil {
+		t.Error("expected an error for an unclosed string")
+	}
+}
+
+func TestAppendTo(t *testing.T) {
+	tok := &Token{Type: TokenIdent, Value: "abc"}
+	b := make([]byte, 0, 16)
+	if got := string(tok.AppendTo(append(b, "x:"...))); got != "x:abc" {
+		t.Errorf("got %q", got)
+	}
+	if allocs := testing.AllocsPerRun(10, func() { b = tok.AppendTo(b[:0]) }); allocs != 0 {
+		t.Errorf("got %v allocations", allocs)
+	}
+}
+
+func BenchmarkScanner(b *testing.B) {
+	input := strings.Repeat(".foo-bar > baz, #qux { color: red; font-family: 'Helvetica Neue', sans-serif; background: url(a.png) }\n", 100)
+	b.SetBytes(int64(len(input)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s := New(input)
+		for t := s.Next(); t.Type != TokenEOF; t = s.Next() {
+		}
+	}
+}
+
+// BenchmarkScannerHotPaths measures the paths taken by almost every token:
+// whitespace, which is scanned without a regexp, the tokens found with the
+// matchers, which are indexed by token type, and punctuation. It fails if
+// scanning allocates much more than the batches of tokens, or if converting
+// a token type to a string allocates.
+func BenchmarkScannerHotPaths(b *testing.B) {
+	inputs := []struct {
+		name  string
+		input string
+	}{
+		{"whitespace", strings.Repeat("a \t\n  b\n\n\t", 2000)},
+		{"matchers", strings.Repeat("foo-bar 12px 50% 1.5 rgb( #fff url(a.png) @media U+0-7F ", 1000)},
+		{"punctuation", strings.Repeat("{}:;,>+()[]=%&", 2000)},
+	}
+	for _, in := range inputs {
+		tokens := 0
+		s := New(in.input)
+		for t := s.Next(); t.Type != TokenEOF; t = s.Next() {
+			tokens++
+		}
+		b.Run(in.name, func(b *testing.B) {
+			b.SetBytes(int64(len(in.input)))
+			b.ReportAllocs()
+			var before, after runtime.MemStats
+			runtime.ReadMemStats(&before)
+			for i := 0; i < b.N; i++ {
+				s := New(in.input)
+				for t := s.Next(); t.Type != TokenEOF; t = s.Next() {
+				}
+			}
+			runtime.ReadMemStats(&after)
+			// The regexps allocate now and then, and so does the scanner.
+			max := uint64(2*(tokens/tokenBatch) + 4)
+			if allocs := (after.Mallocs - before.Mallocs) / uint64(b.N); allocs > max {
+				b.Errorf("got %d allocations for %d tokens, want at most %d", allocs, tokens, max)
+			}
+		})
+	}
+	b.Run("String", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = tokenType(i % len(tokenNames)).String()
+		}
+		if allocs := testing.AllocsPerRun(100, func() { _ = TokenIdent.String() }); allocs != 0 {
+			b.Errorf("got %v allocations", allocs)
+		}
+	})
+}
+
+// BenchmarkPipe streams minified input, which has no whitespace to cut it
+// at, and checks that the memory allocated grows with the input, rather
+// than with its square as it would if the text read were scanned again.
+// Most of it is the tokens, about 40 bytes each.
+func BenchmarkPipe(b *testing.B) {
+	input := strings.Repeat(".foo-bar>.baz,#qux{color:red;font-family:'Helvetica Neue',sans-serif;background:url(a.png)}", 40000)
+	b.SetBytes(int64(len(input)))
+	b.ReportAllocs()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	for i := 0; i < b.N; i++ {
+		if err := Pipe(io.Discard, strings.NewReader(input)); err != nil {
+			b.Fatal(err)
+		}
+	}
+	runtime.ReadMemStats(&after)
+	perByte := float64(after.TotalAlloc-before.TotalAlloc) / float64(b.N) / float64(len(input))
+	b.ReportMetric(perByte, "B/input-byte")
+	if perByte > 32 {
+		b.Errorf("allocated %.1f bytes per byte of input", perByte)
+	}
+}
+
+func TestTokensNotReused(t *testing.T) {
+	s := New(strings.Repeat("a ", tokenBatch))
+	first := s.Next()
+	for tok := s.Next(); tok.Type != TokenEOF; tok = s.Next() {
+		tok.Value = "x"
+	}
+	if first.Type != TokenIdent || first.Value != "a" {
+		t.Errorf("first token changed to %v", first)
+	}
+}
+
+// TestV1API checks that the API of the first version of the package, used
+// by dependents such as bluemonday, still compiles and behaves the same,
+// but for the token changes listed in the package documentation.
+func TestV1API(t *testing.T) {
+	var (
+		_ func(string) *Scanner  = New
+		_ func(*Scanner) *Token  = (*Scanner).Next
+		_ func(*Token) string    = (*Token).String
+		_ func(tokenType) string = tokenType.String
+		_ struct {
+			Type         tokenType
+			Value        string
+			Line, Column int
+		} = Token{}
+	)
+	s := New("a {\n  color: #fff; }\n@media")
+	var got []string
+	for {
+		tok := s.Next()
+		if tok.Type == TokenEOF || tok.Type == TokenError {
+			break
+		}
+		got = append(got, tok.String())
+	}
+	want := []string{
+		`IDENT (line: 1, column: 1): "a"`,
+		`S (line: 1, column: 2): " "`,
+		`CHAR (line: 1, column: 3): "{"`,
+		`S (line: 1, column: 4): "\n  "`,
+		`IDENT (line: 2, column: 3): "color"`,
+		`CHAR (line: 2, column: 8): ":"`,
+		`S (line: 2, column: 9): " "`,
+		`HASH (line: 2, column: 10): "#fff"`,
+		`CHAR (line: 2, column: 14): ";"`,
+		`S (line: 2, column: 15): " "`,
+		`CHAR (line: 2, column: 16): "}"`,
+		`S (line: 2, column: 17): "\n"`,
+		`ATKEYWORD (line: 3, column: 1): "@media"`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// Signs, hyphens and urls, which CSS Syntax Level 3 reads differently,
+	// have the tokens of the first version.
+	tcs := []struct {
+		input, v1 string
+	}{
+		{"a-1", `IDENT "a-1"`},
+		{"a -b", `IDENT "a" S " " IDENT "-b"`},
+		{"a+b", `IDENT "a" CHAR "+" IDENT "b"`},
+		{"- 1", `CHAR "-" S " " NUMBER "1"`},
+		{"-1px", `CHAR "-" DIMENSION "1px"`},
+		{"+.5", `CHAR "+" NUMBER ".5"`},
+		{"-4.2%", `CHAR "-" PERCENTAGE "4.2%"`},
+		{"2n+1", `DIMENSION "2n" CHAR "+" NUMBER "1"`},
+		{"--x", `CHAR "-" IDENT "-x"`},
+		{"--->", `CHAR "-" CDC "-->"`},
+		{"url(a)", `URI "url(a)"`},
+		{"URL(a)", `FUNCTION "URL(" IDENT "a" CHAR ")"`},
+	}
+	for _, tc := range tcs {
+		var got []string
+		s := New(tc.input)
+		for tok := s.Next(); tok.Type != TokenEOF; tok = s.Next() {
+			got = append(got, fmt.Sprintf("%s %q", tok.Type, tok.Value))
+		}
+		if strings.Join(got, " ") != tc.v1 {
+			t.Errorf("%q: got %s, want %s", tc.input, strings.Join(got, " "), tc.v1)
+		}
+	}
+}
+
+func TestReset(t *testing.T) {
+	s := New("a b")
+	first := s.Next()
+	s.Reset("c\r\nd")
+	var values []string
+	for tok := s.Next(); tok.Type != TokenEOF; tok = s.Next() {
+		values = append(values, fmt.Sprintf("%s@%d:%d", tok.Value, tok.Line, tok.Column))
+	}
+	if got, want := strings.Join(values, " "), "c@1:1 \n@1:2 d@2:1"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if first.Value != "a" {
+		t.Errorf("the first token was reused: %v", first)
+	}
+}
+
+func TestNewBytes(t *testing.T) {
+	// NewBytes copies the input.
+	input := []byte("a { color: red }")
+	s := NewBytes(input)
+	input[0] = 'x'
+	if tok := s.Next(); tok.Type != TokenIdent || tok.Value != "a" {
+		t.Errorf("got %v", tok)
+	}
+
+	// NewBytesNoCopy doesn't, unless preprocessing does.
+	input = []byte("a { color: red }")
+	s = NewBytesNoCopy(input)
+	tok := s.Next()
+	if tok.Type != TokenIdent || tok.Value != "a" {
+		t.Fatalf("got %v", tok)
+	}
+	if unsafe.StringData(tok.Value) != &input[0] {
+		t.Error("the token value is a copy")
+	}
+	input = []byte("a\r\nb")
+	s = NewBytesNoCopy(input)
+	tok = s.Next()
+	input[0] = 'x'
+	if tok.Value != "a" {
+		t.Errorf("got %q, want %q", tok.Value, "a")
+	}
+
+	for _, s := range []*Scanner{NewBytes(nil), NewBytesNoCopy(nil)} {
+		if tok := s.Next(); tok.Type != TokenEOF {
+			t.Errorf("got %v, want EOF", tok)
+		}
+	}
+}
+
+// normalizeReplace is the preprocessing formerly done by New, one
+// replacement at a time.
+func normalizeReplace(input string) string {
+	input = strings.Replace(input, "\r\n", "\n", -1)
+	input = strings.Replace(input, "\r", "\n", -1)
+	input = strings.Replace(input, "\f", "\n", -1)
+	return strings.Replace(input, "\u0000", "\ufffd", -1)
+}
+
+func FuzzNormalize(f *testing.F) {
+	for _, s := range []string{"", "a", "\r\n\r\r\n\f\x00", "a\rb\nc\r\n\x00d\f", "\r", "\x00\x00"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		if got, want := Normalize(s), normalizeReplace(s); got != want {
+			t.Errorf("%q: got %q, want %q", s, got, want)
+		}
+	})
+}
+
+func FuzzStringAndComment(f *testing.F) {
+	for _, s := range []string{`"a"`, `'a\'b'`, `"a\31 b"`, "\"a\\\nb\"", "\"\\31\t\"", "'a\tb'", `"é\é"`, "/* a */", "/*/ */", "/** a **/", "/* a"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		if s == "" {
+			return
+		}
+		if s[0] == '"' || s[0] == '\'' {
+			if got, want := stringLen(s), len(matchers[TokenString].FindString(s)); got != want {
+				t.Errorf("string %q: got length %d, want %d", s, got, want)
+			}
+		}
+		if strings.HasPrefix(s, "/*") {
+			want := matchers[TokenComment].FindString(s)
+			got := ""
+			if i := strings.Index(s[2:], "*/"); i >= 0 {
+				got = s[:i+4]
+			}
+			if got != want {
+				t.Errorf("comment %q: got %q, want %q", s, got, want)
+			}
+		}
+	})
+}
+
+func FuzzMatchRegexps(f *testing.F) {
+	for _, s := range []string{"a", "a(", "--a(", "-a", "-1", "-->", "1px", "1.5%", "1.px", "+.5e3", "1\\31 x", "U+0-7F", "u+1", "url(a)", "url( a b)", "a\\(", "\\31 (", "é("} {
+		f.Add(s)
+	}
+	// matchOrder is the order in which all the regexps used to be tried.
+	matchOrder := []tokenType{TokenURI, TokenFunction, TokenUnicodeRange, TokenIdent, TokenDimension, TokenPercentage, TokenNumber, TokenCDC}
+	f.Fuzz(func(t *testing.T, s string) {
+		if s == "" {
+			return
+		}
+		wantType, want := TokenChar, ""
+		for _, token := range matchOrder {
+			if match := matchers[token].FindString(s); match != "" {
+				wantType, want = token, match
+				break
+			}
+		}
+		if gotType, got := matchRegexps(s); gotType != wantType || got != want {
+			t.Errorf("%q: got %s %q, want %s %q", s, gotType, got, wantType, want)
+		}
+	})
+}
+
+func FuzzCheck(f *testing.F) {
+	for _, s := range []string{"a{b:c}", `"a`, "a\n'b", "/* a", "url(a/*b)", "aurl(/*b)", "url(a)url(/*)", "\\\"a", "\\\n\"", "\\31 '", "é\t\"a", "\uFEFF/*", "a\uFEFF'"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := New(s)
+		token := want.Next()
+		for token.Type != TokenEOF && token.Type != TokenError {
+			token = want.Next()
+		}
+		got := New(s).Check()
+		if token.Type == TokenEOF {
+			if got != nil {
+				t.Errorf("%q: got %v, want no error", s, got)
+			}
+		} else if got == nil || *got != *token {
+			t.Errorf("%q: got %v, want %v", s, got, token)
+		}
+	})
+}
+
+func FuzzScanner(f *testing.F) {
+	for _, s := range []string{"a{b:c}", "--custom-prop", `"ab\"cd"`, "42''", "-4.2%", "2n+1", "url( a.png )", "URL(a.png)", "U+0042", "<!-- -->", "a\r\nb\f\x00", "/* a */", "~=|=^=$=*=", "\uFEFFa", `╯︵┻━┻"stuff"`, "\\31 a", "é\t'a"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		// The values of the tokens are the input, normalized, and their
+		// positions follow each other.
+		var b strings.Builder
+		line, col := 1, 1
+		s := New(input)
+		for tok := s.Next(); tok.Type != TokenEOF; tok = s.Next() {
+			if tok.Type == TokenError {
+				return
+			}
+			if tok.Value == "" {
+				t.Fatalf("%q: empty token %v", input, tok)
+			}
+			if tok.Line != line || tok.Column != col {
+				t.Fatalf("%q: got %v, want line %d, column %d", input, tok, line, col)
+			}
+			b.WriteString(tok.Value)
+			switch i := strings.LastIndexByte(tok.Value, '\n'); {
+			case tok.Type == TokenBOM:
+				// The byte order mark counts as three columns, its
+				// length in bytes.
+				col += len(tok.Value)
+			case i >= 0:
+				line += strings.Count(tok.Value, "\n")
+				col = utf8.RuneCountInString(tok.Value[i+1:]) + 1
+			default:
+				col += utf8.RuneCountInString(tok.Value)
+			}
+		}
+		if got, want := b.String(), Normalize(input); got != want {
+			t.Errorf("%q: got tokens %q, want %q", input, got, want)
+		}
+	})
+}
+
+func TestCheck(t *testing.T) {
+	s := New("a{}\n  'b")
+	s.Next()
+	if got, want := s.Check(), (&Token{TokenError, "unclosed quotation mark", 2, 3}); got == nil || *got != *want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := s.Next(); got.Type != TokenError {
+		t.Errorf("Next after Check: got %v, want the error", got)
+	}
+	s = New("a{b:c}")
+	if got := s.Check(); got != nil {
+		t.Errorf("got %v, want no error", got)
+	}
+	if got := s.Next(); got.Type != TokenEOF || got.Column != 7 {
+		t.Errorf("Next after Check: got %v, want EOF at column 7", got)
+	}
+}
+
+func TestUnescape(t *testing.T) {
+	tcs := []struct{ input, expected string }{
+		{`abc`, `abc`},
+		{`caf\e9 `, `café`},
+		{`caf\0000e9x`, `caféx`},
+		{`\31 0`, `10`},
+		{`a\"b`, `a"b`},
+		{"a\\\nb", `ab`},
+		{`\0`, "\uFFFD"},
+		{`\110000`, "\uFFFD"},
+		{`a\`, `a`},
+	}
+	for _, tc := range tcs {
+		if got := Unescape(tc.input); got != tc.expected {
+			t.Errorf("%q: got %q, want %q", tc.input, got, tc.expected)
+		}
+	}
+}
+
+func TestDecodedValue(t *testing.T) {
+	tcs := []struct{ input, expected string }{
+		{`caf\e9`, `café`},
+		{`c\61 lc(`, `calc`},
+		{`@m\65 dia`, `media`},
+		{`#\31 23`, `123`},
+		{`'a\'b'`, `a'b`},
+		{`url( "a\"b" )`, `a"b`},
+		{`url(a\)b)`, `a)b`},
+		{`10p\78`, `10px`},
+		{`\2c`, `,`},
+		{`+`, `+`},
+	}
+	for _, tc := range tcs {
+		tok := New(tc.input).Next()
+		if got := tok.DecodedValue(); got != tc.expected {
+			t.Errorf("%q: got %q, want %q", tc.input, got, tc.expected)
+		}
+	}
+	// Values without escape sequences aren't copied.
+	tok := New("abc").Next()
+	if got := tok.DecodedValue(); unsafe.StringData(got) != unsafe.StringData(tok.Value) {
+		t.Error("the value was copied")
+	}
+}
+
+func TestMarshalTokens(t *testing.T) {
+	var tokens []*Token
+	for s := New("@media print {\n  a { color: #fff; content: \"\u00e9\" }\n}\n'unclosed"); ; {
+		tok := s.Next()
+		tokens = append(tokens, tok)
+		if tok.Type == TokenEOF || tok.Type == TokenError {
+			break
+		}
+	}
+	data := MarshalTokens(tokens)
+	got, err := UnmarshalTokens(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(tokens) {
+		t.Fatalf("got %d tokens, want %d", len(got), len(tokens))
+	}
+	for i, tok := range tokens {
+		if *got[i] != *tok {
+			t.Errorf("token %d: got %v, want %v", i, got[i], tok)
+		}
+	}
+	if got, err := UnmarshalTokens(MarshalTokens(nil)); err != nil || len(got) != 0 {
+		t.Errorf("got %v, %v, want no tokens", got, err)
+	}
+
+	version := append([]byte(nil), data...)
+	version[len(binaryMagic)]++
+	tcs := []struct {
+		desc     string
+		input    []byte
+		expected error
+	}{
+		{"empty", nil, ErrCorrupt},
+		{"magic", append([]byte("GCSS"), data[4:]...), ErrCorrupt},
+		{"version", version, ErrBinaryVersion},
+		{"truncated", data[:len(data)-1], ErrCorrupt},
+		{"flipped", append(append(append([]byte(nil), data[:10]...), data[10]^1), data[11:]...), ErrCorrupt},
+		{"type", appendChecksum(append(appendHeader(nil, binaryMagic, binaryVersion), 1, 0xff, 0, 0, 0)), ErrCorrupt},
+		{"count", appendChecksum(append(appendHeader(nil, binaryMagic, binaryVersion), 0xff, 0xff, 0xff, 0xff, 0x0f)), ErrCorrupt},
+		{"length", appendChecksum(append(appendHeader(nil, binaryMagic, binaryVersion), 1, 1, 0, 0, 9, 'a')), ErrCorrupt},
+		{"trailing", appendChecksum(append(appendHeader(nil, binaryMagic, binaryVersion), 0, 0)), ErrCorrupt},
+	}
+	for _, tc := range tcs {
+		if _, err := UnmarshalTokens(tc.input); !errors.Is(err, tc.expected) {
+			t.Errorf("%s: got %v, want %v", tc.desc, err, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tokentest
+
+import (
+	"strings"
+
+	"github.com/gorilla/css/scanner"
+)
+
+// CompareOptions are the differences TokensEqual ignores.
+type CompareOptions struct {
+	// IgnoreWhitespace ignores the content of whitespace tokens, and the
+	// whitespace at the start and end of the streams and where CSS doesn't
+	// give it a meaning: around braces, parentheses, semicolons, commas,
+	// the combinators ">", "+" and "~" and "!", and after colons. Whitespace
+	// elsewhere is significant, as in "a .b" and "a.b".
+	IgnoreWhitespace bool
+	// IgnoreComments ignores comments. With IgnoreWhitespace, comments
+	// count as whitespace, since they separate tokens that would otherwise
+	// be joined, as in "a/**/b".
+	IgnoreComments bool
+	// IgnoreCase compares identifiers, function names, at-keywords and
+	// units ignoring ASCII case, except for the custom names that start
+	// with two dashes.
+	IgnoreCase bool
+}
+
+// TokensEqual reports whether two token streams are the same, except for
+// the differences that opts ignores. It tells whether a transform, such as
+// a minifier, changed a stylesheet in a meaningful way:
+//
+//	opts := tokentest.CompareOptions{IgnoreWhitespace: true, IgnoreComments: true}
+//	if !tokentest.TokensEqual(tokentest.Tokens(in), tokentest.Tokens(out), opts) {
+//		// The transform changed more than whitespace and comments.
+//	}
+func TokensEqual(a, b []*scanner.Token, opts CompareOptions) bool {
+	a, b = opts.normalize(a), opts.normalize(b)
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		// The scanner reads a custom name such as "--x" as "-" followed by
+		// an identifier starting with "-".
+		afterHyphen := i > 0 && a[i-1].Type == scanner.TokenChar && a[i-1].Value == "-"
+		if !opts.equal(a[i], b[i], afterHyphen) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalize returns the tokens without those opts ignores.
+func (opts CompareOptions) normalize(tokens []*scanner.Token) []*scanner.Token {
+	var out []*scanner.Token
+	for _, t := range tokens {
+		if t.Type == scanner.TokenComment && opts.IgnoreComments {
+			if !opts.IgnoreWhitespace {
+				continue
+			}
+			t = &scanner.Token{Type: scanner.TokenS, Value: " ", Line: t.Line, Column: t.Column}
+		}
+		if t.Type == scanner.TokenS && opts.IgnoreWhitespace {
+			if len(out) == 0 || out[len(out)-1].Type == scanner.TokenS || insignificantAfter(out[len(out)-1]) {
+				continue
+			}
+		}
+		if opts.IgnoreWhitespace && len(out) > 0 && out[len(out)-1].Type == scanner.TokenS && insignificantBefore(t) {
+			out = out[:len(out)-1]
+		}
+		out = append(out, t)
+	}
+	if opts.IgnoreWhitespace && len(out) > 0 && out[len(out)-1].Type == scanner.TokenS {
+		out = out[:len(out)-1]
+	}
+	return out
+}
+
+// insignificantBefore reports whether whitespace before t has no meaning.
+func insignificantBefore(t *scanner.Token) bool {
+	return t.Type == scanner.TokenChar && strings.Contains("{}();,>+~!", t.Value)
+}
+
+// insignificantAfter reports whether whitespace after t has no meaning.
+func insignificantAfter(t *scanner.Token) bool {
+	return t.Type == scanner.TokenChar && strings.Contains("{}();,>+~!:", t.Value) ||
+		t.Type == scanner.TokenFunction
+}
+
+// equal reports whether two tokens are the same for opts. afterHyphen
+// reports whether they follow a "-".
+func (opts CompareOptions) equal(a, b *scanner.Token, afterHyphen bool) bool {
+	if a.Type != b.Type {
+		return false
+	}
+	switch {
+	case a.Value == b.Value:
+		return true
+	case a.Type == scanner.TokenS:
+		return opts.IgnoreWhitespace
+	case !opts.IgnoreCase:
+		return false
+	}
+	switch a.Type {
+	case scanner.TokenIdent, scanner.TokenFunction, scanner.TokenAtKeyword:
+		name := strings.TrimPrefix(a.Value, "@")
+		if afterHyphen && a.Type != scanner.TokenAtKeyword {
+			name = "-" + name
+		}
+		return !isCustom(name) && strings.EqualFold(a.Value, b.Value)
+	case scanner.TokenDimension:
+		// The number has no other letter than its exponent, whose case
+		// doesn't matter either.
+		return strings.EqualFold(a.Value, b.Value)
+	}
+	return false
+}
+
+// isCustom reports whether a name is a custom name, which starts with two
+// dashes and is case-sensitive.
+func isCustom(name string) bool {
+	return strings.HasPrefix(name, "--")
+}
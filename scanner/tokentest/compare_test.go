@@ -0,0 +1,47 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tokentest
+
+import "testing"
+
+func TestTokensEqual(t *testing.T) {
+	ws := CompareOptions{IgnoreWhitespace: true}
+	comments := CompareOptions{IgnoreComments: true}
+	all := CompareOptions{IgnoreWhitespace: true, IgnoreComments: true, IgnoreCase: true}
+	tcs := []struct {
+		a, b     string
+		opts     CompareOptions
+		expected bool
+	}{
+		{"a{b:c}", "a{b:c}", CompareOptions{}, true},
+		{"a { b: c }", "a{b:c}", CompareOptions{}, false},
+		{"a { b: c ; }\n", "a{b:c;}", ws, true},
+		{"a { b : c }", "a{b:c}", ws, false},
+		{" a  >  b , c ", "a>b,c", ws, true},
+		{"a  b", "a\tb", ws, true},
+		{"a .b", "a.b", ws, false},
+		{"a :hover", "a:hover", ws, false},
+		{"red !important", "red!important", ws, true},
+		{"f( a, b )", "f(a,b)", ws, true},
+		{"1px 2px", "1px2px", ws, false},
+		{"a/* c */{}", "a{}", comments, true},
+		{"a/**/b", "a b", comments, false},
+		{"a/**/b", "a b", all, true},
+		{"a /* c */ {}", "a{}", all, true},
+		{"a/**/b", "ab", all, false},
+		{"A { COLOR: RED; width: 1PX; height: 1E3PX }", "a{color:red;width:1px;height:1e3px}", all, true},
+		{"width: 1EM", "width: 1em", CompareOptions{IgnoreCase: true}, true},
+		{"width: 1em", "width: 1e0m", CompareOptions{IgnoreCase: true}, false},
+		{"@MEDIA Rgb(", "@media rgb(", CompareOptions{IgnoreCase: true}, true},
+		{"--X: a", "--x: a", CompareOptions{IgnoreCase: true}, false},
+		{"#ID 'A'", "#id 'a'", CompareOptions{IgnoreCase: true}, false},
+		{"a", "a b", all, false},
+	}
+	for _, tc := range tcs {
+		if got := TokensEqual(Tokens(tc.a), Tokens(tc.b), tc.opts); got != tc.expected {
+			t.Errorf("%q, %q with %+v: got %v, want %v", tc.a, tc.b, tc.opts, got, tc.expected)
+		}
+	}
+}
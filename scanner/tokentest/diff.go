@@ -0,0 +1,215 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tokentest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/scanner"
+)
+
+// Op is the kind of a Difference.
+type Op int
+
+const (
+	// Changed is a token of the first stream replaced by a token of the
+	// second one with another type or value.
+	Changed Op = iota
+	// Deleted is a token of the first stream missing from the second one.
+	Deleted
+	// Inserted is a token of the second stream missing from the first one.
+	Inserted
+)
+
+// String returns a string representation of the operation.
+func (op Op) String() string {
+	switch op {
+	case Changed:
+		return "changed"
+	case Deleted:
+		return "deleted"
+	case Inserted:
+		return "inserted"
+	}
+	return fmt.Sprintf("Op(%d)", int(op))
+}
+
+// Difference is a difference between two token streams.
+type Difference struct {
+	Op Op
+	// A and B are the tokens of the first and second streams: A is nil for
+	// insertions and B for deletions.
+	A, B *scanner.Token
+	// IndexA and IndexB are the indexes of A and B in their streams. For
+	// insertions, IndexA is the index of the token of the first stream
+	// that B is inserted before, and for deletions IndexB is the index of
+	// the token of the second stream that would follow A.
+	IndexA, IndexB int
+}
+
+// String returns a string representation of the difference, with the
+// positions of the tokens, such as:
+//
+//	changed 1:5 IDENT "a" to 1:5 STRING "'a'"
+func (d Difference) String() string {
+	switch d.Op {
+	case Changed:
+		return fmt.Sprintf("changed %s to %s", describe(d.A), describe(d.B))
+	case Deleted:
+		return "deleted " + describe(d.A)
+	}
+	return "inserted " + describe(d.B)
+}
+
+// describe returns the position, type and quoted value of a token.
+func describe(t *scanner.Token) string {
+	return fmt.Sprintf("%d:%d %s %q", t.Line, t.Column, t.Type, t.Value)
+}
+
+// maxDiffCells is the maximum size of the table of the longest common
+// subsequence computed by Diff, in cells.
+const maxDiffCells = 1 << 22
+
+// Diff returns the differences between two token streams, in the order of
+// the streams. The tokens are compared by type and value: their positions
+// only annotate the differences, since a token inserted or deleted shifts
+// those of the tokens after it.
+//
+// The differences are the smallest set of insertions and deletions turning
+// a into b, where a deletion followed by an insertion is a change. Past a
+// few thousand tokens between the first and last differences, Diff
+// compares the remaining tokens in pairs instead, and reports the tokens
+// left over in the longer stream as insertions or deletions.
+func Diff(a, b []*scanner.Token) []Difference {
+	start := 0
+	for start < len(a) && start < len(b) && equal(a[start], b[start]) {
+		start++
+	}
+	endA, endB := len(a), len(b)
+	for endA > start && endB > start && equal(a[endA-1], b[endB-1]) {
+		endA--
+		endB--
+	}
+	n, m := endA-start, endB-start
+	if n == 0 && m == 0 {
+		return nil
+	}
+	if int64(n)*int64(m) > maxDiffCells {
+		var diffs []Difference
+		for i := 0; i < n || i < m; i++ {
+			switch {
+			case i >= m:
+				diffs = append(diffs, Difference{Op: Deleted, A: a[start+i], IndexA: start + i, IndexB: endB})
+			case i >= n:
+				diffs = append(diffs, Difference{Op: Inserted, B: b[start+i], IndexA: endA, IndexB: start + i})
+			case !equal(a[start+i], b[start+i]):
+				diffs = append(diffs, Difference{Op: Changed, A: a[start+i], B: b[start+i], IndexA: start + i, IndexB: start + i})
+			}
+		}
+		return diffs
+	}
+
+	// lcs[i*(m+1)+j] is the length of the longest common subsequence of
+	// the middle parts of a and b from i and j.
+	lcs := make([]int32, (n+1)*(m+1))
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			switch {
+			case equal(a[start+i], b[start+j]):
+				lcs[i*(m+1)+j] = lcs[(i+1)*(m+1)+j+1] + 1
+			case lcs[(i+1)*(m+1)+j] >= lcs[i*(m+1)+j+1]:
+				lcs[i*(m+1)+j] = lcs[(i+1)*(m+1)+j]
+			default:
+				lcs[i*(m+1)+j] = lcs[i*(m+1)+j+1]
+			}
+		}
+	}
+	var diffs []Difference
+	// The deletions and insertions since the last common token, paired
+	// into changes when it is reached.
+	var deleted, inserted []int
+	flush := func(i, j int) {
+		k := 0
+		for ; k < len(deleted) && k < len(inserted); k++ {
+			diffs = append(diffs, Difference{Op: Changed, A: a[deleted[k]], B: b[inserted[k]], IndexA: deleted[k], IndexB: inserted[k]})
+		}
+		for _, d := range deleted[k:] {
+			diffs = append(diffs, Difference{Op: Deleted, A: a[d], IndexA: d, IndexB: j})
+		}
+		for _, in := range inserted[k:] {
+			diffs = append(diffs, Difference{Op: Inserted, B: b[in], IndexA: i, IndexB: in})
+		}
+		deleted, inserted = deleted[:0], inserted[:0]
+	}
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && equal(a[start+i], b[start+j]):
+			flush(start+i, start+j)
+			i++
+			j++
+		case j == m || i < n && lcs[(i+1)*(m+1)+j] >= lcs[i*(m+1)+j+1]:
+			deleted = append(deleted, start+i)
+			i++
+		default:
+			inserted = append(inserted, start+j)
+			j++
+		}
+	}
+	flush(endA, endB)
+	return diffs
+}
+
+// equal reports whether two tokens have the same type and value.
+func equal(a, b *scanner.Token) bool {
+	return a.Type == b.Type && a.Value == b.Value
+}
+
+// CheckRoundTrip checks that the serialization of a stylesheet is stable:
+// input is parsed and serialized, and the output parsed and serialized
+// again, and the tokens of both serializations must be the same. It
+// reports the differences with t.Errorf, and fails with t.Fatalf if input
+// or its serialization doesn't parse.
+//
+// Programs that transform stylesheets can check that their output round-
+// trips safely, that is that a browser or another tool reading it sees the
+// same stylesheet:
+//
+//	tokentest.CheckRoundTrip(t, transformed.String())
+func CheckRoundTrip(t testing.TB, input string) {
+	t.Helper()
+	checkRoundTrip(t, input, func(input string) (string, error) {
+		s, err := css.ParseStylesheet(input)
+		if err != nil {
+			return "", err
+		}
+		return s.String(), nil
+	})
+}
+
+// checkRoundTrip is CheckRoundTrip with a serialization function.
+func checkRoundTrip(t testing.TB, input string, serialize func(string) (string, error)) {
+	t.Helper()
+	out, err := serialize(input)
+	if err != nil {
+		t.Fatalf("%q doesn't parse: %v", input, err)
+	}
+	again, err := serialize(out)
+	if err != nil {
+		t.Fatalf("%q is serialized as %q, which doesn't parse: %v", input, out, err)
+	}
+	diffs := Diff(Tokens(out), Tokens(again))
+	if diffs == nil {
+		return
+	}
+	var b strings.Builder
+	for _, d := range diffs {
+		b.WriteString("\n" + d.String())
+	}
+	t.Errorf("%q is serialized as %q, then as %q:%s", input, out, again, b.String())
+}
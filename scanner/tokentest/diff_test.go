@@ -0,0 +1,107 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tokentest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tcs := []struct {
+		a, b     string
+		expected []string
+	}{
+		{"a { b: c }", "a { b: c }", nil},
+		{"", "", nil},
+		{"a b", "a 'b'", []string{`changed 1:3 IDENT "b" to 1:3 STRING "'b'"`}},
+		{"a{b:c}", "a{b:cd}", []string{`changed 1:5 IDENT "c" to 1:5 IDENT "cd"`}},
+		{"a{b:c}", "a{b:c;}", []string{`inserted 1:6 CHAR ";"`}},
+		{"a{b:c;}", "a{b:c}", []string{`deleted 1:6 CHAR ";"`}},
+		{"a\n{b:c}", "a {b:c}", []string{`changed 1:2 S "\n" to 1:2 S " "`}},
+		{"a b c d", "a x c y d", []string{
+			`changed 1:3 IDENT "b" to 1:3 IDENT "x"`,
+			`inserted 1:6 S " "`,
+			`inserted 1:7 IDENT "y"`,
+		}},
+		{"", "a", []string{`inserted 1:1 IDENT "a"`}},
+		{"a /* b", "a /* b */", []string{`changed 1:3 error "unclosed comment" to 1:3 COMMENT "/* b */"`}},
+	}
+	for _, tc := range tcs {
+		var got []string
+		for _, d := range Diff(Tokens(tc.a), Tokens(tc.b)) {
+			got = append(got, d.String())
+		}
+		if strings.Join(got, "\n") != strings.Join(tc.expected, "\n") {
+			t.Errorf("Diff(%q, %q):\ngot:\n%s\nwant:\n%s", tc.a, tc.b, strings.Join(got, "\n"), strings.Join(tc.expected, "\n"))
+		}
+	}
+}
+
+func TestDiffIndexes(t *testing.T) {
+	a, b := Tokens("a b c"), Tokens("a c d")
+	var got []string
+	for _, d := range Diff(a, b) {
+		got = append(got, fmt.Sprintf("%s %d %d", d.Op, d.IndexA, d.IndexB))
+	}
+	// "b " is deleted before the "c" of b, and " d" inserted at the end of a.
+	want := "deleted 2 2\ndeleted 3 2\ninserted 5 3\ninserted 5 4"
+	if strings.Join(got, "\n") != want {
+		t.Errorf("got:\n%s\nwant:\n%s", strings.Join(got, "\n"), want)
+	}
+}
+
+func TestDiffLarge(t *testing.T) {
+	// Too many tokens for the longest common subsequence: the middle
+	// parts are compared in pairs.
+	a := Tokens(strings.Repeat("a ", 3000) + "b")
+	b := Tokens("x " + strings.Repeat("a ", 2999) + "y c")
+	var got []string
+	for _, d := range Diff(a, b) {
+		got = append(got, d.String())
+	}
+	want := []string{
+		`changed 1:1 IDENT "a" to 1:1 IDENT "x"`,
+		`changed 1:6001 IDENT "b" to 1:6001 IDENT "y"`,
+		`inserted 1:6002 S " "`,
+		`inserted 1:6003 IDENT "c"`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestCheckRoundTrip(t *testing.T) {
+	for _, input := range []string{"", "a { b: c }", "a { content: x\\", "a { background: url(x", "@media (min-width: 1px) { a { &:hover { b: c } } }"} {
+		if failures := record(func(tb testing.TB) { CheckRoundTrip(tb, input) }); failures != nil {
+			t.Errorf("%q: got failures %q", input, failures)
+		}
+	}
+	tcs := []struct {
+		input     string
+		serialize func(string) (string, error)
+		expected  string
+	}{
+		{"a", func(s string) (string, error) { return s + " b", nil }, `"a" is serialized as "a b", then as "a b b":` + "\n" + `inserted 1:4 S " "` + "\n" + `inserted 1:5 IDENT "b"`},
+		{"a", func(s string) (string, error) { return s, errors.New("x") }, `"a" doesn't parse: x`},
+		{"a", func(s string) (string, error) {
+			if s == "b" {
+				return "", errors.New("x")
+			}
+			return "b", nil
+		}, `"a" is serialized as "b", which doesn't parse: x`},
+	}
+	for _, tc := range tcs {
+		failures := record(func(tb testing.TB) { checkRoundTrip(tb, tc.input, tc.serialize) })
+		if len(failures) != 1 || failures[0] != tc.expected {
+			t.Errorf("%q: got %q, want %q", tc.input, failures, tc.expected)
+		}
+	}
+	if failures := record(func(tb testing.TB) { CheckRoundTrip(tb, "a { b: 'c") }); len(failures) != 1 {
+		t.Errorf("unclosed string: got %q, want a failure", failures)
+	}
+}
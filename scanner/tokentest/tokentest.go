@@ -0,0 +1,191 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package gorilla/css/scanner/tokentest compares the tokens of the scanner
+with golden files or with each other, for the tests of the scanner and of
+programs that depend on how it tokenizes their stylesheets.
+
+Format writes tokens in a canonical text format, one token per line with its
+position, type and value as found in the input:
+
+	1:1	IDENT	"a"
+	1:2	S	" "
+	1:3	CHAR	"{"
+
+GoldenFiles compares the tokens of stylesheets with golden files holding
+that text, next to them with the ".tokens" extension:
+
+	func TestTokens(t *testing.T) {
+		tokentest.GoldenFiles(t, "testdata/*.css")
+	}
+
+The golden files are written instead with the -tokentest.update flag:
+
+	go test -run TestTokens . -tokentest.update
+
+Diff compares two token streams, such as those of a stylesheet before and
+after a change, and returns the tokens changed, deleted or inserted with
+their positions:
+
+	for _, d := range tokentest.Diff(tokentest.Tokens(old), tokentest.Tokens(new)) {
+		fmt.Println(d) // changed 1:5 IDENT "a" to 1:5 STRING "'a'"
+	}
+
+TokensEqual compares two token streams ignoring the differences that don't
+change the meaning of a stylesheet, such as whitespace and comments.
+
+CheckRoundTrip checks with Diff that serializing a stylesheet is stable, so
+that programs transforming stylesheets can check that their output reads
+back the same.
+*/
+package tokentest
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/css/scanner"
+)
+
+var update = flag.Bool("tokentest.update", false, "write the golden files of tokentest instead of comparing them")
+
+// Tokens returns the tokens of input, up to the end of the input, which
+// isn't included, or up to a TokenError, which is.
+func Tokens(input string) []*scanner.Token {
+	var tokens []*scanner.Token
+	s := scanner.New(input)
+	for {
+		t := s.Next()
+		if t.Type == scanner.TokenEOF {
+			return tokens
+		}
+		tokens = append(tokens, t)
+		if t.Type == scanner.TokenError {
+			return tokens
+		}
+	}
+}
+
+// Format returns the tokens of input, as returned by Tokens, one per line,
+// with their position, type and quoted value, separated by tabs.
+func Format(input string) string {
+	var b strings.Builder
+	for _, t := range Tokens(input) {
+		fmt.Fprintf(&b, "%d:%d\t%s\t%q\n", t.Line, t.Column, t.Type, t.Value)
+	}
+	return b.String()
+}
+
+// Golden compares the tokens of input, as returned by Format, with the
+// golden file named golden, and reports the first difference with
+// t.Errorf. With the -tokentest.update flag, it writes the golden file
+// instead.
+func Golden(t testing.TB, input, golden string) {
+	t.Helper()
+	got := Format(input)
+	if *update {
+		if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(golden)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("%s doesn't exist; run the test with -tokentest.update to write it", golden)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := diffText(got, string(want)); diff != "" {
+		t.Errorf("tokens differ from %s:\n%s", golden, diff)
+	}
+}
+
+// GoldenFiles calls Golden in a subtest for each file matching pattern,
+// with the golden file of the same name and the ".tokens" extension. It
+// fails if no file matches.
+func GoldenFiles(t *testing.T, pattern string) {
+	t.Helper()
+	names, err := filepath.Glob(pattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names == nil {
+		t.Fatalf("no file matches %s", pattern)
+	}
+	for _, name := range names {
+		name := name
+		t.Run(filepath.Base(name), func(t *testing.T) {
+			input, err := os.ReadFile(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			Golden(t, string(input), strings.TrimSuffix(name, filepath.Ext(name))+".tokens")
+		})
+	}
+}
+
+// diffContext is the number of lines shown by diffText before and after
+// the first difference.
+const diffContext = 3
+
+// diffText returns a description of the first difference between two texts,
+// or an empty string if they are equal. It shows the line number of the
+// difference and the lines around it, those of want prefixed with "-" and
+// those of got with "+".
+func diffText(got, want string) string {
+	if got == want {
+		return ""
+	}
+	g := strings.SplitAfter(got, "\n")
+	w := strings.SplitAfter(want, "\n")
+	i := 0
+	for i < len(g) && i < len(w) && g[i] == w[i] {
+		i++
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "first difference at line %d:\n", i+1)
+	start := i - diffContext
+	if start < 0 {
+		start = 0
+	}
+	for _, line := range w[start:i] {
+		writeLine(&b, " ", line)
+	}
+	for _, lines := range []struct {
+		prefix string
+		lines  []string
+	}{{"-", w}, {"+", g}} {
+		end := i + diffContext
+		if end > len(lines.lines) {
+			end = len(lines.lines)
+		}
+		for _, line := range lines.lines[i:end] {
+			writeLine(&b, lines.prefix, line)
+		}
+		if end < len(lines.lines) && lines.lines[end] != "" {
+			b.WriteString(lines.prefix + "...\n")
+		}
+	}
+	return b.String()
+}
+
+// writeLine writes a line of diffText with its prefix. The last line of a text
+// without a final newline is marked.
+func writeLine(b *strings.Builder, prefix, line string) {
+	if line == "" {
+		return
+	}
+	if !strings.HasSuffix(line, "\n") {
+		line += " (no newline at end)\n"
+	}
+	b.WriteString(prefix + line)
+}
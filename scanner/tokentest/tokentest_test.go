@@ -0,0 +1,100 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tokentest
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestGoldenFiles(t *testing.T) {
+	GoldenFiles(t, filepath.Join("testdata", "*.css"))
+}
+
+func TestFormat(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected string
+	}{
+		{"", ""},
+		{"a{\n b: 1px }", "1:1\tIDENT\t\"a\"\n1:2\tCHAR\t\"{\"\n1:3\tS\t\"\\n \"\n2:2\tIDENT\t\"b\"\n2:3\tCHAR\t\":\"\n2:4\tS\t\" \"\n2:5\tDIMENSION\t\"1px\"\n2:8\tS\t\" \"\n2:9\tCHAR\t\"}\"\n"},
+		{"a /* b", "1:1\tIDENT\t\"a\"\n1:2\tS\t\" \"\n1:3\terror\t\"unclosed comment\"\n"},
+	}
+	for _, tc := range tcs {
+		if got := Format(tc.input); got != tc.expected {
+			t.Errorf("%q: got %q, want %q", tc.input, got, tc.expected)
+		}
+	}
+}
+
+func TestDiffText(t *testing.T) {
+	tcs := []struct {
+		got, want string
+		expected  string
+	}{
+		{"a\nb\n", "a\nb\n", ""},
+		{"1\n2\n3\n4\n5\nx\n7\n8\n9\n10\n", "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n", "first difference at line 6:\n 3\n 4\n 5\n-6\n-7\n-8\n-...\n+x\n+7\n+8\n+...\n"},
+		{"a\nb\n", "a\n", "first difference at line 2:\n a\n+b\n"},
+		{"a", "a\n", "first difference at line 1:\n-a\n+a (no newline at end)\n"},
+	}
+	for _, tc := range tcs {
+		if got := diffText(tc.got, tc.want); got != tc.expected {
+			t.Errorf("diffText(%q, %q): got %q, want %q", tc.got, tc.want, got, tc.expected)
+		}
+	}
+}
+
+// recorder is a testing.TB recording the failures of Golden.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.Errorf(format, args...)
+	runtime.Goexit()
+}
+
+// record calls fn and returns its failures.
+func record(fn func(tb testing.TB)) []string {
+	r := &recorder{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(r)
+	}()
+	<-done
+	return r.failures
+}
+
+func TestGolden(t *testing.T) {
+	golden := filepath.Join("testdata", "error.tokens")
+	tcs := []struct {
+		input, golden string
+		expected      string
+	}{
+		{"a { content: \"unclosed\n", golden, ""},
+		{"a { content: x }", golden, "tokens differ from " + golden + ":\nfirst difference at line 8:\n 1:5\tIDENT\t\"content\"\n 1:12\tCHAR\t\":\"\n 1:13\tS\t\" \"\n-1:14\terror\t\"unclosed quotation mark\"\n+1:14\tIDENT\t\"x\"\n+1:15\tS\t\" \"\n+1:16\tCHAR\t\"}\"\n"},
+		{"a", filepath.Join("testdata", "missing.tokens"), filepath.Join("testdata", "missing.tokens") + " doesn't exist; run the test with -tokentest.update to write it"},
+	}
+	for _, tc := range tcs {
+		failures := record(func(tb testing.TB) { Golden(tb, tc.input, tc.golden) })
+		got := ""
+		if len(failures) > 0 {
+			got = failures[0]
+		}
+		if len(failures) > 1 || got != tc.expected {
+			t.Errorf("%q: got %q, want %q", tc.input, failures, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scanner
+
+import (
+	"io"
+	"strings"
+)
+
+// Writer writes a token stream back as CSS.
+//
+// When two tokens would be read back as different tokens if written next to
+// each other, as happens when a transformation removes the whitespace or the
+// comment between two identifiers, an empty comment is written between them.
+// Whitespace is written as a single space unless PreserveWhitespace is set,
+// in which case a token stream produced by a Scanner is written exactly as
+// its input.
+//
+// The zero value of Writer discards the tokens it is given while still
+// tracking the last one and the number of bytes; call Reset to give it a
+// destination.
+type Writer struct {
+	// PreserveWhitespace writes whitespace tokens as found in the input
+	// instead of as a single space.
+	PreserveWhitespace bool
+
+	w    io.Writer
+	n    int64
+	err  error
+	prev *Token
+}
+
+// NewWriter returns a new writer that writes tokens to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// NewWriterAfter returns a new writer that writes tokens to w as if last had
+// just been written, so that output appended to earlier output is separated
+// from it correctly. last may be nil.
+func NewWriterAfter(w io.Writer, last *Token) *Writer {
+	return &Writer{w: w, prev: last}
+}
+
+// Reset discards the state of the writer, including its last token, byte
+// count and error, and makes it write to dst. PreserveWhitespace is kept.
+func (w *Writer) Reset(dst io.Writer) {
+	*w = Writer{PreserveWhitespace: w.PreserveWhitespace, w: dst}
+}
+
+// Last returns the last token written, or nil if there is none.
+func (w *Writer) Last() *Token {
+	return w.prev
+}
+
+// WriteToken writes a token. TokenEOF is ignored. It returns the first error
+// encountered by the writer, if any.
+func (w *Writer) WriteToken(t *Token) error {
+	if w.err != nil || t.Type == TokenEOF {
+		return w.err
+	}
+	if w.prev != nil && needsComment(w.prev, t) {
+		w.writeString("/**/")
+	}
+	if t.Type == TokenS && !w.PreserveWhitespace {
+		w.writeString(" ")
+	} else {
+		w.writeString(t.Value)
+	}
+	w.prev = t
+	return w.err
+}
+
+// Written returns the number of bytes written so far.
+func (w *Writer) Written() int64 {
+	return w.n
+}
+
+// writeString writes s unless an error occurred before.
+func (w *Writer) writeString(s string) {
+	if w.err != nil {
+		return
+	}
+	if w.w == nil {
+		w.n += int64(len(s))
+		return
+	}
+	n, err := io.WriteString(w.w, s)
+	w.n += int64(n)
+	w.err = err
+}
+
+// WriteTokens writes a list of tokens to w with a new Writer. It returns the
+// number of bytes written and the first error encountered, if any.
+func WriteTokens(w io.Writer, tokens []*Token, preserveWhitespace bool) (int64, error) {
+	tw := NewWriter(w)
+	tw.PreserveWhitespace = preserveWhitespace
+	for _, t := range tokens {
+		if err := tw.WriteToken(t); err != nil {
+			break
+		}
+	}
+	return tw.n, tw.err
+}
+
+// needsComment reports whether an empty comment must separate two tokens so
+// that they aren't read back as different tokens, following the
+// serialization rules of the CSS Syntax specification. A sign followed by a
+// number, and a "-" followed by an identifier starting with "-", as in
+// "--x", are read back as the same two tokens, so they aren't separated.
+func needsComment(a, b *Token) bool {
+	switch {
+	case a.Type == TokenIdent:
+		return continuesName(b) || isChar(b, "(")
+	case a.Type == TokenAtKeyword, a.Type == TokenHash, a.Type == TokenDimension,
+		isChar(a, "#"), isChar(a, "@"):
+		return continuesName(b)
+	case isChar(a, "-"):
+		return continuesName(b) && !isNumeric(b) &&
+			!((b.Type == TokenIdent || b.Type == TokenFunction) && startsWith(b, "-"))
+	case a.Type == TokenNumber:
+		switch b.Type {
+		case TokenIdent, TokenFunction, TokenURI, TokenUnicodeRange:
+			return true
+		}
+		return isNumeric(b) && !startsWith(b, "+-") || isChar(b, "%") || isChar(b, "\\")
+	case a.Type == TokenUnicodeRange:
+		return continuesName(b) || isChar(b, "?")
+	case isChar(a, "."):
+		return isNumeric(b) && startsWith(b, "0123456789")
+	case isChar(a, "$"), isChar(a, "*"), isChar(a, "^"), isChar(a, "~"):
+		return isChar(b, "=") || b.Type == TokenIncludes || b.Type == TokenDashMatch
+	case isChar(a, "|"):
+		return isChar(b, "=") || isChar(b, "|") || b.Type == TokenDashMatch
+	case isChar(a, "/"):
+		return isChar(b, "*") || b.Type == TokenComment || b.Type == TokenSubstringMatch
+	case isChar(a, "<"):
+		return isChar(b, "!")
+	}
+	return false
+}
+
+// continuesName reports whether a token written right after a name would
+// be read as part of it.
+func continuesName(t *Token) bool {
+	switch t.Type {
+	case TokenIdent, TokenFunction, TokenURI, TokenUnicodeRange, TokenCDC:
+		return true
+	case TokenNumber, TokenPercentage, TokenDimension:
+		return !startsWith(t, "+.")
+	}
+	return isChar(t, "-") || isChar(t, "\\")
+}
+
+// isNumeric reports whether t is a number, a percentage or a dimension.
+func isNumeric(t *Token) bool {
+	return t.Type == TokenNumber || t.Type == TokenPercentage || t.Type == TokenDimension
+}
+
+// startsWith reports whether the value of t starts with one of the bytes.
+func startsWith(t *Token, bytes string) bool {
+	return t.Value != "" && strings.IndexByte(bytes, t.Value[0]) >= 0
+}
+
+// isChar reports whether t is the given delimiter.
+func isChar(t *Token, c string) bool {
+	return t.Type == TokenChar && t.Value == c
+}
@@ -0,0 +1,379 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package selector
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/scanner"
+)
+
+// Element is an element of a document tree, matched against selectors by
+// Match. Adapters for DOM implementations, such as golang.org/x/net/html,
+// only need to expose the element names, attributes and neighbors.
+//
+// The methods returning elements must return a nil Element, rather than a
+// nil pointer of the type implementing it, when there is no such element.
+type Element interface {
+	// LocalName returns the name of the element, in lowercase for HTML
+	// elements.
+	LocalName() string
+	// Attr returns the value of an attribute, such as "id" or "class", and
+	// whether the element has it.
+	Attr(name string) (string, bool)
+	// Parent returns the parent element, or nil for the root element.
+	Parent() Element
+	// PrevSibling and NextSibling return the previous and next sibling
+	// elements, skipping text and comments, or nil.
+	PrevSibling() Element
+	NextSibling() Element
+}
+
+// PseudoClassMatcher is implemented by the elements that match
+// pseudo-classes depending on the state of the document, such as :hover,
+// :checked or :empty. Without it, these pseudo-classes match no element.
+type PseudoClassMatcher interface {
+	// MatchPseudoClass reports whether the element matches the
+	// pseudo-class with the given lowercase name, without colon, and
+	// arguments, which are nil for pseudo-classes that aren't functions.
+	MatchPseudoClass(name string, args []*css.ComponentValue) bool
+}
+
+// Match reports whether e matches one of the selectors of the list. Type
+// and attribute selectors ignore namespaces, the nesting selector "&"
+// matches the root element as :scope does, and selectors with a
+// pseudo-element match no element.
+//
+// The logical pseudo-classes, such as :is() and :not(), the structural
+// ones, such as :nth-child() and :first-of-type, and :root are matched
+// with the Element methods. :has() and the other pseudo-classes are
+// matched by e if it implements PseudoClassMatcher, and match no element
+// otherwise.
+func (l List) Match(e Element) bool {
+	_, ok := l.MatchNested(e, nil)
+	return ok
+}
+
+// MatchNested is like Match for the selector list of a style rule nested in
+// other style rules, whose selector lists are parents, from the outermost
+// one. The nesting selector "&" matches the elements matched by the
+// selectors of the innermost parent, and selectors without it are relative
+// to them, as if they started with "& ". With no parents, MatchNested is
+// like Match.
+//
+// It also returns the highest specificity of the selectors e matches, in
+// which each "&" has the specificity of the parent selector list, as :is(),
+// except in :where().
+func (l List) MatchNested(e Element, parents []List) (Specificity, bool) {
+	m := &matcher{parents: parents}
+	var max Specificity
+	ok := false
+	for _, c := range l {
+		if !m.rule(c, e) {
+			continue
+		}
+		if s := m.specificity(c); !ok || max.Less(s) {
+			max = s
+		}
+		ok = true
+	}
+	return max, ok
+}
+
+// matcher matches selectors against elements.
+type matcher struct {
+	// parents are the selector lists of the rules holding the matched one.
+	parents []List
+}
+
+// nested returns the matcher of the selectors of the innermost parent.
+func (m *matcher) nested() *matcher {
+	return &matcher{parents: m.parents[:len(m.parents)-1]}
+}
+
+// rule reports whether e matches c, a selector of the list of the matched
+// rule, which is relative to the parent selectors if it has no nesting
+// selector.
+func (m *matcher) rule(c *Complex, e Element) bool {
+	if len(m.parents) > 0 && !hasNesting(c) {
+		first := *c.Compounds[0]
+		if first.Combinator == None {
+			first.Combinator = Descendant
+		}
+		compounds := []*Compound{{Simples: []*Simple{{Kind: Nesting}}}, &first}
+		c = &Complex{Compounds: append(compounds, c.Compounds[1:]...)}
+	}
+	return m.compounds(c.Compounds, e)
+}
+
+// hasNesting reports whether c has a nesting selector, including in the
+// arguments of pseudo-classes.
+func hasNesting(c *Complex) bool {
+	return !List{c}.Walk(func(s *Simple) bool { return s.Kind != Nesting })
+}
+
+// compounds reports whether e matches the last compound selector of a list,
+// and the elements it is related to by its combinator match the previous
+// ones.
+func (m *matcher) compounds(list []*Compound, e Element) bool {
+	last := list[len(list)-1]
+	for _, s := range last.Simples {
+		if !m.simple(s, e) {
+			return false
+		}
+	}
+	if len(list) == 1 {
+		return true
+	}
+	rest := list[:len(list)-1]
+	switch last.Combinator {
+	case Descendant:
+		for p := e.Parent(); p != nil; p = p.Parent() {
+			if m.compounds(rest, p) {
+				return true
+			}
+		}
+	case Child:
+		p := e.Parent()
+		return p != nil && m.compounds(rest, p)
+	case NextSibling:
+		p := e.PrevSibling()
+		return p != nil && m.compounds(rest, p)
+	case SubsequentSibling:
+		for p := e.PrevSibling(); p != nil; p = p.PrevSibling() {
+			if m.compounds(rest, p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// simple reports whether e matches s.
+func (m *matcher) simple(s *Simple, e Element) bool {
+	switch s.Kind {
+	case Type:
+		return strings.EqualFold(e.LocalName(), s.Name)
+	case Universal:
+		return true
+	case ID:
+		id, ok := e.Attr("id")
+		return ok && id == s.Name
+	case Class:
+		class, _ := e.Attr("class")
+		for _, c := range strings.Fields(class) {
+			if c == s.Name {
+				return true
+			}
+		}
+		return false
+	case Attribute:
+		return matchAttribute(s, e)
+	case PseudoClass:
+		return m.pseudoClass(s, e)
+	case Nesting:
+		if len(m.parents) == 0 {
+			return e.Parent() == nil
+		}
+		n := m.nested()
+		for _, c := range m.parents[len(m.parents)-1] {
+			if n.rule(c, e) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// list reports whether e matches one of the selectors of l, the arguments
+// of a pseudo-class.
+func (m *matcher) list(l List, e Element) bool {
+	for _, c := range l {
+		if m.compounds(c.Compounds, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchAttribute reports whether e matches the attribute selector s.
+func matchAttribute(s *Simple, e Element) bool {
+	v, ok := e.Attr(s.Name)
+	if !ok {
+		return false
+	}
+	want := s.Value
+	if s.Modifier == "i" {
+		v, want = strings.ToLower(v), strings.ToLower(want)
+	}
+	switch s.Operator {
+	case "":
+		return true
+	case "=":
+		return v == want
+	case "~=":
+		for _, f := range strings.Fields(v) {
+			if f == want {
+				return true
+			}
+		}
+		return false
+	case "|=":
+		return v == want || strings.HasPrefix(v, want+"-")
+	case "^=":
+		return want != "" && strings.HasPrefix(v, want)
+	case "$=":
+		return want != "" && strings.HasSuffix(v, want)
+	case "*=":
+		return want != "" && strings.Contains(v, want)
+	}
+	return false
+}
+
+// pseudoClass reports whether e matches the pseudo-class s.
+func (m *matcher) pseudoClass(s *Simple, e Element) bool {
+	switch s.Name {
+	case "is", "where", "matches", "-webkit-any", "-moz-any":
+		return m.list(s.Selectors, e)
+	case "not":
+		return !m.list(s.Selectors, e)
+	case "root":
+		return e.Parent() == nil
+	case "first-child":
+		return e.PrevSibling() == nil
+	case "last-child":
+		return e.NextSibling() == nil
+	case "only-child":
+		return e.PrevSibling() == nil && e.NextSibling() == nil
+	case "first-of-type":
+		return m.position(e, Element.PrevSibling, sameType(e)) == 1
+	case "last-of-type":
+		return m.position(e, Element.NextSibling, sameType(e)) == 1
+	case "only-of-type":
+		return m.position(e, Element.PrevSibling, sameType(e)) == 1 && m.position(e, Element.NextSibling, sameType(e)) == 1
+	case "nth-child", "nth-last-child", "nth-of-type", "nth-last-of-type":
+		a, b, ok := parseNth(s.Args)
+		if !ok {
+			return false
+		}
+		next := Element.PrevSibling
+		if strings.HasPrefix(s.Name, "nth-last-") {
+			next = Element.NextSibling
+		}
+		filter := func(Element) bool { return true }
+		switch {
+		case strings.HasSuffix(s.Name, "-of-type"):
+			filter = sameType(e)
+		case s.Selectors != nil:
+			if !m.list(s.Selectors, e) {
+				return false
+			}
+			filter = func(sibling Element) bool { return m.list(s.Selectors, sibling) }
+		}
+		return nth(a, b, m.position(e, next, filter))
+	}
+	if pm, ok := e.(PseudoClassMatcher); ok {
+		return pm.MatchPseudoClass(s.Name, s.Args)
+	}
+	return false
+}
+
+// sameType returns a filter of the elements with the name of e.
+func sameType(e Element) func(Element) bool {
+	name := e.LocalName()
+	return func(sibling Element) bool { return sibling.LocalName() == name }
+}
+
+// position returns the 1-based position of e among its siblings matching
+// filter, counting from the siblings returned by next.
+func (m *matcher) position(e Element, next func(Element) Element, filter func(Element) bool) int {
+	n := 1
+	for s := next(e); s != nil; s = next(s) {
+		if filter(s) {
+			n++
+		}
+	}
+	return n
+}
+
+// nth reports whether the position n is matched by An+B, that is whether
+// n = A*i + B for some i >= 0.
+func nth(a, b, n int) bool {
+	if a == 0 {
+		return n == b
+	}
+	return (n-b)%a == 0 && (n-b)/a >= 0
+}
+
+// parseNth parses the An+B notation at the start of the arguments of
+// :nth-child() and the like, up to "of", as "2n+1", "odd" or "-n + 3", and
+// reports whether it is valid.
+func parseNth(args []*css.ComponentValue) (a, b int, ok bool) {
+	var text strings.Builder
+	for _, v := range args {
+		if v.Token.Type == scanner.TokenIdent && strings.EqualFold(v.Token.Value, "of") {
+			break
+		}
+		if v.Token.Type != scanner.TokenS {
+			text.WriteString(strings.ToLower(v.Token.Value))
+		}
+	}
+	s := text.String()
+	switch s {
+	case "odd":
+		return 2, 1, true
+	case "even":
+		return 2, 0, true
+	}
+	i := strings.IndexByte(s, 'n')
+	if i < 0 {
+		b, err := strconv.Atoi(s)
+		return 0, b, err == nil
+	}
+	switch s[:i] {
+	case "", "+":
+		a = 1
+	case "-":
+		a = -1
+	default:
+		var err error
+		if a, err = strconv.Atoi(s[:i]); err != nil {
+			return 0, 0, false
+		}
+	}
+	if rest := s[i+1:]; rest != "" {
+		if rest[0] != '+' && rest[0] != '-' {
+			return 0, 0, false
+		}
+		var err error
+		if b, err = strconv.Atoi(rest); err != nil {
+			return 0, 0, false
+		}
+	}
+	return a, b, true
+}
+
+// specificity returns the specificity of c, in which the nesting selector
+// has the specificity of the parent selector list, each time it appears. A
+// selector without nesting selector counts it once, as if it started with
+// "& ".
+func (m *matcher) specificity(c *Complex) Specificity {
+	if len(m.parents) == 0 {
+		return c.Specificity()
+	}
+	n := m.nested()
+	var nesting Specificity
+	for _, p := range m.parents[len(m.parents)-1] {
+		if ps := n.specificity(p); nesting.Less(ps) {
+			nesting = ps
+		}
+	}
+	if !hasNesting(c) {
+		return c.Specificity().add(nesting)
+	}
+	return c.specificity(nesting)
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package selector
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/scanner"
+)
+
+// Entry is a complex selector of a stylesheet with its specificity, as
+// listed by Report.
+type Entry struct {
+	// Selector is the complex selector as written in the input, with each
+	// run of whitespace replaced by a space, so that it fits on a line.
+	Selector    string
+	Specificity Specificity
+	// Line and Column are the position of the selector in the input.
+	Line   int
+	Column int
+	// Rule is the style rule of the selector.
+	Rule *css.Rule
+}
+
+// Report walks a stylesheet and returns the complex selectors of its style
+// rules with their specificity, in the order of the input. A rule with the
+// selector list "a, #b" has two entries, one for each selector. As with
+// HighSpecificity in gorilla/css/validate, the specificity of nested rules
+// doesn't include that of their parent. The selectors that can't be parsed
+// and the keyframe selectors of @keyframes rules are left out.
+//
+// The entries give the specificity graph of a stylesheet, with the position
+// of each selector and its specificity, and can be sorted or grouped with
+// SortBySpecificity and GroupBySpecificity.
+func Report(s *css.Stylesheet) []Entry {
+	return reportRules(s.Rules, nil)
+}
+
+// reportRules appends the entries of a list of rules to entries.
+func reportRules(rules []*css.Rule, entries []Entry) []Entry {
+	for _, r := range rules {
+		if isKeyframes(r) {
+			continue
+		}
+		if !r.IsAtRule() {
+			list, err := Parse(r.Prelude)
+			if err != nil {
+				list, err = ParseRelative(r.Prelude)
+			}
+			if err == nil {
+				for _, c := range list {
+					t := c.Values[0].Token
+					entries = append(entries, Entry{
+						Selector:    css.ValuesString(collapseSpace(c.Values)),
+						Specificity: c.Specificity(),
+						Line:        t.Line,
+						Column:      t.Column,
+						Rule:        r,
+					})
+				}
+			}
+		}
+		entries = reportRules(r.Rules, entries)
+	}
+	return entries
+}
+
+// collapseSpace returns a copy of values with their whitespace tokens,
+// including those of functions and blocks, replaced by a space.
+func collapseSpace(values []*css.ComponentValue) []*css.ComponentValue {
+	if values == nil {
+		return nil
+	}
+	out := make([]*css.ComponentValue, len(values))
+	for i, v := range values {
+		t := v.Token
+		if t.Type == scanner.TokenS {
+			t = &scanner.Token{Type: scanner.TokenS, Value: " ", Line: t.Line, Column: t.Column}
+		}
+		out[i] = &css.ComponentValue{Token: t, Children: collapseSpace(v.Children)}
+	}
+	return out
+}
+
+// isKeyframes reports whether r is a @keyframes rule, possibly with a vendor
+// prefix.
+func isKeyframes(r *css.Rule) bool {
+	name := strings.ToLower(r.AtKeyword)
+	return name == "keyframes" || strings.HasPrefix(name, "-") && strings.HasSuffix(name, "-keyframes")
+}
+
+// SortBySpecificity sorts entries from the highest specificity to the
+// lowest. Entries of the same specificity keep their order.
+func SortBySpecificity(entries []Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[j].Specificity.Less(entries[i].Specificity)
+	})
+}
+
+// Group is a set of entries with the same specificity.
+type Group struct {
+	Specificity Specificity
+	Entries     []Entry
+}
+
+// GroupBySpecificity groups entries by specificity, from the highest to the
+// lowest. The entries of a group keep their order.
+func GroupBySpecificity(entries []Entry) []Group {
+	var groups []Group
+	index := map[Specificity]int{}
+	for _, e := range entries {
+		i, ok := index[e.Specificity]
+		if !ok {
+			i = len(groups)
+			index[e.Specificity] = i
+			groups = append(groups, Group{Specificity: e.Specificity})
+		}
+		groups[i].Entries = append(groups[i].Entries, e)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[j].Specificity.Less(groups[i].Specificity)
+	})
+	return groups
+}
+
+// FindRules returns the style rules of a stylesheet, including nested ones,
+// whose selector list fn returns true for, in the order of the input:
+//
+//	buttons := selector.FindRules(s, func(l selector.List) bool {
+//		return !l.Walk(func(s *selector.Simple) bool {
+//			return s.Kind != selector.Class || s.Name != "btn"
+//		})
+//	})
+//
+// Nested selectors are parsed as relative selectors. The rules whose
+// selector can't be parsed and the keyframe selectors of @keyframes rules
+// are left out.
+func FindRules(s *css.Stylesheet, fn func(l List) bool) []css.Match {
+	var matches []css.Match
+	for _, m := range s.FindRules(func(r *css.Rule) bool { return !r.IsAtRule() }) {
+		if n := len(m.Parents); n > 0 && isKeyframes(m.Parents[n-1]) {
+			continue
+		}
+		list, err := Parse(m.Rule.Prelude)
+		if err != nil {
+			list, err = ParseRelative(m.Rule.Prelude)
+		}
+		if err == nil && fn(list) {
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
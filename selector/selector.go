@@ -0,0 +1,525 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package gorilla/css/selector parses the selectors of style rules, following
+the grammar of Selectors Level 4:
+
+	https://www.w3.org/TR/selectors-4/
+
+A selector list is parsed from the prelude of a style rule, or from a
+string. It is a list of complex selectors, which are compound selectors
+separated by combinators, themselves sequences of simple selectors:
+
+	list, err := selector.Parse(rule.Prelude)
+	for _, c := range list {
+		fmt.Println(c.Specificity()) // (1,1,1) for "a.b#c"
+	}
+
+The selectors of pseudo-classes such as :not() or :is(), and of
+:nth-child(An+B of S), are parsed too, and taken into account in
+specificity. The arguments of other functional pseudo-classes and
+pseudo-elements are kept as component values.
+
+Report lists the selectors of a stylesheet with their specificity and
+position, for specificity graphs and budgets:
+
+	for _, e := range selector.Report(s) {
+		fmt.Println(e.Line, e.Column, e.Specificity, e.Selector) // 1 1 (1,0,1) #nav a
+	}
+
+FindRules returns the style rules whose selector list matches a predicate,
+such as the rules using a class, for codemods.
+
+Match reports whether an element of a document tree, seen through the
+Element interface, matches a selector list, and MatchNested does the same
+for the selectors of nested style rules.
+*/
+package selector
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/scanner"
+)
+
+// List is a selector list, such as "a, b > c".
+type List []*Complex
+
+// Complex is a complex selector: compound selectors separated by
+// combinators.
+type Complex struct {
+	// Compounds are the compound selectors, in order. The combinator of the
+	// first one is None, unless the selector is relative, as in :has(> a).
+	Compounds []*Compound
+	// Values are the component values of the selector, without leading and
+	// trailing whitespace.
+	Values []*css.ComponentValue
+}
+
+// Compound is a compound selector, such as "a.b:hover", with the
+// combinator that precedes it.
+type Compound struct {
+	Combinator Combinator
+	Simples    []*Simple
+}
+
+// Combinator is the combinator between two compound selectors.
+type Combinator int
+
+const (
+	// None is the combinator of the first compound selector.
+	None Combinator = iota
+	// Descendant is whitespace, as in "a b".
+	Descendant
+	// Child is ">", as in "a > b".
+	Child
+	// NextSibling is "+", as in "a + b".
+	NextSibling
+	// SubsequentSibling is "~", as in "a ~ b".
+	SubsequentSibling
+)
+
+// String returns the CSS representation of the combinator.
+func (c Combinator) String() string {
+	switch c {
+	case Descendant:
+		return " "
+	case Child:
+		return ">"
+	case NextSibling:
+		return "+"
+	case SubsequentSibling:
+		return "~"
+	}
+	return ""
+}
+
+// Kind is the kind of a simple selector.
+type Kind int
+
+const (
+	// Type is a type selector, as in "a".
+	Type Kind = iota
+	// Universal is the universal selector "*".
+	Universal
+	// ID is an ID selector, as in "#a".
+	ID
+	// Class is a class selector, as in ".a".
+	Class
+	// Attribute is an attribute selector, as in "[a=b]".
+	Attribute
+	// PseudoClass is a pseudo-class, as in ":hover" or ":not(a)".
+	PseudoClass
+	// PseudoElement is a pseudo-element, as in "::before". The legacy
+	// pseudo-elements written with one colon, such as ":before", are
+	// pseudo-elements too.
+	PseudoElement
+	// Nesting is the nesting selector "&".
+	Nesting
+)
+
+// String returns a string representation of the kind.
+func (k Kind) String() string {
+	switch k {
+	case Type:
+		return "type"
+	case Universal:
+		return "universal"
+	case ID:
+		return "id"
+	case Class:
+		return "class"
+	case Attribute:
+		return "attribute"
+	case PseudoClass:
+		return "pseudo-class"
+	case PseudoElement:
+		return "pseudo-element"
+	}
+	return "nesting"
+}
+
+// Simple is a simple selector.
+type Simple struct {
+	Kind Kind
+	// Name is the name of the selector, with its escape sequences decoded:
+	// the element name of type selectors, the ID, class or attribute name,
+	// or the name of a pseudo-class or pseudo-element without colons.
+	// Element, attribute, pseudo-class and pseudo-element names are
+	// lowercase.
+	Name string
+	// Namespace is the namespace prefix of type, universal and attribute
+	// selectors, "*" for any namespace, or empty. HasNamespace reports
+	// whether there is a prefix, since "|a" has an empty one.
+	Namespace    string
+	HasNamespace bool
+	// Operator, Value and Modifier are the matcher of attribute selectors,
+	// such as "^=", the decoded value and "i" in "[a^=b i]". Operator is
+	// empty for attribute selectors without a matcher.
+	Operator string
+	Value    string
+	Modifier string
+	// Args are the arguments of functional pseudo-classes and
+	// pseudo-elements, such as :nth-child(2n+1), and nil for the others.
+	Args []*css.ComponentValue
+	// Selectors are the selectors in the arguments of :is(), :not(),
+	// :where(), :has(), ::slotted() and of :nth-child(An+B of S) and
+	// :nth-last-child(An+B of S).
+	Selectors List
+}
+
+// IsStandard reports whether a pseudo-class or pseudo-element is defined by
+// a CSS specification, as opposed to vendor-prefixed ones such as
+// ::-webkit-scrollbar and misspelled ones. Other simple selectors are
+// always standard.
+func (s *Simple) IsStandard() bool {
+	switch s.Kind {
+	case PseudoClass:
+		return pseudoClasses[s.Name]
+	case PseudoElement:
+		return pseudoElements[s.Name]
+	}
+	return true
+}
+
+// Error is the error returned for invalid selectors.
+type Error struct {
+	Msg    string
+	Line   int
+	Column int
+}
+
+// Error returns a string representation of the error.
+func (e *Error) Error() string {
+	return fmt.Sprintf("selector: %s (line: %d, column: %d)", e.Msg, e.Line, e.Column)
+}
+
+// ParseString parses s as a selector list.
+func ParseString(s string) (List, error) {
+	values, err := css.ParseComponentValues(s)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(values)
+}
+
+// MustParseString is like ParseString but panics if s can't be parsed. It
+// simplifies the initialization of global variables and tests.
+func MustParseString(s string) List {
+	l, err := ParseString(s)
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+// Parse parses a selector list from component values, such as the prelude
+// of a style rule. It returns an *Error if the selector is invalid.
+func Parse(values []*css.ComponentValue) (List, error) {
+	return parseList(values, false, nil)
+}
+
+// ParseRelative is like Parse but for relative selector lists, whose
+// selectors may start with a combinator, such as the arguments of :has()
+// or the selectors of nested style rules: "> a, + b".
+func ParseRelative(values []*css.ComponentValue) (List, error) {
+	return parseList(values, true, nil)
+}
+
+// parseList parses a comma-separated list of complex selectors, which are
+// relative selectors if relative is true. at, if not nil, is the function
+// whose arguments are parsed, used for the position of errors.
+func parseList(values []*css.ComponentValue, relative bool, at *css.ComponentValue) (List, error) {
+	var list List
+	start := 0
+	for i := 0; i <= len(values); i++ {
+		if i < len(values) && !isChar(values[i], ",") {
+			continue
+		}
+		part := css.TrimSpace(values[start:i])
+		if len(part) == 0 {
+			switch {
+			case i < len(values):
+				at = values[i]
+			case len(values) > 0:
+				at = values[len(values)-1]
+			case at == nil:
+				return nil, &Error{Msg: "empty selector"}
+			}
+			return nil, errorAt(at, "empty selector")
+		}
+		c, err := parseComplex(part, relative)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, c)
+		start = i + 1
+	}
+	return list, nil
+}
+
+// parseComplex parses a complex selector, without leading and trailing
+// whitespace.
+func parseComplex(values []*css.ComponentValue, relative bool) (*Complex, error) {
+	c := &Complex{Values: values}
+	var cur *Compound
+	comb := None
+	for i := 0; i < len(values); {
+		v := values[i]
+		if v.Token.Type == scanner.TokenS {
+			if comb == None {
+				comb = Descendant
+			}
+			i++
+			continue
+		}
+		if k := combinator(v); k != None {
+			if comb > Descendant || cur == nil && !relative {
+				return nil, errorAt(v, fmt.Sprintf("unexpected %q", v.Token.Value))
+			}
+			comb = k
+			i++
+			continue
+		}
+		if cur == nil || comb != None {
+			cur = &Compound{Combinator: comb}
+			c.Compounds = append(c.Compounds, cur)
+			comb = None
+		}
+		s, n, err := parseSimple(values[i:], len(cur.Simples) == 0)
+		if err != nil {
+			return nil, err
+		}
+		cur.Simples = append(cur.Simples, s)
+		i += n
+	}
+	if comb > Descendant {
+		return nil, errorAt(values[len(values)-1], "selector ends with a combinator")
+	}
+	return c, nil
+}
+
+// combinator returns the combinator of a component value, or None.
+func combinator(v *css.ComponentValue) Combinator {
+	if v.Token.Type == scanner.TokenChar {
+		switch v.Token.Value {
+		case ">":
+			return Child
+		case "+":
+			return NextSibling
+		case "~":
+			return SubsequentSibling
+		}
+	}
+	return None
+}
+
+// parseSimple parses the simple selector at the start of values, and
+// returns it with the number of component values it spans. first reports
+// whether it is the first selector of its compound selector, where type
+// and universal selectors are allowed.
+func parseSimple(values []*css.ComponentValue, first bool) (*Simple, int, error) {
+	v := values[0]
+	t := v.Token
+	switch {
+	case t.Type == scanner.TokenHash:
+		return &Simple{Kind: ID, Name: scanner.Unescape(t.Value[1:])}, 1, nil
+	case isChar(v, "."):
+		if len(values) < 2 || values[1].Token.Type != scanner.TokenIdent {
+			return nil, 0, errorAt(v, "expected a class name")
+		}
+		return &Simple{Kind: Class, Name: values[1].Token.DecodedValue()}, 2, nil
+	case isChar(v, "&"):
+		return &Simple{Kind: Nesting}, 1, nil
+	case isChar(v, ":"):
+		return parsePseudo(values)
+	case v.IsBlock() && t.Value == "[":
+		s, err := parseAttribute(v)
+		return s, 1, err
+	case t.Type == scanner.TokenIdent || isChar(v, "*") || isChar(v, "|"):
+		if !first {
+			return nil, 0, errorAt(v, "type selector after other simple selectors")
+		}
+		s := &Simple{}
+		n := parseName(values, s)
+		if n == 0 || s.Name == "" && !isChar(values[n-1], "*") {
+			return nil, 0, errorAt(v, "expected an element name")
+		}
+		if s.Name == "" {
+			s.Kind = Universal
+		} else {
+			s.Name = strings.ToLower(s.Name)
+		}
+		return s, n, nil
+	}
+	return nil, 0, errorAt(v, fmt.Sprintf("unexpected %s", describe(t)))
+}
+
+// parseName parses a name with an optional namespace prefix, such as
+// "svg|a" or "*|*", into s, and returns the number of component values it
+// spans, or 0 if it is invalid. The name is empty for "*".
+func parseName(values []*css.ComponentValue, s *Simple) int {
+	name := func(v *css.ComponentValue) (string, bool) {
+		if v.Token.Type == scanner.TokenIdent {
+			return v.Token.DecodedValue(), true
+		}
+		return "", isChar(v, "*")
+	}
+	n := 0
+	if !isChar(values[0], "|") {
+		first, ok := name(values[0])
+		if !ok {
+			return 0
+		}
+		if len(values) < 2 || !isChar(values[1], "|") {
+			s.Name = first
+			return 1
+		}
+		if first == "" {
+			first = "*"
+		}
+		s.Namespace = first
+		n = 1
+	}
+	s.HasNamespace = true
+	if len(values) < n+2 {
+		return 0
+	}
+	local, ok := name(values[n+1])
+	if !ok {
+		return 0
+	}
+	s.Name = local
+	return n + 2
+}
+
+// parsePseudo parses the pseudo-class or pseudo-element at the start of
+// values, which begins with a colon.
+func parsePseudo(values []*css.ComponentValue) (*Simple, int, error) {
+	s := &Simple{Kind: PseudoClass}
+	n := 1
+	if len(values) > 1 && isChar(values[1], ":") {
+		s.Kind = PseudoElement
+		n = 2
+	}
+	if len(values) <= n {
+		return nil, 0, errorAt(values[0], "expected a pseudo-class name")
+	}
+	v := values[n]
+	switch v.Token.Type {
+	case scanner.TokenIdent:
+		s.Name = strings.ToLower(v.Token.DecodedValue())
+		if legacyPseudoElements[s.Name] {
+			s.Kind = PseudoElement
+		}
+	case scanner.TokenFunction:
+		s.Name = strings.ToLower(v.Token.DecodedValue())
+		s.Args = v.Children
+		if args, ok := selectorArgs(s.Name, v.Children); ok {
+			list, err := parseList(args, s.Name == "has", v)
+			if err != nil {
+				return nil, 0, err
+			}
+			s.Selectors = list
+		}
+	default:
+		return nil, 0, errorAt(v, "expected a pseudo-class name")
+	}
+	return s, n + 1, nil
+}
+
+// selectorArgs returns the part of the arguments of a functional
+// pseudo-class or pseudo-element that is a selector list, and whether
+// there is one.
+func selectorArgs(name string, args []*css.ComponentValue) ([]*css.ComponentValue, bool) {
+	switch name {
+	case "is", "not", "where", "has", "matches", "-webkit-any", "-moz-any", "slotted":
+		return args, true
+	case "nth-child", "nth-last-child":
+		for i, v := range args {
+			if v.Token.Type == scanner.TokenIdent && strings.EqualFold(v.Token.Value, "of") {
+				return args[i+1:], true
+			}
+		}
+	}
+	return nil, false
+}
+
+// parseAttribute parses an attribute selector from a "[" block.
+func parseAttribute(block *css.ComponentValue) (*Simple, error) {
+	values := css.TrimSpace(block.Children)
+	s := &Simple{Kind: Attribute}
+	if len(values) == 0 {
+		return nil, errorAt(block, "expected an attribute name")
+	}
+	n := parseName(values, s)
+	if n == 0 || s.Name == "" {
+		return nil, errorAt(block, "expected an attribute name")
+	}
+	s.Name = strings.ToLower(s.Name)
+	values = css.TrimSpace(values[n:])
+	if len(values) == 0 {
+		return s, nil
+	}
+	switch t := values[0].Token; {
+	case isChar(values[0], "="):
+		s.Operator = "="
+	case t.Type >= scanner.TokenIncludes && t.Type <= scanner.TokenSubstringMatch:
+		s.Operator = t.Value
+	default:
+		return nil, errorAt(values[0], fmt.Sprintf("unexpected %s in attribute selector", describe(t)))
+	}
+	values = css.TrimSpace(values[1:])
+	if len(values) == 0 || values[0].Token.Type != scanner.TokenIdent && values[0].Token.Type != scanner.TokenString {
+		return nil, errorAt(block, "expected an attribute value")
+	}
+	s.Value = values[0].Token.DecodedValue()
+	values = css.TrimSpace(values[1:])
+	if len(values) == 0 {
+		return s, nil
+	}
+	if len(values) > 1 || values[0].Token.Type != scanner.TokenIdent {
+		return nil, errorAt(values[0], "unexpected "+describe(values[0].Token)+" in attribute selector")
+	}
+	s.Modifier = strings.ToLower(values[0].Token.DecodedValue())
+	return s, nil
+}
+
+// isChar reports whether v is the delimiter c.
+func isChar(v *css.ComponentValue, c string) bool {
+	return v.Token.Type == scanner.TokenChar && v.Token.Value == c
+}
+
+// describe returns a short description of a token for error messages.
+func describe(t *scanner.Token) string {
+	if t.Type == scanner.TokenChar {
+		return fmt.Sprintf("%q", t.Value)
+	}
+	return t.Type.String()
+}
+
+// errorAt returns an error at the position of v.
+func errorAt(v *css.ComponentValue, msg string) *Error {
+	return &Error{msg, v.Token.Line, v.Token.Column}
+}
+
+// Walk calls fn for each simple selector of a list, in order, including the
+// selectors in the arguments of pseudo-classes, which are visited after the
+// pseudo-class. Walk stops if fn returns false, and reports whether it
+// visited all selectors.
+func (l List) Walk(fn func(*Simple) bool) bool {
+	for _, c := range l {
+		for _, cp := range c.Compounds {
+			for _, s := range cp.Simples {
+				if !fn(s) || !s.Selectors.Walk(fn) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,464 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package selector
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/css"
+)
+
+// dump returns a compact description of a selector list.
+func dump(l List) string {
+	var parts []string
+	for _, c := range l {
+		var b strings.Builder
+		for _, cp := range c.Compounds {
+			if cp.Combinator != None {
+				b.WriteString(cp.Combinator.String())
+			}
+			for i, s := range cp.Simples {
+				if i > 0 {
+					b.WriteByte('.')
+				}
+				b.WriteString(s.Kind.String())
+				if s.HasNamespace {
+					b.WriteString(" " + s.Namespace + "|")
+				}
+				b.WriteString(" " + s.Name)
+				if s.Operator != "" {
+					b.WriteString(" " + s.Operator + s.Value + " " + s.Modifier)
+				}
+				if s.Selectors != nil {
+					b.WriteString("(" + dump(s.Selectors) + ")")
+				}
+			}
+		}
+		parts = append(parts, b.String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+func TestParse(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected string
+	}{
+		{"A.b#c", "type a.class b.id c"},
+		{"a  >  b + c ~ d e", "type a>type b+type c~type d type e"},
+		{"*|*, |A, svg|* , *", "universal *| , type | a, universal svg| , universal "},
+		{`[Href^="x" I][b][ns|c~=d]`, "attribute href ^=x i.attribute b.attribute ns| c ~=d "},
+		{"a:HOVER::Before:after:not(.x, #y)", "type a.pseudo-class hover.pseudo-element before.pseudo-element after.pseudo-class not(class x, id y)"},
+		{":has(> a, + .b) :nth-child(2n+1 of .c) :nth-of-type(2)", "pseudo-class has(>type a, +class b) pseudo-class nth-child(class c) pseudo-class nth-of-type"},
+		{"& > .a, .b &", "nesting >class a, class b nesting "},
+		{`.\31 a#\#b`, "class 1a.id #b"},
+	}
+	for _, tc := range tcs {
+		l, err := ParseString(tc.input)
+		if err != nil {
+			t.Errorf("%s: %v", tc.input, err)
+			continue
+		}
+		if got := dump(l); got != tc.expected {
+			t.Errorf("%s:\ngot  %q\nwant %q", tc.input, got, tc.expected)
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected string
+	}{
+		{"", "selector: empty selector (line: 0, column: 0)"},
+		{"a,", `selector: empty selector (line: 1, column: 2)`},
+		{"a, , b", `selector: empty selector (line: 1, column: 4)`},
+		{"> a", `selector: unexpected ">" (line: 1, column: 1)`},
+		{"a > > b", `selector: unexpected ">" (line: 1, column: 5)`},
+		{"a +", `selector: selector ends with a combinator (line: 1, column: 3)`},
+		{".a b.", `selector: expected a class name (line: 1, column: 5)`},
+		{".a*", `selector: type selector after other simple selectors (line: 1, column: 3)`},
+		{"a:not()", `selector: empty selector (line: 1, column: 3)`},
+		{"a:is(b, ~)", `selector: unexpected "~" (line: 1, column: 9)`},
+		{"[a=]", `selector: expected an attribute value (line: 1, column: 1)`},
+		{"[a b]", `selector: unexpected IDENT in attribute selector (line: 1, column: 4)`},
+		{"a{}", `selector: unexpected "{" (line: 1, column: 2)`},
+		{"a:", `selector: expected a pseudo-class name (line: 1, column: 2)`},
+	}
+	for _, tc := range tcs {
+		_, err := ParseString(tc.input)
+		if err == nil || err.Error() != tc.expected {
+			t.Errorf("%s: got %v, want %s", tc.input, err, tc.expected)
+		}
+	}
+}
+
+func TestMustParseString(t *testing.T) {
+	if got := dump(MustParseString("a > b")); got != "type a>type b" {
+		t.Errorf("got %s, want type a>type b", got)
+	}
+	defer func() {
+		if _, ok := recover().(*Error); !ok {
+			t.Error("expected a panic with an *Error")
+		}
+	}()
+	MustParseString("a >")
+}
+
+func FuzzParse(f *testing.F) {
+	for _, s := range []string{"A.b#c", "a  >  b + c ~ d e", "*|*, |A, svg|* , *", `[Href^="x" I][b][ns|c~=d]`, "a:HOVER::Before:after:not(.x, #y)", ":has(> a, + .b) :nth-child(2n+1 of .c)", "& > .a, .b &", `.\31 a#\#b`, "a,", "[a=]", ":not(", "::"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		values, err := css.ParseComponentValues(input)
+		if err != nil {
+			return
+		}
+		l, err := Parse(values)
+		if err != nil {
+			return
+		}
+		l.Specificity()
+		// Serializing the selectors again gives the same selectors.
+		out := css.ValuesString(values)
+		again, err := ParseString(out)
+		if err != nil {
+			t.Fatalf("%q serialized as %q: %v", input, out, err)
+		}
+		if got, want := dump(again), dump(l); got != want {
+			t.Errorf("%q serialized as %q: got %s, want %s", input, out, got, want)
+		}
+	})
+}
+
+func TestSpecificity(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected string
+	}{
+		{"*", "(0,0,0)"},
+		{"a.b#c", "(1,1,1)"},
+		{"ul li > a[href]:hover::before", "(0,2,4)"},
+		{":is(#a, .b) :where(#c) :not(a, .d.e)", "(1,2,0)"},
+		{"li:nth-child(2n of #a, .b):first-child", "(1,2,1)"},
+		{"::slotted(.a) :has(> #b) & :first-line", "(1,1,2)"},
+	}
+	for _, tc := range tcs {
+		l, err := ParseString(tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := l[0].Specificity().String(); got != tc.expected {
+			t.Errorf("%s: got %s, want %s", tc.input, got, tc.expected)
+		}
+	}
+	l, _ := ParseString("a, #b, .c")
+	if got := l.Specificity(); got != (Specificity{1, 0, 0}) {
+		t.Errorf("list: got %s", got)
+	}
+}
+
+func TestReport(t *testing.T) {
+	s := css.MustParseStylesheet(`a, #b .c, .i { color: red }
+@media print {
+  .d:hover { color: red }
+  .j	>
+  :is(k,
+  l) { color: red }
+}
+@keyframes e { from { opacity: 0 } }
+.f { & > #g { color: red } }
+1 { color: red }
+`)
+	var got []string
+	for _, e := range Report(s) {
+		got = append(got, fmt.Sprintf("%d:%d %s %s", e.Line, e.Column, e.Specificity, e.Selector))
+	}
+	expected := []string{
+		"1:1 (0,0,1) a",
+		"1:4 (1,1,0) #b .c",
+		"1:11 (0,1,0) .i",
+		"3:3 (0,2,0) .d:hover",
+		"4:3 (0,1,1) .j > :is(k, l)",
+		"9:1 (0,1,0) .f",
+		"9:6 (1,0,0) & > #g",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %q, want %q", got, expected)
+	}
+
+	entries := Report(s)
+	SortBySpecificity(entries)
+	got = nil
+	for _, e := range entries {
+		got = append(got, e.Selector)
+	}
+	if expected := []string{"#b .c", "& > #g", ".d:hover", ".j > :is(k, l)", ".i", ".f", "a"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("sorted: got %q, want %q", got, expected)
+	}
+
+	got = nil
+	for _, g := range GroupBySpecificity(Report(s)) {
+		got = append(got, fmt.Sprintf("%s %d", g.Specificity, len(g.Entries)))
+	}
+	if expected := []string{"(1,1,0) 1", "(1,0,0) 1", "(0,2,0) 1", "(0,1,1) 1", "(0,1,0) 2", "(0,0,1) 1"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("grouped: got %q, want %q", got, expected)
+	}
+}
+
+func TestWalk(t *testing.T) {
+	l, _ := ParseString("a:not(.b:is(c)), d")
+	var names []string
+	done := l.Walk(func(s *Simple) bool {
+		names = append(names, s.Name)
+		return s.Name != "c"
+	})
+	if got := strings.Join(names, " "); done || got != "a not b is c" {
+		t.Errorf("got %q %v", got, done)
+	}
+}
+
+func TestParseRelative(t *testing.T) {
+	l, err := ParseString("a > b ~ c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := ParseRelative(css.TrimSpace(l[0].Values[1:]))
+	if got := dump(r); err != nil || got != ">type b~type c" {
+		t.Errorf("got %q %v", got, err)
+	}
+	if _, err := Parse(css.TrimSpace(l[0].Values[1:])); err == nil {
+		t.Error("got no error for a relative selector")
+	}
+}
+
+func TestIsStandard(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected bool
+	}{
+		{"a.b#c[d]", true},
+		{":hover", true},
+		{":NTH-CHILD(2)", true},
+		{"::before", true},
+		{":first-line", true},
+		{"::view-transition-old(x)", true},
+		{":hovr", false},
+		{"::before:hover::-webkit-scrollbar", false},
+		{":-moz-focusring", false},
+		{"::hover", false},
+	}
+	for _, tc := range tcs {
+		l, err := ParseString(tc.input)
+		if err != nil {
+			t.Fatalf("%q: %v", tc.input, err)
+		}
+		got := l.Walk(func(s *Simple) bool { return s.IsStandard() })
+		if got != tc.expected {
+			t.Errorf("%q: got %v, want %v", tc.input, got, tc.expected)
+		}
+	}
+}
+
+func TestFindRules(t *testing.T) {
+	s, err := css.ParseStylesheet(".btn { x: y } @media print { a .btn:hover, b { x: y } } .nav { & .btn { x: y } } @keyframes btn { from { x: y } } .btn-x { x: y } a[ { x: y }")
+	if err != nil {
+		t.Fatal(err)
+	}
+	matches := FindRules(s, func(l List) bool {
+		return !l.Walk(func(s *Simple) bool {
+			return s.Kind != Class || s.Name != "btn"
+		})
+	})
+	var got []string
+	for _, m := range matches {
+		line, col := m.Position()
+		got = append(got, fmt.Sprintf("%d:%d %s %d", line, col, css.ValuesString(m.Rule.Prelude), len(m.Parents)))
+	}
+	if expected := []string{"1:1 .btn 0", "1:30 a .btn:hover, b 1", "1:64 & .btn 1"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %q, want %q", got, expected)
+	}
+}
+
+// node is an Element of the tests.
+type node struct {
+	name     string
+	attrs    map[string]string
+	parent   *node
+	children []*node
+	hover    bool
+}
+
+// el returns a node with attributes written as "id=a class=b c", where a
+// value runs to the next "=", and children.
+func el(name, attrs string, children ...*node) *node {
+	n := &node{name: name, attrs: map[string]string{}, children: children}
+	fields := strings.Fields(attrs)
+	for i := 0; i < len(fields); i++ {
+		k, v, _ := strings.Cut(fields[i], "=")
+		for i+1 < len(fields) && !strings.Contains(fields[i+1], "=") {
+			i++
+			v += " " + fields[i]
+		}
+		n.attrs[k] = v
+	}
+	for _, c := range children {
+		c.parent = n
+	}
+	return n
+}
+
+func (n *node) LocalName() string { return n.name }
+
+func (n *node) Attr(name string) (string, bool) {
+	v, ok := n.attrs[name]
+	return v, ok
+}
+
+func (n *node) Parent() Element {
+	if n.parent == nil {
+		return nil
+	}
+	return n.parent
+}
+
+// sibling returns the sibling at offset d of n, or nil.
+func (n *node) sibling(d int) Element {
+	if n.parent == nil {
+		return nil
+	}
+	for i, c := range n.parent.children {
+		if c == n && i+d >= 0 && i+d < len(n.parent.children) {
+			return n.parent.children[i+d]
+		}
+	}
+	return nil
+}
+
+func (n *node) PrevSibling() Element { return n.sibling(-1) }
+func (n *node) NextSibling() Element { return n.sibling(1) }
+
+func (n *node) MatchPseudoClass(name string, args []*css.ComponentValue) bool {
+	return name == "hover" && n.hover
+}
+
+func TestMatch(t *testing.T) {
+	items := []*node{
+		el("li", "id=first class=item a"),
+		el("li", "class=item lang=en-US"),
+		el("p", ""),
+		el("li", "class=item data-x=Foo"),
+	}
+	items[1].hover = true
+	list := el("ul", "class=list", items...)
+	root := el("html", "", el("body", "", list))
+	tcs := []struct {
+		selector string
+		e        *node
+		expected bool
+	}{
+		{"li", items[0], true},
+		{"LI#first.item.a", items[0], true},
+		{"li.b", items[0], false},
+		{"*", items[2], true},
+		{"html > body ul > li", items[0], true},
+		{"body > li", items[0], false},
+		{".list .item + p", items[2], true},
+		{"#first ~ li", items[3], true},
+		{"#first + li", items[3], false},
+		{"[lang|=en]", items[1], true},
+		{"[data-x=foo]", items[3], false},
+		{"[data-x=foo i]", items[3], true},
+		{"[data-x^=F][data-x$=o][data-x*=oo]", items[3], true},
+		{"[class~=a]", items[0], true},
+		{"li:first-child", items[0], true},
+		{"li:last-child", items[3], true},
+		{"li:nth-child(2n+1)", items[2], false},
+		{":nth-child(odd)", items[2], true},
+		{"li:nth-of-type(3)", items[3], true},
+		{"li:nth-last-of-type(1)", items[3], true},
+		{":nth-child(2 of .item)", items[1], true},
+		{":nth-child(-n + 2)", items[3], false},
+		{"p:only-of-type", items[2], true},
+		{"li:first-of-type", items[1], false},
+		{":root", root, true},
+		{"&", root, true},
+		{":not(.item)", items[2], true},
+		{":is(p, #first)", items[0], true},
+		{"li:hover", items[1], true},
+		{"li:hover", items[0], false},
+		{"li::before", items[0], false},
+	}
+	for _, tc := range tcs {
+		l, err := ParseString(tc.selector)
+		if err != nil {
+			t.Fatalf("%q: %v", tc.selector, err)
+		}
+		if got := l.Match(tc.e); got != tc.expected {
+			t.Errorf("%q: got %v, want %v", tc.selector, got, tc.expected)
+		}
+	}
+}
+
+func TestMatchNested(t *testing.T) {
+	item := el("li", "class=item")
+	list := el("ul", "id=list", item)
+	el("body", "class=dark", list)
+	parents := []List{MustParseString(".dark"), MustParseString("#list, ul")}
+	tcs := []struct {
+		selector    string
+		e           *node
+		specificity Specificity
+		expected    bool
+	}{
+		{".item", item, Specificity{1, 2, 0}, true},
+		{"> li", item, Specificity{1, 1, 1}, true},
+		{"&.item", item, Specificity{}, false},
+		{"&", list, Specificity{1, 1, 0}, true},
+		{".x, li", item, Specificity{1, 1, 1}, true},
+		{":not(&) li", item, Specificity{1, 1, 1}, true},
+		{"& > .x", item, Specificity{}, false},
+	}
+	for _, tc := range tcs {
+		values, _ := css.ParseComponentValues(tc.selector)
+		l, err := ParseRelative(values)
+		if err != nil {
+			t.Fatalf("%q: %v", tc.selector, err)
+		}
+		s, ok := l.MatchNested(tc.e, parents)
+		if ok != tc.expected || s != tc.specificity {
+			t.Errorf("%q: got %v %v, want %v %v", tc.selector, s, ok, tc.specificity, tc.expected)
+		}
+	}
+}
+
+func TestMatchNestedSpecificity(t *testing.T) {
+	second := el("p", "class=a")
+	el("div", "", el("p", "class=a"), second)
+	parents := []List{MustParseString(".a")}
+	tcs := []struct {
+		selector    string
+		specificity Specificity
+	}{
+		{"& + &", Specificity{0, 2, 0}},
+		{":where(&)", Specificity{}},
+		{":is(&, p) + p", Specificity{0, 1, 1}},
+		{"&:last-child", Specificity{0, 2, 0}},
+	}
+	for _, tc := range tcs {
+		values, _ := css.ParseComponentValues(tc.selector)
+		l, err := ParseRelative(values)
+		if err != nil {
+			t.Fatalf("%q: %v", tc.selector, err)
+		}
+		s, ok := l.MatchNested(second, parents)
+		if !ok || s != tc.specificity {
+			t.Errorf("%q: got %v %v, want %v true", tc.selector, s, ok, tc.specificity)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package selector
+
+import (
+	"fmt"
+)
+
+// Specificity is the specificity of a selector: the number of ID
+// selectors, of class selectors, attribute selectors and pseudo-classes,
+// and of type selectors and pseudo-elements.
+type Specificity [3]int
+
+// String returns a string representation of the specificity, as "(1,2,3)".
+func (s Specificity) String() string {
+	return fmt.Sprintf("(%d,%d,%d)", s[0], s[1], s[2])
+}
+
+// Less reports whether s is lower than t.
+func (s Specificity) Less(t Specificity) bool {
+	for i := range s {
+		if s[i] != t[i] {
+			return s[i] < t[i]
+		}
+	}
+	return false
+}
+
+// add returns the sum of s and t.
+func (s Specificity) add(t Specificity) Specificity {
+	return Specificity{s[0] + t[0], s[1] + t[1], s[2] + t[2]}
+}
+
+// Specificity returns the highest specificity of the selectors of the list,
+// the specificity of :is() with the list as argument.
+func (l List) Specificity() Specificity {
+	return l.specificity(Specificity{})
+}
+
+// specificity is like Specificity, with nesting the specificity of the
+// nesting selector.
+func (l List) specificity(nesting Specificity) Specificity {
+	var max Specificity
+	for _, c := range l {
+		if s := c.specificity(nesting); max.Less(s) {
+			max = s
+		}
+	}
+	return max
+}
+
+// Specificity returns the specificity of the selector. The nesting
+// selector doesn't count, since its specificity depends on the parent rule.
+func (c *Complex) Specificity() Specificity {
+	return c.specificity(Specificity{})
+}
+
+// specificity is like Specificity, with nesting the specificity of the
+// nesting selector, counted each time it appears.
+func (c *Complex) specificity(nesting Specificity) Specificity {
+	var s Specificity
+	for _, cp := range c.Compounds {
+		for _, simple := range cp.Simples {
+			s = s.add(simple.specificity(nesting))
+		}
+	}
+	return s
+}
+
+// Specificity returns the specificity of the simple selector. That of the
+// nesting selector is zero, since it depends on the parent rule.
+func (s *Simple) Specificity() Specificity {
+	return s.specificity(Specificity{})
+}
+
+// specificity is like Specificity, with nesting the specificity of the
+// nesting selector.
+func (s *Simple) specificity(nesting Specificity) Specificity {
+	switch s.Kind {
+	case ID:
+		return Specificity{1, 0, 0}
+	case Class, Attribute:
+		return Specificity{0, 1, 0}
+	case Type:
+		return Specificity{0, 0, 1}
+	case Nesting:
+		return nesting
+	case PseudoElement:
+		return Specificity{0, 0, 1}.add(s.Selectors.specificity(nesting))
+	case PseudoClass:
+		switch s.Name {
+		case "where":
+			return Specificity{}
+		case "is", "not", "has", "matches", "-webkit-any", "-moz-any":
+			return s.Selectors.specificity(nesting)
+		}
+		return Specificity{0, 1, 0}.add(s.Selectors.specificity(nesting))
+	}
+	return Specificity{}
+}
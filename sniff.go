@@ -0,0 +1,250 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package css
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gorilla/css/scanner"
+)
+
+// sniffLen is the number of bytes read by Sniff.
+const sniffLen = 8 << 10
+
+// Profile lists the features found by Sniff.
+type Profile struct {
+	// Nesting reports whether a style rule has nested rules.
+	Nesting bool
+	// Layers reports whether there is a @layer rule or a layered @import.
+	Layers bool
+	// ContainerQueries reports whether there is a @container rule.
+	ContainerQueries bool
+	// CustomProperties reports whether a custom property is declared.
+	CustomProperties bool
+	// Imports reports whether there is an @import rule.
+	Imports bool
+}
+
+// Sniff reads the start of r, up to 8 KiB, and reports whether it is
+// plausibly CSS, as opposed to HTML, JavaScript, JSON or binary data, along
+// with the features found in it if it is. It is meant to reject mislabeled files
+// before processing them, and is not a validator: a file that starts like
+// CSS may have anything after the part read, and CSS with many errors may
+// be reported as not CSS. Content without any rule, such as plain text or
+// the declarations of a style attribute, isn't plausibly CSS, except
+// whitespace and comments.
+//
+// The encoding is detected as OpenStylesheet does, but without a
+// Content-Type header. A read error ends the input.
+func Sniff(r io.Reader) (bool, Profile) {
+	b, _ := io.ReadAll(io.LimitReader(r, sniffLen))
+	truncated := len(b) == sniffLen
+	enc, b := sniffEncoding(b, "")
+	input, err := decode(b, enc)
+	if err != nil {
+		return false, Profile{}
+	}
+	if truncated {
+		// The last characters may be cut in the middle.
+		input = strings.TrimRight(input, "\ufffd")
+	}
+	if isBinary(input) || isMarkup(input) {
+		return false, Profile{}
+	}
+	sn := &sniffer{}
+	sn.sniff(input)
+	if sn.good > 0 && sn.bad*4 <= sn.good || sn.empty {
+		return true, sn.profile
+	}
+	return false, Profile{}
+}
+
+// isBinary reports whether decoded input looks like binary data: it has NUL
+// or other control characters than whitespace, or many malformed
+// sequences.
+func isBinary(input string) bool {
+	invalid := 0
+	for _, r := range input {
+		switch {
+		case r < 0x20 && r != '\t' && r != '\n' && r != '\f' && r != '\r', r == 0x7f:
+			return true
+		case r == utf8.RuneError:
+			invalid++
+		}
+	}
+	return invalid > 0 && invalid*10 > utf8.RuneCountInString(input)
+}
+
+// isMarkup reports whether input starts like HTML or XML, with a tag, a
+// doctype or a processing instruction. An HTML comment start, "<!--", is a
+// CDO token that CSS allows.
+func isMarkup(input string) bool {
+	input = strings.TrimLeft(input, " \t\n\f\r")
+	if len(input) < 2 || input[0] != '<' || strings.HasPrefix(input, "<!--") {
+		return false
+	}
+	c := input[1]
+	return c == '!' || c == '?' || c == '/' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// sniffer scores how much tokens look like CSS.
+type sniffer struct {
+	profile Profile
+	// good counts the rules and declarations, bad the constructs that
+	// can't be CSS, such as an assignment.
+	good, bad int
+	// empty reports whether there were only whitespace and comments.
+	empty bool
+	// blocks holds whether each open block is the block of a style rule.
+	blocks []bool
+	// The statement being read: its significant tokens, its at-rule name
+	// and the nesting level of its parentheses and brackets.
+	tokens []*scanner.Token
+	atRule string
+	parens int
+}
+
+// sniff scores the tokens of input.
+func (sn *sniffer) sniff(input string) {
+	sn.empty = true
+	s := scanner.New(input)
+	for {
+		t := s.Next()
+		switch t.Type {
+		case scanner.TokenEOF, scanner.TokenError:
+			// An unclosed string or comment may be cut by the end of the
+			// part read.
+			return
+		case scanner.TokenS, scanner.TokenComment, scanner.TokenBOM, scanner.TokenCDO, scanner.TokenCDC:
+			continue
+		}
+		sn.empty = false
+		sn.token(t)
+	}
+}
+
+// token scores a significant token.
+func (sn *sniffer) token(t *scanner.Token) {
+	inStyle := len(sn.blocks) > 0 && sn.blocks[len(sn.blocks)-1]
+	if t.Type == scanner.TokenAtKeyword && len(sn.tokens) == 0 {
+		sn.atRule = strings.ToLower(t.DecodedValue())
+		switch sn.atRule {
+		case "layer":
+			sn.profile.Layers = true
+		case "container":
+			sn.profile.ContainerQueries = true
+		case "import":
+			sn.profile.Imports = true
+		}
+	}
+	if sn.atRule == "import" && (t.Type == scanner.TokenFunction || t.Type == scanner.TokenIdent) && strings.EqualFold(t.DecodedValue(), "layer") {
+		sn.profile.Layers = true
+	}
+	switch {
+	case t.Type == scanner.TokenFunction:
+		// Functions are only found after a colon in selectors, as in
+		// :not(a), not as in a function call.
+		if sn.atRule == "" && sn.parens == 0 && !sn.inDeclaration() && !sn.follows(":") {
+			sn.bad++
+		}
+		sn.parens++
+	case t.Type != scanner.TokenChar:
+	case t.Value == "(" || t.Value == "[":
+		sn.parens++
+	case t.Value == ")" || t.Value == "]":
+		if sn.parens > 0 {
+			sn.parens--
+		}
+	case sn.parens > 0:
+	case t.Value == "{":
+		if len(sn.tokens) == 0 {
+			// An object literal, as in JSON.
+			sn.bad++
+		} else {
+			sn.good++
+		}
+		style := sn.atRule == ""
+		if inStyle {
+			sn.profile.Nesting = true
+		}
+		sn.blocks = append(sn.blocks, style)
+		sn.reset()
+		return
+	case t.Value == "}":
+		sn.endStatement()
+		if len(sn.blocks) == 0 {
+			sn.bad++
+		} else {
+			sn.blocks = sn.blocks[:len(sn.blocks)-1]
+		}
+		return
+	case t.Value == ";":
+		sn.endStatement()
+		return
+	case sn.atRule != "" || sn.inDeclaration():
+	case t.Value == "=" || t.Value == "/":
+		// Assignments and comparisons, or JavaScript comments.
+		sn.bad++
+	}
+	sn.tokens = append(sn.tokens, t)
+}
+
+// endStatement scores the statement ended by a semicolon or a closing
+// brace.
+func (sn *sniffer) endStatement() {
+	switch {
+	case len(sn.tokens) == 0:
+	case sn.atRule != "":
+		sn.good++
+	case sn.inDeclaration():
+		if len(sn.blocks) > 0 {
+			sn.good++
+		}
+		if sn.customName() {
+			sn.profile.CustomProperties = true
+		}
+	default:
+		sn.bad++
+	}
+	sn.reset()
+}
+
+// inDeclaration reports whether the statement being read starts like a
+// declaration, with a name followed by a colon. The name may be preceded
+// by "*" or "_", as in the hacks for old versions of Internet Explorer. A
+// selector such as a:hover starts like a declaration too, until its block.
+func (sn *sniffer) inDeclaration() bool {
+	tokens := sn.tokens
+	if len(tokens) > 0 && tokens[0].Type == scanner.TokenChar && (tokens[0].Value == "*" || tokens[0].Value == "_") || sn.customName() {
+		tokens = tokens[1:]
+	}
+	return len(tokens) >= 2 && tokens[0].Type == scanner.TokenIdent &&
+		tokens[1].Type == scanner.TokenChar && tokens[1].Value == ":"
+}
+
+// customName reports whether the statement starts with a custom name,
+// which the scanner reads as "-" followed by an identifier starting with
+// "-", as in "--x".
+func (sn *sniffer) customName() bool {
+	tokens := sn.tokens
+	return len(tokens) >= 2 && tokens[0].Type == scanner.TokenChar && tokens[0].Value == "-" &&
+		tokens[1].Type == scanner.TokenIdent && strings.HasPrefix(tokens[1].Value, "-")
+}
+
+// follows reports whether the last significant token of the statement is
+// the character c.
+func (sn *sniffer) follows(c string) bool {
+	n := len(sn.tokens)
+	return n > 0 && sn.tokens[n-1].Type == scanner.TokenChar && sn.tokens[n-1].Value == c
+}
+
+// reset starts a new statement.
+func (sn *sniffer) reset() {
+	sn.tokens = sn.tokens[:0]
+	sn.atRule = ""
+	sn.parens = 0
+}
@@ -0,0 +1,396 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sourcemap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// UnmarshalJSON decodes a source map, such as one written by another tool
+// for a stylesheet that is minified again, to compose them with Apply.
+//
+// The names and sourcesContent fields are ignored, as are the segments of
+// the mappings without a source position. Index maps, which have sections
+// instead of mappings, are not supported. Other tools usually count columns
+// in UTF-16 code units rather than runes, which only differ for the
+// characters outside the Basic Multilingual Plane.
+func (m *Map) UnmarshalJSON(data []byte) error {
+	d := &jsonDecoder{data: data}
+	v, err := d.decode()
+	if err != nil {
+		return err
+	}
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return errors.New("sourcemap: not a JSON object")
+	}
+	if version, _ := obj["version"].(float64); version != 3 {
+		return fmt.Errorf("sourcemap: unsupported version %v", obj["version"])
+	}
+	if _, ok := obj["sections"]; ok {
+		return errors.New("sourcemap: index maps are not supported")
+	}
+	*m = Map{}
+	m.File, _ = obj["file"].(string)
+	m.SourceRoot, _ = obj["sourceRoot"].(string)
+	sources, _ := obj["sources"].([]interface{})
+	for _, s := range sources {
+		// Null sources are unknown.
+		name, _ := s.(string)
+		m.Sources = append(m.Sources, name)
+	}
+	mappings, ok := obj["mappings"].(string)
+	if !ok {
+		return errors.New("sourcemap: missing mappings")
+	}
+	return m.decodeMappings(mappings)
+}
+
+// decodeMappings decodes the "mappings" field of a source map and adds the
+// mappings to m, in the order of their generated positions.
+func (m *Map) decodeMappings(mappings string) error {
+	var fields [5]int
+	for i, line := range strings.Split(mappings, ";") {
+		fields[0] = 0
+		for _, segment := range strings.Split(line, ",") {
+			if segment == "" {
+				continue
+			}
+			n := 0
+			for s := segment; s != ""; n++ {
+				if n == len(fields) {
+					return fmt.Errorf("sourcemap: invalid segment %q", segment)
+				}
+				v, rest, err := readVLQ(s)
+				if err != nil {
+					return err
+				}
+				fields[n] += v
+				s = rest
+			}
+			if n != 1 && n != 4 && n != 5 {
+				return fmt.Errorf("sourcemap: invalid segment %q", segment)
+			}
+			if n == 1 {
+				continue
+			}
+			if fields[1] < 0 || fields[1] >= len(m.Sources) {
+				return fmt.Errorf("sourcemap: invalid source index %d", fields[1])
+			}
+			m.Mappings = append(m.Mappings, Mapping{
+				Line:         i + 1,
+				Column:       fields[0] + 1,
+				Source:       fields[1],
+				SourceLine:   fields[2] + 1,
+				SourceColumn: fields[3] + 1,
+			})
+		}
+	}
+	sort.SliceStable(m.Mappings, func(i, j int) bool {
+		a, b := m.Mappings[i], m.Mappings[j]
+		return a.Line < b.Line || a.Line == b.Line && a.Column < b.Column
+	})
+	return nil
+}
+
+// readVLQ reads a base64 variable-length quantity at the start of s, and
+// returns it with the rest of s.
+func readVLQ(s string) (int, string, error) {
+	v, shift := 0, 0
+	for i := 0; i < len(s); i++ {
+		digit := strings.IndexByte(base64Digits, s[i])
+		if digit < 0 {
+			return 0, "", fmt.Errorf("sourcemap: invalid base64 digit %q", s[i])
+		}
+		if shift > 30 {
+			return 0, "", errors.New("sourcemap: VLQ overflow")
+		}
+		v |= (digit & 31) << shift
+		shift += 5
+		if digit&32 == 0 {
+			if v&1 != 0 {
+				return -(v >> 1), s[i+1:], nil
+			}
+			return v >> 1, s[i+1:], nil
+		}
+	}
+	return 0, "", errors.New("sourcemap: truncated VLQ")
+}
+
+// Apply composes m with prev, the map of the source of m of index source,
+// so that the mappings to that source map to the sources of prev instead.
+// This keeps a map pointing at the original sources when a generated file
+// is transformed again, such as a minified stylesheet minified once more.
+//
+// The sources of prev, prefixed with its SourceRoot, are added to those of
+// m unless already present, and the source of index source is removed.
+// Mappings to positions that prev doesn't map are removed. A position maps
+// to the source position of the closest mapping of prev before it on the
+// same line.
+func (m *Map) Apply(source int, prev *Map) {
+	index := make([]int, len(prev.Sources))
+	for i, name := range prev.Sources {
+		if prev.SourceRoot != "" && name != "" {
+			name = strings.TrimSuffix(prev.SourceRoot, "/") + "/" + name
+		}
+		index[i] = -1
+		for j, s := range m.Sources {
+			if j != source && s == name {
+				index[i] = j
+				break
+			}
+		}
+		if index[i] < 0 {
+			index[i] = len(m.Sources)
+			m.Sources = append(m.Sources, name)
+		}
+	}
+	mappings := make([]Mapping, 0, len(m.Mappings))
+	for _, mp := range m.Mappings {
+		if mp.Source == source {
+			p, ok := prev.lookup(mp.SourceLine, mp.SourceColumn)
+			if !ok {
+				continue
+			}
+			mp.Source, mp.SourceLine, mp.SourceColumn = index[p.Source], p.SourceLine, p.SourceColumn
+		}
+		if mp.Source > source {
+			mp.Source--
+		}
+		mappings = append(mappings, mp)
+	}
+	m.Mappings = mappings
+	m.Sources = append(m.Sources[:source:source], m.Sources[source+1:]...)
+}
+
+// lookup returns the last mapping at or before a generated position on the
+// same line, and whether there is one. The mappings must be in the order of
+// their generated positions.
+func (m *Map) lookup(line, column int) (Mapping, bool) {
+	i := sort.Search(len(m.Mappings), func(i int) bool {
+		mp := m.Mappings[i]
+		return mp.Line > line || mp.Line == line && mp.Column > column
+	})
+	if i == 0 || m.Mappings[i-1].Line != line {
+		return Mapping{}, false
+	}
+	return m.Mappings[i-1], true
+}
+
+// jsonDecoder decodes JSON values. Source maps are decoded without
+// encoding/json for the same reason they are encoded without it.
+type jsonDecoder struct {
+	data []byte
+	off  int
+}
+
+// decode decodes the JSON value of the whole input: a map[string]interface{},
+// a []interface{}, a string, a float64, a bool or nil.
+func (d *jsonDecoder) decode() (interface{}, error) {
+	v, err := d.value(0)
+	if err != nil {
+		return nil, err
+	}
+	if d.skipSpace(); d.off < len(d.data) {
+		return nil, d.error()
+	}
+	return v, nil
+}
+
+// maxJSONDepth is the maximum nesting depth of JSON values.
+const maxJSONDepth = 100
+
+// value decodes the JSON value at the current offset, nested in depth
+// objects or arrays.
+func (d *jsonDecoder) value(depth int) (interface{}, error) {
+	if depth > maxJSONDepth {
+		return nil, errors.New("sourcemap: JSON nested too deeply")
+	}
+	d.skipSpace()
+	if d.off >= len(d.data) {
+		return nil, errors.New("sourcemap: unexpected end of JSON input")
+	}
+	switch c := d.data[d.off]; {
+	case c == '{':
+		d.off++
+		obj := map[string]interface{}{}
+		if d.skipSpace(); d.consume('}') {
+			return obj, nil
+		}
+		for {
+			d.skipSpace()
+			if d.off >= len(d.data) || d.data[d.off] != '"' {
+				return nil, d.error()
+			}
+			key, err := d.string()
+			if err != nil {
+				return nil, err
+			}
+			if d.skipSpace(); !d.consume(':') {
+				return nil, d.error()
+			}
+			v, err := d.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			obj[key] = v
+			d.skipSpace()
+			if d.consume('}') {
+				return obj, nil
+			}
+			if !d.consume(',') {
+				return nil, d.error()
+			}
+		}
+	case c == '[':
+		d.off++
+		list := []interface{}{}
+		if d.skipSpace(); d.consume(']') {
+			return list, nil
+		}
+		for {
+			v, err := d.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+			d.skipSpace()
+			if d.consume(']') {
+				return list, nil
+			}
+			if !d.consume(',') {
+				return nil, d.error()
+			}
+		}
+	case c == '"':
+		return d.string()
+	case c == '-' || c >= '0' && c <= '9':
+		start := d.off
+		for d.off < len(d.data) && strings.IndexByte("+-.0123456789eE", d.data[d.off]) >= 0 {
+			d.off++
+		}
+		f, err := strconv.ParseFloat(string(d.data[start:d.off]), 64)
+		if err != nil {
+			d.off = start
+			return nil, d.error()
+		}
+		return f, nil
+	}
+	for _, lit := range []struct {
+		text  string
+		value interface{}
+	}{{"true", true}, {"false", false}, {"null", nil}} {
+		if bytes.HasPrefix(d.data[d.off:], []byte(lit.text)) {
+			d.off += len(lit.text)
+			return lit.value, nil
+		}
+	}
+	return nil, d.error()
+}
+
+// string decodes the JSON string at the current offset.
+func (d *jsonDecoder) string() (string, error) {
+	d.off++
+	var b strings.Builder
+	for d.off < len(d.data) {
+		c := d.data[d.off]
+		switch {
+		case c == '"':
+			d.off++
+			return b.String(), nil
+		case c < 0x20:
+			return "", d.error()
+		case c != '\\':
+			b.WriteByte(c)
+			d.off++
+			continue
+		}
+		if d.off+1 >= len(d.data) {
+			break
+		}
+		d.off += 2
+		switch e := d.data[d.off-1]; e {
+		case '"', '\\', '/':
+			b.WriteByte(e)
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'u':
+			r, ok := d.hex4()
+			if !ok {
+				return "", d.error()
+			}
+			if utf16.IsSurrogate(r) {
+				r2 := utf8.RuneError
+				if bytes.HasPrefix(d.data[d.off:], []byte(`\u`)) {
+					d.off += 2
+					if r2, ok = d.hex4(); !ok {
+						return "", d.error()
+					}
+				}
+				r = utf16.DecodeRune(r, r2)
+			}
+			b.WriteRune(r)
+		default:
+			d.off -= 2
+			return "", d.error()
+		}
+	}
+	return "", errors.New("sourcemap: unexpected end of JSON input")
+}
+
+// hex4 decodes the 4 hexadecimal digits of a \u escape sequence at the
+// current offset.
+func (d *jsonDecoder) hex4() (rune, bool) {
+	if d.off+4 > len(d.data) {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(string(d.data[d.off:d.off+4]), 16, 32)
+	if err != nil {
+		return 0, false
+	}
+	d.off += 4
+	return rune(n), true
+}
+
+// skipSpace skips the whitespace at the current offset.
+func (d *jsonDecoder) skipSpace() {
+	for d.off < len(d.data) && strings.IndexByte(" \t\n\r", d.data[d.off]) >= 0 {
+		d.off++
+	}
+}
+
+// consume skips c if it is at the current offset, and reports whether it
+// was.
+func (d *jsonDecoder) consume(c byte) bool {
+	if d.off < len(d.data) && d.data[d.off] == c {
+		d.off++
+		return true
+	}
+	return false
+}
+
+// error returns the error for an unexpected character at the current
+// offset.
+func (d *jsonDecoder) error() error {
+	if d.off >= len(d.data) {
+		return errors.New("sourcemap: unexpected end of JSON input")
+	}
+	return fmt.Errorf("sourcemap: invalid character %q in JSON at offset %d", d.data[d.off], d.off)
+}
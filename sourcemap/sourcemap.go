@@ -0,0 +1,174 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package gorilla/css/sourcemap builds source maps, which map positions in
+generated CSS back to positions in its sources.
+
+It writes the version 3 format described at:
+
+	https://tc39.es/ecma426/
+
+A Map is filled while serializing a stylesheet, by passing it in
+css.RenderOptions or format.Options, and then encoded as JSON:
+
+	m := &sourcemap.Map{File: "out.css", Sources: []string{"in.css"}}
+	sheet.Render(w, css.RenderOptions{SourceMap: m})
+	json.NewEncoder(mapFile).Encode(m)
+
+Lines and columns are 1-based, as in tokens, and columns count runes.
+
+A source map written by another tool can be decoded to compose it with a
+new one, with Apply, so that transforming a generated stylesheet again,
+such as minifying a minified stylesheet, keeps mapping to the original
+sources:
+
+	prev := &sourcemap.Map{}
+	if err := json.Unmarshal(data, prev); err != nil {
+		return err
+	}
+	m.Apply(0, prev) // The source of index 0 was generated from prev.Sources.
+*/
+package sourcemap
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Mapping maps a position in the generated output to a position in a source.
+type Mapping struct {
+	// Line and Column are the position in the generated output.
+	Line, Column int
+	// Source is the index of the source in Map.Sources.
+	Source int
+	// SourceLine and SourceColumn are the position in the source.
+	SourceLine, SourceColumn int
+}
+
+// Map is a source map.
+type Map struct {
+	// File is the name of the generated file.
+	File string
+	// SourceRoot is prepended to the names of the sources.
+	SourceRoot string
+	// Sources are the names of the sources.
+	Sources []string
+	// Mappings are the mappings, in the order of their generated positions.
+	Mappings []Mapping
+}
+
+// Add appends a mapping. It is ignored if it has the same generated position
+// as the last mapping or if any of its positions is unknown.
+func (m *Map) Add(mapping Mapping) {
+	if mapping.Line < 1 || mapping.Column < 1 || mapping.SourceLine < 1 || mapping.SourceColumn < 1 {
+		return
+	}
+	if n := len(m.Mappings); n > 0 {
+		last := m.Mappings[n-1]
+		if last.Line == mapping.Line && last.Column == mapping.Column {
+			return
+		}
+	}
+	m.Mappings = append(m.Mappings, mapping)
+}
+
+// MarshalJSON returns the JSON encoding of the source map. It is written
+// without encoding/json, which would make up most of the size of small
+// programs using the package, such as WebAssembly modules.
+func (m *Map) MarshalJSON() ([]byte, error) {
+	var b strings.Builder
+	b.WriteString(`{"version":3`)
+	if m.File != "" {
+		b.WriteString(`,"file":`)
+		writeJSONString(&b, m.File)
+	}
+	if m.SourceRoot != "" {
+		b.WriteString(`,"sourceRoot":`)
+		writeJSONString(&b, m.SourceRoot)
+	}
+	b.WriteString(`,"sources":[`)
+	for i, source := range m.Sources {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		writeJSONString(&b, source)
+	}
+	b.WriteString(`],"names":[],"mappings":"`)
+	b.WriteString(m.encodeMappings())
+	b.WriteString(`"}`)
+	return []byte(b.String()), nil
+}
+
+// writeJSONString writes s as a JSON string. Invalid UTF-8 is replaced by
+// U+FFFD, as done by encoding/json.
+func writeJSONString(b *strings.Builder, s string) {
+	const hex = "0123456789abcdef"
+	b.WriteByte('"')
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r == '<' || r == '>' || r == '&' || r == '\u2028' || r == '\u2029':
+			b.WriteString(`\u`)
+			b.WriteByte(hex[r>>12&0xf])
+			b.WriteByte(hex[r>>8&0xf])
+			b.WriteByte(hex[r>>4&0xf])
+			b.WriteByte(hex[r&0xf])
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+}
+
+// encodeMappings returns the "mappings" field of the source map: a group of
+// segments per generated line, separated by semicolons, each segment
+// holding the zero-based positions as differences from the previous
+// segment encoded as base64 VLQs.
+func (m *Map) encodeMappings() string {
+	var b strings.Builder
+	line, column, source, sourceLine, sourceColumn := 1, 0, 0, 0, 0
+	for i, mp := range m.Mappings {
+		if mp.Line > line {
+			b.WriteString(strings.Repeat(";", mp.Line-line))
+			line = mp.Line
+			column = 0
+		} else if i > 0 {
+			b.WriteByte(',')
+		}
+		writeVLQ(&b, mp.Column-1-column)
+		writeVLQ(&b, mp.Source-source)
+		writeVLQ(&b, mp.SourceLine-1-sourceLine)
+		writeVLQ(&b, mp.SourceColumn-1-sourceColumn)
+		column, source = mp.Column-1, mp.Source
+		sourceLine, sourceColumn = mp.SourceLine-1, mp.SourceColumn-1
+	}
+	return b.String()
+}
+
+const base64Digits = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+// writeVLQ writes n as a base64 variable-length quantity: the sign is the
+// lowest bit, and each digit holds 5 bits with a continuation bit.
+func writeVLQ(b *strings.Builder, n int) {
+	v := n << 1
+	if n < 0 {
+		v = -n<<1 | 1
+	}
+	for {
+		digit := v & 31
+		v >>= 5
+		if v > 0 {
+			digit |= 32
+		}
+		b.WriteByte(base64Digits[digit])
+		if v == 0 {
+			return
+		}
+	}
+}
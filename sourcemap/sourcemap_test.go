@@ -0,0 +1,149 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sourcemap
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestVLQ(t *testing.T) {
+	for n, want := range map[int]string{
+		0: "A", 1: "C", -1: "D", 15: "e", 16: "gB", -16: "hB", 1000: "w+B",
+	} {
+		var b strings.Builder
+		writeVLQ(&b, n)
+		if b.String() != want {
+			t.Errorf("%d: got %q, want %q", n, b.String(), want)
+		}
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	m := &Map{File: "out.css", Sources: []string{"a.css"}}
+	m.Add(Mapping{Line: 1, Column: 1, SourceLine: 1, SourceColumn: 1})
+	m.Add(Mapping{Line: 1, Column: 1, SourceLine: 2, SourceColumn: 1})
+	m.Add(Mapping{Line: 1, Column: 3, SourceLine: 2, SourceColumn: 3})
+	m.Add(Mapping{Line: 3, Column: 2, SourceLine: 5, SourceColumn: 1})
+	m.Add(Mapping{Line: 3, Column: 4})
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"version":3,"file":"out.css","sources":["a.css"],"names":[],"mappings":"AAAA,EACE;;CAGF"}`
+	if string(b) != want {
+		t.Errorf("got %s, want %s", b, want)
+	}
+}
+
+func TestMarshalJSONEscaping(t *testing.T) {
+	m := &Map{SourceRoot: "a\"b\\c", Sources: []string{"\x01<d> \xff", "é"}}
+	got, err := m.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := json.Marshal(struct {
+		Version    int      `json:"version"`
+		SourceRoot string   `json:"sourceRoot"`
+		Sources    []string `json:"sources"`
+		Names      []string `json:"names"`
+		Mappings   string   `json:"mappings"`
+	}{3, m.SourceRoot, m.Sources, []string{}, ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	m := &Map{File: "out.css", SourceRoot: "src", Sources: []string{"a.css", "b.css"}}
+	m.Add(Mapping{Line: 1, Column: 1, SourceLine: 1, SourceColumn: 1})
+	m.Add(Mapping{Line: 1, Column: 40, Source: 1, SourceLine: 20, SourceColumn: 3})
+	m.Add(Mapping{Line: 3, Column: 2, SourceLine: 5, SourceColumn: 1})
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := &Map{}
+	if err := json.Unmarshal(b, got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, m) {
+		t.Errorf("got %+v, want %+v", got, m)
+	}
+
+	// Names, segments without a source position, escape sequences and
+	// unsorted segments.
+	got = &Map{}
+	err = got.UnmarshalJSON([]byte(` {"version": 3, "sources": ["é😀.css", null],
+		"names": ["a"], "sourcesContent": [null, "b{}"], "x": [1.5e3, true, false, {}],
+		"mappings": "C,AAAAA;;GCCC,HDAA"} `))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Map{
+		Sources: []string{"é😀.css", ""},
+		Mappings: []Mapping{
+			{Line: 1, Column: 2, SourceLine: 1, SourceColumn: 1},
+			{Line: 3, Column: 1, SourceLine: 2, SourceColumn: 2},
+			{Line: 3, Column: 4, Source: 1, SourceLine: 2, SourceColumn: 2},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	for _, input := range []string{
+		``,
+		`[]`,
+		`{"version":2,"sources":[],"mappings":""}`,
+		`{"version":3,"sections":[]}`,
+		`{"version":3,"sources":[]}`,
+		`{"version":3,"sources":[],"mappings":"AAAA"}`,
+		`{"version":3,"sources":["a"],"mappings":"AA"}`,
+		`{"version":3,"sources":["a"],"mappings":"A!"}`,
+		`{"version":3,"sources":["a"],"mappings":"g"}`,
+		`{"version":3,"sources":["a"],"mappings":"AAAA"`,
+		`{"version":3,"sources":["a\x"],"mappings":""}`,
+		`{"version":3,"sources":["a"],"mappings":""} x`,
+		strings.Repeat("[", 1000),
+	} {
+		if err := (&Map{}).UnmarshalJSON([]byte(input)); err == nil {
+			t.Errorf("%q: expected an error", input)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	// out.css is generated from x.css and mid.css, which is generated from
+	// a.css and b.css.
+	m := &Map{Sources: []string{"x.css", "mid.css", "y.css"}}
+	m.Add(Mapping{Line: 1, Column: 1, Source: 0, SourceLine: 1, SourceColumn: 1})
+	m.Add(Mapping{Line: 1, Column: 5, Source: 1, SourceLine: 1, SourceColumn: 1})
+	m.Add(Mapping{Line: 1, Column: 9, Source: 1, SourceLine: 1, SourceColumn: 7})
+	m.Add(Mapping{Line: 1, Column: 12, Source: 1, SourceLine: 2, SourceColumn: 1})
+	m.Add(Mapping{Line: 2, Column: 1, Source: 2, SourceLine: 3, SourceColumn: 1})
+	prev := &Map{SourceRoot: "src/", Sources: []string{"a.css", "b.css"}}
+	prev.Add(Mapping{Line: 1, Column: 1, Source: 0, SourceLine: 10, SourceColumn: 1})
+	prev.Add(Mapping{Line: 1, Column: 6, Source: 1, SourceLine: 4, SourceColumn: 3})
+	prev.Add(Mapping{Line: 2, Column: 2, Source: 1, SourceLine: 5, SourceColumn: 1})
+	m.Apply(1, prev)
+	want := &Map{
+		Sources: []string{"x.css", "y.css", "src/a.css", "src/b.css"},
+		Mappings: []Mapping{
+			{Line: 1, Column: 1, Source: 0, SourceLine: 1, SourceColumn: 1},
+			{Line: 1, Column: 5, Source: 2, SourceLine: 10, SourceColumn: 1},
+			{Line: 1, Column: 9, Source: 3, SourceLine: 4, SourceColumn: 3},
+			{Line: 2, Column: 1, Source: 1, SourceLine: 3, SourceColumn: 1},
+		},
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("got %+v, want %+v", m, want)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package css
+
+import (
+	"io"
+	"strings"
+	"unsafe"
+
+	"github.com/gorilla/css/scanner"
+)
+
+// streamChunkSize is the size of the reads of ParseRules.
+const streamChunkSize = 32 << 10
+
+// ParseRules parses a stylesheet read from r and calls fn with each
+// top-level rule as soon as it is complete. The rules aren't retained, so
+// that a stylesheet of any size can be processed with memory proportional
+// to its largest rule, but the rules passed to fn are the same as those of
+// ParseStylesheet, including their positions, comments and raw text.
+//
+// If fn returns an error, parsing stops and ParseRules returns it. If the
+// input has an unclosed quotation mark or an unclosed comment, ParseRules
+// returns a *ParseError after passing the rules before it to fn; the rest
+// of the input is then read at once.
+func ParseRules(r io.Reader, fn func(*Rule) error) error {
+	st := &ruleStream{r: r, buf: make([]byte, streamChunkSize), sp: newRuleSplitter(), line: 1, col: 1}
+	for i := 0; ; {
+		end, resume, ok := st.sp.next(st.view(), i, st.eof)
+		switch {
+		case !ok:
+			// Let the parser report the problem.
+			if err := st.readAll(); err != nil {
+				return err
+			}
+			return st.parse(len(st.view()), fn)
+		case end > 0:
+			if err := st.parse(end, fn); err != nil {
+				return err
+			}
+			i = 0
+		case st.eof:
+			return st.parse(len(st.view()), fn)
+		default:
+			i = resume
+			if err := st.read(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ruleStream holds the state of ParseRules.
+type ruleStream struct {
+	r   io.Reader
+	buf []byte
+	sp  *ruleSplitter
+	// pending[off:] is the normalized input not parsed yet, and cr reports
+	// whether a carriage return was held back after it, since it may be
+	// followed by a line feed in the next read.
+	pending []byte
+	off     int
+	cr      bool
+	eof     bool
+	// line and col are the position of the input not parsed yet, and
+	// started reports whether some input was parsed.
+	line, col int
+	started   bool
+}
+
+// view returns the input not parsed yet. It is only valid until the next
+// read.
+func (st *ruleStream) view() string {
+	if st.off == len(st.pending) {
+		return ""
+	}
+	return unsafe.String(&st.pending[st.off], len(st.pending)-st.off)
+}
+
+// read appends the next read to the pending input.
+func (st *ruleStream) read() error {
+	n, err := st.r.Read(st.buf)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	st.eof = err == io.EOF
+	st.append(string(st.buf[:n]))
+	return nil
+}
+
+// readAll appends the rest of the input to the pending input.
+func (st *ruleStream) readAll() error {
+	rest, err := io.ReadAll(st.r)
+	if err != nil {
+		return err
+	}
+	st.eof = true
+	st.append(string(rest))
+	return nil
+}
+
+// append normalizes text and appends it to the pending input.
+func (st *ruleStream) append(text string) {
+	if st.cr {
+		text = "\r" + text
+	}
+	st.cr = !st.eof && strings.HasSuffix(text, "\r")
+	if st.cr {
+		text = text[:len(text)-1]
+	}
+	st.pending = append(st.pending[:0], st.pending[st.off:]...)
+	st.off = 0
+	st.pending = append(st.pending, scanner.Normalize(text)...)
+}
+
+// parse parses the pending input up to end, passes its rules to fn and
+// drops it.
+func (st *ruleStream) parse(end int, fn func(*Rule) error) error {
+	// The text is copied, so that the rules don't keep the buffer alive.
+	text := string(st.pending[st.off : st.off+end])
+	p := newParser(text)
+	rules := p.parseRules()
+	if p.err != nil {
+		p.err.Line, p.err.Column = shift(p.err.Line, p.err.Column, st.line, st.col)
+		return p.err
+	}
+	if st.started {
+		shiftRules(rules, st.line, st.col)
+	}
+	setChecksums(rules)
+	st.line, st.col = advancePosition(st.line, st.col, text, !st.started)
+	st.started = true
+	st.off += end
+	for _, r := range rules {
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,476 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package css
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gorilla/css/diag"
+	"github.com/gorilla/css/scanner"
+)
+
+// Stylesheet is a list of rules.
+type Stylesheet struct {
+	Rules []*Rule
+}
+
+// Rule is a qualified rule, such as a style rule, or an at-rule.
+type Rule struct {
+	// AtKeyword is the name of an at-rule, without the "@". It is empty for
+	// qualified rules.
+	AtKeyword string
+	// Prelude are the component values before the block, without leading
+	// and trailing whitespace. For style rules it is the selector list.
+	Prelude []*ComponentValue
+	// HasBlock reports whether the rule has a {}-block. At-rules such as
+	// @import end with a semicolon instead.
+	HasBlock bool
+	// Declarations and Rules are the contents of the block.
+	Declarations []*Declaration
+	Rules        []*Rule
+	Line         int
+	Column       int
+	// Comments are the comments preceding the rule, with their delimiters.
+	// Other comments, such as those inside a prelude, are dropped.
+	Comments []string
+	// Raw is the source text of the rule, or an empty string if the rule
+	// wasn't created by the parser. It excludes the semicolon ending
+	// at-rules without a block.
+	Raw string
+	// sum is the checksum of the rule when it was parsed.
+	sum uint64
+}
+
+// Declaration is a property and its value.
+type Declaration struct {
+	// Property is the property name as written in the input.
+	Property string
+	// Value are the component values of the declaration, without leading and
+	// trailing whitespace and without the !important flag.
+	Value     []*ComponentValue
+	Important bool
+	Line      int
+	Column    int
+	// Comments are the comments preceding the declaration, with their
+	// delimiters. Other comments, such as those inside a value, are dropped.
+	Comments []string
+	// Raw is the source text of the declaration, without the semicolon, or
+	// an empty string if the declaration wasn't created by the parser.
+	Raw string
+	// sum is the checksum of the declaration when it was parsed.
+	sum uint64
+}
+
+// IsAtRule reports whether the rule is an at-rule.
+func (r *Rule) IsAtRule() bool {
+	return r.AtKeyword != ""
+}
+
+// Clone returns a deep copy of the rule.
+func (r *Rule) Clone() *Rule {
+	c := *r
+	c.Comments = cloneStrings(r.Comments)
+	c.Prelude = CloneValues(r.Prelude)
+	if r.Declarations != nil {
+		c.Declarations = make([]*Declaration, len(r.Declarations))
+		for i, d := range r.Declarations {
+			c.Declarations[i] = d.Clone()
+		}
+	}
+	if r.Rules != nil {
+		c.Rules = make([]*Rule, len(r.Rules))
+		for i, child := range r.Rules {
+			c.Rules[i] = child.Clone()
+		}
+	}
+	return &c
+}
+
+// Clone returns a deep copy of the declaration.
+func (d *Declaration) Clone() *Declaration {
+	c := *d
+	c.Comments = cloneStrings(d.Comments)
+	c.Value = CloneValues(d.Value)
+	return &c
+}
+
+// cloneStrings returns a copy of a list of strings.
+func cloneStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string(nil), s...)
+}
+
+// String returns the CSS representation of the stylesheet.
+func (s *Stylesheet) String() string {
+	var b strings.Builder
+	s.WriteTo(&b)
+	return b.String()
+}
+
+// String returns the CSS representation of the rule.
+func (r *Rule) String() string {
+	var b strings.Builder
+	r.WriteTo(&b)
+	return b.String()
+}
+
+// String returns the CSS representation of the declaration.
+func (d *Declaration) String() string {
+	var b strings.Builder
+	d.WriteTo(&b)
+	return b.String()
+}
+
+// writeRules appends the CSS representation of a list of rules to w.
+func writeRules(w *writer, rules []*Rule) {
+	for _, r := range rules {
+		writeRule(w, r)
+	}
+}
+
+// writeRule appends the CSS representation of r to w.
+func writeRule(w *writer, r *Rule) {
+	if w.preserveRaw() && r.Raw != "" && r.sum == checksum(r) {
+		w.mark(r.Line, r.Column)
+		w.writeString(r.Raw)
+		if !r.HasBlock {
+			w.writeByte(';')
+		}
+		return
+	}
+	writeComments(w, r.Comments)
+	w.mark(r.Line, r.Column)
+	if r.IsAtRule() {
+		w.writeByte('@')
+		w.writeIdent(r.AtKeyword)
+		if len(r.Prelude) > 0 {
+			w.writeByte(' ')
+		}
+	}
+	writeValues(w, r.Prelude)
+	if !r.HasBlock {
+		w.writeByte(';')
+		return
+	}
+	w.writeByte('{')
+	for i, d := range r.Declarations {
+		writeDeclaration(w, d)
+		// The last semicolon is only needed to separate nested rules.
+		if i < len(r.Declarations)-1 || len(r.Rules) > 0 {
+			w.writeByte(';')
+		}
+	}
+	writeRules(w, r.Rules)
+	w.writeByte('}')
+}
+
+// writeDeclaration appends the CSS representation of d to w.
+func writeDeclaration(w *writer, d *Declaration) {
+	if w.preserveRaw() && d.Raw != "" && d.sum == checksum(d) {
+		w.mark(d.Line, d.Column)
+		w.writeString(d.Raw)
+		return
+	}
+	writeComments(w, d.Comments)
+	w.mark(d.Line, d.Column)
+	w.writeIdent(d.Property)
+	w.writeByte(':')
+	writeValues(w, d.Value)
+	if d.Important {
+		w.writeString("!important")
+	}
+}
+
+// writeComments appends comments to w.
+func writeComments(w *writer, comments []string) {
+	if w.canonical() || w.opts != nil && w.opts.htmlAttr {
+		return
+	}
+	for _, c := range comments {
+		w.writeString(c)
+	}
+}
+
+// ParseStylesheet parses the input as a stylesheet.
+//
+// Invalid rules and declarations are dropped following the error recovery
+// rules of the CSS Syntax specification. An error is only returned if the
+// input has an unclosed quotation mark or an unclosed comment.
+func ParseStylesheet(input string) (*Stylesheet, error) {
+	return newParser(input).parseStylesheet()
+}
+
+// MustParseStylesheet is like ParseStylesheet but panics if the input can't
+// be parsed. It simplifies the initialization of global variables and
+// tests.
+func MustParseStylesheet(input string) *Stylesheet {
+	s, err := ParseStylesheet(input)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// parseStylesheet consumes the input as a stylesheet.
+func (p *parser) parseStylesheet() (*Stylesheet, error) {
+	s := &Stylesheet{Rules: p.parseRules()}
+	if err := p.error(); err != nil {
+		return nil, err
+	}
+	setChecksums(s.Rules)
+	return s, nil
+}
+
+// setChecksums records the checksums of freshly parsed rules and their
+// contents, used to detect modifications.
+func setChecksums(rules []*Rule) {
+	for _, r := range rules {
+		r.sum = checksum(r)
+		for _, d := range r.Declarations {
+			d.sum = checksum(d)
+		}
+		setChecksums(r.Rules)
+	}
+}
+
+// parseRules consumes the top-level rules of a stylesheet.
+func (p *parser) parseRules() []*Rule {
+	var rules []*Rule
+	for {
+		t := p.next()
+		switch t.Type {
+		case scanner.TokenEOF:
+			return rules
+		case scanner.TokenS, scanner.TokenCDO, scanner.TokenCDC:
+			continue
+		case scanner.TokenAtKeyword:
+			rules = append(rules, p.parseAtRule(t, false))
+		default:
+			if r := p.parseQualifiedRule(t); r != nil {
+				rules = append(rules, r)
+			}
+		}
+	}
+}
+
+// parseAtRule consumes an at-rule that starts with the at-keyword t.
+//
+// Nested at-rules also end before the closing bracket of the parent block.
+func (p *parser) parseAtRule(t *scanner.Token, nested bool) *Rule {
+	p.rules++
+	p.exceed("MaxRules", p.rules, p.limits.MaxRules, t)
+	r := p.newRule()
+	*r = Rule{AtKeyword: t.Value[1:], Line: t.Line, Column: t.Column, Comments: p.takeComments()}
+	start, end := p.start, p.end
+	mark := len(p.stack)
+	for {
+		t := p.next()
+		if t.Type == scanner.TokenEOF || isChar(t, ";") {
+			break
+		}
+		if nested && isChar(t, "}") {
+			p.back(t)
+			break
+		}
+		if isChar(t, "{") {
+			r.HasBlock = true
+			p.takeComments()
+			r.Declarations, r.Rules = p.parseBlock(t)
+			end = p.end
+			break
+		}
+		p.stack = append(p.stack, p.parseValue(t))
+		if t.Type != scanner.TokenS {
+			end = p.end
+		}
+	}
+	r.Prelude = p.pop(mark, true)
+	r.Raw = p.raw(start, end)
+	p.takeComments()
+	return r
+}
+
+// parseQualifiedRule consumes a top-level qualified rule that starts with
+// the token t. It returns nil if the input ends before the block.
+func (p *parser) parseQualifiedRule(t *scanner.Token) *Rule {
+	first := t
+	comments := p.takeComments()
+	start := p.start
+	mark := len(p.stack)
+	for ; t.Type != scanner.TokenEOF; t = p.next() {
+		if isChar(t, "{") {
+			p.takeComments()
+			p.rules++
+			if p.exceed("MaxRules", p.rules, p.limits.MaxRules, first) ||
+				p.exceed("MaxSelectorLength", p.preludeLen(start), p.limits.MaxSelectorLength, first) {
+				break
+			}
+			r := p.newRule()
+			*r = Rule{Line: first.Line, Column: first.Column, HasBlock: true, Comments: comments}
+			r.Prelude = p.pop(mark, true)
+			r.Declarations, r.Rules = p.parseBlock(t)
+			r.Raw = p.raw(start, p.end)
+			return r
+		}
+		p.stack = append(p.stack, p.parseValue(t))
+	}
+	p.stack = p.stack[:mark]
+	p.warn(diag.CodeIncompleteRule, "rule dropped: the input ends before its block", first)
+	return nil
+}
+
+// preludeLen returns the length of the prelude of a rule that starts at
+// the offset start, up to the last token returned by next, without trailing
+// whitespace.
+func (p *parser) preludeLen(start int) int {
+	return len(strings.TrimRight(p.raw(start, p.start), " \t\n\r\f"))
+}
+
+// parseBlock consumes the contents of a {}-block, opened by the token open,
+// up to the closing bracket or the end of the input. The contents are a mix
+// of declarations and nested rules.
+func (p *parser) parseBlock(open *scanner.Token) ([]*Declaration, []*Rule) {
+	declMark, ruleMark := len(p.declStack), len(p.ruleStack)
+	p.depth++
+	if p.exceed("MaxDepth", p.depth, p.limits.MaxDepth, open) {
+		p.depth--
+		return nil, nil
+	}
+	for {
+		t := p.next()
+		switch {
+		case t.Type == scanner.TokenEOF || isChar(t, "}"):
+			p.takeComments()
+			p.depth--
+			return p.popBlock(declMark, ruleMark)
+		case t.Type == scanner.TokenS || isChar(t, ";"):
+			continue
+		case t.Type == scanner.TokenAtKeyword:
+			p.ruleStack = append(p.ruleStack, p.parseAtRule(t, true))
+		default:
+			d, r := p.parseBlockItem(t)
+			if d != nil {
+				p.declStack = append(p.declStack, d)
+			}
+			if r != nil {
+				p.ruleStack = append(p.ruleStack, r)
+			}
+		}
+	}
+}
+
+// popBlock removes the declarations and rules pushed on the stacks since
+// the given marks and returns them in new lists, which are nil if empty.
+func (p *parser) popBlock(declMark, ruleMark int) ([]*Declaration, []*Rule) {
+	var decls []*Declaration
+	var rules []*Rule
+	if n := len(p.declStack) - declMark; n > 0 {
+		if p.arena != nil {
+			decls = p.arena.newDeclList(n)
+		} else {
+			decls = make([]*Declaration, n)
+		}
+		copy(decls, p.declStack[declMark:])
+	}
+	if n := len(p.ruleStack) - ruleMark; n > 0 {
+		if p.arena != nil {
+			rules = p.arena.newRuleList(n)
+		} else {
+			rules = make([]*Rule, n)
+		}
+		copy(rules, p.ruleStack[ruleMark:])
+	}
+	p.declStack, p.ruleStack = p.declStack[:declMark], p.ruleStack[:ruleMark]
+	return decls, rules
+}
+
+// parseBlockItem consumes a declaration or a nested rule that starts with
+// the token t. Invalid declarations are dropped, so both returned values
+// may be nil.
+func (p *parser) parseBlockItem(t *scanner.Token) (*Declaration, *Rule) {
+	// Custom properties may have {}-blocks in their values.
+	custom := t.Type == scanner.TokenIdent && strings.HasPrefix(t.Value, "--")
+	first := t
+	comments := p.takeComments()
+	start, end := p.start, p.end
+	mark := len(p.stack)
+	for ; t.Type != scanner.TokenEOF && !isChar(t, ";"); t = p.next() {
+		if isChar(t, "}") {
+			p.back(t)
+			break
+		}
+		if isChar(t, "{") && !custom {
+			p.rules++
+			if p.exceed("MaxRules", p.rules, p.limits.MaxRules, first) ||
+				p.exceed("MaxSelectorLength", p.preludeLen(start), p.limits.MaxSelectorLength, first) {
+				p.stack = p.stack[:mark]
+				return nil, nil
+			}
+			r := p.newRule()
+			*r = Rule{Prelude: p.pop(mark, true), HasBlock: true, Line: first.Line, Column: first.Column, Comments: comments}
+			p.takeComments()
+			r.Declarations, r.Rules = p.parseBlock(t)
+			r.Raw = p.raw(start, p.end)
+			return nil, r
+		}
+		p.stack = append(p.stack, p.parseValue(t))
+		if t.Type != scanner.TokenS {
+			end = p.end
+		}
+	}
+	p.takeComments()
+	d := p.parseDeclaration(p.stack[mark:])
+	p.stack = p.stack[:mark]
+	if d != nil {
+		d.Comments = comments
+		d.Raw = p.raw(start, end)
+	}
+	return d, nil
+}
+
+// parseDeclaration returns a declaration from a list of component values, or
+// nil if the values don't start with a property name and a colon.
+func (p *parser) parseDeclaration(values []*ComponentValue) *Declaration {
+	if len(values) == 0 {
+		return nil
+	}
+	if values[0].Token.Type != scanner.TokenIdent {
+		p.warn(diag.CodeInvalidDeclaration, "declaration dropped: expected a property name", values[0].Token)
+		return nil
+	}
+	name := values[0].Token
+	values = TrimSpace(values[1:])
+	if len(values) == 0 || !isChar(values[0].Token, ":") {
+		p.warn(diag.CodeInvalidDeclaration, fmt.Sprintf("declaration dropped: expected a colon after %q", name.Value), name)
+		return nil
+	}
+	p.decls++
+	if p.exceed("MaxDeclarations", p.decls, p.limits.MaxDeclarations, name) {
+		return nil
+	}
+	d := p.newDeclaration()
+	*d = Declaration{Property: name.Value, Line: name.Line, Column: name.Column}
+	values = TrimSpace(values[1:])
+	if n := len(values); n >= 2 {
+		last := values[n-1].Token
+		if last.Type == scanner.TokenIdent && strings.EqualFold(last.Value, "important") {
+			rest := TrimSpace(values[:n-1])
+			if len(rest) > 0 && isChar(rest[len(rest)-1].Token, "!") {
+				d.Important = true
+				values = TrimSpace(rest[:len(rest)-1])
+			}
+		}
+	}
+	d.Value = p.list(values)
+	return d
+}
+
+// isChar reports whether t is a Char token with the value c.
+func isChar(t *scanner.Token, c string) bool {
+	return t.Type == scanner.TokenChar && t.Value == c
+}
@@ -0,0 +1,649 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package css
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/gorilla/css/diag"
+	"github.com/gorilla/css/scanner"
+)
+
+func TestParseStylesheet(t *testing.T) {
+	tcs := []struct{ desc, input, expected string }{
+		{
+			"style rule",
+			"a, b > c { color : red ; margin: 0  auto; }",
+			"a, b > c{color:red;margin:0  auto}",
+		},
+		{
+			"important",
+			"a { color: red ! IMPORTANT }",
+			"a{color:red!important}",
+		},
+		{
+			"at-rules",
+			"@charset \"utf-8\"; @import url(a.css) screen; @media print { a { color: black } }",
+			"@charset \"utf-8\";@import url(a.css) screen;@media print{a{color:black}}",
+		},
+		{
+			"font-face",
+			"@font-face { font-family: x; src: url(x.woff) }",
+			"@font-face{font-family:x;src:url(x.woff)}",
+		},
+		{
+			"keyframes",
+			"@keyframes spin { from { rotate: 0deg } 50% { rotate: 180deg } }",
+			"@keyframes spin{from{rotate:0deg}50%{rotate:180deg}}",
+		},
+		{
+			"nesting",
+			"a { color: red; &:hover { color: blue } b { c: d } }",
+			"a{color:red;&:hover{color:blue}b{c:d}}",
+		},
+		{
+			"nested at-rule",
+			"a { @media print { color: black } color: red }",
+			"a{color:red;@media print{color:black}}",
+		},
+		{
+			"custom property",
+			"a { --x: { a: b }; --y:; }",
+			"a{--x:{ a: b };--y:}",
+		},
+		{
+			"invalid declarations",
+			"a { color red; : x; 1px: 2; color: blue }",
+			"a{color:blue}",
+		},
+		{
+			"comments and cdo",
+			"<!-- /* c */ a /* d */ { /* e */ color: /* f */ red /* g */ } /* h */ -->",
+			"/* c */a{/* e */color:red}",
+		},
+		{
+			"unclosed",
+			"a { b { color: red",
+			"a{b{color:red}}",
+		},
+		{
+			"missing block",
+			"a { color: red } b",
+			"a{color:red}",
+		},
+		{
+			"backslash at the end",
+			"a { content: x\\",
+			"a{content:x\\\n}",
+		},
+		{
+			"unclosed url",
+			"a { background: url(x",
+			"a{background:ur\\l(x)}",
+		},
+		{
+			"comments between tokens",
+			"a/**/b/**/(c) { d: 1/**/px 1/**/.5 1/**/% -/**/1 #/**/a U+1/**/? U/**/+1 .x/**/-y; e: |/**/= //**/* }",
+			"a/**/b/**/(c){d:1/**/px 1/**/.5 1/**/% -/**/1 #/**/a U+1/**/? U/**/+1 .x/**/-y;e:|/**/= //**/*}",
+		},
+		{
+			"comments between separate tokens",
+			"a/**/.b/**/#c/**/>d { e: 1/**/+2 f/**/,g/**/\\\n }",
+			"a.b#c>d{e:1+2 f,g\\\n}",
+		},
+	}
+	for _, tc := range tcs {
+		s, err := ParseStylesheet(tc.input)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.desc, err)
+			continue
+		}
+		if got := s.String(); got != tc.expected {
+			t.Errorf("%s: got=%q, want=%q", tc.desc, got, tc.expected)
+		}
+	}
+}
+
+func TestParseStylesheetPositions(t *testing.T) {
+	s, err := ParseStylesheet("a {\n  color: red;\n}\n@media print {}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := s.Rules[0]; r.Line != 1 || r.Column != 1 {
+		t.Errorf("rule: got line %d, column %d", r.Line, r.Column)
+	}
+	if d := s.Rules[0].Declarations[0]; d.Line != 2 || d.Column != 3 {
+		t.Errorf("declaration: got line %d, column %d", d.Line, d.Column)
+	}
+	if r := s.Rules[1]; r.Line != 4 || r.Column != 1 || r.AtKeyword != "media" {
+		t.Errorf("at-rule: got %q at line %d, column %d", r.AtKeyword, r.Line, r.Column)
+	}
+}
+
+func TestParseStylesheetError(t *testing.T) {
+	if _, err := ParseStylesheet(`a { content: "x }`); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	if got := MustParseStylesheet("a { b: c }").String(); got != "a{b:c}" {
+		t.Errorf("got %q, want %q", got, "a{b:c}")
+	}
+	if got := ValuesString(MustParseComponentValues("a  b")); got != "a  b" {
+		t.Errorf("got %q, want %q", got, "a  b")
+	}
+	for name, fn := range map[string]func(){
+		"MustParseStylesheet":      func() { MustParseStylesheet(`a { content: "x }`) },
+		"MustParseComponentValues": func() { MustParseComponentValues("a /* b") },
+	} {
+		func() {
+			defer func() {
+				if _, ok := recover().(*ParseError); !ok {
+					t.Errorf("%s: expected a panic with a *ParseError", name)
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	for _, input := range splitInputs {
+		f.Add(input)
+	}
+	for _, input := range []string{"a { --x: { a: b }; --y:; }", "a { color: red; &:hover { color: blue } }", "<!-- a{} -->", "a { b { color: red"} {
+		f.Add(input)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		s, err := ParseStylesheet(input)
+		if err != nil {
+			return
+		}
+		// Serializing again gives the same stylesheet.
+		out := s.String()
+		again, err := ParseStylesheet(out)
+		if err != nil {
+			t.Fatalf("%q serialized as %q: %v", input, out, err)
+		}
+		if got := again.String(); got != out {
+			t.Errorf("%q serialized as %q, then as %q", input, out, got)
+		}
+		// It has the same rules and tokens, unless a newline had to be
+		// written after a stray backslash.
+		if !strings.Contains(input, `\`) && !reflect.DeepEqual(conformRules(again.Rules), conformRules(s.Rules)) {
+			t.Errorf("%q serialized as %q, which parses to other rules", input, out)
+		}
+	})
+}
+
+// checkParallel checks that parsing the input in parts of one byte or
+// more gives the same result as parsing it sequentially.
+func checkParallel(t *testing.T, input string) {
+	t.Helper()
+	want, wantErr := ParseStylesheet(input)
+	got, err := parseParallel(scanner.Normalize(input), 3, 1)
+	if !reflect.DeepEqual(err, wantErr) {
+		t.Fatalf("%q: got error %v, want %v", input, err, wantErr)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%q: got %q, want %q", input, got, want)
+	}
+}
+
+// splitInputs are stylesheets that are tricky to split between rules.
+var splitInputs = []string{
+	"a { color: red }\n@import url(a.css);\n/* c */ b { margin: 0 }",
+	"\uFEFFa{}b{}",
+	"a{}\uFEFFb{}",
+	"@media print { a { color: black } } @charset 'x'; b{} c{}",
+	"a { x: '}' } b { x: \"\\\"}\" } c { x: \\} }",
+	"a { background: url(x{y}.png) } b{} c { background: URL( 'x}' ) }",
+	"a { x: url(a b}) } b{} c{}",
+	"a ( { ) } b{} c{}",
+	"} a{} ) b{} @x ( ; ) ; c{}",
+	"<!-- a{} --> @x; b{}",
+	"@;a{}b{} @-x;c{} @1;d{}",
+	"a{}\n\n  b{}\r\nc{\n d: e\n}",
+	"a{} /* } */ b{} /",
+	"a{} b{ x: 'y\n' } c{}",
+	"a{} b{} /* c",
+	"a { b { c: d } } e{ f: g",
+	"a{}b{}\r",
+	"a{x:url(  b  )}c{x:url(\\)}d{}",
+}
+
+func TestParseStylesheetParallel(t *testing.T) {
+	for _, input := range splitInputs {
+		checkParallel(t, input)
+	}
+	for name, input := range corpus(t) {
+		t.Run(name, func(t *testing.T) {
+			checkParallel(t, input)
+		})
+	}
+}
+
+// checkParseRules checks that ParseRules passes the rules of
+// ParseStylesheet to its callback, reading the input at once or byte by
+// byte.
+func checkParseRules(t *testing.T, input string) {
+	t.Helper()
+	want, wantErr := ParseStylesheet(input)
+	for _, r := range []io.Reader{strings.NewReader(input), iotest.OneByteReader(strings.NewReader(input))} {
+		got := &Stylesheet{}
+		err := ParseRules(r, func(r *Rule) error {
+			got.Rules = append(got.Rules, r)
+			return nil
+		})
+		if !reflect.DeepEqual(err, wantErr) {
+			t.Fatalf("%q: got error %v, want %v", input, err, wantErr)
+		}
+		if err == nil && !reflect.DeepEqual(got, want) {
+			t.Errorf("%q: got %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	for _, input := range splitInputs {
+		checkParseRules(t, input)
+	}
+	for name, input := range corpus(t) {
+		t.Run(name, func(t *testing.T) {
+			checkParseRules(t, input)
+		})
+	}
+	stop := errors.New("stop")
+	n := 0
+	err := ParseRules(strings.NewReader("a{} b{} c{}"), func(r *Rule) error {
+		if n++; n == 2 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop || n != 2 {
+		t.Errorf("got error %v after %d rules, want %v after 2", err, n, stop)
+	}
+}
+
+func TestOpenStylesheet(t *testing.T) {
+	tcs := []struct {
+		input       string
+		contentType string
+		encoding    string
+		expected    string
+	}{
+		{"a{}", "", "utf-8", "a{}"},
+		{"\xef\xbb\xbfa{}", "text/css; charset=latin1", "utf-8", "a{}"},
+		{"\xfe\xff\x00a\x00{\x00}", "", "utf-16be", "a{}"},
+		{"\xff\xfea\x00{\x00}\x00", "", "utf-16le", "a{}"},
+		{"@charset \"latin1\";a{}", "text/css; charset=utf-8", "utf-8", "@charset \"latin1\";a{}"},
+		{"@charset \"utf-16\";a{}", "", "utf-8", "@charset \"utf-16\";a{}"},
+		{"@charset 'latin1';a{content:'\xe9'}", "", "utf-8", "@charset 'latin1';a{content:'\ufffd'}"},
+		{"@charset \"foo\";a{}", "text/css; charset=bar", "utf-8", "@charset \"foo\";a{}"},
+		{"a{}", "not a media type", "utf-8", "a{}"},
+		{"\x00a", "text/css;Charset=\"UTF-16BE\"", "utf-16be", "a"},
+		{"\x00a", "text/css; x=\"a;charset=utf-8\"; charset=\"utf\\-16be\" ", "utf-16be", "a"},
+		{"\x00a", "text/css; charset=; charset=utf-16be", "utf-16be", "a"},
+		{"\x00a", "text /css; charset=utf-8", "utf-16be", "a"},
+		// UTF-16 without a byte order mark.
+		{"a\x00{\x00}\x00", "", "utf-16le", "a{}"},
+		{"\x00a\x00{\x00}", "", "utf-16be", "a{}"},
+		{"\x00a\x00{\x00}", "text/css; charset=UTF-16BE", "utf-16be", "a{}"},
+		{"@\x00c\x00h\x00a\x00r\x00s\x00e\x00t\x00 \x00\"\x00u\x00t\x00f\x00-\x001\x006\x00\"\x00;\x00", "", "utf-16le", "@charset \"utf-16\";"},
+		// Mislabeled stylesheets: the byte order mark wins over the labels,
+		// and the Content-Type header over the @charset rule.
+		{"\xef\xbb\xbf@charset \"iso-8859-1\";.a:after{content:'\xe2\x80\x94'}", "text/css; charset=windows-1252", "utf-8", "@charset \"iso-8859-1\";.a:after{content:'\u2014'}"},
+		{"\xff\xfe.\x00a\x00", "text/css; charset=utf-8", "utf-16le", ".a"},
+		{".a:after{content:'\x93\xfa'}", "text/css; charset=utf-8", "utf-8", ".a:after{content:'\ufffd\ufffd'}"},
+		// Malformed sequences are replaced by a U+FFFD each, as long as
+		// they could be the start of a valid sequence.
+		{"a\xe2\x82b\xf0\x9f\x98\xed\xa0\x80\xc0\x80", "", "utf-8", "a\ufffdb\ufffd\ufffd\ufffd\ufffd\ufffd\ufffd"},
+		{"\xff\xfea\x00\x00\xd8b", "", "utf-16le", "a\ufffd\ufffd"},
+	}
+	for _, tc := range tcs {
+		s, enc, err := OpenStylesheet(strings.NewReader(tc.input), tc.contentType)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.input, err)
+			continue
+		}
+		if enc != tc.encoding || s.Input() != tc.expected {
+			t.Errorf("%q: got %q decoded as %q, want %q decoded as %q", tc.input, enc, s.Input(), tc.encoding, tc.expected)
+		}
+	}
+	if _, _, err := OpenStylesheet(iotest.ErrReader(io.ErrUnexpectedEOF), ""); err != io.ErrUnexpectedEOF {
+		t.Errorf("got error %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+func FuzzSplitRules(f *testing.F) {
+	f.Add("a{}b{color:red}")
+	f.Add("@import url(a{b});a{}")
+	f.Fuzz(func(t *testing.T, input string) {
+		checkParallel(t, input)
+		checkParseRules(t, input)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	inputs := append([]string{"a { x: url(a/*b) }", "a { x: \\\" }", "\uFEFFa{}\n b{ x: 'c\td }"}, splitInputs...)
+	for _, input := range inputs {
+		var want []*ParseError
+		if _, err := ParseStylesheet(input); err != nil {
+			want = []*ParseError{err.(*ParseError)}
+		}
+		if got := Validate(strings.NewReader(input)); !reflect.DeepEqual(got, want) {
+			t.Errorf("%q: got %v, want %v", input, got, want)
+		}
+	}
+	for name, input := range corpus(t) {
+		if got := Validate(strings.NewReader(input)); got != nil {
+			t.Errorf("%s: got %v, want no error", name, got)
+		}
+	}
+	want := []*ParseError{{Msg: iotest.ErrTimeout.Error(), Err: iotest.ErrTimeout}}
+	if got := Validate(iotest.TimeoutReader(strings.NewReader("a{}"))); !reflect.DeepEqual(got, want) {
+		t.Errorf("read error: got %v, want %v", got, want)
+	}
+}
+
+func TestArena(t *testing.T) {
+	var a Arena
+	for name, input := range corpus(t) {
+		want, err := ParseStylesheet(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := a.ParseStylesheet(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: the stylesheets differ", name)
+		}
+	}
+	a.Release()
+
+	// Lists allocated from the arena don't overlap.
+	s, err := a.ParseStylesheet("a b { c: d e } f g { h: i }")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Rules[0].Prelude = append(s.Rules[0].Prelude, s.Rules[1].Prelude...)
+	s.Rules[0].Declarations[0].Value = append(s.Rules[0].Declarations[0].Value, s.Rules[1].Prelude...)
+	if got, want := s.String(), "a b/**/f g{c:d e/**/f g}f g{h:i}"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestParser(t *testing.T) {
+	var p Parser
+	for _, input := range []string{"a { color: red }", "@media print { b { c: d } }", `a { content: "x }`, "e{f:g(h)}"} {
+		want, wantErr := ParseStylesheet(input)
+		got, err := p.ParseStylesheet(input)
+		if !reflect.DeepEqual(got, want) || !reflect.DeepEqual(err, wantErr) {
+			t.Errorf("%q: got %v, %v, want %v, %v", input, got, err, want, wantErr)
+		}
+	}
+	values, err := p.ParseComponentValues("rgb(0 0 0) [a]")
+	if err != nil || ValuesString(values) != "rgb(0 0 0) [a]" {
+		t.Errorf("got %q, %v", ValuesString(values), err)
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		p.ParseStylesheet("a { color: red }")
+	})
+	if want := testing.AllocsPerRun(100, func() {
+		ParseStylesheet("a { color: red }")
+	}); allocs >= want {
+		t.Errorf("got %v allocations, want less than %v", allocs, want)
+	}
+}
+
+func TestParserLimits(t *testing.T) {
+	tcs := []struct {
+		limits   Limits
+		input    string
+		expected string
+	}{
+		{Limits{MaxDepth: 3}, "a { b: c((([x]))) }", "css: MaxDepth of 3 exceeded (line: 1, column: 11)"},
+		{Limits{MaxDepth: 3}, "@media a { @supports b { c { d { e: f } } } }", "css: MaxDepth of 3 exceeded (line: 1, column: 32)"},
+		{Limits{MaxDepth: 3}, "@media a { @supports b { c { d: e } } }", ""},
+		{Limits{MaxRules: 2}, "a {} b { c {} }", "css: MaxRules of 2 exceeded (line: 1, column: 10)"},
+		{Limits{MaxRules: 2}, "a {}\n@media b {} @c;", "css: MaxRules of 2 exceeded (line: 2, column: 13)"},
+		{Limits{MaxDeclarations: 2}, "a { b: c; d: e } f { g: h }", "css: MaxDeclarations of 2 exceeded (line: 1, column: 22)"},
+		{Limits{MaxSelectorLength: 5}, "abcde {} a b c d {}", "css: MaxSelectorLength of 5 exceeded (line: 1, column: 10)"},
+		{Limits{MaxSelectorLength: 5}, "a { b c d e f {} }", "css: MaxSelectorLength of 5 exceeded (line: 1, column: 5)"},
+		{Limits{MaxDepth: 1, MaxRules: 1, MaxDeclarations: 1, MaxSelectorLength: 1}, "a { b: c }", ""},
+	}
+	for _, tc := range tcs {
+		p := Parser{Limits: tc.limits}
+		s, err := p.ParseStylesheet(tc.input)
+		if tc.expected == "" {
+			if err != nil {
+				t.Errorf("%s: got %v", tc.input, err)
+			}
+			continue
+		}
+		if _, ok := err.(*LimitExceededError); !ok || !errors.Is(err, ErrLimitExceeded) || s != nil || err.Error() != tc.expected {
+			t.Errorf("%s: got %v, want %s", tc.input, err, tc.expected)
+		}
+	}
+	// Deep nesting fails fast instead of recursing.
+	p := Parser{Limits: Limits{MaxDepth: 64}}
+	if _, err := p.ParseComponentValues(strings.Repeat("(", 1e6)); err == nil {
+		t.Error("got no error for deep nesting")
+	}
+
+	// The default limits accept real-world stylesheets.
+	p = Parser{Limits: DefaultLimits}
+	for name, input := range corpus(t) {
+		if _, err := p.ParseStylesheet(input); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+	got := Limits{MaxDepth: 3, MaxRules: -1}.WithDefaults(DefaultLimits)
+	want := Limits{3, -1, DefaultLimits.MaxDeclarations, DefaultLimits.MaxSelectorLength}
+	if got != want {
+		t.Errorf("WithDefaults: got %+v, want %+v", got, want)
+	}
+}
+
+func TestParserOnWarning(t *testing.T) {
+	tcs := []struct {
+		limits   Limits
+		input    string
+		expected []string
+	}{
+		{Limits{}, "a { color: red; b { c: d } }", nil},
+		{Limits{}, "a { 1px; color red; --x: 1; b: c }\nd { e: f } g", []string{
+			"warning: declaration dropped: expected a property name [invalid-declaration] (line: 1, column: 5)",
+			`warning: declaration dropped: expected a colon after "color" [invalid-declaration] (line: 1, column: 10)`,
+			"warning: rule dropped: the input ends before its block [incomplete-rule] (line: 2, column: 12)",
+		}},
+		{Limits{}, "@media print { a { b } }", []string{
+			`warning: declaration dropped: expected a colon after "b" [invalid-declaration] (line: 1, column: 20)`,
+		}},
+		// Nothing is reported once parsing failed.
+		{Limits{}, "a { b 'c }", nil},
+		{Limits{MaxDepth: 1}, "a { b { c } }", nil},
+	}
+	for _, tc := range tcs {
+		var got []string
+		p := Parser{Limits: tc.limits, OnWarning: func(d diag.Diagnostic) {
+			got = append(got, d.String())
+		}}
+		p.ParseStylesheet(tc.input)
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("%q:\ngot  %q\nwant %q", tc.input, got, tc.expected)
+		}
+	}
+}
+
+func TestExtractURLs(t *testing.T) {
+	s, err := ParseStylesheet(`@import url(a.css) screen;
+@import "b\2e css";
+@namespace svg url(http://www.w3.org/2000/svg);
+@font-face { src: url(c.woff2) format("woff2"), local(x) }
+a {
+  background: url( "d.png" ), image-set('e.png' 1x, url(f.png) 2x);
+  -webkit-mask-image: -webkit-image-set("g.png" 1x);
+  cursor: url(h.cur), pointer;
+  filter: url(#i);
+  --j: url(j.png);
+  b { list-style: url("k" x) }
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []URLRef{
+		{"a.css", "url(a.css)", URLImport, "", 1, 9},
+		{"b.css", `"b\2e css"`, URLImport, "", 2, 9},
+		{"c.woff2", "url(c.woff2)", URLFont, "src", 4, 19},
+		{"d.png", `url( "d.png" )`, URLImage, "background", 6, 15},
+		{"e.png", "'e.png'", URLImage, "background", 6, 41},
+		{"f.png", "url(f.png)", URLImage, "background", 6, 53},
+		{"g.png", `"g.png"`, URLImage, "-webkit-mask-image", 7, 41},
+		{"h.cur", "url(h.cur)", URLCursor, "cursor", 8, 11},
+		{"#i", "url(#i)", URLOther, "filter", 9, 11},
+		{"j.png", "url(j.png)", URLOther, "--j", 10, 8},
+		{"k", `"k"`, URLImage, "list-style", 11, 23},
+	}
+	got := ExtractURLs(s)
+	if len(got) != len(want) {
+		t.Fatalf("got %d URLs, want %d: %v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %+v, want %+v", got[i], want[i])
+		}
+	}
+}
+
+func TestParseDataURL(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected DataURL
+		ok       bool
+	}{
+		{"data:image/png;base64,iVBORw0KGgo=", DataURL{"image/png", true, 8}, true},
+		{"DATA:Image/SVG+XML;charset=utf-8,%3Csvg%3E", DataURL{"image/svg+xml", false, 5}, true},
+		{" data:;base64,YW Jj\nZA%3d%3D", DataURL{"text/plain", true, 4}, true},
+		{"data:,a%2", DataURL{"text/plain", false, 3}, true},
+		{"data:text/css", DataURL{}, false},
+		{"https://a/data:,", DataURL{}, false},
+	}
+	for _, tc := range tcs {
+		got, ok := ParseDataURL(tc.input)
+		if got != tc.expected || ok != tc.ok {
+			t.Errorf("%q: got %+v, %v, want %+v, %v", tc.input, got, ok, tc.expected, tc.ok)
+		}
+	}
+}
+
+func TestClone(t *testing.T) {
+	s, err := ParseStylesheet("a { color: rgb(0, 0, 0); b { margin: 0 } }")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := s.Rules[0]
+	c := r.Clone()
+	c.Declarations[0].Value[0].Children[0].Token.Value = "255"
+	c.Rules[0].Declarations[0].Property = "padding"
+	if got, want := r.String(), "a{color:rgb(0, 0, 0);b{margin:0}}"; got != want {
+		t.Errorf("original modified: got=%q, want=%q", got, want)
+	}
+	if got, want := c.String(), "a{color:rgb(255, 0, 0);b{padding:0}}"; got != want {
+		t.Errorf("clone: got=%q, want=%q", got, want)
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	for name, input := range corpus(t) {
+		s, err := ParseStylesheet(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := s.MarshalBinary()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		var got Stylesheet
+		if err := got.UnmarshalBinary(data); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !reflect.DeepEqual(got.Rules, s.Rules) {
+			t.Errorf("%s: the stylesheet doesn't round-trip", name)
+		}
+	}
+
+	s, err := ParseStylesheet("a { color: red }")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	version := append([]byte(nil), data...)
+	version[len(binaryMagic)]++
+	flipped := append([]byte(nil), data...)
+	flipped[len(data)/2] ^= 1
+	tcs := []struct {
+		desc     string
+		input    []byte
+		expected error
+	}{
+		{"empty", nil, scanner.ErrCorrupt},
+		{"tokens", scanner.MarshalTokens(nil), scanner.ErrCorrupt},
+		{"version", version, scanner.ErrBinaryVersion},
+		{"truncated", data[:len(data)-1], scanner.ErrCorrupt},
+		{"flipped", flipped, scanner.ErrCorrupt},
+	}
+	for _, tc := range tcs {
+		if err := new(Stylesheet).UnmarshalBinary(tc.input); !errors.Is(err, tc.expected) {
+			t.Errorf("%s: got %v, want %v", tc.desc, err, tc.expected)
+		}
+	}
+}
+
+func TestSniff(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		input   string
+		css     bool
+		profile Profile
+	}{
+		{"rules", "a { color: red }\nb:hover, c > d::before { margin: 0 }", true, Profile{}},
+		{"empty", " /* nothing yet */ ", true, Profile{}},
+		{"declarations", "color: red; --x: 1;", false, Profile{}},
+		{"features", "@import url(a.css) layer(base);\n@container (width > 1px) { a { --c: 0; &:hover { color: red } } }", true,
+			Profile{Nesting: true, Layers: true, ContainerQueries: true, CustomProperties: true, Imports: true}},
+		{"hacks", "a { *zoom: 1; _height: 1px; filter: progid:DXImageTransform.Microsoft.Alpha(opacity=50) }", true, Profile{}},
+		{"selectors", "a[href^='http'], :is(b, c) d:not(.e) { color: red }", true, Profile{}},
+		{"utf-16", "\xff\xfea\x00{\x00}\x00", true, Profile{}},
+		{"cut", strings.Repeat("a { color: red }\n", 1000) + "b { content: 'unclosed", true, Profile{}},
+		{"html", "\n<!DOCTYPE html><style>a { color: red }</style>", false, Profile{}},
+		{"xml", "<?xml version=\"1.0\"?><a/>", false, Profile{}},
+		{"javascript", "function f(a) {\n  return a + 1;\n}\n", false, Profile{}},
+		{"javascript assignment", "const style = {color: 'red'};\nexport default style;", false, Profile{}},
+		{"javascript comment", "// a { color: red }\nwindow.x = 1;", false, Profile{}},
+		{"json", `{"a": {"b": [1, 2]}, "c": "d"}`, false, Profile{}},
+		{"text", "Hello world. This is a text file.", false, Profile{}},
+		{"binary", "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR", false, Profile{}},
+		{"stray brace", "} a { color: red } } }", false, Profile{}},
+	}
+	for _, tc := range tcs {
+		ok, profile := Sniff(strings.NewReader(tc.input))
+		if ok != tc.css || profile != tc.profile {
+			t.Errorf("%s: got %t, %+v, want %t, %+v", tc.desc, ok, profile, tc.css, tc.profile)
+		}
+	}
+}
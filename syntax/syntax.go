@@ -0,0 +1,248 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package gorilla/css/syntax compiles syntax descriptors, as used by the
+@property rule, and validates component values against them.
+
+It follows the CSS Properties and Values API specification located at:
+
+	https://www.w3.org/TR/css-properties-values-api-1/#syntax-strings
+
+A descriptor is either the universal syntax "*" or a list of components
+separated by "|". Each component is a data type name such as "<length>" or a
+keyword, optionally followed by a "+" (space-separated list) or "#"
+(comma-separated list) multiplier:
+
+	s := syntax.MustCompile("<length> | auto")
+	s.MatchString("10px") // true
+	s.MatchString("auto") // true
+	s.MatchString("red")  // false
+*/
+package syntax
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/scanner"
+)
+
+// multiplier is the multiplier of a syntax component.
+type multiplier byte
+
+const (
+	single    multiplier = 0
+	spaceList multiplier = '+'
+	commaList multiplier = '#'
+)
+
+// component is a single data type or keyword of a syntax descriptor.
+type component struct {
+	// name is the data type name, without angle brackets, or the keyword.
+	name string
+	// match is the data type matcher. It is nil for keywords.
+	match matcher
+	mult  multiplier
+}
+
+// Syntax is a compiled syntax descriptor.
+type Syntax struct {
+	source     string
+	universal  bool
+	components []component
+}
+
+// Compile parses a syntax descriptor and returns a Syntax that can be used
+// to validate values.
+func Compile(descriptor string) (*Syntax, error) {
+	s := &Syntax{source: descriptor}
+	d := strings.TrimSpace(descriptor)
+	if d == "*" {
+		s.universal = true
+		return s, nil
+	}
+	if d == "" {
+		return nil, fmt.Errorf("syntax: empty descriptor")
+	}
+	for _, part := range strings.Split(d, "|") {
+		c, err := compileComponent(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		s.components = append(s.components, c)
+	}
+	return s, nil
+}
+
+// MustCompile is like Compile but panics if the descriptor can't be parsed.
+func MustCompile(descriptor string) *Syntax {
+	s, err := Compile(descriptor)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// compileComponent parses a single component of a descriptor.
+func compileComponent(part string) (component, error) {
+	var c component
+	if part == "" {
+		return c, fmt.Errorf("syntax: empty component")
+	}
+	switch multiplier(part[len(part)-1]) {
+	case spaceList, commaList:
+		c.mult = multiplier(part[len(part)-1])
+		part = part[:len(part)-1]
+	}
+	if strings.HasPrefix(part, "<") && strings.HasSuffix(part, ">") {
+		c.name = part[1 : len(part)-1]
+		if c.name == "transform-list" {
+			// <transform-list> is a pre-multiplied <transform-function>+.
+			if c.mult != single {
+				return c, fmt.Errorf("syntax: %q can't have a multiplier", part)
+			}
+			c.mult = spaceList
+		}
+		c.match = dataTypes[c.name]
+		if c.match == nil {
+			return c, fmt.Errorf("syntax: unknown data type %q", part)
+		}
+		return c, nil
+	}
+	// A keyword must be a single identifier which isn't a CSS-wide keyword.
+	tokens, err := css.ParseComponentValues(part)
+	if err != nil || len(tokens) != 1 || tokens[0].Token.Type != scanner.TokenIdent {
+		return c, fmt.Errorf("syntax: invalid component %q", part)
+	}
+	if isWideKeyword(part) {
+		return c, fmt.Errorf("syntax: invalid keyword %q", part)
+	}
+	c.name = part
+	return c, nil
+}
+
+// String returns the source descriptor used to compile the syntax.
+func (s *Syntax) String() string {
+	return s.source
+}
+
+// MatchString parses value as a list of component values and reports whether
+// it matches the syntax. Values that can't be parsed never match.
+func (s *Syntax) MatchString(value string) bool {
+	values, err := css.ParseComponentValues(value)
+	if err != nil {
+		return false
+	}
+	return s.Match(values)
+}
+
+// Match reports whether the list of component values matches the syntax.
+//
+// Leading and trailing whitespace is ignored. CSS-wide keywords such as
+// "inherit" match any syntax, and so do values containing var() or env()
+// references because they can only be checked after substitution.
+func (s *Syntax) Match(values []*css.ComponentValue) bool {
+	values = css.TrimSpace(values)
+	if s.universal {
+		return true
+	}
+	if len(values) == 0 {
+		return false
+	}
+	if len(values) == 1 && values[0].Token.Type == scanner.TokenIdent &&
+		isWideKeyword(values[0].Token.Value) {
+		return true
+	}
+	if hasSubstitution(values) {
+		return true
+	}
+	for _, c := range s.components {
+		if c.matchList(values) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchList reports whether the values match the component, taking the
+// multiplier into account.
+func (c component) matchList(values []*css.ComponentValue) bool {
+	switch c.mult {
+	case spaceList:
+		items := splitSpace(values)
+		for _, item := range items {
+			if !c.matchOne(item) {
+				return false
+			}
+		}
+		return len(items) > 0
+	case commaList:
+		for _, item := range splitComma(values) {
+			item = css.TrimSpace(item)
+			if len(item) != 1 || !c.matchOne(item[0]) {
+				return false
+			}
+		}
+		return true
+	}
+	return len(values) == 1 && c.matchOne(values[0])
+}
+
+// matchOne reports whether a single value matches the component.
+func (c component) matchOne(v *css.ComponentValue) bool {
+	if c.match == nil {
+		return v.Token.Type == scanner.TokenIdent && strings.EqualFold(v.Token.Value, c.name)
+	}
+	return c.match(v)
+}
+
+// splitSpace returns the values that are not whitespace.
+func splitSpace(values []*css.ComponentValue) []*css.ComponentValue {
+	var items []*css.ComponentValue
+	for _, v := range values {
+		if v.Token.Type != scanner.TokenS {
+			items = append(items, v)
+		}
+	}
+	return items
+}
+
+// splitComma splits the values at top-level commas.
+func splitComma(values []*css.ComponentValue) [][]*css.ComponentValue {
+	var items [][]*css.ComponentValue
+	start := 0
+	for i, v := range values {
+		if v.Token.Type == scanner.TokenChar && v.Token.Value == "," {
+			items = append(items, values[start:i])
+			start = i + 1
+		}
+	}
+	return append(items, values[start:])
+}
+
+// isWideKeyword reports whether s is a CSS-wide keyword.
+func isWideKeyword(s string) bool {
+	switch strings.ToLower(s) {
+	case "initial", "inherit", "unset", "revert", "revert-layer":
+		return true
+	}
+	return false
+}
+
+// hasSubstitution reports whether the values contain a var() or env()
+// function at any depth.
+func hasSubstitution(values []*css.ComponentValue) bool {
+	for _, v := range values {
+		switch strings.ToLower(v.Name()) {
+		case "var", "env":
+			return true
+		}
+		if hasSubstitution(v.Children) {
+			return true
+		}
+	}
+	return false
+}
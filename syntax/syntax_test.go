@@ -0,0 +1,89 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package syntax
+
+import (
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	tcs := []struct {
+		syntax string
+		value  string
+		match  bool
+	}{
+		{"*", "anything { at all }", true},
+		{"<length>", "10px", true},
+		{"<length>", "-1.5em", true},
+		{"<length>", "0", true},
+		{"<length>", "10", false},
+		{"<length>", "10%", false},
+		{"<length>", "calc(1px + 2em)", true},
+		{"<length>", "10px 10px", false},
+		{"<length> | auto", "auto", true},
+		{"<length> | auto", "AUTO", true},
+		{"<length> | auto", "none", false},
+		{"<length>+", "1px 2px  3px", true},
+		{"<length>+", "1px, 2px", false},
+		{"<length>#", "1px, 2px ,3px", true},
+		{"<length>#", "1px 2px", false},
+		{"<length>#", "1px,", false},
+		{"<length-percentage>", "50%", true},
+		{"<percentage>", "50px", false},
+		{"<number>", "1.5", true},
+		{"<integer>", "3", true},
+		{"<integer>", "3.5", false},
+		{"<angle>", "90deg", true},
+		{"<angle>", "1TURN", true},
+		{"<time>", "200ms", true},
+		{"<resolution>", "2dppx", true},
+		{"<color>", "#fff", true},
+		{"<color>", "#ffff", true},
+		{"<color>", "#fffff", false},
+		{"<color>", "#ggg", false},
+		{"<color>", "RebeccaPurple", true},
+		{"<color>", "currentColor", true},
+		{"<color>", "rgb(0 0 0 / 50%)", true},
+		{"<color>", "notacolor", false},
+		{"<color>#", "red, blue", true},
+		{"<image>", "url(a.png)", true},
+		{"<image>", "linear-gradient(red, blue)", true},
+		{"<url>", `url("a.png")`, true},
+		{"<string>", `"a"`, true},
+		{"<custom-ident>", "foo", true},
+		{"<custom-ident>", "default", false},
+		{"<transform-function>", "rotate(10deg)", true},
+		{"<transform-list>", "rotate(10deg) scale(2)", true},
+		{"<transform-list>", "rotate(10deg) 2", false},
+		{"big | bigger | BIGGEST", "biggest", true},
+		{"<length>", "inherit", true},
+		{"<length>", "var(--x)", true},
+		{"<length>", "calc(var(--x) * 2)", true},
+		{"<length>", "", false},
+		{"<string>", `"unclosed`, false},
+	}
+	for _, tc := range tcs {
+		s := MustCompile(tc.syntax)
+		if got := s.MatchString(tc.value); got != tc.match {
+			t.Errorf("%q matching %q: got %v, want %v", tc.syntax, tc.value, got, tc.match)
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, d := range []string{
+		"",
+		"<length> |",
+		"<foo>",
+		"<transform-list>+",
+		"inherit",
+		"a b",
+		"10px",
+	} {
+		if _, err := Compile(d); err == nil {
+			t.Errorf("%q: expected an error", d)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by internal/gen from internal/gen/data. DO NOT EDIT.
+
+package syntax
+
+// namedColors is the set of color keywords, including the special keywords
+// "transparent" and "currentcolor" and the system colors.
+var namedColors = map[string]bool{
+	"accentcolor": true, "accentcolortext": true, "activetext": true,
+	"aliceblue": true, "antiquewhite": true, "aqua": true,
+	"aquamarine": true, "azure": true, "beige": true, "bisque": true,
+	"black": true, "blanchedalmond": true, "blue": true, "blueviolet": true,
+	"brown": true, "burlywood": true, "buttonborder": true,
+	"buttonface": true, "buttontext": true, "cadetblue": true,
+	"canvas": true, "canvastext": true, "chartreuse": true,
+	"chocolate": true, "coral": true, "cornflowerblue": true,
+	"cornsilk": true, "crimson": true, "currentcolor": true, "cyan": true,
+	"darkblue": true, "darkcyan": true, "darkgoldenrod": true,
+	"darkgray": true, "darkgreen": true, "darkgrey": true,
+	"darkkhaki": true, "darkmagenta": true, "darkolivegreen": true,
+	"darkorange": true, "darkorchid": true, "darkred": true,
+	"darksalmon": true, "darkseagreen": true, "darkslateblue": true,
+	"darkslategray": true, "darkslategrey": true, "darkturquoise": true,
+	"darkviolet": true, "deeppink": true, "deepskyblue": true,
+	"dimgray": true, "dimgrey": true, "dodgerblue": true, "field": true,
+	"fieldtext": true, "firebrick": true, "floralwhite": true,
+	"forestgreen": true, "fuchsia": true, "gainsboro": true,
+	"ghostwhite": true, "gold": true, "goldenrod": true, "gray": true,
+	"graytext": true, "green": true, "greenyellow": true, "grey": true,
+	"highlight": true, "highlighttext": true, "honeydew": true,
+	"hotpink": true, "indianred": true, "indigo": true, "ivory": true,
+	"khaki": true, "lavender": true, "lavenderblush": true,
+	"lawngreen": true, "lemonchiffon": true, "lightblue": true,
+	"lightcoral": true, "lightcyan": true, "lightgoldenrodyellow": true,
+	"lightgray": true, "lightgreen": true, "lightgrey": true,
+	"lightpink": true, "lightsalmon": true, "lightseagreen": true,
+	"lightskyblue": true, "lightslategray": true, "lightslategrey": true,
+	"lightsteelblue": true, "lightyellow": true, "lime": true,
+	"limegreen": true, "linen": true, "linktext": true, "magenta": true,
+	"mark": true, "marktext": true, "maroon": true,
+	"mediumaquamarine": true, "mediumblue": true, "mediumorchid": true,
+	"mediumpurple": true, "mediumseagreen": true, "mediumslateblue": true,
+	"mediumspringgreen": true, "mediumturquoise": true,
+	"mediumvioletred": true, "midnightblue": true, "mintcream": true,
+	"mistyrose": true, "moccasin": true, "navajowhite": true, "navy": true,
+	"oldlace": true, "olive": true, "olivedrab": true, "orange": true,
+	"orangered": true, "orchid": true, "palegoldenrod": true,
+	"palegreen": true, "paleturquoise": true, "palevioletred": true,
+	"papayawhip": true, "peachpuff": true, "peru": true, "pink": true,
+	"plum": true, "powderblue": true, "purple": true, "rebeccapurple": true,
+	"red": true, "rosybrown": true, "royalblue": true, "saddlebrown": true,
+	"salmon": true, "sandybrown": true, "seagreen": true, "seashell": true,
+	"selecteditem": true, "selecteditemtext": true, "sienna": true,
+	"silver": true, "skyblue": true, "slateblue": true, "slategray": true,
+	"slategrey": true, "snow": true, "springgreen": true, "steelblue": true,
+	"tan": true, "teal": true, "thistle": true, "tomato": true,
+	"transparent": true, "turquoise": true, "violet": true,
+	"visitedtext": true, "wheat": true, "white": true, "whitesmoke": true,
+	"yellow": true, "yellowgreen": true,
+}
+
+// lengthUnits is the set of length units.
+var lengthUnits = map[string]bool{
+	"cap": true, "ch": true, "cm": true, "cqb": true, "cqh": true,
+	"cqi": true, "cqmax": true, "cqmin": true, "cqw": true, "dvb": true,
+	"dvh": true, "dvi": true, "dvmax": true, "dvmin": true, "dvw": true,
+	"em": true, "ex": true, "ic": true, "in": true, "lh": true, "lvb": true,
+	"lvh": true, "lvi": true, "lvmax": true, "lvmin": true, "lvw": true,
+	"mm": true, "pc": true, "pt": true, "px": true, "q": true, "rcap": true,
+	"rch": true, "rem": true, "rex": true, "ric": true, "rlh": true,
+	"svb": true, "svh": true, "svi": true, "svmax": true, "svmin": true,
+	"svw": true, "vb": true, "vh": true, "vi": true, "vmax": true,
+	"vmin": true, "vw": true,
+}
+
+// angleUnits is the set of angle units.
+var angleUnits = map[string]bool{
+	"deg": true, "grad": true, "rad": true, "turn": true,
+}
+
+// timeUnits is the set of time units.
+var timeUnits = map[string]bool{
+	"ms": true, "s": true,
+}
+
+// resolutionUnits is the set of resolution units.
+var resolutionUnits = map[string]bool{
+	"dpcm": true, "dpi": true, "dppx": true, "x": true,
+}
@@ -0,0 +1,175 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package syntax
+
+import (
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/scanner"
+)
+
+// matcher reports whether a single component value belongs to a data type.
+type matcher func(v *css.ComponentValue) bool
+
+// dataTypes maps the supported data type names to their matchers.
+var dataTypes = map[string]matcher{
+	"angle":              dimensionOf(angleUnits),
+	"color":              isColor,
+	"custom-ident":       isCustomIdent,
+	"image":              isImage,
+	"integer":            isInteger,
+	"length":             isLength,
+	"length-percentage":  isLengthPercentage,
+	"number":             isNumber,
+	"percentage":         isPercentage,
+	"resolution":         dimensionOf(resolutionUnits),
+	"string":             isString,
+	"time":               dimensionOf(timeUnits),
+	"transform-function": isTransformFunction,
+	"transform-list":     isTransformFunction,
+	"url":                isURL,
+}
+
+// Functions ------------------------------------------------------------------
+
+// mathFunctions can stand for any numeric data type.
+var mathFunctions = set(
+	"calc", "min", "max", "clamp", "round", "mod", "rem", "sin", "cos", "tan",
+	"asin", "acos", "atan", "atan2", "pow", "sqrt", "hypot", "log", "exp",
+	"abs", "sign",
+)
+
+var colorFunctions = set(
+	"rgb", "rgba", "hsl", "hsla", "hwb", "lab", "lch", "oklab", "oklch",
+	"color", "color-mix", "light-dark",
+)
+
+var imageFunctions = set(
+	"linear-gradient", "radial-gradient", "conic-gradient",
+	"repeating-linear-gradient", "repeating-radial-gradient",
+	"repeating-conic-gradient", "image", "image-set", "cross-fade", "element",
+	"-webkit-image-set",
+)
+
+var transformFunctions = set(
+	"matrix", "matrix3d", "perspective",
+	"rotate", "rotate3d", "rotatex", "rotatey", "rotatez",
+	"scale", "scale3d", "scalex", "scaley", "scalez",
+	"skew", "skewx", "skewy",
+	"translate", "translate3d", "translatex", "translatey", "translatez",
+)
+
+// Matchers -------------------------------------------------------------------
+
+// dimensionOf returns a matcher for dimensions with one of the given units or
+// math functions.
+func dimensionOf(units map[string]bool) matcher {
+	return func(v *css.ComponentValue) bool {
+		return units[unit(v)] || isMath(v)
+	}
+}
+
+func isString(v *css.ComponentValue) bool {
+	return v.Token.Type == scanner.TokenString
+}
+
+func isURL(v *css.ComponentValue) bool {
+	return v.Token.Type == scanner.TokenURI
+}
+
+func isNumber(v *css.ComponentValue) bool {
+	return v.Token.Type == scanner.TokenNumber || isMath(v)
+}
+
+func isInteger(v *css.ComponentValue) bool {
+	return v.Token.Type == scanner.TokenNumber && !strings.Contains(v.Token.Value, ".") ||
+		isMath(v)
+}
+
+func isPercentage(v *css.ComponentValue) bool {
+	return v.Token.Type == scanner.TokenPercentage || isMath(v)
+}
+
+// isLength matches lengths, including unitless zero.
+func isLength(v *css.ComponentValue) bool {
+	return lengthUnits[unit(v)] || isZero(v) || isMath(v)
+}
+
+func isLengthPercentage(v *css.ComponentValue) bool {
+	return isLength(v) || isPercentage(v)
+}
+
+// isCustomIdent matches identifiers that can be defined by authors.
+func isCustomIdent(v *css.ComponentValue) bool {
+	return v.Token.Type == scanner.TokenIdent && !isWideKeyword(v.Token.Value) &&
+		!strings.EqualFold(v.Token.Value, "default")
+}
+
+// isColor matches hex colors, named colors and color functions.
+func isColor(v *css.ComponentValue) bool {
+	switch v.Token.Type {
+	case scanner.TokenHash:
+		switch len(v.Token.Value) - 1 {
+		case 3, 4, 6, 8:
+			return isHex(v.Token.Value[1:])
+		}
+		return false
+	case scanner.TokenIdent:
+		return namedColors[strings.ToLower(v.Token.Value)]
+	}
+	return colorFunctions[strings.ToLower(v.Name())]
+}
+
+// isImage matches urls and image functions.
+func isImage(v *css.ComponentValue) bool {
+	return v.Token.Type == scanner.TokenURI || imageFunctions[strings.ToLower(v.Name())]
+}
+
+func isTransformFunction(v *css.ComponentValue) bool {
+	return transformFunctions[strings.ToLower(v.Name())]
+}
+
+func isMath(v *css.ComponentValue) bool {
+	return mathFunctions[strings.ToLower(v.Name())]
+}
+
+// isZero reports whether v is a number equal to zero.
+func isZero(v *css.ComponentValue) bool {
+	return v.Token.Type == scanner.TokenNumber &&
+		strings.Trim(v.Token.Value, "+-0.") == ""
+}
+
+// unit returns the lowercase unit of a dimension, or an empty string if v is
+// not a dimension.
+func unit(v *css.ComponentValue) string {
+	if v.Token.Type != scanner.TokenDimension {
+		return ""
+	}
+	i := strings.IndexFunc(v.Token.Value, func(r rune) bool {
+		return r != '+' && r != '-' && r != '.' && (r < '0' || r > '9')
+	})
+	return strings.ToLower(v.Token.Value[i:])
+}
+
+// isHex reports whether s only has hexadecimal digits.
+func isHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// set returns a set with the given strings.
+func set(s ...string) map[string]bool {
+	m := make(map[string]bool, len(s))
+	for _, v := range s {
+		m[v] = true
+	}
+	return m
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build ignore
+
+// Fetch downloads the fixtures of the css-parsing-tests suite run by
+// TestConformance into the directory of this file. It is run by go
+// generate in the root package.
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// base is the location of the fixtures. It is the latest revision of the
+// suite, not a pinned one, so fetching them again may change the result of
+// TestConformance.
+const base = "https://raw.githubusercontent.com/SimonSapin/css-parsing-tests/HEAD/"
+
+// files are the fixtures run by TestConformance.
+var files = []string{
+	"component_value_list.json",
+	"one_component_value.json",
+	"declaration_list.json",
+	"one_declaration.json",
+	"rule_list.json",
+	"one_rule.json",
+	"stylesheet.json",
+}
+
+func main() {
+	dir := filepath.Join("testdata", "css-parsing-tests")
+	for _, f := range files {
+		if err := fetch(base+f, filepath.Join(dir, f)); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// fetch downloads url to the file name.
+func fetch(url, name string) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, b, 0o644)
+}
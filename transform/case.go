@@ -0,0 +1,78 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transform
+
+import (
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/props"
+	"github.com/gorilla/css/scanner"
+)
+
+// Lowercase converts to lowercase the at-rule names, property names, keyword
+// values, function names and unit suffixes of a stylesheet.
+//
+// Strings, urls, custom property names and values, and preludes are left
+// untouched, and so are selectors, which may contain case-sensitive class
+// names. Identifiers are only lowercased in the values of known properties
+// that do not accept author-defined identifiers, such as animation names.
+func Lowercase(s *css.Stylesheet) {
+	lowercaseRules(s.Rules)
+}
+
+// lowercaseRules lowercases a list of rules and their contents.
+func lowercaseRules(rules []*css.Rule) {
+	for _, r := range rules {
+		r.AtKeyword = strings.ToLower(r.AtKeyword)
+		for _, d := range r.Declarations {
+			LowercaseDeclaration(d)
+		}
+		lowercaseRules(r.Rules)
+	}
+}
+
+// LowercaseDeclaration is like Lowercase but for a single declaration.
+func LowercaseDeclaration(d *css.Declaration) {
+	if isCustomProperty(d.Property) {
+		return
+	}
+	d.Property = strings.ToLower(d.Property)
+	p := props.Lookup(d.Property)
+	lowercaseValues(d.Value, p != nil && !p.CustomIdents)
+}
+
+// lowercaseValues lowercases function names and units in values, and
+// identifiers if idents is true.
+func lowercaseValues(values []*css.ComponentValue, idents bool) {
+	for _, v := range values {
+		t := v.Token
+		switch t.Type {
+		case scanner.TokenIdent:
+			if idents && !isCustomProperty(t.Value) {
+				t.Value = strings.ToLower(t.Value)
+			}
+		case scanner.TokenFunction:
+			if !isCustomProperty(t.Value) {
+				t.Value = strings.ToLower(t.Value)
+			}
+		case scanner.TokenDimension:
+			t.Value = lowercaseUnit(t.Value)
+		}
+		lowercaseValues(v.Children, idents)
+	}
+}
+
+// lowercaseUnit lowercases the unit of a dimension, leaving the number as is.
+func lowercaseUnit(dim string) string {
+	i := strings.TrimLeft(dim, "+-.0123456789")
+	return dim[:len(dim)-len(i)] + strings.ToLower(i)
+}
+
+// isCustomProperty reports whether name is a custom property name, a
+// dashed identifier or a custom function.
+func isCustomProperty(name string) bool {
+	return strings.HasPrefix(name, "--")
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package gorilla/css/transform provides transforms that rewrite a parsed
+stylesheet in place.
+
+Lowercase normalizes the case of the parts of a stylesheet that CSS treats as
+ASCII case-insensitive, so that equivalent stylesheets serialize identically:
+
+	sheet, _ := css.ParseStylesheet("A { COLOR: RED; Width: 10PX }")
+	transform.Lowercase(sheet)
+	sheet.String() // "A{color:red;width:10px}"
+
+ToPhysical replaces flow-relative properties, such as margin-inline-start,
+with their physical equivalents for a writing mode and direction, for
+renderers that only support physical properties. ToLogical does the opposite.
+ReplaceInitial replaces the "initial" keyword with the concrete initial value
+of the property, for renderers without cascade support, and ReplaceUnset
+replaces "unset" with "inherit" or the initial value depending on whether the
+property is inherited.
+
+FilterProperties removes the declarations whose property is denied, or isn't
+allowed, by a PropertyFilter. It is lighter than the sanitize package, which
+also checks values, at-rules and selectors, and takes shorthands into
+account so that "background" can't set a denied "background-image".
+
+Obfuscate renames the classes, IDs, keyframes and custom properties of
+stylesheets to short generated names, and returns the mapping to apply to
+the HTML and scripts using them.
+
+RemoveEmptyRules removes the rules with an empty block, including those
+left empty by other transforms, and then the rules that only held them.
+
+MergeMediaRules merges the @media rules with the same queries, compared
+with the media package rather than as text, where it doesn't change the
+cascade. With MediaOptions.Hoist, it also unwraps and combines nested
+@media rules:
+
+	@media print { a { top: 0 } } @media PRINT { b { top: 0 } } // @media print{a{top:0}b{top:0}}
+
+PruneConditionalRules generates the stylesheet of a fixed environment, such
+as a device, removing the @media and @supports rules that never apply there
+and unwrapping those that always do:
+
+	transform.PruneConditionalRules(sheet, transform.PruneOptions{
+		Media: &media.Env{Type: "screen", Features: map[string]string{"width": "390px"}},
+	})
+
+PurgeRules removes the selectors, and the rules, that can't match because
+they reference classes, IDs or elements that the documents using a
+stylesheet don't use, such as those collected from their templates, and
+reports the bytes saved:
+
+	report := transform.PurgeRules(sheet, transform.PurgeOptions{
+		Classes:  []string{"btn", "nav"},
+		Safelist: []*regexp.Regexp{regexp.MustCompile(`^\.js-`)},
+	})
+
+RewriteURLs replaces the URLs referenced by a stylesheet, such as to serve
+the images from a CDN, and ResolveURLs makes the relative ones absolute.
+
+A Pipeline runs a sequence of transforms, like the plugins of PostCSS,
+sharing settings such as the target browsers and the base URL of the
+stylesheets, and returns the time each transform took and the diagnostics
+it reported:
+
+	p := &transform.Pipeline{}
+	p.Targets = prefix.Targets{prefix.Safari: 14}
+	p.Add("prefix", transform.Prefix())
+	p.Add("minify", transform.Minify(minify.Options{}))
+	results, err := p.Run(sheet)
+
+Prefix, StripPrefixes, Minify, Sanitize, Resolve, RemoveEmpty, MergeMedia,
+PruneConditionals and Purge adapt passes to a Pipeline, and Func and
+ContextFunc adapt functions.
+*/
+package transform
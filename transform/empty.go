@@ -0,0 +1,70 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transform
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/diag"
+)
+
+// RemoveEmptyRules removes the rules of a stylesheet with an empty block,
+// such as "a {}" or "@media print {}", which have no effect, and returns
+// them. The removal cascades upward: a rule whose block only holds empty
+// rules is removed as well, after them, so that "@media print { a {} }" is
+// removed as a whole. Rules emptied by other transforms, such as
+// FilterProperties or a sanitize.Policy, are removed the same way.
+//
+// @layer rules are kept even if their block is empty, since they declare
+// the order of the cascade layers. The comments preceding a removed rule
+// are removed with it.
+func RemoveEmptyRules(s *css.Stylesheet) []*css.Rule {
+	var removed []*css.Rule
+	s.Rules = removeEmpty(s.Rules, &removed)
+	return removed
+}
+
+// removeEmpty removes the empty rules of a list of rules, appending them to
+// removed, and returns the rules kept. The result shares the backing array
+// of rules.
+func removeEmpty(rules []*css.Rule, removed *[]*css.Rule) []*css.Rule {
+	out := rules[:0]
+	for _, r := range rules {
+		if len(r.Rules) > 0 {
+			r.Rules = removeEmpty(r.Rules, removed)
+		}
+		if r.HasBlock && len(r.Declarations) == 0 && len(r.Rules) == 0 && !strings.EqualFold(r.AtKeyword, "layer") {
+			*removed = append(*removed, r)
+			continue
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// RemoveEmpty returns a transform removing the empty rules with
+// RemoveEmptyRules. Each removed rule is reported as a warning with the
+// code "removed-empty-rule". Added after other transforms, it removes the
+// rules they left empty.
+func RemoveEmpty() ContextTransform {
+	return ContextFunc(func(c *Context, s *css.Stylesheet) error {
+		for _, r := range RemoveEmptyRules(s) {
+			name := css.ValuesString(css.TrimSpace(r.Prelude))
+			if r.IsAtRule() {
+				name = "@" + r.AtKeyword
+			}
+			pos := diag.Position{Line: r.Line, Column: r.Column}
+			c.Report(diag.Diagnostic{
+				Code:     "removed-empty-rule",
+				Severity: diag.Warning,
+				Message:  fmt.Sprintf("removed empty rule %q", name),
+				Span:     diag.Span{Start: pos, End: pos},
+			})
+		}
+		return nil
+	})
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transform
+
+import (
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/props"
+	"github.com/gorilla/css/scanner"
+)
+
+// PropertyFilter decides which declarations FilterProperties keeps, by
+// property name. Names are compared once their escape sequences are
+// decoded, so that "b\61 ckground" is background, and in lowercase, except
+// for custom properties, which are case-sensitive.
+//
+// Shorthands and longhands are taken into account, so that a shorthand
+// can't set a property that isn't allowed: denying background-image denies
+// background too, and denying margin denies margin-top. Allowing a
+// shorthand, such as margin, allows its longhands.
+type PropertyFilter struct {
+	// Allow, if not nil, lists the only properties that are kept.
+	Allow []string
+	// Deny lists the properties that are removed, even if they are allowed.
+	Deny []string
+}
+
+// Allowed reports whether the declarations of property are kept.
+func (f *PropertyFilter) Allowed(property string) bool {
+	property = scanner.Unescape(property)
+	if !isCustomProperty(property) {
+		property = strings.ToLower(property)
+	}
+	if f.Allow != nil && !f.allowed(property) {
+		return false
+	}
+	return !f.denied(property, true)
+}
+
+// allowed reports whether property or one of its shorthands is in the allow
+// list.
+func (f *PropertyFilter) allowed(property string) bool {
+	if contains(f.Allow, property) {
+		return true
+	}
+	if p := props.Lookup(property); p != nil {
+		for _, s := range p.Shorthands {
+			if contains(f.Allow, s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// denied reports whether property, one of its longhands, or one of its
+// shorthands if shorthands is true, is in the deny list.
+func (f *PropertyFilter) denied(property string, shorthands bool) bool {
+	if contains(f.Deny, property) {
+		return true
+	}
+	p := props.Lookup(property)
+	if p == nil {
+		return false
+	}
+	for _, l := range p.Longhands {
+		if f.denied(l, false) {
+			return true
+		}
+	}
+	if shorthands {
+		for _, s := range p.Shorthands {
+			if contains(f.Deny, s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Filter returns the declarations of decls whose property is allowed. The
+// result shares the backing array of decls.
+func (f *PropertyFilter) Filter(decls []*css.Declaration) []*css.Declaration {
+	out := decls[:0]
+	for _, d := range decls {
+		if f.Allowed(d.Property) {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// FilterProperties removes the declarations of a stylesheet whose property
+// isn't allowed by f. Rules left without declarations are kept;
+// RemoveEmptyRules removes them.
+func FilterProperties(s *css.Stylesheet, f *PropertyFilter) {
+	walkDeclarations(s.Rules, f.Filter)
+}
+
+// contains reports whether list contains name, ignoring the case of names
+// that aren't custom properties.
+func contains(list []string, name string) bool {
+	for _, n := range list {
+		if n == name || !isCustomProperty(n) && strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transform
+
+import (
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/props"
+	"github.com/gorilla/css/scanner"
+)
+
+// ReplaceInitial replaces the "initial" keyword in the declarations of a
+// stylesheet with the initial value of the property, as in "color: initial"
+// becoming "color: canvastext". Shorthands are expanded into their
+// longhands. Declarations of properties without a fixed initial value are
+// left untouched.
+func ReplaceInitial(s *css.Stylesheet) {
+	walkDeclarations(s.Rules, func(decls []*css.Declaration) []*css.Declaration {
+		var out []*css.Declaration
+		for _, d := range decls {
+			if isKeyword(d.Value, "initial") {
+				if expanded := initialDeclarations(d); expanded != nil {
+					out = append(out, expanded...)
+					continue
+				}
+			}
+			out = append(out, d)
+		}
+		return out
+	})
+}
+
+// ReplaceUnset replaces the "unset" keyword in the declarations of a
+// stylesheet with "inherit" for inherited properties, and with the initial
+// value of the property for the others. Shorthands are expanded into their
+// longhands. Declarations of properties without a fixed initial value are
+// left untouched.
+func ReplaceUnset(s *css.Stylesheet) {
+	walkDeclarations(s.Rules, func(decls []*css.Declaration) []*css.Declaration {
+		var out []*css.Declaration
+		for _, d := range decls {
+			if isKeyword(d.Value, "unset") {
+				if expanded := unsetDeclarations(d); expanded != nil {
+					out = append(out, expanded...)
+					continue
+				}
+			}
+			out = append(out, d)
+		}
+		return out
+	})
+}
+
+// isKeyword reports whether the values consist of the keyword k.
+func isKeyword(values []*css.ComponentValue, k string) bool {
+	values = css.TrimSpace(values)
+	return len(values) == 1 && values[0].Token.Type == scanner.TokenIdent &&
+		strings.EqualFold(values[0].Token.Value, k)
+}
+
+// unsetDeclarations returns the declarations equivalent to unsetting the
+// property of d, or nil if one of them has no fixed initial value.
+func unsetDeclarations(d *css.Declaration) []*css.Declaration {
+	if props.IsInherited(d.Property) {
+		c := d.Clone()
+		c.Value = css.TrimSpace(c.Value)
+		c.Value[0].Token.Value = "inherit"
+		return []*css.Declaration{c}
+	}
+	p := props.Lookup(d.Property)
+	if p == nil || !p.IsShorthand() {
+		return initialDeclarations(d)
+	}
+	var decls []*css.Declaration
+	for _, name := range p.Longhands {
+		c := d.Clone()
+		c.Property = name
+		expanded := unsetDeclarations(c)
+		if expanded == nil {
+			return nil
+		}
+		decls = append(decls, expanded...)
+	}
+	return decls
+}
+
+// initialDeclarations returns the declarations setting the property of d and
+// its longhands to their initial value, or nil if one of them has no fixed
+// initial value.
+func initialDeclarations(d *css.Declaration) []*css.Declaration {
+	if v := props.InitialValue(d.Property); v != nil {
+		c := d.Clone()
+		c.Value = v
+		return []*css.Declaration{c}
+	}
+	p := props.Lookup(d.Property)
+	if p == nil || !p.IsShorthand() {
+		return nil
+	}
+	var decls []*css.Declaration
+	for _, name := range p.Longhands {
+		c := d.Clone()
+		c.Property = name
+		expanded := initialDeclarations(c)
+		if expanded == nil {
+			return nil
+		}
+		decls = append(decls, expanded...)
+	}
+	return decls
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transform
+
+import (
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/props"
+	"github.com/gorilla/css/scanner"
+)
+
+// ToPhysical replaces the flow-relative declarations of a stylesheet with
+// their physical equivalents in the given writing mode and direction.
+//
+// Shorthands of an axis are expanded: with a horizontal writing mode,
+// "margin-block: 1px 2px" becomes "margin-top: 1px; margin-bottom: 2px".
+// Flow-relative keywords, as in "float: inline-start", are left untouched.
+func ToPhysical(s *css.Stylesheet, mode props.WritingMode, dir props.Direction) {
+	walkDeclarations(s.Rules, func(decls []*css.Declaration) []*css.Declaration {
+		var out []*css.Declaration
+		for _, d := range decls {
+			out = append(out, physical(d, mode, dir)...)
+		}
+		return out
+	})
+}
+
+// ToLogical renames the physical longhands of a stylesheet, such as
+// margin-left or width, to their flow-relative equivalents in the given
+// writing mode and direction. Physical shorthands such as margin are left
+// untouched.
+func ToLogical(s *css.Stylesheet, mode props.WritingMode, dir props.Direction) {
+	walkDeclarations(s.Rules, func(decls []*css.Declaration) []*css.Declaration {
+		for _, d := range decls {
+			if !isCustomProperty(d.Property) {
+				if logical := props.Logical(d.Property, mode, dir); logical != "" {
+					d.Property = logical
+				}
+			}
+		}
+		return decls
+	})
+}
+
+// walkDeclarations replaces the declarations of a list of rules and their
+// contents with the result of fn.
+func walkDeclarations(rules []*css.Rule, fn func([]*css.Declaration) []*css.Declaration) {
+	for _, r := range rules {
+		if len(r.Declarations) > 0 {
+			r.Declarations = fn(r.Declarations)
+		}
+		walkDeclarations(r.Rules, fn)
+	}
+}
+
+// physical returns the physical declarations equivalent to d, or d itself if
+// it isn't flow-relative.
+func physical(d *css.Declaration, mode props.WritingMode, dir props.Direction) []*css.Declaration {
+	if isCustomProperty(d.Property) {
+		return []*css.Declaration{d}
+	}
+	names := props.Physical(d.Property, mode, dir)
+	switch len(names) {
+	case 0:
+		return []*css.Declaration{d}
+	case 1:
+		d.Property = names[0]
+		return []*css.Declaration{d}
+	}
+	// Shorthands such as margin-block take a value for each side, and
+	// shorthands such as border-block a value for both.
+	p := props.Lookup(d.Property)
+	start, end := d, d.Clone()
+	start.Property, end.Property = names[0], names[1]
+	if p != nil && strings.HasSuffix(p.Value, "{1,2}") {
+		if parts := splitSpace(d.Value); len(parts) == 2 {
+			start.Value, end.Value = parts[0], splitSpace(end.Value)[1]
+		}
+	}
+	return []*css.Declaration{start, end}
+}
+
+// splitSpace splits values on whitespace at the top level.
+func splitSpace(values []*css.ComponentValue) [][]*css.ComponentValue {
+	var parts [][]*css.ComponentValue
+	var part []*css.ComponentValue
+	for _, v := range values {
+		if v.Token.Type == scanner.TokenS {
+			if part != nil {
+				parts = append(parts, part)
+				part = nil
+			}
+			continue
+		}
+		part = append(part, v)
+	}
+	if part != nil {
+		parts = append(parts, part)
+	}
+	return parts
+}
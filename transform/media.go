@@ -0,0 +1,268 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transform
+
+import (
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/media"
+	"github.com/gorilla/css/prefix"
+	"github.com/gorilla/css/props"
+)
+
+// MediaOptions are the options of MergeMediaRules.
+type MediaOptions struct {
+	// Hoist unwraps the @media rules nested in an @media rule with the same
+	// queries, and combines an @media rule only holding another one into a
+	// single rule, as "@media screen { @media (color) { ... } }" becoming
+	// "@media screen and (color) { ... }".
+	Hoist bool
+}
+
+// MergeMediaRules merges the @media rules of a stylesheet with the same
+// queries, as told by media.List.Equal, so that "@media (color)" and
+// "@media all and (COLOR)" rules are merged. The contents of a rule are
+// appended to those of the previous rule with the same queries, and the
+// rule is removed. It returns the number of rules removed.
+//
+// Merging moves the contents of a rule before the rules between the two,
+// which doesn't change the cascade unless one of those rules sets the same
+// properties, taking shorthands, vendor prefixes and flow-relative
+// properties into account; the rule is only merged otherwise. Rules are
+// never moved past @import, @layer, @namespace and @charset rules, nor
+// moved if they hold one, since their order matters. @media rules with an
+// invalid query list are left as is, and so are the rules with comments,
+// though other rules may be merged into them.
+func MergeMediaRules(s *css.Stylesheet, opts MediaOptions) int {
+	m := &mediaMerger{opts: opts}
+	s.Rules = m.rules(s.Rules)
+	return m.removed
+}
+
+// MergeMedia returns a transform merging @media rules with
+// MergeMediaRules.
+func MergeMedia(opts MediaOptions) Transform {
+	return Func(func(s *css.Stylesheet) error {
+		MergeMediaRules(s, opts)
+		return nil
+	})
+}
+
+// mediaMerger merges the @media rules of stylesheets.
+type mediaMerger struct {
+	opts    MediaOptions
+	removed int
+}
+
+// mediaRule is an @media rule of a list of rules, with its queries.
+type mediaRule struct {
+	list  media.List
+	index int
+}
+
+// rules merges the @media rules of a list of rules, after those of their
+// contents, and returns the rules kept. The result shares the backing array
+// of rules.
+func (m *mediaMerger) rules(rules []*css.Rule) []*css.Rule {
+	for _, r := range rules {
+		if len(r.Rules) > 0 {
+			r.Rules = m.rules(r.Rules)
+		}
+		if m.opts.Hoist {
+			m.hoist(r)
+		}
+	}
+	var seen []mediaRule
+	out := rules[:0]
+	for _, r := range rules {
+		list, ok := mediaQueries(r)
+		if !ok {
+			out = append(out, r)
+			continue
+		}
+		// Merging into an earlier rule than the last one with the same
+		// queries would move r past more rules.
+		last := -1
+		for i := len(seen) - 1; i >= 0; i-- {
+			if seen[i].list.Equal(list) {
+				last = seen[i].index
+				break
+			}
+		}
+		if last >= 0 && len(r.Comments) == 0 {
+			p := out[last]
+			if (len(p.Rules) == 0 || len(r.Declarations) == 0) && movable(r, out[last+1:]) {
+				p.Declarations = append(p.Declarations, r.Declarations...)
+				p.Rules = append(p.Rules, r.Rules...)
+				m.removed++
+				continue
+			}
+		}
+		seen = append(seen, mediaRule{list, len(out)})
+		out = append(out, r)
+	}
+	return out
+}
+
+// hoist unwraps the @media rules of the contents of r with the same
+// queries as r, then combines r with the @media rule it only holds, if
+// any.
+func (m *mediaMerger) hoist(r *css.Rule) {
+	list, ok := mediaQueries(r)
+	if !ok {
+		return
+	}
+	var rules []*css.Rule
+	for _, c := range r.Rules {
+		if inner, ok := mediaQueries(c); ok && len(c.Declarations) == 0 && len(c.Comments) == 0 && inner.Equal(list) {
+			rules = append(rules, c.Rules...)
+			m.removed++
+			continue
+		}
+		rules = append(rules, c)
+	}
+	r.Rules = rules
+	if len(r.Declarations) > 0 || len(r.Rules) != 1 {
+		return
+	}
+	c := r.Rules[0]
+	inner, ok := mediaQueries(c)
+	if !ok || len(c.Comments) > 0 || len(list) != 1 || len(inner) != 1 {
+		return
+	}
+	q, ok := combine(list[0], inner[0])
+	if !ok {
+		return
+	}
+	prelude, err := css.ParseComponentValues(q.String())
+	if err != nil {
+		return
+	}
+	r.Prelude = prelude
+	r.Declarations = c.Declarations
+	r.Rules = c.Rules
+	m.removed++
+}
+
+// combine returns the query matching the media matched by both a and b,
+// and whether there is one. Negated queries aren't combined.
+func combine(a, b *media.Query) (*media.Query, bool) {
+	if a.Not || b.Not {
+		return nil, false
+	}
+	q := &media.Query{Type: a.Type}
+	switch {
+	case b.Type == "" || b.Type == "all":
+	case a.Type == "" || a.Type == "all":
+		q.Type = b.Type
+	case a.Type != b.Type:
+		return nil, false
+	}
+	q.Only = (a.Only || b.Only) && q.Type != ""
+	var operands []*media.Condition
+	for _, c := range []*media.Condition{a.Condition, b.Condition} {
+		switch {
+		case c == nil:
+		case c.Kind == media.And:
+			operands = append(operands, c.Conditions...)
+		default:
+			operands = append(operands, c)
+		}
+	}
+	switch len(operands) {
+	case 0:
+	case 1:
+		q.Condition = operands[0]
+	default:
+		q.Condition = &media.Condition{Kind: media.And, Conditions: operands}
+	}
+	return q, true
+}
+
+// mediaQueries returns the queries of an @media rule, and whether it is an
+// @media rule with a valid query list.
+func mediaQueries(r *css.Rule) (media.List, bool) {
+	if !r.IsAtRule() || !strings.EqualFold(r.AtKeyword, "media") || !r.HasBlock {
+		return nil, false
+	}
+	list, err := media.Parse(r.Prelude)
+	return list, err == nil
+}
+
+// movable reports whether the contents of r can be moved before the rules
+// between without changing the cascade.
+func movable(r *css.Rule, between []*css.Rule) bool {
+	if len(between) == 0 {
+		return true
+	}
+	set := map[string]bool{}
+	if !collectLonghands(between, nil, set) {
+		return false
+	}
+	moved := map[string]bool{}
+	if !collectLonghands(r.Rules, r.Declarations, moved) {
+		return false
+	}
+	for l := range moved {
+		if set[l] {
+			return false
+		}
+	}
+	return true
+}
+
+// collectLonghands adds the longhands set by a list of declarations and
+// rules to set, and reports whether they can be reordered with other rules:
+// not if they hold an at-rule whose order matters, or an "all" declaration,
+// which sets every property.
+func collectLonghands(rules []*css.Rule, decls []*css.Declaration, set map[string]bool) bool {
+	for _, d := range decls {
+		if strings.EqualFold(d.Property, "all") {
+			return false
+		}
+		for _, l := range longhands(d.Property) {
+			set[l] = true
+		}
+	}
+	for _, r := range rules {
+		switch strings.ToLower(r.AtKeyword) {
+		case "import", "layer", "namespace", "charset":
+			return false
+		}
+		if !collectLonghands(r.Rules, r.Declarations, set) {
+			return false
+		}
+	}
+	return true
+}
+
+// writingModes are the writing modes for which longhands maps the
+// flow-relative properties to physical properties.
+var writingModes = []props.WritingMode{props.HorizontalTB, props.VerticalRL, props.VerticalLR, props.SidewaysRL, props.SidewaysLR}
+
+// longhands returns the longhands set by a property, without vendor prefix,
+// and the physical properties they may map to, so that two properties set
+// the same property if they have a longhand in common. Custom properties
+// and unknown properties are their own longhand.
+func longhands(property string) []string {
+	if isCustomProperty(property) {
+		return []string{property}
+	}
+	name := strings.ToLower(prefix.Strip(property))
+	list := []string{name}
+	if p := props.Lookup(name); p != nil && p.IsShorthand() {
+		list = p.Longhands
+	}
+	out := append([]string(nil), list...)
+	for _, l := range list {
+		for _, mode := range writingModes {
+			for _, dir := range []props.Direction{props.LTR, props.RTL} {
+				out = append(out, props.Physical(l, mode, dir)...)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,287 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transform
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/scanner"
+)
+
+// NameKind is a kind of author-defined name renamed by Obfuscate.
+type NameKind int
+
+const (
+	// ClassName is the name of a class, in selectors such as ".a".
+	ClassName NameKind = iota
+	// IDName is an ID, in selectors such as "#a".
+	IDName
+	// KeyframesName is the name of a @keyframes rule.
+	KeyframesName
+	// CustomPropertyName is the name of a custom property, such as "--a".
+	CustomPropertyName
+	numNameKinds
+)
+
+// Mapping maps the original names of each kind to the names generated by
+// Obfuscate. Names are unescaped, as they are written in HTML or passed to
+// the DOM.
+type Mapping [numNameKinds]map[string]string
+
+// Obfuscate renames the classes, IDs, keyframes and custom properties of a
+// set of stylesheets that are used together to short generated names, and
+// returns the mapping from the original names to the new ones, to rewrite
+// the HTML and scripts that reference them.
+//
+// A name gets the same new name in all the stylesheets, and the most used
+// names get the shortest ones. keep, if not nil, reports whether a name is
+// kept as is, such as classes set by scripts that aren't rewritten; new
+// names never collide with kept names, or with the keywords of the
+// properties that reference keyframes.
+//
+// Classes and IDs are renamed in the selectors of style rules and @scope
+// rules, but not in attribute selectors such as [class~=a], nor in URLs
+// such as url(#a). Keyframes are renamed in @keyframes rules and in the
+// animation and animation-name properties; only keyframes defined by one
+// of the stylesheets are renamed. Custom properties are renamed in
+// declarations, var() and @property rules.
+func Obfuscate(sheets []*css.Stylesheet, keep func(kind NameKind, name string) bool) Mapping {
+	o := &obfuscator{keep: keep, keyframes: make(map[string]bool)}
+	for k := range o.counts {
+		o.counts[k] = make(map[string]int)
+	}
+	// The keyframes are collected first since only those that are defined
+	// are renamed.
+	for _, s := range sheets {
+		o.collectKeyframes(s.Rules)
+	}
+	for _, s := range sheets {
+		o.walkRules(s.Rules, o.count)
+	}
+	var m Mapping
+	for k := range m {
+		m[k] = o.assign(NameKind(k))
+	}
+	o.mapping = m
+	for _, s := range sheets {
+		o.walkRules(s.Rules, o.rename)
+	}
+	return m
+}
+
+// obfuscator holds the state of Obfuscate.
+type obfuscator struct {
+	keep func(NameKind, string) bool
+	// keyframes are the names of the defined keyframes.
+	keyframes map[string]bool
+	// counts are the numbers of uses of each name, and order the names in
+	// order of first use.
+	counts  [numNameKinds]map[string]int
+	order   [numNameKinds][]string
+	mapping Mapping
+}
+
+// visitor is the visitor of walkRules, called with each token holding a name
+// of the given kind, and the name as decoded.
+type visitor func(kind NameKind, t *scanner.Token, name string)
+
+// collectKeyframes records the names of the @keyframes rules of a list of
+// rules.
+func (o *obfuscator) collectKeyframes(rules []*css.Rule) {
+	for _, r := range rules {
+		if isKeyframes(r) {
+			if t := keyframesName(r); t != nil {
+				o.keyframes[t.DecodedValue()] = true
+			}
+		}
+		o.collectKeyframes(r.Rules)
+	}
+}
+
+// count is the visitor counting the uses of names.
+func (o *obfuscator) count(kind NameKind, t *scanner.Token, name string) {
+	if o.counts[kind][name] == 0 {
+		o.order[kind] = append(o.order[kind], name)
+	}
+	o.counts[kind][name]++
+}
+
+// rename is the visitor replacing names with their new name.
+func (o *obfuscator) rename(kind NameKind, t *scanner.Token, name string) {
+	to, ok := o.mapping[kind][name]
+	if !ok {
+		return
+	}
+	if t.Type == scanner.TokenHash {
+		t.Value = "#" + to
+	} else {
+		t.Type, t.Value = scanner.TokenIdent, to
+	}
+}
+
+// assign generates the new names of a kind, shortest first for the most
+// used names, skipping kept names and keywords.
+func (o *obfuscator) assign(kind NameKind) map[string]string {
+	names := o.order[kind]
+	sort.SliceStable(names, func(i, j int) bool {
+		return o.counts[kind][names[i]] > o.counts[kind][names[j]]
+	})
+	used := make(map[string]bool)
+	var renamed []string
+	for _, name := range names {
+		if o.keep != nil && o.keep(kind, name) {
+			used[name] = true
+		} else {
+			renamed = append(renamed, name)
+		}
+	}
+	m := make(map[string]string, len(renamed))
+	n := 0
+	for _, name := range renamed {
+		for {
+			to := generateName(n)
+			n++
+			if kind == CustomPropertyName {
+				to = "--" + to
+			}
+			if !used[to] && !reservedNames[to] {
+				m[name] = to
+				break
+			}
+		}
+	}
+	return m
+}
+
+// reservedNames are the generated names that are keywords where keyframes
+// names can appear.
+var reservedNames = map[string]bool{
+	"default": true, "inherit": true, "initial": true, "none": true,
+	"revert": true, "unset": true,
+}
+
+// generateName returns the nth generated name: "a" to "z", then names of
+// two characters or more whose first character is a letter and whose
+// other characters are letters or digits.
+func generateName(n int) string {
+	const first, rest = "abcdefghijklmnopqrstuvwxyz", "abcdefghijklmnopqrstuvwxyz0123456789"
+	b := []byte{first[n%len(first)]}
+	for n /= len(first); n > 0; n /= len(rest) {
+		n--
+		b = append(b, rest[n%len(rest)])
+	}
+	return string(b)
+}
+
+// walkRules calls fn for each renamable name of a list of rules.
+func (o *obfuscator) walkRules(rules []*css.Rule, fn visitor) {
+	for _, r := range rules {
+		switch {
+		case isKeyframes(r):
+			if t := keyframesName(r); t != nil {
+				fn(KeyframesName, t, t.DecodedValue())
+			}
+			// The rules of @keyframes have keyframe selectors.
+			for _, k := range r.Rules {
+				o.walkDeclarations(k.Declarations, fn)
+			}
+			continue
+		case !r.IsAtRule():
+			walkSelector(r.Prelude, fn)
+		case strings.EqualFold(r.AtKeyword, "scope"):
+			walkSelector(r.Prelude, fn)
+		case strings.EqualFold(r.AtKeyword, "property"):
+			if len(r.Prelude) == 1 && isCustomProperty(r.Prelude[0].Token.Value) {
+				fn(CustomPropertyName, r.Prelude[0].Token, r.Prelude[0].Token.DecodedValue())
+			}
+		}
+		o.walkDeclarations(r.Declarations, fn)
+		o.walkRules(r.Rules, fn)
+	}
+}
+
+// walkDeclarations calls fn for each renamable name of a list of
+// declarations.
+func (o *obfuscator) walkDeclarations(decls []*css.Declaration, fn visitor) {
+	for _, d := range decls {
+		if isCustomProperty(d.Property) {
+			t := &scanner.Token{Type: scanner.TokenIdent, Value: d.Property}
+			fn(CustomPropertyName, t, t.DecodedValue())
+			d.Property = t.Value
+		}
+		name := strings.ToLower(d.Property)
+		animation := name == "animation" || name == "animation-name" ||
+			name == "-webkit-animation" || name == "-webkit-animation-name"
+		o.walkValues(d.Value, animation, fn)
+	}
+}
+
+// walkValues calls fn for the var() references of a list of component
+// values, and for the keyframes names if animation is true.
+func (o *obfuscator) walkValues(values []*css.ComponentValue, animation bool, fn visitor) {
+	for _, v := range values {
+		t := v.Token
+		switch {
+		case animation && (t.Type == scanner.TokenIdent || t.Type == scanner.TokenString):
+			// Keyframes names may be strings, as in animation-name: "a".
+			if o.keyframes[t.DecodedValue()] {
+				fn(KeyframesName, t, t.DecodedValue())
+			}
+		case v.IsFunction() && strings.EqualFold(t.DecodedValue(), "var"):
+			if args := css.TrimSpace(v.Children); len(args) > 0 && isCustomProperty(args[0].Token.Value) {
+				fn(CustomPropertyName, args[0].Token, args[0].Token.DecodedValue())
+			}
+		}
+		o.walkValues(v.Children, false, fn)
+	}
+}
+
+// walkSelector calls fn for the classes and IDs of a selector.
+func walkSelector(values []*css.ComponentValue, fn visitor) {
+	for i, v := range values {
+		t := v.Token
+		switch {
+		case t.Type == scanner.TokenHash:
+			if name := scanner.Unescape(t.Value[1:]); name != "" && !isDigit(name[0]) {
+				fn(IDName, t, name)
+			}
+		case isDot(v) && i+1 < len(values) && values[i+1].Token.Type == scanner.TokenIdent:
+			fn(ClassName, values[i+1].Token, values[i+1].Token.DecodedValue())
+		case v.IsBlock() && t.Value == "[":
+			// Attribute selectors are left untouched.
+			continue
+		}
+		walkSelector(v.Children, fn)
+	}
+}
+
+// isKeyframes reports whether r is a @keyframes rule, prefixed or not.
+func isKeyframes(r *css.Rule) bool {
+	name := strings.ToLower(r.AtKeyword)
+	return name == "keyframes" || strings.HasPrefix(name, "-") && strings.HasSuffix(name, "-keyframes")
+}
+
+// keyframesName returns the token of the name of a @keyframes rule, or nil.
+func keyframesName(r *css.Rule) *scanner.Token {
+	if len(r.Prelude) != 1 {
+		return nil
+	}
+	if t := r.Prelude[0].Token; t.Type == scanner.TokenIdent || t.Type == scanner.TokenString {
+		return t
+	}
+	return nil
+}
+
+// isDot reports whether v is a "." delimiter.
+func isDot(v *css.ComponentValue) bool {
+	return v.Token.Type == scanner.TokenChar && v.Token.Value == "."
+}
+
+// isDigit reports whether c is an ASCII digit.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transform
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/diag"
+	"github.com/gorilla/css/minify"
+	"github.com/gorilla/css/prefix"
+	"github.com/gorilla/css/sanitize"
+)
+
+// Transform is a pass of a Pipeline.
+type Transform interface {
+	// TransformStylesheet transforms a stylesheet in place.
+	TransformStylesheet(s *css.Stylesheet) error
+}
+
+// ContextTransform is a Transform using the settings shared by the
+// transforms of a Pipeline, or reporting diagnostics. A Pipeline calls
+// TransformStylesheetContext instead of TransformStylesheet.
+type ContextTransform interface {
+	Transform
+	TransformStylesheetContext(c *Context, s *css.Stylesheet) error
+}
+
+// Func adapts a function to a Transform:
+//
+//	p.Add("lowercase", transform.Func(func(s *css.Stylesheet) error {
+//		transform.Lowercase(s)
+//		return nil
+//	}))
+type Func func(s *css.Stylesheet) error
+
+// TransformStylesheet calls f(s).
+func (f Func) TransformStylesheet(s *css.Stylesheet) error {
+	return f(s)
+}
+
+// ContextFunc adapts a function to a ContextTransform.
+type ContextFunc func(c *Context, s *css.Stylesheet) error
+
+// TransformStylesheet calls f with an empty context.
+func (f ContextFunc) TransformStylesheet(s *css.Stylesheet) error {
+	return f(&Context{}, s)
+}
+
+// TransformStylesheetContext calls f(c, s).
+func (f ContextFunc) TransformStylesheetContext(c *Context, s *css.Stylesheet) error {
+	return f(c, s)
+}
+
+// Context holds the settings shared by the transforms of a Pipeline, and
+// collects their diagnostics.
+type Context struct {
+	// Targets are the browsers the stylesheets must support.
+	Targets prefix.Targets
+	// BaseURL is the URL of the stylesheets, or nil if it is unknown.
+	BaseURL *url.URL
+
+	diags []diag.Diagnostic
+}
+
+// Report adds a diagnostic to the Result of the running transform.
+func (c *Context) Report(d diag.Diagnostic) {
+	c.diags = append(c.diags, d)
+}
+
+// Result describes a run of a transform of a Pipeline.
+type Result struct {
+	// Name is the name the transform was added with.
+	Name string
+	// Duration is the time the transform took.
+	Duration time.Duration
+	// Diagnostics are the diagnostics reported by the transform.
+	Diagnostics []diag.Diagnostic
+}
+
+// Pipeline runs transforms in order on stylesheets, like the plugins of
+// PostCSS:
+//
+//	p := &transform.Pipeline{}
+//	p.Targets = prefix.Targets{prefix.Safari: 14}
+//	p.Add("prefix", transform.Prefix())
+//	p.Add("sanitize", transform.Sanitize(sanitize.UserContent()))
+//	p.Add("minify", transform.Minify(minify.Options{}))
+//	results, err := p.Run(sheet)
+type Pipeline struct {
+	// Context holds the settings shared by the transforms.
+	Context
+	steps []step
+}
+
+// step is a named transform of a Pipeline.
+type step struct {
+	name string
+	t    Transform
+}
+
+// Add appends a transform to the pipeline. The name identifies it in the
+// results and errors of Run.
+func (p *Pipeline) Add(name string, t Transform) {
+	p.steps = append(p.steps, step{name, t})
+}
+
+// Run runs the transforms on a stylesheet, in the order they were added,
+// and returns a Result for each of them. If a transform fails, Run stops
+// and returns the results of the transforms that ran, including the failed
+// one, along with its error.
+//
+// Each run has its own copy of the Context, so that a Pipeline can run on
+// several stylesheets concurrently if its transforms can.
+func (p *Pipeline) Run(s *css.Stylesheet) ([]Result, error) {
+	results := make([]Result, 0, len(p.steps))
+	c := p.Context
+	for _, st := range p.steps {
+		c.diags = nil
+		start := time.Now()
+		var err error
+		if ct, ok := st.t.(ContextTransform); ok {
+			err = ct.TransformStylesheetContext(&c, s)
+		} else {
+			err = st.t.TransformStylesheet(s)
+		}
+		results = append(results, Result{st.name, time.Since(start), c.diags})
+		if err != nil {
+			return results, fmt.Errorf("transform: %s: %w", st.name, err)
+		}
+	}
+	return results, nil
+}
+
+// Prefix returns a transform adding the vendor prefixes needed by the
+// targets of the context, with prefix.Add.
+func Prefix() ContextTransform {
+	return ContextFunc(func(c *Context, s *css.Stylesheet) error {
+		prefix.Add(s, c.Targets)
+		return nil
+	})
+}
+
+// StripPrefixes returns a transform removing the prefixed declarations and
+// at-rules that duplicate an unprefixed sibling, with
+// prefix.StripDuplicates.
+func StripPrefixes() Transform {
+	return Func(func(s *css.Stylesheet) error {
+		prefix.StripDuplicates(s)
+		return nil
+	})
+}
+
+// Minify returns a transform minifying stylesheets with minify.Stylesheet.
+func Minify(opts minify.Options) Transform {
+	return Func(func(s *css.Stylesheet) error {
+		minify.Stylesheet(s, opts)
+		return nil
+	})
+}
+
+// Sanitize returns a transform removing the constructs that p doesn't
+// allow, such as the rules outside of its Scope. Each removal is reported
+// as a warning whose code is "removed-" followed by its kind, such as
+// "removed-property".
+func Sanitize(p *sanitize.Policy) ContextTransform {
+	return ContextFunc(func(c *Context, s *css.Stylesheet) error {
+		for _, r := range p.Stylesheet(s) {
+			c.Report(r.Diagnostic())
+		}
+		return nil
+	})
+}
+
+// Resolve returns a transform resolving the relative URLs of stylesheets
+// against the base URL of the context, with ResolveURLs. It does nothing if
+// the context has no base URL.
+func Resolve() ContextTransform {
+	return ContextFunc(func(c *Context, s *css.Stylesheet) error {
+		if c.BaseURL != nil {
+			ResolveURLs(s, c.BaseURL)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,165 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transform
+
+import (
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/media"
+	"github.com/gorilla/css/scanner"
+)
+
+// PruneOptions are the options of PruneConditionalRules.
+type PruneOptions struct {
+	// Media, if not nil, is the environment the @media rules are evaluated
+	// against.
+	Media *media.Env
+	// Supports, if not nil, tells whether a feature of an @supports
+	// condition is supported: a declaration in parentheses, as
+	// "(display: grid)", or a function, as "selector(:has(a))".
+	Supports func(feature *css.ComponentValue) media.Result
+}
+
+// PruneConditionalRules removes the @media and @supports rules of a
+// stylesheet that never apply in a fixed environment, such as a device, and
+// unwraps those that always apply, replacing them with the rules they
+// hold, so as to generate a stylesheet for that environment. It returns the
+// number of rules removed or unwrapped.
+//
+// @media rules are evaluated with media.List.Eval against opts.Media, and
+// @supports rules with opts.Supports. Rules whose condition is unknown,
+// because it depends on a part of the environment that isn't known, or
+// because it is invalid, are kept, and so are the @media and @supports
+// rules if the corresponding option is nil. Rules that always apply are
+// kept as well if they hold declarations, as when nested in a style rule,
+// or comments.
+func PruneConditionalRules(s *css.Stylesheet, opts PruneOptions) int {
+	p := &pruner{opts: opts}
+	s.Rules = p.rules(s.Rules)
+	return p.pruned
+}
+
+// PruneConditionals returns a transform pruning the @media and @supports
+// rules with PruneConditionalRules.
+func PruneConditionals(opts PruneOptions) Transform {
+	return Func(func(s *css.Stylesheet) error {
+		PruneConditionalRules(s, opts)
+		return nil
+	})
+}
+
+// pruner prunes the conditional rules of stylesheets.
+type pruner struct {
+	opts   PruneOptions
+	pruned int
+}
+
+// rules prunes a list of rules, after the contents of the rules, and
+// returns the rules kept.
+func (p *pruner) rules(rules []*css.Rule) []*css.Rule {
+	var out []*css.Rule
+	for _, r := range rules {
+		if len(r.Rules) > 0 {
+			r.Rules = p.rules(r.Rules)
+		}
+		switch p.eval(r) {
+		case media.False:
+			p.pruned++
+			continue
+		case media.True:
+			if len(r.Declarations) == 0 && len(r.Comments) == 0 {
+				out = append(out, r.Rules...)
+				p.pruned++
+				continue
+			}
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// eval evaluates the condition of an @media or @supports rule.
+func (p *pruner) eval(r *css.Rule) media.Result {
+	if !r.IsAtRule() || !r.HasBlock {
+		return media.Unknown
+	}
+	switch strings.ToLower(r.AtKeyword) {
+	case "media":
+		if p.opts.Media == nil {
+			return media.Unknown
+		}
+		list, err := media.Parse(r.Prelude)
+		if err != nil {
+			return media.Unknown
+		}
+		return list.Eval(p.opts.Media)
+	case "supports":
+		if p.opts.Supports == nil {
+			return media.Unknown
+		}
+		return p.supports(r.Prelude)
+	}
+	return media.Unknown
+}
+
+// supports evaluates an @supports condition, made of features combined
+// with "not", "and" and "or". It returns media.Unknown if it is invalid.
+func (p *pruner) supports(values []*css.ComponentValue) media.Result {
+	var vs []*css.ComponentValue
+	for _, v := range values {
+		if v.Token.Type != scanner.TokenS {
+			vs = append(vs, v)
+		}
+	}
+	if len(vs) == 0 {
+		return media.Unknown
+	}
+	if isIdent(vs[0], "not") {
+		if len(vs) != 2 {
+			return media.Unknown
+		}
+		return p.supportsInParens(vs[1]).Not()
+	}
+	if len(vs)%2 == 0 {
+		return media.Unknown
+	}
+	r := p.supportsInParens(vs[0])
+	op := ""
+	for i := 1; i < len(vs); i += 2 {
+		switch {
+		case isIdent(vs[i], "and") && op != "or":
+			op = "and"
+			r = r.And(p.supportsInParens(vs[i+1]))
+		case isIdent(vs[i], "or") && op != "and":
+			op = "or"
+			r = r.Or(p.supportsInParens(vs[i+1]))
+		default:
+			return media.Unknown
+		}
+	}
+	return r
+}
+
+// supportsInParens evaluates a condition in parentheses, or a feature.
+func (p *pruner) supportsInParens(v *css.ComponentValue) media.Result {
+	switch {
+	case v.IsFunction():
+		return p.opts.Supports(v)
+	case !v.IsBlock() || v.Token.Value != "(":
+		return media.Unknown
+	}
+	inner := css.TrimSpace(v.Children)
+	if len(inner) > 0 && inner[0].Token.Type == scanner.TokenIdent && !isIdent(inner[0], "not") {
+		// A declaration, such as "(display: grid)".
+		return p.opts.Supports(v)
+	}
+	return p.supports(inner)
+}
+
+// isIdent reports whether v is the identifier name, ignoring case.
+func isIdent(v *css.ComponentValue, name string) bool {
+	return v.Token.Type == scanner.TokenIdent && strings.EqualFold(v.Token.Value, name)
+}
@@ -0,0 +1,222 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transform
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/scanner"
+	"github.com/gorilla/css/selector"
+)
+
+// PurgeOptions are the options of PurgeRules: the names used by the
+// documents a stylesheet applies to, such as those collected from their
+// templates.
+type PurgeOptions struct {
+	// Classes, IDs and Elements are the classes, IDs and element names
+	// used by the documents, unescaped. Element names are compared
+	// ignoring case. A nil list keeps all the selectors of its kind, so
+	// that, for instance, only unused classes are purged.
+	Classes, IDs, Elements []string
+	// Safelist are the patterns of the selectors that are kept even if
+	// they aren't used, such as classes added by scripts. They are matched
+	// against the class, ID and type selectors, as ".name", "#name" and
+	// "name", unescaped: `^\.js-` keeps the classes starting with "js-".
+	Safelist []*regexp.Regexp
+}
+
+// PurgeReport tells what PurgeRules removed.
+type PurgeReport struct {
+	// Rules are the rules removed: the style rules whose selectors can't
+	// match any element, and the conditional rules left empty by their
+	// removal.
+	Rules []*css.Rule
+	// Selectors are the selectors removed from the selector lists of the
+	// style rules kept, as written.
+	Selectors []string
+	// Bytes is the number of bytes removed from the stylesheet, as
+	// written by String.
+	Bytes int
+}
+
+// PurgeRules removes the selectors of a stylesheet that can't match any
+// element of the documents using it, because they reference a class, an ID
+// or an element name that the documents don't use, and the rules left
+// without selectors. It is the equivalent of PurgeCSS.
+//
+// Selectors are removed from selector lists, so that ".used, .unused {}"
+// becomes ".used {}". The arguments of :is(), :where() and :has() can't
+// match if none of their selectors can, while those of :not() are ignored.
+// Attribute selectors, such as "[class~=a]", are always kept. Rules nested
+// in purged rules are purged with them, and @media, @supports, @container
+// and @scope rules that only held purged rules are removed too, while
+// @layer rules are kept for the order of their layers. Rules with an
+// invalid selector and other at-rules, such as @keyframes, are kept.
+func PurgeRules(s *css.Stylesheet, opts PurgeOptions) PurgeReport {
+	before := len(s.String())
+	p := &purger{opts: opts}
+	p.classes = nameSet(opts.Classes, false)
+	p.ids = nameSet(opts.IDs, false)
+	p.elements = nameSet(opts.Elements, true)
+	s.Rules = p.rules(s.Rules, false)
+	p.report.Bytes = before - len(s.String())
+	return p.report
+}
+
+// Purge returns a transform purging the unused selectors with PurgeRules.
+func Purge(opts PurgeOptions) Transform {
+	return Func(func(s *css.Stylesheet) error {
+		PurgeRules(s, opts)
+		return nil
+	})
+}
+
+// nameSet returns the set of names of a list, lowercase if fold is true, or
+// nil for a nil list.
+func nameSet(names []string, fold bool) map[string]bool {
+	if names == nil {
+		return nil
+	}
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		if fold {
+			name = strings.ToLower(name)
+		}
+		set[name] = true
+	}
+	return set
+}
+
+// purger purges the unused selectors of stylesheets.
+type purger struct {
+	opts                   PurgeOptions
+	classes, ids, elements map[string]bool
+	report                 PurgeReport
+}
+
+// rules purges a list of rules, nested in style rules if nested is true,
+// and returns the rules kept. The result shares the backing array of rules.
+func (p *purger) rules(rules []*css.Rule, nested bool) []*css.Rule {
+	out := rules[:0]
+	for _, r := range rules {
+		if !r.IsAtRule() {
+			if !p.styleRule(r, nested) {
+				p.report.Rules = append(p.report.Rules, r)
+				continue
+			}
+			out = append(out, r)
+			continue
+		}
+		switch strings.ToLower(r.AtKeyword) {
+		case "media", "supports", "container", "scope", "layer":
+			if len(r.Rules) == 0 {
+				break
+			}
+			r.Rules = p.rules(r.Rules, nested)
+			if len(r.Rules) == 0 && len(r.Declarations) == 0 && !strings.EqualFold(r.AtKeyword, "layer") {
+				p.report.Rules = append(p.report.Rules, r)
+				continue
+			}
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// styleRule removes the selectors of a style rule that can't match, and
+// purges its nested rules. It reports whether the rule is kept.
+func (p *purger) styleRule(r *css.Rule, nested bool) bool {
+	var list selector.List
+	var err error
+	if nested {
+		list, err = selector.ParseRelative(r.Prelude)
+	} else {
+		list, err = selector.Parse(r.Prelude)
+	}
+	if err != nil {
+		return true
+	}
+	var kept selector.List
+	var removed []string
+	for _, c := range list {
+		if p.list(selector.List{c}) {
+			kept = append(kept, c)
+		} else {
+			removed = append(removed, css.ValuesString(c.Values))
+		}
+	}
+	if len(kept) == 0 {
+		return false
+	}
+	if len(removed) > 0 {
+		p.report.Selectors = append(p.report.Selectors, removed...)
+		var prelude []*css.ComponentValue
+		for i, c := range kept {
+			if i > 0 {
+				prelude = append(prelude,
+					&css.ComponentValue{Token: &scanner.Token{Type: scanner.TokenChar, Value: ","}},
+					&css.ComponentValue{Token: &scanner.Token{Type: scanner.TokenS, Value: " "}})
+			}
+			prelude = append(prelude, c.Values...)
+		}
+		r.Prelude = prelude
+	}
+	r.Rules = p.rules(r.Rules, true)
+	return true
+}
+
+// list reports whether one of the selectors of a list may match an element
+// of the documents.
+func (p *purger) list(l selector.List) bool {
+	for _, c := range l {
+		ok := true
+		for _, compound := range c.Compounds {
+			for _, s := range compound.Simples {
+				if !p.simple(s) {
+					ok = false
+				}
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// simple reports whether a simple selector may match an element of the
+// documents.
+func (p *purger) simple(s *selector.Simple) bool {
+	switch s.Kind {
+	case selector.Class:
+		return p.used(p.classes, s.Name, "."+s.Name)
+	case selector.ID:
+		return p.used(p.ids, s.Name, "#"+s.Name)
+	case selector.Type:
+		return p.used(p.elements, s.Name, s.Name)
+	case selector.PseudoClass:
+		if s.Name == "not" || s.Selectors == nil {
+			return true
+		}
+		return p.list(s.Selectors)
+	}
+	return true
+}
+
+// used reports whether name is in set, or set is nil, or the selector is
+// safelisted.
+func (p *purger) used(set map[string]bool, name, sel string) bool {
+	if set == nil || set[name] {
+		return true
+	}
+	for _, re := range p.opts.Safelist {
+		if re.MatchString(sel) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,469 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transform
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/media"
+	"github.com/gorilla/css/minify"
+	"github.com/gorilla/css/prefix"
+	"github.com/gorilla/css/props"
+	"github.com/gorilla/css/sanitize"
+	"github.com/gorilla/css/validate"
+)
+
+func TestLowercase(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected string
+	}{
+		{"A.Foo { COLOR: RED; Width: CALC(10PX + 2Em) }", "A.Foo{color:red;width:calc(10px + 2em)}"},
+		{"a { background: URL(A.PNG), Linear-Gradient(RED, Blue) }", "a{background:URL(A.PNG), linear-gradient(red, blue)}"},
+		{"a { content: \"ABC\"; font-family: \"Open Sans\", Arial }", "a{content:\"ABC\";font-family:\"Open Sans\", Arial}"},
+		{"a { --Main-Color: RED; color: VAR(--Main-Color, BLUE) }", "a{--Main-Color:RED;color:var(--Main-Color, blue)}"},
+		{"a { ANIMATION: Spin 1S; Unknown-Prop: FOO }", "a{animation:Spin 1s;unknown-prop:FOO}"},
+		{"@MEDIA (MIN-WIDTH: 10PX) { a { DISPLAY: Block } }", "@media (MIN-WIDTH: 10PX){a{display:block}}"},
+	}
+	for _, tc := range tcs {
+		s, err := css.ParseStylesheet(tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		Lowercase(s)
+		if got := s.String(); got != tc.expected {
+			t.Errorf("%s:\ngot  %q\nwant %q", tc.input, got, tc.expected)
+		}
+	}
+}
+
+func TestToPhysical(t *testing.T) {
+	tcs := []struct {
+		mode     props.WritingMode
+		dir      props.Direction
+		input    string
+		expected string
+	}{
+		{
+			props.HorizontalTB, props.LTR,
+			"a { margin-inline-start: 1px; inset-block-end: 0; inline-size: 10em; color: red }",
+			"a{margin-left:1px;bottom:0;width:10em;color:red}",
+		},
+		{
+			props.HorizontalTB, props.RTL,
+			"a { padding-inline: 1px calc(2px + 3px) !important; border-block: 1px solid red }",
+			"a{padding-right:1px!important;padding-left:calc(2px + 3px)!important;border-top:1px solid red;border-bottom:1px solid red}",
+		},
+		{
+			props.VerticalRL, props.LTR,
+			"@media print { a { margin-block: 1px; block-size: 2px; border-start-start-radius: 3px } }",
+			"@media print{a{margin-right:1px;margin-left:1px;width:2px;border-top-right-radius:3px}}",
+		},
+	}
+	for _, tc := range tcs {
+		s, err := css.ParseStylesheet(tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ToPhysical(s, tc.mode, tc.dir)
+		if got := s.String(); got != tc.expected {
+			t.Errorf("%s:\ngot  %q\nwant %q", tc.input, got, tc.expected)
+		}
+	}
+}
+
+func TestToLogical(t *testing.T) {
+	s, _ := css.ParseStylesheet("a { margin: 0; margin-left: 1px; top: 0; height: 1em; border-top-right-radius: 2px; --x: 0 }")
+	ToLogical(s, props.HorizontalTB, props.RTL)
+	want := "a{margin:0;margin-inline-end:1px;inset-block-start:0;block-size:1em;border-start-start-radius:2px;--x:0}"
+	if got := s.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestReplaceInitial(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected string
+	}{
+		{"a { color: INITIAL; width: 1px; z-index: initial !important }", "a{color:canvastext;width:1px;z-index:auto!important}"},
+		{"a { margin: initial }", "a{margin-top:0;margin-right:0;margin-bottom:0;margin-left:0}"},
+		{"a { --x: initial; unknown: initial; background-position: initial }", "a{--x:initial;unknown:initial;background-position:0% 0%}"},
+	}
+	for _, tc := range tcs {
+		s, err := css.ParseStylesheet(tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ReplaceInitial(s)
+		if got := s.String(); got != tc.expected {
+			t.Errorf("%s:\ngot  %q\nwant %q", tc.input, got, tc.expected)
+		}
+	}
+}
+
+func TestReplaceUnset(t *testing.T) {
+	s, _ := css.ParseStylesheet("a { color: unset; width: UNSET; --x: unset; border-top: unset; unknown: unset }")
+	ReplaceUnset(s)
+	want := "a{color:inherit;width:auto;--x:inherit;border-top-width:medium;border-top-style:none;border-top-color:currentcolor;unknown:unset}"
+	if got := s.String(); got != want {
+		t.Errorf("got  %q\nwant %q", got, want)
+	}
+}
+
+func TestFilterProperties(t *testing.T) {
+	tcs := []struct {
+		filter   PropertyFilter
+		input    string
+		expected string
+	}{
+		{
+			PropertyFilter{Deny: []string{"background-image", "Position"}},
+			"a { background: url(a.png); BACKGROUND-IMAGE: none; background-color: red; POSITION: fixed; color: red }",
+			"a{background-color:red;color:red}",
+		},
+		{
+			PropertyFilter{Deny: []string{"margin", "--X"}},
+			"a { margin: 0; margin-top: 1px; padding: 0; --X: 0; --x: 0 }",
+			"a{padding:0;--x:0}",
+		},
+		{
+			PropertyFilter{Deny: []string{"border-top-color"}},
+			"a { border: 0; border-color: red; border-top: 0; border-bottom: 0; border-width: 0 }",
+			"a{border-bottom:0;border-width:0}",
+		},
+		{
+			PropertyFilter{Allow: []string{"color", "margin", "font"}, Deny: []string{"font-size"}},
+			"@media print { a { color: red; margin-left: 0; font: 1em a; font-family: a; font-size: 1px; width: 0 } }",
+			"@media print{a{color:red;margin-left:0;font-family:a}}",
+		},
+		{
+			PropertyFilter{Deny: []string{"background-image", "--x"}},
+			`a { \62 ackground-image: url(x.png); b\61 ckground: url(y.png); BACKGROUND\-IMAGE: none; --\78: 0; color: red }`,
+			"a{color:red}",
+		},
+		{
+			PropertyFilter{Allow: []string{"color"}},
+			`a { \63 olor: red; \77 idth: 0 }`,
+			`a{\63 olor:red}`,
+		},
+		{
+			PropertyFilter{Allow: []string{}},
+			"a { color: red } b { --x: 0 }",
+			"a{}b{}",
+		},
+	}
+	for _, tc := range tcs {
+		s, err := css.ParseStylesheet(tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		FilterProperties(s, &tc.filter)
+		if got := s.String(); got != tc.expected {
+			t.Errorf("%s:\ngot  %q\nwant %q", tc.input, got, tc.expected)
+		}
+	}
+}
+
+func TestRemoveEmptyRules(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected string
+		removed  []string
+	}{
+		{"a { color: red } @import 'a.css'; @layer x;", `a{color:red}@import 'a.css';@layer x;`, nil},
+		{"a {} b { c: d }\n@font-face {}", "b{c:d}", []string{"1:1 a", "2:1 font-face"}},
+		{"@media print { a {} @supports (x: y) { b {} } } c { d {} }", "", []string{"1:16 a", "1:40 b", "1:21 supports", "1:1 media", "1:53 d", "1:49 c"}},
+		{"@media print { a {} b { c: d } } e { f: g; h {} }", "@media print{b{c:d}}e{f:g}", []string{"1:16 a", "1:44 h"}},
+		{"@layer x {} @layer { a {} }", "@layer x{}@layer{}", []string{"1:22 a"}},
+	}
+	for _, tc := range tcs {
+		s, err := css.ParseStylesheet(tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var removed []string
+		for _, r := range RemoveEmptyRules(s) {
+			name := r.AtKeyword
+			if !r.IsAtRule() {
+				name = css.ValuesString(r.Prelude)
+			}
+			removed = append(removed, fmt.Sprintf("%d:%d %s", r.Line, r.Column, name))
+		}
+		if got := s.String(); got != tc.expected || !reflect.DeepEqual(removed, tc.removed) {
+			t.Errorf("%s:\ngot  %q %q\nwant %q %q", tc.input, got, removed, tc.expected, tc.removed)
+		}
+	}
+
+	s, _ := css.ParseStylesheet("@media print { a { color: red } }")
+	p := &Pipeline{}
+	p.Add("filter", Func(func(s *css.Stylesheet) error {
+		FilterProperties(s, &PropertyFilter{Deny: []string{"color"}})
+		return nil
+	}))
+	p.Add("empty", RemoveEmpty())
+	results, err := p.Run(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, d := range results[1].Diagnostics {
+		got = append(got, d.String())
+	}
+	expected := []string{
+		`warning: removed empty rule "a" [removed-empty-rule] (line: 1, column: 16)`,
+		`warning: removed empty rule "@media" [removed-empty-rule] (line: 1, column: 1)`,
+	}
+	if s.String() != "" || !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %q, %q, want an empty stylesheet and %q", s.String(), got, expected)
+	}
+}
+
+func TestMergeMediaRules(t *testing.T) {
+	tcs := []struct {
+		input    string
+		hoist    bool
+		expected string
+		removed  int
+	}{
+		{"@media print { a { color: red } } @media PRINT { b { color: blue } }", false, "@media print{a{color:red}b{color:blue}}", 1},
+		{"@media screen and (min-width: 600px) { a { top: 0 } } b { color: red } @media SCREEN AND (MIN-WIDTH: 600.0px) { c { left: 0 } }", false,
+			"@media screen and (min-width: 600px){a{top:0}c{left:0}}b{color:red}", 1},
+		{"@media print { a { color: red } } b { color: blue } @media print { c { color: green } }", false,
+			"@media print{a{color:red}}b{color:blue}@media print{c{color:green}}", 0},
+		{"@media print { a { margin-left: 0 } } b { margin-inline-start: 1px } @media print { c { margin-left: 0 } }", false,
+			"@media print{a{margin-left:0}}b{margin-inline-start:1px}@media print{c{margin-left:0}}", 0},
+		{"@media print { a { top: 0 } } @layer x; @media print { b { top: 0 } }", false, "@media print{a{top:0}}@layer x;@media print{b{top:0}}", 0},
+		{"@media print { a { top: 0 } } @media screen { b { top: 0 } } @media print { c { top: 0 } }", false,
+			"@media print{a{top:0}}@media screen{b{top:0}}@media print{c{top:0}}", 0},
+		{"@media print { a { top: 0 } } @media screen { b { left: 0 } } @media print { c { right: 0 } }", false,
+			"@media print{a{top:0}c{right:0}}@media screen{b{left:0}}", 1},
+		{"@supports (x: y) { @media print { a { top: 0 } } @media print { b { left: 0 } } }", false, "@supports (x: y){@media print{a{top:0}b{left:0}}}", 1},
+		{"a { @media print { color: red } @media print { top: 0 } }", false, "a{@media print{color:red;top:0}}", 1},
+		{"/*! a */ @media print { a { top: 0 } } @media print { b { left: 0 } } /*! c */ @media print { c { right: 0 } }", false,
+			"/*! a */@media print{a{top:0}b{left:0}}/*! c */@media print{c{right:0}}", 1},
+		{"@media print { a { top: 0 } } @media print, print { b { left: 0 } } @media print and { c {} } @media print and { d {} }", false,
+			"@media print{a{top:0}b{left:0}}@media print and{c{}}@media print and{d{}}", 1},
+		{"@media (x y) { c {} } @media (X  Y) { d {} }", false, "@media (x y){c{}d{}}", 1},
+		{"@media print { @media print { a { top: 0 } } b { left: 0 } }", true, "@media print{a{top:0}b{left:0}}", 1},
+		{"@media print { @media print { a { top: 0 } } b { left: 0 } }", false, "@media print{@media print{a{top:0}}b{left:0}}", 0},
+		{"@media only screen { @media (min-width: 600px) and (hover) { a { top: 0 } } }", true, "@media only screen and (min-width:600px) and (hover){a{top:0}}", 1},
+		{"@media screen { @media (color) { a { top: 0 } } } @media screen and (color) { b { left: 0 } }", true, "@media screen and (color){a{top:0}b{left:0}}", 2},
+		{"@media screen { @media print { a { top: 0 } } } @media not print { @media (color) { b {} } }", true,
+			"@media screen{@media print{a{top:0}}}@media not print{@media (color){b{}}}", 0},
+	}
+	for _, tc := range tcs {
+		s, err := css.ParseStylesheet(tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		removed := MergeMediaRules(s, MediaOptions{Hoist: tc.hoist})
+		if got := s.String(); got != tc.expected || removed != tc.removed {
+			t.Errorf("%s:\ngot  %q %d\nwant %q %d", tc.input, got, removed, tc.expected, tc.removed)
+		}
+	}
+}
+
+func TestPruneConditionalRules(t *testing.T) {
+	env := &media.Env{Type: "screen", Features: map[string]string{"width": "1024px", "prefers-color-scheme": "dark"}}
+	supports := func(v *css.ComponentValue) media.Result {
+		switch s := css.ValuesString(css.TrimSpace(v.Children)); {
+		case s == "display: grid":
+			return media.True
+		case s == "display: box":
+			return media.False
+		}
+		return media.Unknown
+	}
+	tcs := []struct {
+		input    string
+		expected string
+		pruned   int
+	}{
+		{"@media print { a { top: 0 } } @media screen { b { top: 0 } } c { top: 0 }", "b{top:0}c{top:0}", 2},
+		{"@media (min-width: 600px) and (max-width: 900px) { a { top: 0 } } @media (hover) { b { top: 0 } }", "@media (hover){b{top:0}}", 1},
+		{"@media (prefers-color-scheme: light) { a { top: 0 } } @media not print { @media (width >= 1000px) { b { top: 0 } } }", "b{top:0}", 3},
+		{"@media screen and { a { top: 0 } } @media (x y) { b { top: 0 } }", "@media screen and{a{top:0}}@media (x y){b{top:0}}", 0},
+		{"a { color: red; @media screen { color: blue } @media print { top: 0 } }", "a{color:red;@media screen{color:blue}}", 1},
+		{"/* c */ @media screen { a { top: 0 } }", "/* c */@media screen{a{top:0}}", 0},
+		{"@supports (display: grid) { a { top: 0 } } @supports not (display: grid) { b { top: 0 } }", "a{top:0}", 2},
+		{"@supports (display: box) or (display: grid) { a { top: 0 } } @supports (display: grid) and (x: y) { b { top: 0 } }", "a{top:0}@supports (display: grid) and (x: y){b{top:0}}", 1},
+		{"@supports ((display: box) and (x: y)) or selector(a) { a { top: 0 } } @supports (display: grid) and (x: y) or (z: w) { b { top: 0 } }",
+			"@supports ((display: box) and (x: y)) or selector(a){a{top:0}}@supports (display: grid) and (x: y) or (z: w){b{top:0}}", 0},
+		{"@supports not ((display: box) or (display: box)) { @media print { a { top: 0 } } }", "", 2},
+	}
+	for _, tc := range tcs {
+		s, err := css.ParseStylesheet(tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pruned := PruneConditionalRules(s, PruneOptions{Media: env, Supports: supports})
+		if got := s.String(); got != tc.expected || pruned != tc.pruned {
+			t.Errorf("%s:\ngot  %q %d\nwant %q %d", tc.input, got, pruned, tc.expected, tc.pruned)
+		}
+	}
+}
+
+func TestPurgeRules(t *testing.T) {
+	opts := PurgeOptions{
+		Classes:  []string{"btn", "nav", "a:b"},
+		IDs:      []string{"main"},
+		Elements: []string{"DIV", "a"},
+		Safelist: []*regexp.Regexp{regexp.MustCompile(`^\.js-`), regexp.MustCompile(`^span$`)},
+	}
+	tcs := []struct {
+		input     string
+		expected  string
+		selectors []string
+	}{
+		{".btn { top: 0 } .card { top: 0 } #main a { top: 0 } #other { top: 0 } p { top: 0 }", ".btn{top:0}#main a{top:0}", nil},
+		{".btn, .card > a, div.nav { top: 0 }", ".btn, div.nav{top:0}", []string{".card > a"}},
+		{".js-toggle, span, .a\\:b, [class~=card], *, :root { top: 0 }", ".js-toggle, span, .a\\:b, [class~=card], *, :root{top:0}", nil},
+		{":is(.card, .btn) :not(.card) { top: 0 } :where(.card) { top: 0 } div:has(> .card) { top: 0 } a:hover::before { top: 0 }",
+			":is(.card, .btn) :not(.card){top:0}a:hover::before{top:0}", nil},
+		{"@media print { .card { top: 0 } } @media screen { .card { top: 0 } .btn { top: 0 } } @layer x { .card { top: 0 } } @keyframes card { to { top: 0 } }",
+			"@media screen{.btn{top:0}}@layer x{}@keyframes card{to{top:0}}", nil},
+		{".card { top: 0; .btn { top: 0 } } .btn { top: 0; & .card, & a { top: 0 } @media print { .card & { top: 0 } } }",
+			".btn{top:0;& a{top:0}}", []string{"& .card"}},
+		{"a! { top: 0 }", "a!{top:0}", nil},
+	}
+	for _, tc := range tcs {
+		s, err := css.ParseStylesheet(tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		before := len(s.String())
+		report := PurgeRules(s, opts)
+		got := s.String()
+		if got != tc.expected || !reflect.DeepEqual(report.Selectors, tc.selectors) || report.Bytes != before-len(got) {
+			t.Errorf("%s:\ngot  %q %q %d\nwant %q %q %d", tc.input, got, report.Selectors, report.Bytes, tc.expected, tc.selectors, before-len(tc.expected))
+		}
+	}
+}
+
+func TestPurgeReport(t *testing.T) {
+	s, _ := css.ParseStylesheet("@media print { .card { top: 0 } } .btn { top: 0 }")
+	rules := []*css.Rule{s.Rules[0].Rules[0], s.Rules[0], s.Rules[1]}
+	report := PurgeRules(s, PurgeOptions{Classes: []string{}})
+	if !reflect.DeepEqual(report.Rules, rules) || s.String() != "" {
+		t.Errorf("got %q, %d rules removed, want an empty stylesheet and 3 rules", s.String(), len(report.Rules))
+	}
+}
+
+func TestObfuscate(t *testing.T) {
+	a, _ := css.ParseStylesheet(`.menu, .menu-item:not(.active) > #main { animation: spin 1s, fade 2s; color: var(--main-color) }
+@keyframes spin { from { --angle: 0deg } }
+@media print { .active[class~=menu] { --main-color: red; background: url(#a) } }
+@property --angle { syntax: "<angle>"; inherits: false; initial-value: 0deg }`)
+	b, _ := css.ParseStylesheet(`.js-toggle.menu { animation-name: "spin", other } #\31 23, #main, .b { color: #fff } @keyframes a { }`)
+	m := Obfuscate([]*css.Stylesheet{a, b}, func(kind NameKind, name string) bool {
+		return kind == ClassName && strings.HasPrefix(name, "js-") || kind == KeyframesName && name == "a"
+	})
+	wantA := `.a, .c:not(.b) > #a{animation:b 1s, fade 2s;color:var(--a)}@keyframes b{from{--b:0deg}}@media print{.b[class~=menu]{--a:red;background:url(#a)}}@property --b{syntax:"<angle>";inherits:false;initial-value:0deg}`
+	wantB := `.js-toggle.a{animation-name:b, other}#\31 23, #a, .d{color:#fff}@keyframes a{}`
+	if got := a.String(); got != wantA {
+		t.Errorf("got  %s\nwant %s", got, wantA)
+	}
+	if got := b.String(); got != wantB {
+		t.Errorf("got  %s\nwant %s", got, wantB)
+	}
+	want := Mapping{
+		ClassName:          {"menu": "a", "active": "b", "menu-item": "c", "b": "d"},
+		IDName:             {"main": "a"},
+		KeyframesName:      {"spin": "b"},
+		CustomPropertyName: {"--main-color": "--a", "--angle": "--b"},
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("got  %v\nwant %v", m, want)
+	}
+}
+
+func TestGenerateName(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 50000; i++ {
+		name := generateName(i)
+		if seen[name] || name[0] < 'a' || name[0] > 'z' {
+			t.Fatalf("%d: bad name %q", i, name)
+		}
+		seen[name] = true
+	}
+	if got := generateName(26) + " " + generateName(27); got != "aa ba" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestResolveURLs(t *testing.T) {
+	s, err := css.ParseStylesheet(`@import "a.css"; a { background: url(../b.png), url(#c), url("data:,d"); cursor: url(https://x/e) }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base, _ := url.Parse("https://example.com/css/site.css")
+	ResolveURLs(s, base)
+	want := `@import "https://example.com/css/a.css";a{background:url("https://example.com/b.png"), url(#c), url("data:,d");cursor:url(https://x/e)}`
+	if got := s.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	s, err := css.ParseStylesheet("a { display: flex; background: url(b.png); behavior: url(c.htc) }")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &Pipeline{}
+	p.Targets = prefix.Targets{prefix.Safari: 8}
+	p.BaseURL, _ = url.Parse("https://example.com/")
+	var order []string
+	p.Add("sanitize", Sanitize(sanitize.UserContent()))
+	p.Add("prefix", Prefix())
+	p.Add("resolve", Resolve())
+	p.Add("minify", Minify(minify.Options{}))
+	p.Add("record", Func(func(s *css.Stylesheet) error {
+		order = append(order, s.String())
+		return nil
+	}))
+	results, err := p.Run(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `a{display:-webkit-flex;display:flex;background:url("https://example.com/b.png")}`
+	if len(order) != 1 || order[0] != want {
+		t.Errorf("got %q, want %q", order, want)
+	}
+	var names []string
+	for _, r := range results {
+		names = append(names, r.Name)
+	}
+	if got := strings.Join(names, " "); got != "sanitize prefix resolve minify record" {
+		t.Errorf("got results %q", got)
+	}
+	if d := results[0].Diagnostics; len(d) != 1 || d[0].Code != "removed-property" || d[0].Span.Start.Column != 44 {
+		t.Errorf("got diagnostics %+v, want the removal of behavior", d)
+	}
+	if len(results[1].Diagnostics) != 0 {
+		t.Errorf("got diagnostics %+v for prefix, want none", results[1].Diagnostics)
+	}
+
+	p = &Pipeline{}
+	p.Add("report", ContextFunc(func(c *Context, s *css.Stylesheet) error {
+		c.Report(validate.Diagnostic{Code: "x"})
+		return errors.New("failed")
+	}))
+	p.Add("never", Func(func(s *css.Stylesheet) error {
+		t.Error("transform after a failed one was run")
+		return nil
+	}))
+	results, err = p.Run(s)
+	if err == nil || err.Error() != "transform: report: failed" || len(results) != 1 || len(results[0].Diagnostics) != 1 {
+		t.Errorf("got %+v, %v, want the result and error of the failed transform", results, err)
+	}
+}
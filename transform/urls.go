@@ -0,0 +1,102 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transform
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/sanitize"
+	"github.com/gorilla/css/scanner"
+)
+
+// RewriteURLs replaces the URLs referenced by a stylesheet, as listed by
+// css.ExtractURLs, by the result of fn. fn is called with the URL with its
+// escape sequences decoded, and URLs for which it returns its argument are
+// left as written.
+func RewriteURLs(s *css.Stylesheet, fn func(u string) string) {
+	rewriteRules(s.Rules, fn)
+}
+
+// ResolveURLs resolves the relative URLs referenced by a stylesheet against
+// base, the URL of the stylesheet, so that the stylesheet can be served from
+// another location. Fragment-only URLs, such as url(#clip) which references
+// an element of the document, and data URLs are left as written.
+func ResolveURLs(s *css.Stylesheet, base *url.URL) {
+	RewriteURLs(s, func(u string) string {
+		trimmed := strings.TrimSpace(u)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			return u
+		}
+		ref, err := url.Parse(trimmed)
+		if err != nil || ref.IsAbs() {
+			return u
+		}
+		return base.ResolveReference(ref).String()
+	})
+}
+
+// rewriteRules rewrites the URLs of a list of rules.
+func rewriteRules(rules []*css.Rule, fn func(u string) string) {
+	for _, r := range rules {
+		if strings.EqualFold(scanner.Unescape(r.AtKeyword), "import") {
+			for _, v := range r.Prelude {
+				if t := v.Token; t.Type == scanner.TokenURI || t.Type == scanner.TokenString {
+					rewriteToken(t, fn)
+					break
+				}
+				if v.Token.Type != scanner.TokenS {
+					rewriteValues([]*css.ComponentValue{v}, fn)
+					break
+				}
+			}
+		}
+		for _, d := range r.Declarations {
+			rewriteValues(d.Value, fn)
+		}
+		rewriteRules(r.Rules, fn)
+	}
+}
+
+// rewriteValues rewrites the URLs of a list of component values.
+func rewriteValues(values []*css.ComponentValue, fn func(u string) string) {
+	for _, v := range values {
+		t := v.Token
+		switch {
+		case t.Type == scanner.TokenURI:
+			rewriteToken(t, fn)
+		case v.IsFunction():
+			switch strings.ToLower(t.DecodedValue()) {
+			case "url", "src":
+				if args := css.TrimSpace(v.Children); len(args) > 0 && args[0].Token.Type == scanner.TokenString {
+					rewriteToken(args[0].Token, fn)
+				}
+				continue
+			case "image-set", "-webkit-image-set", "image":
+				// Strings are URLs in these functions.
+				for _, arg := range v.Children {
+					if arg.Token.Type == scanner.TokenString {
+						rewriteToken(arg.Token, fn)
+					}
+				}
+			}
+		}
+		rewriteValues(v.Children, fn)
+	}
+}
+
+// rewriteToken rewrites the URL of a url token or a string.
+func rewriteToken(t *scanner.Token, fn func(u string) string) {
+	u := t.DecodedValue()
+	rewritten := fn(u)
+	if rewritten == u {
+		return
+	}
+	t.Value = `"` + sanitize.EscapeCSSString(rewritten) + `"`
+	if t.Type == scanner.TokenURI {
+		t.Value = "url(" + t.Value + ")"
+	}
+}
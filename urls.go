@@ -0,0 +1,250 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package css
+
+import (
+	"strings"
+
+	"github.com/gorilla/css/scanner"
+)
+
+// URLContext is the syntactic context of a URL, which tells what kind of
+// resource it references.
+type URLContext int
+
+const (
+	// URLOther is used for URLs in other properties, such as filter or
+	// clip-path, and in custom properties.
+	URLOther URLContext = iota
+	// URLImport is used for the stylesheet of an @import rule.
+	URLImport
+	// URLFont is used for the src descriptor of @font-face.
+	URLFont
+	// URLImage is used for properties whose URLs are images, such as
+	// background-image, list-style-image or content.
+	URLImage
+	// URLCursor is used for the cursor property.
+	URLCursor
+)
+
+// String returns a string representation of the context.
+func (c URLContext) String() string {
+	switch c {
+	case URLImport:
+		return "import"
+	case URLFont:
+		return "font"
+	case URLImage:
+		return "image"
+	case URLCursor:
+		return "cursor"
+	}
+	return "other"
+}
+
+// URLRef is a reference to an external resource found in a stylesheet.
+type URLRef struct {
+	// URL is the URL with its escape sequences decoded.
+	URL string
+	// Raw is the url token or string as written in the input, such as
+	// url("a.png") or 'a.css'.
+	Raw     string
+	Context URLContext
+	// Property is the lowercase property of the declaration the URL was
+	// found in, or an empty string for @import.
+	Property string
+	Line     int
+	Column   int
+}
+
+// imageProperties are the properties whose URLs are images, without
+// vendor prefix.
+var imageProperties = map[string]bool{
+	"background":          true,
+	"background-image":    true,
+	"border-image":        true,
+	"border-image-source": true,
+	"content":             true,
+	"list-style":          true,
+	"list-style-image":    true,
+	"mask":                true,
+	"mask-image":          true,
+	"mask-border":         true,
+	"mask-border-source":  true,
+	"shape-outside":       true,
+}
+
+// ExtractURLs returns the URLs referenced by a stylesheet: the url tokens
+// and url() functions of declarations, the strings of image-set(), and the
+// stylesheets of @import rules. They are returned in the order of the
+// rules, the declarations of a rule coming before its nested rules.
+//
+// URLs in other at-rule preludes, such as the namespace of @namespace,
+// aren't references and are not returned.
+func ExtractURLs(s *Stylesheet) []URLRef {
+	return extractRuleURLs(nil, s.Rules, false)
+}
+
+// extractRuleURLs appends the URLs of a list of rules to refs. inFontFace
+// reports whether the rules are in a @font-face rule.
+func extractRuleURLs(refs []URLRef, rules []*Rule, inFontFace bool) []URLRef {
+	for _, r := range rules {
+		name := strings.ToLower(scanner.Unescape(r.AtKeyword))
+		if name == "import" {
+			for _, v := range r.Prelude {
+				if t := v.Token; t.Type == scanner.TokenURI || t.Type == scanner.TokenString {
+					refs = append(refs, newURLRef(t, URLImport, ""))
+					break
+				}
+				if v.Token.Type != scanner.TokenS {
+					refs = extractURLs(refs, []*ComponentValue{v}, URLImport, "")
+					break
+				}
+			}
+		}
+		fontFace := inFontFace || name == "font-face"
+		for _, d := range r.Declarations {
+			property := scanner.Unescape(d.Property)
+			if !strings.HasPrefix(property, "--") {
+				property = strings.ToLower(property)
+			}
+			context := URLOther
+			switch unprefixed := trimVendorPrefix(property); {
+			case fontFace && property == "src":
+				context = URLFont
+			case unprefixed == "cursor":
+				context = URLCursor
+			case imageProperties[unprefixed]:
+				context = URLImage
+			}
+			refs = extractURLs(refs, d.Value, context, property)
+		}
+		refs = extractRuleURLs(refs, r.Rules, fontFace)
+	}
+	return refs
+}
+
+// extractURLs appends the URLs of a list of component values to refs.
+func extractURLs(refs []URLRef, values []*ComponentValue, context URLContext, property string) []URLRef {
+	for _, v := range values {
+		t := v.Token
+		switch {
+		case t.Type == scanner.TokenURI:
+			refs = append(refs, newURLRef(t, context, property))
+		case v.IsFunction():
+			switch strings.ToLower(t.DecodedValue()) {
+			case "url", "src":
+				// A url() that isn't a url token, such as url("a" b), or its
+				// variant src().
+				if args := TrimSpace(v.Children); len(args) > 0 && args[0].Token.Type == scanner.TokenString {
+					refs = append(refs, newURLRef(args[0].Token, context, property))
+				}
+				continue
+			case "image-set", "-webkit-image-set", "image":
+				// Strings are URLs in these functions.
+				for _, arg := range v.Children {
+					if arg.Token.Type == scanner.TokenString {
+						refs = append(refs, newURLRef(arg.Token, context, property))
+					}
+				}
+			}
+		}
+		refs = extractURLs(refs, v.Children, context, property)
+	}
+	return refs
+}
+
+// newURLRef returns a reference to the URL of a url token or a string.
+func newURLRef(t *scanner.Token, context URLContext, property string) URLRef {
+	return URLRef{t.DecodedValue(), t.Value, context, property, t.Line, t.Column}
+}
+
+// trimVendorPrefix returns a property name without its vendor prefix, such
+// as "-webkit-".
+func trimVendorPrefix(property string) string {
+	if len(property) < 2 || property[0] != '-' || property[1] == '-' {
+		return property
+	}
+	if i := strings.IndexByte(property[1:], '-'); i >= 0 {
+		return property[i+2:]
+	}
+	return property
+}
+
+// DataURL describes a data URL, such as "data:image/png;base64,iVBO...".
+type DataURL struct {
+	// MediaType is the lowercase media type, without its parameters, such as
+	// "image/png". It is "text/plain" if the URL has none.
+	MediaType string
+	// Base64 reports whether the data is base64-encoded.
+	Base64 bool
+	// Size is the size of the decoded data, in bytes.
+	Size int
+}
+
+// Data parses the URL as a data URL, and reports whether it is one.
+func (r URLRef) Data() (DataURL, bool) {
+	return ParseDataURL(r.URL)
+}
+
+// ParseDataURL parses a URL, with its escape sequences decoded, as a data
+// URL, and reports whether it is one. The data isn't decoded, but its size
+// is computed as browsers would decode it: percent-encoded bytes count as
+// one byte, and whitespace in base64 data is ignored.
+func ParseDataURL(u string) (DataURL, bool) {
+	u = strings.TrimLeft(u, " \t\n\r\f")
+	if len(u) < 5 || !strings.EqualFold(u[:5], "data:") {
+		return DataURL{}, false
+	}
+	header, data, ok := strings.Cut(u[5:], ",")
+	if !ok {
+		return DataURL{}, false
+	}
+	var d DataURL
+	if i := strings.LastIndexByte(header, ';'); i >= 0 && strings.EqualFold(strings.TrimSpace(header[i+1:]), "base64") {
+		d.Base64 = true
+		header = header[:i]
+	}
+	if i := strings.IndexByte(header, ';'); i >= 0 {
+		header = header[:i]
+	}
+	d.MediaType = strings.ToLower(strings.TrimSpace(header))
+	if !strings.Contains(d.MediaType, "/") {
+		d.MediaType = "text/plain"
+	}
+	// The data is percent-decoded, then base64-decoded if needed.
+	n := 0
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if c == '%' && i+2 < len(data) && isHexDigit(data[i+1]) && isHexDigit(data[i+2]) {
+			c = unhex(data[i+1])<<4 | unhex(data[i+2])
+			i += 2
+		}
+		if !d.Base64 || c != '=' && strings.IndexByte(" \t\n\r\f", c) < 0 {
+			n++
+		}
+	}
+	if d.Base64 {
+		n = n * 3 / 4
+	}
+	d.Size = n
+	return d, true
+}
+
+// isHexDigit reports whether c is a hexadecimal digit.
+func isHexDigit(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+// unhex returns the value of a hexadecimal digit.
+func unhex(c byte) byte {
+	switch {
+	case c >= 'a':
+		return c - 'a' + 10
+	case c >= 'A':
+		return c - 'A' + 10
+	}
+	return c - '0'
+}
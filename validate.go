@@ -0,0 +1,32 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package css
+
+import (
+	"io"
+
+	"github.com/gorilla/css/scanner"
+)
+
+// Validate reads a stylesheet from r and reports the errors that
+// ParseStylesheet would return for it, without building tokens or rules.
+// It is meant for linters and gates that only need to know whether the
+// input is well-formed.
+//
+// The only errors are an unclosed quotation mark and an unclosed comment,
+// and tokenizing stops at the first one, so there is at most one error; the
+// result is nil if the input is well-formed. If reading from r fails, the
+// error is returned as a *ParseError wrapping it, with its message and no
+// position.
+func Validate(r io.Reader) []*ParseError {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return []*ParseError{{Msg: err.Error(), Err: err}}
+	}
+	if t := scanner.NewBytesNoCopy(b).Check(); t != nil {
+		return []*ParseError{newParseError(t)}
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package validate
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/scanner"
+)
+
+// Confusables walks a stylesheet and reports the names that may not be what
+// they look like to a reviewer: at-rule, property, function and keyword
+// names, class names, IDs and custom properties that have bidirectional
+// control characters or invisible characters, or that mix Latin letters
+// with lookalike letters of other scripts, such as a Cyrillic "а" in
+// ".pаy". Names consisting only of lookalike letters of a single other
+// script are reported too.
+//
+// Escape sequences are decoded before checking names, so that "\200B" is
+// reported like a literal zero-width space. The suggestion of each
+// diagnostic is the name without its control and invisible characters, and
+// with its lookalike letters replaced by Latin ones.
+func Confusables(s *css.Stylesheet) []Diagnostic {
+	var diags []Diagnostic
+	confusableRules(s.Rules, &diags)
+	return diags
+}
+
+// confusableRules appends the diagnostics of a list of rules to diags.
+func confusableRules(rules []*css.Rule, diags *[]Diagnostic) {
+	for _, r := range rules {
+		if r.IsAtRule() {
+			checkName(scanner.Unescape(r.AtKeyword), nameSpan(r.Line, r.Column, "@"+r.AtKeyword), diags)
+		}
+		confusableValues(r.Prelude, diags)
+		for _, d := range r.Declarations {
+			checkName(scanner.Unescape(d.Property), nameSpan(d.Line, d.Column, d.Property), diags)
+			confusableValues(d.Value, diags)
+		}
+		confusableRules(r.Rules, diags)
+	}
+}
+
+// confusableValues appends the diagnostics of the names of a list of
+// component values to diags.
+func confusableValues(values []*css.ComponentValue, diags *[]Diagnostic) {
+	for _, v := range values {
+		switch v.Token.Type {
+		case scanner.TokenIdent, scanner.TokenHash, scanner.TokenFunction, scanner.TokenAtKeyword:
+			checkName(v.Token.DecodedValue(), Span{
+				Start: Position{Line: v.Token.Line, Column: v.Token.Column},
+				End:   advance(Position{Line: v.Token.Line, Column: v.Token.Column}, v.Token.Value),
+			}, diags)
+		}
+		confusableValues(v.Children, diags)
+	}
+}
+
+// checkName appends the diagnostics of a decoded name to diags.
+func checkName(name string, span Span, diags *[]Diagnostic) {
+	var bidi, invisible bool
+	scripts := 0
+	lookalikes := true
+	for _, r := range name {
+		switch {
+		case isBidiControl(r):
+			bidi = true
+		case isInvisible(r):
+			invisible = true
+		case unicode.IsLetter(r):
+			scripts |= letterScript(r)
+			if _, ok := latinLookalikes[r]; !ok {
+				lookalikes = false
+			}
+		}
+	}
+	if !bidi && !invisible && scripts&^latin == 0 {
+		return
+	}
+	add := func(code, msg string) {
+		*diags = append(*diags, Diagnostic{
+			Code:       code,
+			Severity:   Warning,
+			Message:    msg,
+			Suggestion: normalizeName(name),
+			Span:       span,
+		})
+	}
+	if bidi {
+		add(CodeBidiControl, fmt.Sprintf("name %+q has bidirectional control characters", name))
+	}
+	if invisible {
+		add(CodeInvisibleChar, fmt.Sprintf("name %+q has invisible characters", name))
+	}
+	switch {
+	case scripts&latin != 0 && scripts&^latin != 0:
+		add(CodeMixedScript, fmt.Sprintf("name %+q mixes Latin letters with letters of other scripts", name))
+	case scripts != 0 && scripts&(scripts-1) == 0 && lookalikes:
+		add(CodeConfusable, fmt.Sprintf("name %+q only has letters that look like Latin letters", name))
+	}
+}
+
+// The scripts of letters that can be confused with Latin letters.
+const (
+	latin = 1 << iota
+	greek
+	cyrillic
+	armenian
+	cherokee
+)
+
+// letterScript returns the script of a letter among those that can be
+// confused, or 0.
+func letterScript(r rune) int {
+	switch {
+	case r < 0x80 || unicode.Is(unicode.Latin, r):
+		return latin
+	case unicode.Is(unicode.Greek, r):
+		return greek
+	case unicode.Is(unicode.Cyrillic, r):
+		return cyrillic
+	case unicode.Is(unicode.Armenian, r):
+		return armenian
+	case unicode.Is(unicode.Cherokee, r):
+		return cherokee
+	}
+	return 0
+}
+
+// isBidiControl reports whether r is a bidirectional control character.
+func isBidiControl(r rune) bool {
+	return r == 0x061c || r == 0x200e || r == 0x200f ||
+		r >= 0x202a && r <= 0x202e || r >= 0x2066 && r <= 0x2069
+}
+
+// isInvisible reports whether r is a character that is rendered without a
+// glyph, such as a zero-width space or a variation selector.
+func isInvisible(r rune) bool {
+	switch {
+	case r == 0x00ad, r == 0x034f, r == 0x115f, r == 0x1160, r == 0x180e,
+		r >= 0x200b && r <= 0x200d, r >= 0x2060 && r <= 0x2064,
+		r == 0x3164, r == 0xfeff, r == 0xffa0,
+		r >= 0xfe00 && r <= 0xfe0f, r >= 0xe0000 && r <= 0xe01ef:
+		return true
+	}
+	return false
+}
+
+// latinLookalikes maps letters of other scripts to the Latin letters they
+// look like.
+var latinLookalikes = map[rune]rune{
+	// Cyrillic.
+	'а': 'a', 'в': 'b', 'е': 'e', 'о': 'o', 'р': 'p', 'с': 'c', 'у': 'y',
+	'х': 'x', 'і': 'i', 'ј': 'j', 'ѕ': 's', 'ԁ': 'd', 'ԛ': 'q', 'ԝ': 'w',
+	'һ': 'h', 'ӏ': 'l', 'А': 'A', 'В': 'B', 'Е': 'E', 'К': 'K', 'М': 'M',
+	'Н': 'H', 'О': 'O', 'Р': 'P', 'С': 'C', 'Т': 'T', 'Х': 'X', 'У': 'Y',
+	'І': 'I', 'Ј': 'J', 'Ѕ': 'S',
+	// Greek.
+	'ο': 'o', 'ν': 'v', 'ρ': 'p', 'ι': 'i', 'κ': 'k', 'Α': 'A', 'Β': 'B',
+	'Ε': 'E', 'Ζ': 'Z', 'Η': 'H', 'Ι': 'I', 'Κ': 'K', 'Μ': 'M', 'Ν': 'N',
+	'Ο': 'O', 'Ρ': 'P', 'Τ': 'T', 'Υ': 'Y', 'Χ': 'X',
+	// Armenian.
+	'օ': 'o', 'ս': 'u', 'հ': 'h', 'ո': 'n',
+}
+
+// normalizeName returns a name without bidirectional control characters and
+// invisible characters, and with lookalike letters replaced by Latin ones.
+func normalizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if isBidiControl(r) || isInvisible(r) {
+			return -1
+		}
+		if l, ok := latinLookalikes[r]; ok {
+			return l
+		}
+		return r
+	}, name)
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package validate
+
+import (
+	"fmt"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/prefix"
+	"github.com/gorilla/css/props"
+	"github.com/gorilla/css/scanner"
+)
+
+// Stylesheet validates all the declarations of a stylesheet with Validate,
+// and reports deprecated or nonstandard at-rules.
+func Stylesheet(s *css.Stylesheet) []Diagnostic {
+	return walk(s.Rules, nil, func(d *css.Declaration, diags []Diagnostic) []Diagnostic {
+		return append(diags, Validate(d)...)
+	})
+}
+
+// Deprecated walks a stylesheet and reports deprecated or nonstandard
+// properties, keyword values and at-rules, with their replacement as the
+// suggestion when there is one.
+//
+// Vendor-prefixed at-rules are looked up without their prefix. Prefixed
+// properties and values are not reported: they are handled by the
+// gorilla/css/prefix package.
+func Deprecated(s *css.Stylesheet) []Diagnostic {
+	return walk(s.Rules, nil, checkStatus)
+}
+
+// walk checks the at-rules of a list of rules and their contents, calls fn
+// for each declaration, and returns the diagnostics appended to diags.
+func walk(rules []*css.Rule, diags []Diagnostic, fn func(*css.Declaration, []Diagnostic) []Diagnostic) []Diagnostic {
+	for _, r := range rules {
+		if r.IsAtRule() {
+			diags = checkAtRule(r, diags)
+		}
+		for _, d := range r.Declarations {
+			diags = fn(d, diags)
+		}
+		diags = walk(r.Rules, diags, fn)
+	}
+	return diags
+}
+
+// checkAtRule appends to diags a diagnostic for a deprecated or nonstandard
+// at-rule.
+func checkAtRule(r *css.Rule, diags []Diagnostic) []Diagnostic {
+	a := props.LookupAtRule(prefix.Strip(r.AtKeyword))
+	if a == nil || a.Status == props.Standard {
+		return diags
+	}
+	return append(diags, statusDiagnostic(
+		CodeDeprecatedAtRule,
+		fmt.Sprintf("at-rule %q is %s", "@"+r.AtKeyword, a.Status),
+		a.Replacement, nameSpan(r.Line, r.Column, "@"+r.AtKeyword)))
+}
+
+// checkStatus appends to diags the diagnostics for a deprecated or
+// nonstandard property or keyword.
+func checkStatus(d *css.Declaration, diags []Diagnostic) []Diagnostic {
+	p := props.Lookup(d.Property)
+	if p == nil {
+		return diags
+	}
+	if p.Status != props.Standard {
+		code := CodeDeprecatedProp
+		if p.Status == props.Nonstandard {
+			code = CodeNonstandardProp
+		}
+		diags = append(diags, statusDiagnostic(code,
+			fmt.Sprintf("property %q is %s", d.Property, p.Status),
+			p.Replacement, declarationSpan(d)))
+	}
+	for _, v := range d.Value {
+		if v.Token.Type != scanner.TokenIdent {
+			continue
+		}
+		if k := p.KeywordStatus(v.Token.Value); k.Status != props.Standard {
+			diags = append(diags, statusDiagnostic(CodeDeprecatedKeyword,
+				fmt.Sprintf("keyword %q for property %q is %s", v.Token.Value, p.Name, k.Status),
+				k.Replacement, valueSpan(v)))
+		}
+	}
+	return diags
+}
+
+// statusDiagnostic returns a warning suggesting a replacement, if any.
+func statusDiagnostic(code, msg, replacement string, span Span) Diagnostic {
+	if replacement != "" {
+		msg += fmt.Sprintf(", use %s instead", replacement)
+	}
+	return Diagnostic{
+		Code:       code,
+		Severity:   Warning,
+		Message:    msg,
+		Suggestion: replacement,
+		Span:       span,
+	}
+}
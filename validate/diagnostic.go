@@ -0,0 +1,87 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package validate
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/diag"
+)
+
+// Severity is the severity of a diagnostic.
+type Severity = diag.Severity
+
+const (
+	// Error is used for values that are invalid and dropped by browsers.
+	Error = diag.Error
+	// Warning is used for valid but discouraged constructs.
+	Warning = diag.Warning
+)
+
+// Diagnostic codes. They are stable and meant to be matched by tools.
+const (
+	CodeUnknownKeyword    = "unknown-keyword"
+	CodeInvalidValue      = "invalid-value"
+	CodeDeprecatedProp    = "deprecated-property"
+	CodeNonstandardProp   = "nonstandard-property"
+	CodeDeprecatedKeyword = "deprecated-keyword"
+	CodeDeprecatedAtRule  = "deprecated-at-rule"
+	CodeBidiControl       = "bidi-control"
+	CodeInvisibleChar     = "invisible-character"
+	CodeMixedScript       = "mixed-script"
+	CodeConfusable        = "confusable-name"
+	CodeUnknownProp       = "unknown-property"
+	CodeDuplicateDecl     = "duplicate-declaration"
+	CodeDuplicateSelector = "duplicate-selector"
+	CodeDuplicateBlock    = "duplicate-block"
+	CodeEmptyRule         = "empty-rule"
+	CodeHighSpecificity   = "high-specificity"
+)
+
+// Position, Span, Related and Diagnostic are those of package
+// gorilla/css/diag, shared with the parser and the sanitizer.
+type (
+	Position   = diag.Position
+	Span       = diag.Span
+	Related    = diag.Related
+	Diagnostic = diag.Diagnostic
+)
+
+// advance returns the position after text, starting at p.
+func advance(p Position, text string) Position {
+	if i := strings.LastIndex(text, "\n"); i >= 0 {
+		return Position{Line: p.Line + strings.Count(text, "\n"), Column: utf8.RuneCountInString(text[i:])}
+	}
+	return Position{Line: p.Line, Column: p.Column + utf8.RuneCountInString(text)}
+}
+
+// valueSpan returns the span of a component value. Comments inside functions
+// and blocks are not accounted for.
+func valueSpan(v *css.ComponentValue) Span {
+	start := Position{Line: v.Token.Line, Column: v.Token.Column}
+	return Span{Start: start, End: advance(start, v.String())}
+}
+
+// declarationSpan returns the span of a declaration, from its property to
+// the end of its value.
+func declarationSpan(d *css.Declaration) Span {
+	start := Position{Line: d.Line, Column: d.Column}
+	end := advance(start, d.Property)
+	for i := len(d.Value) - 1; i >= 0; i-- {
+		if v := d.Value[i]; v.Token.Line > 0 {
+			end = valueSpan(v).End
+			break
+		}
+	}
+	return Span{Start: start, End: end}
+}
+
+// nameSpan returns the span of a name starting at the given position.
+func nameSpan(line, column int, name string) Span {
+	start := Position{Line: line, Column: column}
+	return Span{Start: start, End: advance(start, name)}
+}
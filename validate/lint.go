@@ -0,0 +1,284 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package validate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/prefix"
+	"github.com/gorilla/css/props"
+	"github.com/gorilla/css/scanner"
+	"github.com/gorilla/css/selector"
+)
+
+// UnknownProperties walks a stylesheet and reports the properties that
+// aren't in the property table of gorilla/css/props, with the closest known
+// property as the suggestion. Custom properties and vendor-prefixed
+// properties are not reported. The diagnostics are warnings, since the
+// table may lag behind the properties implemented by browsers.
+func UnknownProperties(s *css.Stylesheet) []Diagnostic {
+	var names []string
+	return walk(s.Rules, nil, func(d *css.Declaration, diags []Diagnostic) []Diagnostic {
+		name := scanner.Unescape(d.Property)
+		if strings.HasPrefix(name, "--") || prefix.Has(name) || props.Lookup(name) != nil {
+			return diags
+		}
+		if names == nil {
+			for _, p := range props.All() {
+				names = append(names, p.Name)
+			}
+		}
+		diag := Diagnostic{
+			Code:     CodeUnknownProp,
+			Severity: Warning,
+			Message:  fmt.Sprintf("unknown property %q", d.Property),
+			Span:     nameSpan(d.Line, d.Column, d.Property),
+		}
+		if s := closest(strings.ToLower(name), names); s != "" {
+			diag.Suggestion = s
+			diag.Message += fmt.Sprintf(", did you mean %q?", s)
+		}
+		return append(diags, diag)
+	})
+}
+
+// Duplicates walks a stylesheet and reports the declarations of a property
+// already declared in the same block, related to the first one, telling
+// which of the two wins: the last one, unless only the first one is
+// !important. Consecutive declarations of a property with different values
+// are not reported, since they are the usual way to provide a fallback for
+// browsers that don't support the second value, as in
+// "width: 100px; width: calc(100% - 1em)".
+func Duplicates(s *css.Stylesheet) []Diagnostic {
+	return duplicateRules(s.Rules, nil)
+}
+
+// duplicateRules appends the diagnostics of a list of rules to diags.
+func duplicateRules(rules []*css.Rule, diags []Diagnostic) []Diagnostic {
+	for _, r := range rules {
+		seen := map[string]int{}
+		for i, d := range r.Declarations {
+			name := strings.ToLower(scanner.Unescape(d.Property))
+			if j, ok := seen[name]; ok {
+				prev := r.Declarations[j]
+				fallback := j == i-1 && css.ValuesString(css.TrimSpace(prev.Value)) != css.ValuesString(css.TrimSpace(d.Value))
+				if !fallback {
+					winner := "this one overrides it"
+					if prev.Important && !d.Important {
+						winner = "it overrides this one since it is !important"
+					}
+					diags = append(diags, Diagnostic{
+						Code:     CodeDuplicateDecl,
+						Severity: Warning,
+						Message:  fmt.Sprintf("property %q is already declared at line %d, column %d, and %s", d.Property, prev.Line, prev.Column, winner),
+						Span:     declarationSpan(d),
+						Related:  []Related{{Message: "first declaration", Span: declarationSpan(prev)}},
+					})
+				}
+			}
+			seen[name] = i
+		}
+		diags = duplicateRules(r.Rules, diags)
+	}
+	return diags
+}
+
+// DuplicateRules walks a stylesheet and reports the style rules with the
+// same selectors as a previous rule of the same block, such as the second
+// rule of "a, b { color: red } b,a { margin: 0 }", and the style rules with
+// the same declarations as a previous rule of the same block, which may be
+// merged into one if the rules between them don't declare the same
+// properties. Selectors are compared ignoring whitespace and their
+// order in the list, and declarations ignoring whitespace and the case of
+// property names. Rules with nested rules are not compared by their
+// declarations.
+func DuplicateRules(s *css.Stylesheet) []Diagnostic {
+	return duplicateBlocks(s.Rules, nil)
+}
+
+// duplicateBlocks appends the diagnostics of a list of rules to diags.
+func duplicateBlocks(rules []*css.Rule, diags []Diagnostic) []Diagnostic {
+	selectors := map[string]*css.Rule{}
+	blocks := map[string]*css.Rule{}
+	for _, r := range rules {
+		diags = duplicateBlocks(r.Rules, diags)
+		if r.IsAtRule() {
+			continue
+		}
+		text := css.ValuesString(css.TrimSpace(r.Prelude))
+		key := selectorKey(r.Prelude)
+		if prev := selectors[key]; prev != nil {
+			diags = append(diags, duplicateRule(CodeDuplicateSelector, fmt.Sprintf("selector %q is already used at line %d, column %d", text, prev.Line, prev.Column), r, prev))
+			continue
+		}
+		selectors[key] = r
+		if len(r.Declarations) == 0 || len(r.Rules) > 0 {
+			continue
+		}
+		key = declarationsKey(r.Declarations)
+		if prev := blocks[key]; prev != nil {
+			prevText := css.ValuesString(css.TrimSpace(prev.Prelude))
+			diags = append(diags, duplicateRule(CodeDuplicateBlock, fmt.Sprintf("rule %q has the same declarations as rule %q at line %d, column %d", text, prevText, prev.Line, prev.Column), r, prev))
+			continue
+		}
+		blocks[key] = r
+	}
+	return diags
+}
+
+// duplicateRule returns the diagnostic of the rule r, a duplicate of prev.
+func duplicateRule(code, msg string, r, prev *css.Rule) Diagnostic {
+	return Diagnostic{
+		Code:     code,
+		Severity: Warning,
+		Message:  msg,
+		Span:     nameSpan(r.Line, r.Column, css.ValuesString(css.TrimSpace(r.Prelude))),
+		Related:  []Related{{Message: "first rule", Span: nameSpan(prev.Line, prev.Column, css.ValuesString(css.TrimSpace(prev.Prelude)))}},
+	}
+}
+
+// selectorKey returns the selectors of a prelude in a form for comparing
+// them: the selectors of the list, with their whitespace normalized, sorted
+// and without duplicates.
+func selectorKey(prelude []*css.ComponentValue) string {
+	var list []string
+	start := 0
+	for i := 0; i <= len(prelude); i++ {
+		if i == len(prelude) || isChar(prelude[i], ",") {
+			list = append(list, valuesKey(css.TrimSpace(prelude[start:i])))
+			start = i + 1
+		}
+	}
+	sort.Strings(list)
+	out := list[:0]
+	for i, sel := range list {
+		if i == 0 || sel != list[i-1] {
+			out = append(out, sel)
+		}
+	}
+	return strings.Join(out, ",")
+}
+
+// declarationsKey returns declarations in a form for comparing them.
+func declarationsKey(decls []*css.Declaration) string {
+	var b strings.Builder
+	for _, d := range decls {
+		b.WriteString(strings.ToLower(scanner.Unescape(d.Property)))
+		b.WriteByte(':')
+		b.WriteString(valuesKey(css.TrimSpace(d.Value)))
+		if d.Important {
+			b.WriteString("!important")
+		}
+		b.WriteByte(';')
+	}
+	return b.String()
+}
+
+// valuesKey returns values in a form for comparing them: their
+// serialization with each run of whitespace written as a space, except
+// around commas, combinators and slashes, where it is dropped.
+func valuesKey(values []*css.ComponentValue) string {
+	var b strings.Builder
+	writeValuesKey(&b, values)
+	return b.String()
+}
+
+// writeValuesKey writes the key of values to b.
+func writeValuesKey(b *strings.Builder, values []*css.ComponentValue) {
+	for i, v := range values {
+		if v.Token.Type == scanner.TokenS {
+			if i > 0 && i < len(values)-1 && !isChar(values[i-1], ",>+~/") && !isChar(values[i+1], ",>+~/") {
+				b.WriteByte(' ')
+			}
+			continue
+		}
+		b.WriteString(v.Token.Value)
+		switch {
+		case v.IsFunction():
+			writeValuesKey(b, v.Children)
+			b.WriteByte(')')
+		case v.IsBlock():
+			writeValuesKey(b, v.Children)
+			b.WriteString(closingBracket(v.Token.Value))
+		}
+	}
+}
+
+// closingBracket returns the bracket closing the block opened by open.
+func closingBracket(open string) string {
+	switch open {
+	case "(":
+		return ")"
+	case "[":
+		return "]"
+	}
+	return "}"
+}
+
+// isChar reports whether v is one of the delimiters of chars.
+func isChar(v *css.ComponentValue, chars string) bool {
+	return v.Token.Type == scanner.TokenChar && len(v.Token.Value) == 1 && strings.Contains(chars, v.Token.Value)
+}
+
+// EmptyRules walks a stylesheet and reports the rules with an empty block,
+// such as "a {}" or "@media print {}", which have no effect.
+func EmptyRules(s *css.Stylesheet) []Diagnostic {
+	return emptyRules(s.Rules, nil)
+}
+
+// emptyRules appends the diagnostics of a list of rules to diags.
+func emptyRules(rules []*css.Rule, diags []Diagnostic) []Diagnostic {
+	for _, r := range rules {
+		if r.HasBlock && len(r.Declarations) == 0 && len(r.Rules) == 0 {
+			name := css.ValuesString(css.TrimSpace(r.Prelude))
+			if r.IsAtRule() {
+				name = "@" + r.AtKeyword
+			}
+			diags = append(diags, Diagnostic{
+				Code:     CodeEmptyRule,
+				Severity: Warning,
+				Message:  fmt.Sprintf("rule %q is empty", name),
+				Span:     nameSpan(r.Line, r.Column, name),
+			})
+		}
+		diags = emptyRules(r.Rules, diags)
+	}
+	return diags
+}
+
+// HighSpecificity walks a stylesheet and reports the style rules with a
+// selector whose specificity is higher than max, such as "#nav a.active"
+// for a max of (1,0,0). Such selectors are hard to override without
+// raising the specificity further. The specificity of nested rules doesn't
+// include that of their parent.
+func HighSpecificity(s *css.Stylesheet, max selector.Specificity) []Diagnostic {
+	return specificityRules(s.Rules, max, nil)
+}
+
+// specificityRules appends the diagnostics of a list of rules to diags.
+func specificityRules(rules []*css.Rule, max selector.Specificity, diags []Diagnostic) []Diagnostic {
+	for _, r := range rules {
+		if !r.IsAtRule() {
+			list, err := selector.Parse(r.Prelude)
+			if err != nil {
+				list, err = selector.ParseRelative(r.Prelude)
+			}
+			if s := list.Specificity(); err == nil && max.Less(s) {
+				text := css.ValuesString(css.TrimSpace(r.Prelude))
+				diags = append(diags, Diagnostic{
+					Code:     CodeHighSpecificity,
+					Severity: Warning,
+					Message:  fmt.Sprintf("selector %q has a specificity of %s, higher than %s", text, s, max),
+					Span:     nameSpan(r.Line, r.Column, text),
+				})
+			}
+		}
+		diags = specificityRules(r.Rules, max, diags)
+	}
+	return diags
+}
@@ -0,0 +1,215 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package gorilla/css/validate checks declarations against the property
+metadata of gorilla/css/props.
+
+Validate reports the keywords that are not accepted by properties whose values
+only consist of keywords, such as display, position or overflow, with the
+closest known keyword as a suggestion:
+
+	for _, d := range validate.Validate(decl) {
+		fmt.Printf("%d:%d: %s\n", d.Span.Start.Line, d.Span.Start.Column, d.Message)
+	}
+
+Values with var() or env() references are not checked because they can only
+be validated after substitution. Validate also reports deprecated properties
+and keywords, and runs the validators added with Register:
+
+	validate.Register("z-index", func(d *css.Declaration) []validate.Diagnostic {
+		...
+	})
+
+Each Diagnostic has a stable Code, such as "unknown-keyword", a Severity and
+the Span of the offending input, so that tools can process them. It is the
+Diagnostic of gorilla/css/diag, which the parser and the sanitizer report
+as well.
+
+Stylesheet validates every declaration of a stylesheet and also reports
+obsolete at-rules such as @viewport. Deprecated is a lint pass that only
+reports deprecated or nonstandard properties such as clip or zoom, keyword
+values such as "word-break: break-word", and at-rules, suggesting their
+replacement:
+
+	for _, d := range validate.Deprecated(sheet) {
+		fmt.Println(d)
+		// warning: property "clip" is deprecated, use clip-path instead [deprecated-property] (line: 1, column: 5)
+	}
+
+Confusables is a lint pass for code review that reports names with
+bidirectional control characters, invisible characters, or letters of
+other scripts that look like Latin letters, such as a Cyrillic "а", with
+the name normalized as the suggestion.
+
+UnknownProperties, Duplicates, DuplicateRules, EmptyRules and
+HighSpecificity are lint passes reporting unknown properties, properties
+declared twice in a block, rules repeating the selectors or the
+declarations of another rule, empty rules and selectors more specific than
+a given maximum.
+*/
+package validate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/prefix"
+	"github.com/gorilla/css/props"
+	"github.com/gorilla/css/scanner"
+)
+
+// Func is a validator for the declarations of a property.
+type Func func(d *css.Declaration) []Diagnostic
+
+var (
+	mu         sync.RWMutex
+	validators = map[string][]Func{}
+)
+
+// Register adds a validator for the declarations of a property. Validators
+// registered for a property run after the built-in checks, in registration
+// order. Register is safe for concurrent use.
+func Register(property string, fn Func) {
+	mu.Lock()
+	defer mu.Unlock()
+	property = strings.ToLower(property)
+	validators[property] = append(validators[property], fn)
+}
+
+// Validate checks a declaration and returns the problems found: invalid or
+// unknown keywords, deprecated or nonstandard properties and keywords, and
+// the problems reported by registered validators.
+func Validate(d *css.Declaration) []Diagnostic {
+	diags := checkKeywords(d, nil)
+	diags = checkStatus(d, diags)
+	mu.RLock()
+	fns := validators[strings.ToLower(d.Property)]
+	mu.RUnlock()
+	for _, fn := range fns {
+		diags = append(diags, fn(d)...)
+	}
+	return diags
+}
+
+// checkKeywords appends to diags the problems found in the value of a
+// declaration of a keyword-only property.
+func checkKeywords(d *css.Declaration, diags []Diagnostic) []Diagnostic {
+	p := props.Lookup(d.Property)
+	if p == nil || len(p.Keywords) == 0 || hasSubstitution(d.Value) {
+		return diags
+	}
+	for _, v := range d.Value {
+		t := v.Token
+		switch {
+		case t.Type == scanner.TokenS:
+		case t.Type == scanner.TokenChar && (t.Value == "," || t.Value == "/"):
+		case t.Type == scanner.TokenIdent:
+			k := strings.ToLower(t.Value)
+			if isWideKeyword(k) || prefix.Has(k) || hasKeyword(p.Keywords, k) {
+				continue
+			}
+			if p.KeywordStatus(k).Status != props.Standard {
+				// Reported by checkStatus.
+				continue
+			}
+			diag := Diagnostic{
+				Code:    CodeUnknownKeyword,
+				Message: fmt.Sprintf("unknown keyword %q for property %q", t.Value, p.Name),
+				Span:    valueSpan(v),
+			}
+			if s := closest(k, p.Keywords); s != "" {
+				diag.Suggestion = s
+				diag.Message += fmt.Sprintf(", did you mean %q?", s)
+			}
+			diags = append(diags, diag)
+		default:
+			diags = append(diags, Diagnostic{
+				Code:    CodeInvalidValue,
+				Message: fmt.Sprintf("invalid value %q for property %q", v.String(), p.Name),
+				Span:    valueSpan(v),
+			})
+		}
+	}
+	return diags
+}
+
+// hasKeyword reports whether k is in the sorted list of keywords.
+func hasKeyword(keywords []string, k string) bool {
+	i := sort.SearchStrings(keywords, k)
+	return i < len(keywords) && keywords[i] == k
+}
+
+// isWideKeyword reports whether k is a lowercase CSS-wide keyword.
+func isWideKeyword(k string) bool {
+	switch k {
+	case "initial", "inherit", "unset", "revert", "revert-layer":
+		return true
+	}
+	return false
+}
+
+// hasSubstitution reports whether the values contain a var() or env()
+// function at any depth.
+func hasSubstitution(values []*css.ComponentValue) bool {
+	for _, v := range values {
+		switch strings.ToLower(v.Name()) {
+		case "var", "env":
+			return true
+		}
+		if hasSubstitution(v.Children) {
+			return true
+		}
+	}
+	return false
+}
+
+// closest returns the candidate closest to word, or an empty string if none
+// is close enough to be a likely typo.
+func closest(word string, candidates []string) string {
+	best, bestDist := "", len(word)/3
+	if bestDist < 2 {
+		bestDist = 2
+	}
+	for _, c := range candidates {
+		if d := distance(word, c); d <= bestDist && (best == "" || d < distance(word, best)) {
+			best = c
+		}
+	}
+	return best
+}
+
+// distance returns the Levenshtein distance between a and b.
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// minInt returns the smallest of its arguments.
+func minInt(a int, rest ...int) int {
+	for _, v := range rest {
+		if v < a {
+			a = v
+		}
+	}
+	return a
+}
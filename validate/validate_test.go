@@ -0,0 +1,213 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/css"
+	"github.com/gorilla/css/selector"
+)
+
+func TestValidate(t *testing.T) {
+	tcs := []struct {
+		input       string
+		suggestions []string
+	}{
+		{"display: flex", nil},
+		{"display: inline flow-root", nil},
+		{"DISPLAY: FLEX", nil},
+		{"display: flexx", []string{"flex"}},
+		{"display: -webkit-box", nil},
+		{"display: inherit", nil},
+		{"display: var(--d)", nil},
+		{"position: abslute", []string{"absolute"}},
+		{"overflow: hiden scrol", []string{"hidden", "scroll"}},
+		{"overflow: nothing-like-it", []string{""}},
+		{"position: 10px", []string{""}},
+		{"background-repeat: repeat-x, no-repeet", []string{"no-repeat"}},
+		{"width: foo", nil},
+		{"unknown-property: foo", nil},
+	}
+	for _, tc := range tcs {
+		s, err := css.ParseStylesheet("a{" + tc.input + "}")
+		if err != nil {
+			t.Fatal(err)
+		}
+		diags := Validate(s.Rules[0].Declarations[0])
+		if len(diags) != len(tc.suggestions) {
+			t.Errorf("%s: got %v, want %d diagnostics", tc.input, diags, len(tc.suggestions))
+			continue
+		}
+		for i, d := range diags {
+			if d.Suggestion != tc.suggestions[i] {
+				t.Errorf("%s: got suggestion %q, want %q", tc.input, d.Suggestion, tc.suggestions[i])
+			}
+		}
+	}
+}
+
+func TestValidatePosition(t *testing.T) {
+	s, _ := css.ParseStylesheet("a {\n  display: block flexx;\n}")
+	diags := Validate(s.Rules[0].Declarations[0])
+	want := Span{Start: Position{Line: 2, Column: 18}, End: Position{Line: 2, Column: 23}}
+	if len(diags) != 1 || diags[0].Span != want || diags[0].Code != CodeUnknownKeyword || diags[0].Severity != Error {
+		t.Errorf("got %v", diags)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register("Z-INDEX", func(d *css.Declaration) []Diagnostic {
+		if css.ValuesString(d.Value) == "9999" {
+			return []Diagnostic{{Code: "z-index-too-high", Span: declarationSpan(d)}}
+		}
+		return nil
+	})
+	s, _ := css.ParseStylesheet("a { z-index: 9999; clip: auto } b { z-index: 1 }")
+	diags := Stylesheet(s)
+	if len(diags) != 2 || diags[0].Code != "z-index-too-high" || diags[1].Code != CodeDeprecatedProp {
+		t.Fatalf("got %v", diags)
+	}
+	if want := (Span{Start: Position{Line: 1, Column: 5}, End: Position{Line: 1, Column: 18}}); diags[0].Span != want {
+		t.Errorf("got span %v, want %v", diags[0].Span, want)
+	}
+}
+
+func TestDeprecated(t *testing.T) {
+	tcs := []struct {
+		input       string
+		suggestions []string
+	}{
+		{"a { color: red; clip-path: none }", nil},
+		{"a { clip: rect(0 0 0 0); zoom: 2 }", []string{"clip-path", "transform: scale()"}},
+		{"a { word-wrap: break-word; word-break: break-word }", []string{"overflow-wrap", "overflow-wrap: anywhere"}},
+		{"a { grid-gap: 1em } b { ime-mode: auto }", []string{"gap", ""}},
+		{"@media print { a { page-break-after: always } }", []string{"break-after"}},
+		{"@-ms-viewport { width: device-width } @-moz-document url-prefix() {}", []string{`<meta name="viewport">`, "@supports or @media"}},
+		{"@unknown; @keyframes x {}", nil},
+	}
+	for _, tc := range tcs {
+		s, err := css.ParseStylesheet(tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		diags := Deprecated(s)
+		if len(diags) != len(tc.suggestions) {
+			t.Errorf("%s: got %v, want %d diagnostics", tc.input, diags, len(tc.suggestions))
+			continue
+		}
+		for i, d := range diags {
+			if d.Suggestion != tc.suggestions[i] {
+				t.Errorf("%s: got suggestion %q, want %q", tc.input, d.Suggestion, tc.suggestions[i])
+			}
+		}
+	}
+}
+
+func TestConfusables(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected []string
+	}{
+		{"a.menu, #main { --x: red; color: var(--x) } .café, .日本 { }", nil},
+		{".p\u0430y { color: red }", []string{"mixed-script p\u0430y pay 1:2"}},
+		{"#\u0441\u043e\u0440 { }", []string{"confusable-name \u0441\u043e\u0440 cop 1:1"}},
+		{"a { --a\u200bb: 1; color: var(--a\\200B b) }", []string{
+			"invisible-character --a\u200bb --ab 1:5",
+			"invisible-character --a\u200bb --ab 1:26",
+		}},
+		{"@media print { .x\u202e { c\u043elor: red } }", []string{
+			"bidi-control x\u202e x 1:17",
+			"mixed-script c\u043elor color 1:22",
+		}},
+	}
+	for _, tc := range tcs {
+		s, err := css.ParseStylesheet(tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, d := range Confusables(s) {
+			name := d.Message[strings.Index(d.Message, `"`):]
+			name, _ = strconv.Unquote(name[:strings.LastIndex(name, `"`)+1])
+			got = append(got, fmt.Sprintf("%s %s %s %d:%d", d.Code, name, d.Suggestion, d.Span.Start.Line, d.Span.Start.Column))
+		}
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("%s:\ngot  %q\nwant %q", tc.input, got, tc.expected)
+		}
+	}
+}
+
+func TestDuplicatesWinner(t *testing.T) {
+	s := css.MustParseStylesheet("a { color: red; margin: 0; color: red } b { color: red !important; margin: 0; color: blue }")
+	var got []string
+	for _, d := range Duplicates(s) {
+		got = append(got, d.Message)
+	}
+	expected := []string{
+		`property "color" is already declared at line 1, column 5, and this one overrides it`,
+		`property "color" is already declared at line 1, column 45, and it overrides this one since it is !important`,
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %q, want %q", got, expected)
+	}
+}
+
+func TestLint(t *testing.T) {
+	tcs := []struct {
+		desc     string
+		lint     func(*css.Stylesheet) []Diagnostic
+		input    string
+		expected []string
+	}{
+		{"unknown", UnknownProperties, "a { color: red; --x: 1; -webkit-foo: 1; c\\olor: red }", nil},
+		{"unknown", UnknownProperties, "a { colr: red }\n@media print { b { fnot-size: 1em; qqqqqq: 1 } }", []string{
+			"unknown-property color 1:5",
+			"unknown-property font-size 2:20",
+			"unknown-property  2:36",
+		}},
+		{"duplicates", Duplicates, "a { width: 1px; width: calc(1px + 1em); color: red }", nil},
+		{"duplicates", Duplicates, "a { color: red; margin: 0; Color: blue; b { color: red; color: red } }", []string{
+			"duplicate-declaration  1:28 (first declaration 1:5)",
+			"duplicate-declaration  1:57 (first declaration 1:45)",
+		}},
+		{"duplicate rules", DuplicateRules, "a { color: red } b { color: blue } @media print { a { color: red } } c { a { color: red } }", nil},
+		{"duplicate rules", DuplicateRules, "a > b, c { color: red }\nc,a>b { margin: 0 }\nd { COLOR: red }\ne { x: y } @media print { f { x: y } g { x: y } }", []string{
+			"duplicate-selector  2:1 (first rule 1:1)",
+			"duplicate-block  3:1 (first rule 1:1)",
+			"duplicate-block  4:38 (first rule 4:27)",
+		}},
+		{"empty", EmptyRules, "a { color: red } @media print { b { c: d } } @font-face { src: url(a) }", nil},
+		{"empty", EmptyRules, "a {}\n@media print { b { } }\n@layer x;", []string{
+			"empty-rule  1:1",
+			"empty-rule  2:16",
+		}},
+		{"specificity", func(s *css.Stylesheet) []Diagnostic { return HighSpecificity(s, selector.Specificity{0, 2, 0}) }, "a.b.c, #d { x: y } .e { .f.g.h { x: y } :is(.i, .j) {} }", []string{
+			"high-specificity  1:1",
+			"high-specificity  1:25",
+		}},
+	}
+	for _, tc := range tcs {
+		s, err := css.ParseStylesheet(tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, d := range tc.lint(s) {
+			line := fmt.Sprintf("%s %s %d:%d", d.Code, d.Suggestion, d.Span.Start.Line, d.Span.Start.Column)
+			for _, r := range d.Related {
+				line += fmt.Sprintf(" (%s %d:%d)", r.Message, r.Span.Start.Line, r.Span.Start.Column)
+			}
+			got = append(got, line)
+		}
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("%s: %s:\ngot  %q\nwant %q", tc.desc, tc.input, got, tc.expected)
+		}
+	}
+}
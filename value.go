@@ -0,0 +1,670 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package css
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gorilla/css/diag"
+	"github.com/gorilla/css/scanner"
+)
+
+// The kinds of errors returned by the parsers, which can be checked with
+// errors.Is:
+//
+//	if errors.Is(err, css.ErrBadString) {
+//		// The input has an unclosed quotation mark.
+//	}
+var (
+	// ErrBadString is the kind of the *ParseError of an unclosed quotation
+	// mark.
+	ErrBadString = errors.New("css: unclosed quotation mark")
+	// ErrBadComment is the kind of the *ParseError of an unclosed comment.
+	ErrBadComment = errors.New("css: unclosed comment")
+	// ErrLimitExceeded is the kind of a *LimitExceededError.
+	ErrLimitExceeded = errors.New("css: limit exceeded")
+)
+
+// ParseError describes a problem found while parsing CSS.
+type ParseError struct {
+	Msg    string
+	Line   int
+	Column int
+	// Err is the kind of the error, ErrBadString or ErrBadComment, or the
+	// error that caused it, such as a read error.
+	Err error
+}
+
+// newParseError returns the *ParseError of an error token.
+func newParseError(t *scanner.Token) *ParseError {
+	e := &ParseError{Msg: t.Value, Line: t.Line, Column: t.Column}
+	switch t.Value {
+	case "unclosed quotation mark":
+		e.Err = ErrBadString
+	case "unclosed comment":
+		e.Err = ErrBadComment
+	}
+	return e
+}
+
+// Error returns a string representation of the error.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("css: %s (line: %d, column: %d)", e.Msg, e.Line, e.Column)
+}
+
+// Unwrap returns the kind of the error, so that errors.Is(err, ErrBadString)
+// reports whether err is an unclosed quotation mark.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ComponentValue is a preserved token, a function or a simple block.
+type ComponentValue struct {
+	// Token is the preserved token, the FUNCTION token of a function or the
+	// opening bracket of a simple block.
+	Token *scanner.Token
+	// Children are the component values inside a function or a simple block.
+	Children []*ComponentValue
+}
+
+// IsFunction reports whether the component value is a function.
+func (v *ComponentValue) IsFunction() bool {
+	return v.Token.Type == scanner.TokenFunction
+}
+
+// IsBlock reports whether the component value is a simple block.
+func (v *ComponentValue) IsBlock() bool {
+	if v.Token.Type != scanner.TokenChar {
+		return false
+	}
+	return closingBrackets[v.Token.Value] != ""
+}
+
+// Name returns the name of a function, without the opening parenthesis.
+// It returns an empty string for other component values.
+func (v *ComponentValue) Name() string {
+	if !v.IsFunction() {
+		return ""
+	}
+	return strings.TrimSuffix(v.Token.Value, "(")
+}
+
+// Clone returns a deep copy of the component value.
+func (v *ComponentValue) Clone() *ComponentValue {
+	t := *v.Token
+	return &ComponentValue{Token: &t, Children: CloneValues(v.Children)}
+}
+
+// CloneValues returns a deep copy of a list of component values.
+func CloneValues(values []*ComponentValue) []*ComponentValue {
+	if values == nil {
+		return nil
+	}
+	c := make([]*ComponentValue, len(values))
+	for i, v := range values {
+		c[i] = v.Clone()
+	}
+	return c
+}
+
+// String returns the CSS representation of the component value.
+func (v *ComponentValue) String() string {
+	var b strings.Builder
+	v.WriteTo(&b)
+	return b.String()
+}
+
+// closingBrackets maps opening brackets of simple blocks to closing ones.
+var closingBrackets = map[string]string{
+	"(": ")",
+	"[": "]",
+	"{": "}",
+}
+
+// writeValue appends the CSS representation of v to w.
+func writeValue(w *writer, v *ComponentValue) {
+	if v.Token.Type == scanner.TokenFunction && strings.EqualFold(v.Token.Value, "url(") &&
+		scanner.New("url("+ValuesString(v.Children)+")").Next().Type == scanner.TokenURI {
+		// A url( function is only found where its arguments aren't a
+		// valid url, such as an unclosed "url(a" at the end of the input.
+		// Once closed, they may be read as a url instead: escaping a
+		// letter keeps it a function.
+		w.mark(v.Token.Line, v.Token.Column)
+		w.writeString(v.Token.Value[:2] + `\` + v.Token.Value[2:])
+	} else {
+		w.writeToken(v.Token)
+	}
+	if v.IsFunction() || v.IsBlock() {
+		writeValues(w, v.Children)
+		if v.IsFunction() {
+			w.writeByte(')')
+		} else {
+			w.writeString(closingBrackets[v.Token.Value])
+		}
+	}
+}
+
+// writeValues appends the CSS representation of a list of values to w.
+//
+// In canonical mode, whitespace is written as a single space, and omitted
+// at the start and end of the list and next to commas. A backslash that
+// isn't part of an escape sequence is followed by a newline, and an empty
+// comment separates the values that would otherwise be read as one token,
+// as the CSS Syntax specification requires.
+func writeValues(w *writer, values []*ComponentValue) {
+	for i, v := range values {
+		if v.Token.Type == scanner.TokenS && w.canonical() {
+			if i > 0 && i < len(values)-1 && values[i-1].Token.Type != scanner.TokenS &&
+				!isComma(values[i-1]) && !isComma(values[i+1]) {
+				w.writeByte(' ')
+			}
+			continue
+		}
+		if i > 0 && needsComment(values[i-1], v) {
+			w.writeString("/**/")
+		}
+		writeValue(w, v)
+		// A backslash that isn't followed by a newline would escape the
+		// next character written, as in "a\\" followed by "}".
+		if v.Token.Type == scanner.TokenChar && v.Token.Value == `\` &&
+			(i == len(values)-1 || w.canonical() || values[i+1].Token.Type != scanner.TokenS ||
+				!strings.HasPrefix(values[i+1].Token.Value, "\n")) {
+			w.writeByte('\n')
+		}
+	}
+}
+
+// needsComment reports whether the values a and b, written one after the
+// other, would be read as other tokens, such as the identifiers "a" and "b"
+// separated by a comment in the input, which would be read as "ab".
+func needsComment(a, b *ComponentValue) bool {
+	if a.IsFunction() || a.IsBlock() || b.Token.Value == "" {
+		return false
+	}
+	// A backslash starts an escape sequence, unless it is a stray one, which
+	// is followed by a newline.
+	next := b.Token.Value[0]
+	name := isNameByte(next) || next == '\\' && b.Token.Type != scanner.TokenChar
+	switch a.Token.Type {
+	case scanner.TokenIdent:
+		// "U" followed by a number such as "+1" is a unicode range.
+		return name || b.IsBlock() && next == '(' || a.Token.Value == "U" && next == '+'
+	case scanner.TokenAtKeyword, scanner.TokenHash, scanner.TokenDimension:
+		return name
+	case scanner.TokenNumber:
+		return name || next == '%' || next == '.' && isNumeric(b)
+	case scanner.TokenUnicodeRange:
+		return name || next == '?'
+	case scanner.TokenChar:
+		switch a.Token.Value {
+		case "#", "@":
+			return name
+		case "-":
+			return name || next == '.' && isNumeric(b)
+		case ".", "+":
+			return next >= '0' && next <= '9' || next == '.' && isNumeric(b)
+		case "/":
+			return next == '*'
+		case "|", "~", "^", "$", "*":
+			return next == '='
+		}
+	}
+	return false
+}
+
+// isNumeric reports whether v is a number, a percentage or a dimension.
+func isNumeric(v *ComponentValue) bool {
+	return isNumericToken(v.Token)
+}
+
+// isNumericToken reports whether t is a number, a percentage or a
+// dimension.
+func isNumericToken(t *scanner.Token) bool {
+	switch t.Type {
+	case scanner.TokenNumber, scanner.TokenPercentage, scanner.TokenDimension:
+		return true
+	}
+	return false
+}
+
+// isComma reports whether v is a comma.
+func isComma(v *ComponentValue) bool {
+	return v.Token.Type == scanner.TokenChar && v.Token.Value == ","
+}
+
+// ValuesString returns the CSS representation of a list of component values.
+func ValuesString(values []*ComponentValue) string {
+	var b strings.Builder
+	WriteValues(&b, values)
+	return b.String()
+}
+
+// TrimSpace returns the list of values without leading and trailing
+// whitespace.
+func TrimSpace(values []*ComponentValue) []*ComponentValue {
+	for len(values) > 0 && values[0].Token.Type == scanner.TokenS {
+		values = values[1:]
+	}
+	for len(values) > 0 && values[len(values)-1].Token.Type == scanner.TokenS {
+		values = values[:len(values)-1]
+	}
+	return values
+}
+
+// ParseComponentValues parses the input as a list of component values.
+//
+// An error is returned if the input has an unclosed quotation mark or an
+// unclosed comment. Functions and blocks left open at the end of the input
+// are closed implicitly.
+func ParseComponentValues(input string) ([]*ComponentValue, error) {
+	return newParser(input).parseComponentValues()
+}
+
+// MustParseComponentValues is like ParseComponentValues but panics if the
+// input can't be parsed. It simplifies the initialization of global
+// variables and tests.
+func MustParseComponentValues(input string) []*ComponentValue {
+	values, err := ParseComponentValues(input)
+	if err != nil {
+		panic(err)
+	}
+	return values
+}
+
+// parseComponentValues consumes the input as a list of component values.
+func (p *parser) parseComponentValues() ([]*ComponentValue, error) {
+	values := p.parseValues("")
+	if err := p.error(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// error returns the error recorded by the parser, or nil.
+func (p *parser) error() error {
+	if p.limitErr != nil {
+		return p.limitErr
+	}
+	if p.err != nil {
+		return p.err
+	}
+	return nil
+}
+
+// Parser ---------------------------------------------------------------------
+
+// parser builds component values and rules from the tokens of a scanner,
+// with one token of lookahead.
+type parser struct {
+	s    *scanner.Scanner
+	peek *scanner.Token
+	err  *ParseError
+	// ahead holds the tokens read from the scanner by scan to join them
+	// with the previous one, which next hasn't returned yet.
+	ahead []*scanner.Token
+	// noURL is the offset before which a "url(" function can't start a url,
+	// as found by urlAhead.
+	noURL int
+	// comments are the comments skipped since the last call to
+	// takeComments.
+	comments []string
+	// pos is the offset in the input after the last token read from the
+	// scanner. start and end are the offsets of the last token returned by
+	// next, and peekStart and peekEnd those of peek.
+	pos                int
+	start, end         int
+	peekStart, peekEnd int
+	// stack, declStack and ruleStack hold the items of the lists being
+	// parsed, which are copied to lists of the right size once complete.
+	stack     []*ComponentValue
+	declStack []*Declaration
+	ruleStack []*Rule
+	// arena, if not nil, allocates the nodes.
+	arena *Arena
+	// limits are the limits of the parser, and limitErr the error recorded
+	// once one is exceeded, after which next only returns EOF tokens.
+	// depth, rules and decls are the counts checked against them.
+	limits              Limits
+	limitErr            *LimitExceededError
+	depth, rules, decls int
+	// onWarning, if not nil, receives the problems recovered from.
+	onWarning func(diag.Diagnostic)
+}
+
+// newParser returns a parser for the given input.
+func newParser(input string) *parser {
+	return &parser{s: scanner.New(input)}
+}
+
+// reset makes the parser parse a new input, reusing its scanner and the
+// memory of its stacks.
+func (p *parser) reset(input string) {
+	if p.s == nil {
+		p.s = scanner.New(input)
+	} else {
+		p.s.Reset(input)
+	}
+	// The stacks are empty, but still reference the nodes of the previous
+	// input.
+	stack, declStack, ruleStack := p.stack[:cap(p.stack)], p.declStack[:cap(p.declStack)], p.ruleStack[:cap(p.ruleStack)]
+	for i := range stack {
+		stack[i] = nil
+	}
+	for i := range declStack {
+		declStack[i] = nil
+	}
+	for i := range ruleStack {
+		ruleStack[i] = nil
+	}
+	*p = parser{s: p.s, stack: stack[:0], declStack: declStack[:0], ruleStack: ruleStack[:0]}
+}
+
+// next returns the next token, skipping comments and the byte order mark.
+// Skipped comments are recorded until the next call to takeComments.
+//
+// Errors are recorded and reported as an EOF token.
+func (p *parser) next() *scanner.Token {
+	if p.limitErr != nil {
+		return &scanner.Token{Type: scanner.TokenEOF, Line: p.limitErr.Line, Column: p.limitErr.Column}
+	}
+	if p.peek != nil {
+		t := p.peek
+		p.peek = nil
+		p.start, p.end = p.peekStart, p.peekEnd
+		return t
+	}
+	for {
+		t := p.scan()
+		p.start = p.pos
+		switch t.Type {
+		case scanner.TokenComment:
+			p.comments = append(p.comments, t.Value)
+			p.pos += len(t.Value)
+			continue
+		case scanner.TokenBOM:
+			p.pos += len(t.Value)
+			continue
+		case scanner.TokenError:
+			if p.err == nil {
+				p.err = newParseError(t)
+			}
+			p.end = p.pos
+			return &scanner.Token{Type: scanner.TokenEOF, Line: t.Line, Column: t.Column}
+		}
+		p.pos += len(t.Value)
+		p.end = p.pos
+		return t
+	}
+}
+
+// scan returns the next token of the scanner, starting at offset p.pos of
+// the input, as the CSS Syntax specification reads it. The scanner reads a
+// sign as a delimiter before the number it belongs to, the hyphens that
+// start a custom name as delimiters before an identifier, and a url whose
+// "url(" isn't in lowercase as a function, as its first version did, so
+// they are joined: "-" and "1px" are read as "-1px", "-" and "-x" as "--x",
+// and "URL(", "a" and ")" as "URL(a)".
+func (p *parser) scan() *scanner.Token {
+	t := p.read()
+	switch {
+	case t.Type == scanner.TokenFunction && len(t.Value) == 4 && strings.EqualFold(t.Value, "url("):
+		if n := p.urlAhead(); n > 0 {
+			t = p.join(t, n)
+			t.Type = scanner.TokenURI
+			return t
+		}
+	case isChar(t, "+"):
+		if u := p.lookahead(0); isNumericToken(u) {
+			return p.join(t, 1)
+		}
+	case isChar(t, "-"):
+		n := 0
+		for isChar(p.lookahead(n), "-") {
+			n++
+		}
+		switch u := p.lookahead(n); {
+		case n == 0 && isNumericToken(u):
+			return p.join(t, 1)
+		case (u.Type == scanner.TokenIdent || u.Type == scanner.TokenFunction) && strings.HasPrefix(u.Value, "-"):
+			return p.join(t, n+1)
+		case n > 0 && (u.Type == scanner.TokenNumber || u.Type == scanner.TokenDimension) && !strings.Contains(u.Value, "."):
+			// A name such as "--1px".
+			return p.joinIdent(t, n+1)
+		case n > 1:
+			// A name of hyphens only, such as "---".
+			return p.joinIdent(t, n)
+		}
+	}
+	return t
+}
+
+// read returns the next token read ahead, or else the next token of the
+// scanner.
+func (p *parser) read() *scanner.Token {
+	if len(p.ahead) > 0 {
+		t := p.ahead[0]
+		p.ahead = p.ahead[1:]
+		return t
+	}
+	return p.s.Next()
+}
+
+// lookahead returns the token at index i of the tokens following the last
+// one returned by read, without consuming it.
+func (p *parser) lookahead(i int) *scanner.Token {
+	for len(p.ahead) <= i {
+		p.ahead = append(p.ahead, p.s.Next())
+	}
+	return p.ahead[i]
+}
+
+// join consumes the n tokens read ahead of t and returns the last one
+// turned into a token spanning all of them, at the position of t. Tokens
+// are substrings of the input, which t starts at offset p.pos of.
+func (p *parser) join(t *scanner.Token, n int) *scanner.Token {
+	end := p.pos + len(t.Value)
+	for _, u := range p.ahead[:n] {
+		end += len(u.Value)
+	}
+	last := p.ahead[n-1]
+	p.ahead = p.ahead[n:]
+	last.Value = p.s.Input()[p.pos:end]
+	last.Line, last.Column = t.Line, t.Column
+	return last
+}
+
+// urlAhead returns the number of tokens read ahead of a "url(" function
+// token at offset p.pos that make up a url with it, or 0 if they don't,
+// which is also the case if the tokens of the scanner end inside the url,
+// as in "URL(/*)*/".
+func (p *parser) urlAhead() int {
+	if p.pos+4 < p.noURL {
+		return 0
+	}
+	input := p.s.Input()[p.pos:]
+	end, stop := urlEnd(input)
+	if end == 0 {
+		p.noURL = p.pos + stop
+		return 0
+	}
+	if t := scanner.New("url(" + input[4:end]).Next(); t.Type != scanner.TokenURI || len(t.Value) != end {
+		return 0
+	}
+	size := 4
+	for n := 0; ; n++ {
+		t := p.lookahead(n)
+		if t.Type == scanner.TokenEOF || t.Type == scanner.TokenError {
+			return 0
+		}
+		if size += len(t.Value); size >= end {
+			if size > end {
+				return 0
+			}
+			return n + 1
+		}
+	}
+}
+
+// urlEnd returns the end of the url that input, which starts with "url(",
+// would be, found with its closing parenthesis, or 0 and the offset at
+// which the unquoted url stops with a character it can't have. A "url("
+// before that offset, in the same unquoted url, can't end either.
+func urlEnd(input string) (end, stop int) {
+	i := 4
+	for i < len(input) && isSpace(input[i]) {
+		i++
+	}
+	if i < len(input) && (input[i] == '"' || input[i] == '\'') {
+		quote := input[i]
+		for i++; i < len(input) && input[i] != quote && input[i] != '\n'; i++ {
+			if input[i] == '\\' {
+				i++
+			}
+		}
+		if i >= len(input) || input[i] != quote {
+			return 0, 0
+		}
+		i++
+	} else {
+		for i < len(input) && input[i] > ' ' && input[i] != 0x7f && input[i] != ')' && input[i] != '"' && input[i] != '\'' {
+			if input[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		stop = i
+	}
+	for i < len(input) && isSpace(input[i]) {
+		i++
+	}
+	if i < len(input) && input[i] == ')' {
+		return i + 1, 0
+	}
+	return 0, stop
+}
+
+// isSpace reports whether c is whitespace in a preprocessed input.
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+// joinIdent is like join for the tokens of an identifier.
+func (p *parser) joinIdent(t *scanner.Token, n int) *scanner.Token {
+	t = p.join(t, n)
+	t.Type = scanner.TokenIdent
+	return t
+}
+
+// back pushes t, the last token returned by next, back so that it is
+// returned by the next call to next.
+func (p *parser) back(t *scanner.Token) {
+	p.peek = t
+	p.peekStart, p.peekEnd = p.start, p.end
+}
+
+// takeComments returns the comments skipped since the last call and forgets
+// them.
+func (p *parser) takeComments() []string {
+	c := p.comments
+	p.comments = nil
+	return c
+}
+
+// raw returns the input between two offsets.
+func (p *parser) raw(start, end int) string {
+	return p.s.Input()[start:end]
+}
+
+// parseValues consumes component values until the given closing bracket or
+// the end of the input. The closing bracket is consumed but not returned.
+func (p *parser) parseValues(closing string) []*ComponentValue {
+	mark := len(p.stack)
+	for {
+		t := p.next()
+		if t.Type == scanner.TokenEOF {
+			break
+		}
+		if closing != "" && t.Type == scanner.TokenChar && t.Value == closing {
+			break
+		}
+		p.stack = append(p.stack, p.parseValue(t))
+	}
+	return p.pop(mark, false)
+}
+
+// pop removes the component values pushed on the stack since mark and
+// returns them in a new list, without leading and trailing whitespace if
+// trim is true.
+func (p *parser) pop(mark int, trim bool) []*ComponentValue {
+	values := p.stack[mark:]
+	if trim {
+		values = TrimSpace(values)
+	}
+	list := p.list(values)
+	p.stack = p.stack[:mark]
+	return list
+}
+
+// list returns a copy of a list of component values, or nil if it is
+// empty.
+func (p *parser) list(values []*ComponentValue) []*ComponentValue {
+	if len(values) == 0 {
+		return nil
+	}
+	var list []*ComponentValue
+	if p.arena != nil {
+		list = p.arena.newList(len(values))
+	} else {
+		list = make([]*ComponentValue, len(values))
+	}
+	copy(list, values)
+	return list
+}
+
+// newValue returns a new component value for the token t.
+func (p *parser) newValue(t *scanner.Token) *ComponentValue {
+	if p.arena == nil {
+		return &ComponentValue{Token: t}
+	}
+	v := p.arena.newValue()
+	v.Token = t
+	return v
+}
+
+// newRule returns a new empty rule.
+func (p *parser) newRule() *Rule {
+	if p.arena == nil {
+		return new(Rule)
+	}
+	return p.arena.newRule()
+}
+
+// newDeclaration returns a new empty declaration.
+func (p *parser) newDeclaration() *Declaration {
+	if p.arena == nil {
+		return new(Declaration)
+	}
+	return p.arena.newDeclaration()
+}
+
+// parseValue returns the component value that starts with the token t.
+func (p *parser) parseValue(t *scanner.Token) *ComponentValue {
+	v := p.newValue(t)
+	closing := closingBrackets[t.Value]
+	switch {
+	case t.Type == scanner.TokenFunction:
+		closing = ")"
+	case t.Type != scanner.TokenChar || closing == "":
+		return v
+	}
+	p.depth++
+	if !p.exceed("MaxDepth", p.depth, p.limits.MaxDepth, t) {
+		v.Children = p.parseValues(closing)
+	}
+	p.depth--
+	return v
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package css
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/css/scanner"
+)
+
+func TestParseComponentValues(t *testing.T) {
+	tcs := []struct{ desc, input, expected string }{
+		{"token", "red", "red"},
+		{"function", "rgb(0, 0, 0)", "rgb(0, 0, 0)"},
+		{"nested", "calc(1px + (2px * 3))", "calc(1px + (2px * 3))"},
+		{"blocks", "[a] {b} (c)", "[a] {b} (c)"},
+		{"comment", "a/* b */ c", "a c"},
+		{"unclosed", "f(a [b", "f(a [b])"},
+		{"stray", "a) b]", "a) b]"},
+	}
+	for _, tc := range tcs {
+		values, err := ParseComponentValues(tc.input)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.desc, err)
+			continue
+		}
+		if got := ValuesString(values); got != tc.expected {
+			t.Errorf("%s: got=%q, want=%q", tc.desc, got, tc.expected)
+		}
+	}
+}
+
+func TestComponentValueTree(t *testing.T) {
+	values, err := ParseComponentValues("f(a [b]) c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 3 {
+		t.Fatalf("got %d values, want 3", len(values))
+	}
+	f := values[0]
+	if !f.IsFunction() || f.Name() != "f" || len(f.Children) != 3 {
+		t.Errorf("bad function: %v", f)
+	}
+	if b := f.Children[2]; !b.IsBlock() || len(b.Children) != 1 {
+		t.Errorf("bad block: %v", b)
+	}
+	if c := values[2]; c.IsFunction() || c.IsBlock() || c.Token.Type != scanner.TokenIdent {
+		t.Errorf("bad token: %v", c)
+	}
+}
+
+func TestParseComponentValuesTokens(t *testing.T) {
+	// The tokens of CSS Syntax Level 3, which the scanner reads as in its
+	// first version.
+	tcs := []struct{ input, expected string }{
+		{"-1px", `DIMENSION "-1px" 1:1`},
+		{"+.5 -4.2%", `NUMBER "+.5" 1:1, S " " 1:4, PERCENTAGE "-4.2%" 1:5`},
+		{"2n+1", `DIMENSION "2n" 1:1, NUMBER "+1" 1:3`},
+		{"a -b", `IDENT "a" 1:1, S " " 1:2, IDENT "-b" 1:3`},
+		{"- 1 +/**/1", `CHAR "-" 1:1, S " " 1:2, NUMBER "1" 1:3, S " " 1:4, CHAR "+" 1:5, NUMBER "1" 1:10`},
+		{"--x --1px", `IDENT "--x" 1:1, S " " 1:4, IDENT "--1px" 1:5`},
+		{"---", `IDENT "---" 1:1`},
+		{"--f(x)", `FUNCTION "--f(" 1:1`},
+		{"URL(a.png) Url( 'a)' )", `URI "URL(a.png)" 1:1, S " " 1:11, URI "Url( 'a)' )" 1:12`},
+		{"URL(a b)", `FUNCTION "URL(" 1:1`},
+		{"URL(/*)*/)", `FUNCTION "URL(" 1:1`},
+		{"URL(URL(a) b", `URI "URL(URL(a)" 1:1, S " " 1:11, IDENT "b" 1:12`},
+		{"-- x", `CHAR "-" 1:1, CHAR "-" 1:2, S " " 1:3, IDENT "x" 1:4`},
+	}
+	for _, tc := range tcs {
+		values, err := ParseComponentValues(tc.input)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.input, err)
+		}
+		var got []string
+		for _, v := range values {
+			got = append(got, fmt.Sprintf("%s %q %d:%d", v.Token.Type, v.Token.Value, v.Token.Line, v.Token.Column))
+		}
+		if strings.Join(got, ", ") != tc.expected {
+			t.Errorf("%s: got %s, want %s", tc.input, strings.Join(got, ", "), tc.expected)
+		}
+	}
+}
+
+func TestParseComponentValuesError(t *testing.T) {
+	tcs := []struct {
+		input string
+		kind  error
+	}{
+		{`a "b`, ErrBadString},
+		{"a /* b", ErrBadComment},
+	}
+	for _, tc := range tcs {
+		_, err := ParseComponentValues(tc.input)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) || !errors.Is(err, tc.kind) {
+			t.Errorf("%q: expected a *ParseError of kind %v, got %v", tc.input, tc.kind, err)
+		}
+	}
+}
+
+func TestErrorDiagnostic(t *testing.T) {
+	_, parseErr := ParseStylesheet("a { b: 'c }")
+	_, limitErr := (&Parser{Limits: Limits{MaxRules: 1}}).ParseStylesheet("a {} b {}")
+	tcs := []struct {
+		err      error
+		expected string
+	}{
+		{parseErr, "error: unclosed quotation mark [parse-error] (line: 1, column: 8)"},
+		{fmt.Errorf("a.css: %w", parseErr), "error: unclosed quotation mark [parse-error] (line: 1, column: 8)"},
+		{limitErr, "error: MaxRules of 1 exceeded [limit-exceeded] (line: 1, column: 6)"},
+		{errors.New("read failed"), "error: read failed [parse-error] (line: 0, column: 0)"},
+	}
+	for _, tc := range tcs {
+		if got := ErrorDiagnostic(tc.err).String(); got != tc.expected {
+			t.Errorf("%v: got %q, want %q", tc.err, got, tc.expected)
+		}
+	}
+}
+
+func TestTrimSpace(t *testing.T) {
+	values, _ := ParseComponentValues("  a b  ")
+	if got := ValuesString(TrimSpace(values)); got != "a b" {
+		t.Errorf("got=%q, want=%q", got, "a b")
+	}
+}
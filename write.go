@@ -0,0 +1,209 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package css
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gorilla/css/scanner"
+	"github.com/gorilla/css/sourcemap"
+)
+
+// writer is an io.Writer wrapper that counts the written bytes and stops
+// writing after the first error.
+type writer struct {
+	w   io.Writer
+	n   int64
+	err error
+	// opts are the rendering options, or nil to write tokens as they were
+	// found in the input.
+	opts *RenderOptions
+	// line and col are the position of the next byte written, tracked
+	// only when building a source map.
+	line, col int
+}
+
+// writeString writes a string unless a previous write failed.
+func (w *writer) writeString(s string) {
+	if w.err != nil {
+		return
+	}
+	if w.n == 0 && strings.HasPrefix(s, bom) {
+		// It would be read as a byte order mark, as in an identifier
+		// that starts with U+FEFF at the start of a stylesheet.
+		s = `\feff ` + s[len(bom):]
+	}
+	n, err := io.WriteString(w.w, s)
+	w.n += int64(n)
+	w.err = err
+	if w.opts != nil && w.opts.SourceMap != nil {
+		w.line, w.col = advance(w.line, w.col, s[:n])
+	}
+}
+
+// advance returns the position after text, starting from line and col.
+// Lines start at 1 and columns count runes from 1.
+func advance(line, col int, text string) (int, int) {
+	if line == 0 {
+		line, col = 1, 1
+	}
+	if i := strings.LastIndexByte(text, '\n'); i >= 0 {
+		return line + strings.Count(text, "\n"), utf8.RuneCountInString(text[i+1:]) + 1
+	}
+	return line, col + utf8.RuneCountInString(text)
+}
+
+// mark maps the current output position to a position in the input when
+// building a source map.
+func (w *writer) mark(line, col int) {
+	if w.opts == nil || w.opts.SourceMap == nil {
+		return
+	}
+	if w.line == 0 {
+		w.line, w.col = 1, 1
+	}
+	w.opts.SourceMap.Add(sourcemap.Mapping{
+		Line:         w.line,
+		Column:       w.col,
+		SourceLine:   line,
+		SourceColumn: col,
+	})
+}
+
+// writeByte writes a byte unless a previous write failed.
+func (w *writer) writeByte(c byte) {
+	bw, ok := w.w.(io.ByteWriter)
+	if !ok || w.err != nil || w.opts != nil && w.opts.SourceMap != nil {
+		w.writeString(string(c))
+		return
+	}
+	if w.err = bw.WriteByte(c); w.err == nil {
+		w.n++
+	}
+}
+
+// preserveRaw reports whether unmodified nodes are written as found in the
+// input.
+func (w *writer) preserveRaw() bool {
+	return w.opts != nil && w.opts.PreserveRaw
+}
+
+// checksum returns a checksum of the CSS representation of a node.
+func checksum(n io.WriterTo) uint64 {
+	h := fnv64a(fnvOffset)
+	n.WriteTo(&h)
+	return uint64(h)
+}
+
+const (
+	fnvOffset = 14695981039346656037
+	fnvPrime  = 1099511628211
+)
+
+// fnv64a is the 64-bit FNV-1a hash of the bytes written to it, like
+// hash/fnv, but it also implements io.StringWriter and io.ByteWriter so
+// that computing checksums doesn't allocate.
+type fnv64a uint64
+
+// Write adds p to the hash.
+func (h *fnv64a) Write(p []byte) (int, error) {
+	for _, c := range p {
+		h.WriteByte(c)
+	}
+	return len(p), nil
+}
+
+// WriteString adds s to the hash.
+func (h *fnv64a) WriteString(s string) (int, error) {
+	for i := 0; i < len(s); i++ {
+		h.WriteByte(s[i])
+	}
+	return len(s), nil
+}
+
+// WriteByte adds c to the hash.
+func (h *fnv64a) WriteByte(c byte) error {
+	*h = (*h ^ fnv64a(c)) * fnvPrime
+	return nil
+}
+
+// canonical reports whether the canonical form is written.
+func (w *writer) canonical() bool {
+	return w.opts != nil && w.opts.Canonical
+}
+
+// writeIdent writes a case-insensitive identifier, such as a property name,
+// that may contain escape sequences.
+func (w *writer) writeIdent(s string) {
+	if w.opts != nil {
+		s = escapeIdent(canonicalName(scanner.Unescape(s), w.opts), w.opts)
+	}
+	w.writeString(s)
+}
+
+// writeToken writes a token, escaped according to the rendering options.
+func (w *writer) writeToken(t *scanner.Token) {
+	w.mark(t.Line, t.Column)
+	if w.opts == nil {
+		w.writeString(t.Value)
+		return
+	}
+	w.writeString(renderToken(t, w.opts))
+}
+
+// The nodes can be written to any io.Writer, such as an http.ResponseWriter,
+// without building a string first.
+var (
+	_ io.WriterTo = (*Stylesheet)(nil)
+	_ io.WriterTo = (*Rule)(nil)
+	_ io.WriterTo = (*Declaration)(nil)
+	_ io.WriterTo = (*ComponentValue)(nil)
+)
+
+// WriteTo writes the CSS representation of the stylesheet to w. It returns
+// the number of bytes written and the first error encountered, if any.
+//
+// Serialization makes many small writes, so w should be buffered.
+func (s *Stylesheet) WriteTo(w io.Writer) (int64, error) {
+	cw := &writer{w: w}
+	writeRules(cw, s.Rules)
+	return cw.n, cw.err
+}
+
+// WriteTo writes the CSS representation of the rule to w. It returns the
+// number of bytes written and the first error encountered, if any.
+func (r *Rule) WriteTo(w io.Writer) (int64, error) {
+	cw := &writer{w: w}
+	writeRule(cw, r)
+	return cw.n, cw.err
+}
+
+// WriteTo writes the CSS representation of the declaration to w. It returns
+// the number of bytes written and the first error encountered, if any.
+func (d *Declaration) WriteTo(w io.Writer) (int64, error) {
+	cw := &writer{w: w}
+	writeDeclaration(cw, d)
+	return cw.n, cw.err
+}
+
+// WriteTo writes the CSS representation of the component value to w. It
+// returns the number of bytes written and the first error encountered, if
+// any.
+func (v *ComponentValue) WriteTo(w io.Writer) (int64, error) {
+	cw := &writer{w: w}
+	writeValue(cw, v)
+	return cw.n, cw.err
+}
+
+// WriteValues writes the CSS representation of a list of component values to
+// w. It returns the number of bytes written and the first error encountered,
+// if any.
+func WriteValues(w io.Writer, values []*ComponentValue) (int64, error) {
+	cw := &writer{w: w}
+	writeValues(cw, values)
+	return cw.n, cw.err
+}
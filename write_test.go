@@ -0,0 +1,81 @@
+// Copyright 2026 The Gorilla Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package css
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// limitWriter fails once more than n bytes have been written.
+type limitWriter struct {
+	b strings.Builder
+	n int
+}
+
+var errLimit = errors.New("limit reached")
+
+func (w *limitWriter) Write(p []byte) (int, error) {
+	if w.b.Len()+len(p) > w.n {
+		n := w.n - w.b.Len()
+		w.b.Write(p[:n])
+		return n, errLimit
+	}
+	return w.b.Write(p)
+}
+
+func TestWriteTo(t *testing.T) {
+	s, err := ParseStylesheet("a { color: red } @media print { b { margin: 0 } }")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := s.String()
+	w := &limitWriter{n: 1000}
+	if n, err := s.WriteTo(w); n != int64(len(want)) || err != nil || w.b.String() != want {
+		t.Errorf("got (%d, %v) %q, want %q", n, err, w.b.String(), want)
+	}
+	w = &limitWriter{n: 10}
+	if n, err := s.WriteTo(w); n != 10 || err != errLimit || w.b.String() != want[:10] {
+		t.Errorf("got (%d, %v) %q, want (10, %v)", n, err, w.b.String(), errLimit)
+	}
+	w = &limitWriter{n: 20}
+	if n, err := s.Rules[1].WriteTo(w); n != 20 || err != errLimit || w.b.String() != "@media print{b{margi" {
+		t.Errorf("got (%d, %v) %q, want (20, %v)", n, err, w.b.String(), errLimit)
+	}
+	w = &limitWriter{n: 3}
+	if n, err := s.Rules[0].Declarations[0].Value[0].WriteTo(w); n != 3 || err != nil || w.b.String() != "red" {
+		t.Errorf("got (%d, %v) %q", n, err, w.b.String())
+	}
+}
+
+func TestWriteToAllocs(t *testing.T) {
+	s, err := ParseStylesheet(strings.Repeat("a > b { color: red; margin: 0 auto } ", 20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b strings.Builder
+	b.Grow(2 * len(s.String()))
+	allocs := testing.AllocsPerRun(10, func() {
+		s.WriteTo(&b)
+	})
+	// Only the writer itself is allocated.
+	if allocs > 1 {
+		t.Errorf("got %v allocations per run", allocs)
+	}
+}
+
+func BenchmarkWriteTo(b *testing.B) {
+	s, err := ParseStylesheet(strings.Repeat("a > b { color: red; margin: 0 auto } @media print { c { top: calc(1px + 2em) } } ", 100))
+	if err != nil {
+		b.Fatal(err)
+	}
+	var out strings.Builder
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		out.Reset()
+		s.WriteTo(&out)
+	}
+}